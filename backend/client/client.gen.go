@@ -0,0 +1,620 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for ErrorResponseErrorCode.
+const (
+	ERRAIGENERATION  ErrorResponseErrorCode = "ERR_AI_GENERATION"
+	ERRCIRCUITOPEN   ErrorResponseErrorCode = "ERR_CIRCUIT_OPEN"
+	ERRCONFLICT      ErrorResponseErrorCode = "ERR_CONFLICT"
+	ERRCONTENTPOLICY ErrorResponseErrorCode = "ERR_CONTENT_POLICY"
+	ERRFILETOOLARGE  ErrorResponseErrorCode = "ERR_FILE_TOO_LARGE"
+	ERRFORBIDDEN     ErrorResponseErrorCode = "ERR_FORBIDDEN"
+	ERRGONE          ErrorResponseErrorCode = "ERR_GONE"
+	ERRINTERNAL      ErrorResponseErrorCode = "ERR_INTERNAL"
+	ERRINVALIDTYPE   ErrorResponseErrorCode = "ERR_INVALID_TYPE"
+	ERRMONGOINSERT   ErrorResponseErrorCode = "ERR_MONGO_INSERT"
+	ERRNOTFOUND      ErrorResponseErrorCode = "ERR_NOT_FOUND"
+	ERRPDFGENERATION ErrorResponseErrorCode = "ERR_PDF_GENERATION"
+	ERRRATELIMITED   ErrorResponseErrorCode = "ERR_RATE_LIMITED"
+	ERRS3UPLOAD      ErrorResponseErrorCode = "ERR_S3_UPLOAD"
+	ERRUNAUTHORIZED  ErrorResponseErrorCode = "ERR_UNAUTHORIZED"
+	ERRUNAVAILABLE   ErrorResponseErrorCode = "ERR_UNAVAILABLE"
+	ERRVALIDATION    ErrorResponseErrorCode = "ERR_VALIDATION"
+)
+
+// Defines values for PropertyJobStatus.
+const (
+	Failed    PropertyJobStatus = "failed"
+	Queued    PropertyJobStatus = "queued"
+	Running   PropertyJobStatus = "running"
+	Succeeded PropertyJobStatus = "succeeded"
+)
+
+// Defines values for PropertyRequestLanguages.
+const (
+	Ar PropertyRequestLanguages = "ar"
+	En PropertyRequestLanguages = "en"
+	Ur PropertyRequestLanguages = "ur"
+)
+
+// Defines values for PropertyRequestPageSize.
+const (
+	A4     PropertyRequestPageSize = "A4"
+	Legal  PropertyRequestPageSize = "Legal"
+	Letter PropertyRequestPageSize = "Letter"
+)
+
+// Defines values for PropertyRequestStatus.
+const (
+	Active  PropertyRequestStatus = "active"
+	Pending PropertyRequestStatus = "pending"
+	Sold    PropertyRequestStatus = "sold"
+)
+
+// DryRunResponse defines model for DryRunResponse.
+type DryRunResponse struct {
+	// EstimatedOpenAICost Upper bound in USD, assuming every completion uses its full token budget
+	EstimatedOpenAICost string `json:"estimatedOpenAICost"`
+
+	// EstimatedPDFSizeKB Rough size of one brochure
+	EstimatedPDFSizeKB int       `json:"estimatedPDFSizeKB"`
+	ImageCount         int       `json:"imageCount"`
+	Success            bool      `json:"success"`
+	ValidationErrors   *[]string `json:"validationErrors,omitempty"`
+	ValidationPassed   bool      `json:"validationPassed"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error     *string                `json:"error,omitempty"`
+	ErrorCode ErrorResponseErrorCode `json:"errorCode"`
+	Message   string                 `json:"message"`
+	Success   bool                   `json:"success"`
+}
+
+// ErrorResponseErrorCode defines model for ErrorResponse.ErrorCode.
+type ErrorResponseErrorCode string
+
+// JobResponse defines model for JobResponse.
+type JobResponse struct {
+	Job     PropertyJob `json:"job"`
+	Message *string     `json:"message,omitempty"`
+	Success bool        `json:"success"`
+}
+
+// PropertyJob defines model for PropertyJob.
+type PropertyJob struct {
+	CreatedAt time.Time         `json:"createdAt"`
+	Error     *string           `json:"error,omitempty"`
+	Id        string            `json:"id"`
+	Result    *PropertyResponse `json:"result,omitempty"`
+	Status    PropertyJobStatus `json:"status"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// PropertyJobStatus defines model for PropertyJob.Status.
+type PropertyJobStatus string
+
+// PropertyRequest defines model for PropertyRequest.
+type PropertyRequest struct {
+	// AdditionalSectionContent Investment section content (English)
+	AdditionalSectionContent *string `json:"additionalSectionContent,omitempty"`
+
+	// AdditionalSectionContentAr Investment section content (Arabic)
+	AdditionalSectionContentAr *string `json:"additionalSectionContentAr,omitempty"`
+
+	// AdditionalSectionTitle Investment section title (English); requires additionalSectionContent
+	AdditionalSectionTitle *string `json:"additionalSectionTitle,omitempty"`
+
+	// AdditionalSectionTitleAr Investment section title (Arabic); requires additionalSectionContentAr
+	AdditionalSectionTitleAr *string `json:"additionalSectionTitleAr,omitempty"`
+
+	// Address Street address
+	Address    string               `json:"address"`
+	AgentEmail *openapi_types.Email `json:"agentEmail,omitempty"`
+
+	// AgentId Stored agent profile (see /api/agents). Its name, email, phone and website replace the agent fields below, which are required without it.
+	AgentId    *string `json:"agentId,omitempty"`
+	AgentName  *string `json:"agentName,omitempty"`
+	AgentPhone *string `json:"agentPhone,omitempty"`
+
+	// AgentWebsite Agency website (http or https URL)
+	AgentWebsite *string `json:"agentWebsite,omitempty"`
+
+	// Amenities Amenities; the usual amenities of propertyType are used when none are given
+	Amenities *[]string `json:"amenities[],omitempty"`
+
+	// Async Queue brochure generation and return a job to poll instead of waiting
+	Async    *bool `json:"async,omitempty"`
+	Bedrooms *int  `json:"bedrooms,omitempty"`
+
+	// Bilingual Also generate the side-by-side bilingual brochure; requires en and ar in languages[]
+	Bilingual *bool `json:"bilingual,omitempty"`
+
+	// City City; required unless enrichMissingFields=true fills it by geocoding the address
+	City               *string `json:"city,omitempty"`
+	ClosingFacebookURL *string `json:"closingFacebookURL,omitempty"`
+
+	// ClosingHeadline White-label closing headline
+	ClosingHeadline     *string `json:"closingHeadline,omitempty"`
+	ClosingInstagramURL *string `json:"closingInstagramURL,omitempty"`
+	ClosingLinkedInURL  *string `json:"closingLinkedInURL,omitempty"`
+
+	// ClosingLogoURL White-label closing: agency logo (http or https URL). Any closing* field replaces the thank-you
+	// message on the contact page with the agency's logo, headline, message and social links.
+	ClosingLogoURL *string `json:"closingLogoURL,omitempty"`
+
+	// ClosingMessage White-label closing message; the localized thank-you copy is used when empty
+	ClosingMessage *string                `json:"closingMessage,omitempty"`
+	ClosingWebsite *string                `json:"closingWebsite,omitempty"`
+	CoAgentEmail   *[]openapi_types.Email `json:"coAgentEmail[],omitempty"`
+
+	// CoAgentName Co-listing agent names, shown with the primary agent in a Listed By row above the contact card; the email and phone lists must have the same length
+	CoAgentName  *[]string `json:"coAgentName[],omitempty"`
+	CoAgentPhone *[]string `json:"coAgentPhone[],omitempty"`
+
+	// Currency Currency name or ISO code
+	Currency *string `json:"currency,omitempty"`
+
+	// Description Agent description used as input for the AI copy; very long descriptions are truncated at a sentence boundary
+	Description *string `json:"description,omitempty"`
+
+	// DownPaymentPct Mortgage down payment percentage
+	DownPaymentPct *float32 `json:"downPaymentPct,omitempty"`
+
+	// DryRun Only validate the submission and estimate its cost; nothing is uploaded, generated or stored.
+	// Answers 200 with a DryRunResponse listing the validation errors.
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// EnrichMissingFields Fill a missing city, state, zip code or coordinates by geocoding the address
+	EnrichMissingFields *bool `json:"enrichMissingFields,omitempty"`
+
+	// FloorPlanHeight Floor plan height in metres
+	FloorPlanHeight *float32 `json:"floorPlanHeight,omitempty"`
+
+	// FloorPlanURL Floor plan image (http or https URL)
+	FloorPlanURL *string `json:"floorPlanURL,omitempty"`
+
+	// FloorPlanWidth Floor plan width in metres
+	FloorPlanWidth *float32 `json:"floorPlanWidth,omitempty"`
+
+	// FontId ID of an agency font uploaded with POST /api/admin/fonts
+	FontId *string `json:"fontId,omitempty"`
+
+	// Images Property photos, the first one is the cover. At least one is required for active listings and at
+	// most MAX_IMAGES_PER_PROPERTY (default 10) are accepted, each up to MAX_FILE_SIZE (default 10 MB).
+	Images *[]openapi_types.File `json:"images[],omitempty"`
+
+	// IncludeComps Add AI-estimated comparable sales
+	IncludeComps *bool `json:"includeComps,omitempty"`
+
+	// InterestRate Mortgage interest rate percentage
+	InterestRate *float32 `json:"interestRate,omitempty"`
+
+	// Landscape Render on landscape pages
+	Landscape *bool `json:"landscape,omitempty"`
+
+	// Languages Brochure languages; English and Arabic by default
+	Languages *[]PropertyRequestLanguages `json:"languages[],omitempty"`
+
+	// Latitude Latitude in decimal degrees for the cover location map; requires longitude
+	Latitude *float64 `json:"latitude,omitempty"`
+
+	// Longitude Longitude in decimal degrees for the cover location map; requires latitude
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	// MarginMm Page margin in millimetres
+	MarginMm *int `json:"marginMm,omitempty"`
+
+	// PageOrder JSON array of page names in render order, from cover, details, investment, gallery, virtualTour,
+	// comps, floorPlan and contact; each page may be listed once
+	PageOrder *string                  `json:"pageOrder,omitempty"`
+	PageSize  *PropertyRequestPageSize `json:"pageSize,omitempty"`
+
+	// PdfPassword Encrypt the brochures (AES-128) with this password; never stored
+	PdfPassword *string `json:"pdfPassword,omitempty"`
+
+	// Price Asking price
+	Price float64 `json:"price"`
+
+	// PrintMode Add a tear-off contact strip
+	PrintMode *bool `json:"printMode,omitempty"`
+
+	// PrintReady Render for print shops with original images, no compression and CMYK-approximated brand colours
+	PrintReady *bool `json:"printReady,omitempty"`
+
+	// PropertyType Property type, used for comparable sales and default amenities
+	PropertyType        *string                `json:"propertyType,omitempty"`
+	SecondaryAgentEmail *[]openapi_types.Email `json:"secondaryAgentEmail[],omitempty"`
+
+	// SecondaryAgentName Secondary agent names, shown after the primary agent on the contact card; the email and phone lists must have the same length
+	SecondaryAgentName  *[]string `json:"secondaryAgentName[],omitempty"`
+	SecondaryAgentPhone *[]string `json:"secondaryAgentPhone[],omitempty"`
+
+	// State State or region; required unless enrichMissingFields=true fills it
+	State  *string                `json:"state,omitempty"`
+	Status *PropertyRequestStatus `json:"status,omitempty"`
+
+	// TermYears Mortgage term in years
+	TermYears *int `json:"termYears,omitempty"`
+
+	// ThankYouMessageAr Closing message (Arabic)
+	ThankYouMessageAr *string `json:"thankYouMessageAr,omitempty"`
+
+	// ThankYouMessageEn Closing message (English)
+	ThankYouMessageEn *string `json:"thankYouMessageEn,omitempty"`
+
+	// ThemePreset Theme preset bundling colours, fonts and cover layout
+	ThemePreset *string `json:"themePreset,omitempty"`
+
+	// Title Listing title
+	Title string `json:"title"`
+
+	// ValidateAddress Normalize the address first and reject it below 0.5 confidence
+	ValidateAddress *bool `json:"validateAddress,omitempty"`
+
+	// VideoURL Video tour link (http or https URL), shown as a play button on the cover
+	VideoURL *string `json:"videoURL,omitempty"`
+
+	// VirtualStaging Space to describe as virtually staged. The AI describes its furniture and decor in a section of the English investment page, labeled as a computer-generated visualization.
+	VirtualStaging *string `json:"virtualStaging,omitempty"`
+
+	// VirtualTourURL Virtual tour link (http or https URL), shown as a QR code page
+	VirtualTourURL *string `json:"virtualTourURL,omitempty"`
+
+	// ZipCode Postal code; required unless enrichMissingFields=true fills it
+	ZipCode *string `json:"zipCode,omitempty"`
+}
+
+// PropertyRequestLanguages defines model for PropertyRequest.Languages.
+type PropertyRequestLanguages string
+
+// PropertyRequestPageSize defines model for PropertyRequest.PageSize.
+type PropertyRequestPageSize string
+
+// PropertyRequestStatus defines model for PropertyRequest.Status.
+type PropertyRequestStatus string
+
+// PropertyResponse defines model for PropertyResponse.
+type PropertyResponse struct {
+	Message string `json:"message"`
+
+	// PasswordProtected The PDF URLs serve encrypted brochures that open only with pdfPassword
+	PasswordProtected       *bool   `json:"passwordProtected,omitempty"`
+	PdfDownloadUrl          *string `json:"pdfDownloadUrl,omitempty"`
+	PdfDownloadUrlArabic    *string `json:"pdfDownloadUrlArabic,omitempty"`
+	PdfDownloadUrlBilingual *string `json:"pdfDownloadUrlBilingual,omitempty"`
+	PdfDownloadUrlEnglish   *string `json:"pdfDownloadUrlEnglish,omitempty"`
+	PdfDownloadUrlUrdu      *string `json:"pdfDownloadUrlUrdu,omitempty"`
+
+	// PdfUrl Legacy field, same as pdfUrlEnglish
+	PdfUrl              *string `json:"pdfUrl,omitempty"`
+	PdfUrlArabic        *string `json:"pdfUrlArabic,omitempty"`
+	PdfUrlEnglish       *string `json:"pdfUrlEnglish,omitempty"`
+	PdfUrlUrdu          *string `json:"pdfUrlUrdu,omitempty"`
+	PdfViewUrl          *string `json:"pdfViewUrl,omitempty"`
+	PdfViewUrlArabic    *string `json:"pdfViewUrlArabic,omitempty"`
+	PdfViewUrlBilingual *string `json:"pdfViewUrlBilingual,omitempty"`
+	PdfViewUrlEnglish   *string `json:"pdfViewUrlEnglish,omitempty"`
+	PdfViewUrlUrdu      *string `json:"pdfViewUrlUrdu,omitempty"`
+	PropertyId          *string `json:"propertyId,omitempty"`
+	Success             bool    `json:"success"`
+
+	// Warnings Non-fatal problems, such as gallery images that could not be loaded
+	Warnings *[]string `json:"warnings,omitempty"`
+}
+
+// Error defines model for Error.
+type Error = ErrorResponse
+
+// SubmitPropertyMultipartRequestBody defines body for SubmitProperty for multipart/form-data ContentType.
+type SubmitPropertyMultipartRequestBody = PropertyRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// SubmitPropertyWithBody request with any body
+	SubmitPropertyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) SubmitPropertyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitPropertyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewSubmitPropertyRequestWithBody generates requests for SubmitProperty with any type of body
+func NewSubmitPropertyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/property")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// SubmitPropertyWithBodyWithResponse request with any body
+	SubmitPropertyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitPropertyResponse, error)
+}
+
+type SubmitPropertyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DryRunResponse
+	JSON201      *PropertyResponse
+	JSON202      *JobResponse
+	JSON400      *Error
+	JSON413      *Error
+	JSON415      *Error
+	JSON422      *Error
+	JSON500      *Error
+	JSON502      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SubmitPropertyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubmitPropertyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// SubmitPropertyWithBodyWithResponse request with arbitrary body returning *SubmitPropertyResponse
+func (c *ClientWithResponses) SubmitPropertyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitPropertyResponse, error) {
+	rsp, err := c.SubmitPropertyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitPropertyResponse(rsp)
+}
+
+// ParseSubmitPropertyResponse parses an HTTP response from a SubmitPropertyWithResponse call
+func ParseSubmitPropertyResponse(rsp *http.Response) (*SubmitPropertyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubmitPropertyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DryRunResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PropertyResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest JobResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 415:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON415 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,5 @@
+// Package client is a typed client of the multipart listing submission, generated from the handwritten
+// OpenAPI 3.0 spec in docs/openapi.yaml. Regenerate it with go generate ./client after changing the spec.
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.4.1 -config oapi-codegen.yaml ../docs/openapi.yaml
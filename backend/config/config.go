@@ -1,13 +1,23 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// minDataEncryptionKeyLength keeps DATA_ENCRYPTION_KEY from being a short, guessable passphrase
+const minDataEncryptionKeyLength = 32
+
+// minAdminAPITokenLength keeps ADMIN_API_TOKEN from being guessed
+const minAdminAPITokenLength = 32
+
 type Config struct {
 	Port              string
 	FrontendURL       string
@@ -17,9 +27,131 @@ type Config struct {
 	AWSSecretKey      string
 	AWSRegion         string
 	AWSS3Bucket       string
+	StorageBackend    string
+	AzureAccountName  string
+	AzureAccountKey   string
+	AzureContainer    string
+	LocalStoragePath  string
 	OpenAIAPIKey      string
 	MaxFileSize       int64
 	AllowedFileTypes  string
+
+	// MaxImagesPerProperty caps the photos of one listing, submitted or added later
+	MaxImagesPerProperty int
+
+	// PublicBucket serves brochures from permanent public S3 URLs instead of pre-signed ones
+	PublicBucket bool
+
+	// Images and PDFs may be kept in separate buckets, each in its own region; both default to
+	// AWSS3Bucket in AWSRegion
+	AWSS3ImageBucket string
+	AWSS3ImageRegion string
+	AWSS3PDFBucket   string
+	AWSS3PDFRegion   string
+
+	// AWSS3Endpoint replaces the AWS endpoint for S3-compatible stores such as MinIO, which usually
+	// also need AWSS3ForcePathStyle (bucket in the URL path instead of the host name)
+	AWSS3Endpoint       string
+	AWSS3ForcePathStyle bool
+
+	// Optional CloudFront distribution for S3 assets; URLs are signed with the key pair
+	CloudFrontDomain     string
+	CloudFrontKeyPairID  string
+	CloudFrontPrivateKey string
+
+	// CompressionLevel of gzip responses: "default", "best-speed" or "best-compression"
+	CompressionLevel string
+
+	// Cross-origin policies: PublicCORS for the frontend-facing API, AdminCORS for /api/admin
+	PublicCORS CORSConfig
+	AdminCORS  CORSConfig
+
+	// AdminAPIToken is the Bearer token /api/admin requires; empty disables the admin API
+	AdminAPIToken string
+
+	// SwaggerEnabled serves the API documentation at /swagger; disable it in production
+	SwaggerEnabled bool
+
+	// GraphiQLEnabled serves the GraphiQL playground at /graphiql; disable it in production
+	GraphiQLEnabled bool
+
+	// MongoDB connection pool sizes and timeouts in seconds (a socket timeout of 0 disables it)
+	MongoPoolMin           int
+	MongoPoolMax           int
+	MongoConnectTimeoutSec int
+	MongoSocketTimeoutSec  int
+
+	// WorkerPoolSize is the number of goroutines generating brochures for async submissions
+	WorkerPoolSize int
+
+	// Brochure rendering: font files, optional logo, cover aspect ratio ("W:H") and preview rasterizer
+	ArabicFontPath   string
+	BodyFontPath     string
+	UrduFontPath     string
+	// RequireFonts makes a missing Arabic or body font a startup error instead of a warning
+	RequireFonts     bool
+	BrandLogoURL     string
+	CoverAspectRatio string
+	PDFToImagePath   string
+
+	// ThemePresetsPath is the YAML file of brochure theme presets; empty disables presets
+	ThemePresetsPath string
+
+	// SectionHeaderPattern fills the section headers of the default theme: "solid", "gradient" or "lines"
+	SectionHeaderPattern string
+
+	// Nominatim server used to fill missing address fields when a submission sets enrichMissingFields;
+	// an empty URL disables enrichment
+	GeocoderURL       string
+	GeocoderUserAgent string
+
+	// ModerationEnabled screens submitted descriptions with the OpenAI Moderation API
+	ModerationEnabled bool
+
+	// PDFOptimize re-compresses brochures larger than PDFOptimizeThresholdMB with Ghostscript
+	PDFOptimize            bool
+	PDFOptimizeThresholdMB int
+	GhostscriptPath        string
+
+	// PDFToolTimeoutSec bounds each run of an external PDF tool (Ghostscript, the linearizer, the preview
+	// rasterizer); on timeout the tool is killed and the brochure is kept as generated
+	PDFToolTimeoutSec int
+
+	// GoogleMapsStaticAPIKey adds a location map to the cover of listings with coordinates; empty disables it
+	GoogleMapsStaticAPIKey string
+
+	// PDFLinearizeCmd linearizes brochures for fast web view, e.g. "qpdf --linearize"; empty disables it
+	PDFLinearizeCmd string
+
+	// IncludeFeaturedListing adds the property of the week inset to brochure contact pages
+	IncludeFeaturedListing bool
+
+	// EncryptionEnabled stores agent emails and phone numbers AES-256-GCM encrypted, with a key derived
+	// from DataEncryptionKey
+	EncryptionEnabled bool
+	DataEncryptionKey string
+
+	// FeatureFlags are loaded from FeatureFlagsFile and hot-reloaded once Watch is started;
+	// featureFlagsErr records a file that failed to load for Validate
+	FeatureFlagsFile string
+	FeatureFlags     *FeatureFlagStore
+	featureFlagsErr  error
+
+	// Secrets resolves API keys and credentials: Vault when VAULT_ADDR is set, the environment otherwise.
+	// secretsErr records a Vault setup failure for Validate.
+	Secrets    SecretsBackend
+	secretsErr error
+
+	// envErrs records the numeric variables that could not be parsed, for Validate
+	envErrs []error
+}
+
+// CORSConfig is the cross-origin policy applied to a group of routes
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	MaxAge         int
 }
 
 func LoadConfig() *Config {
@@ -28,24 +160,291 @@ func LoadConfig() *Config {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	var envErrs []error
 	maxFileSize, err := strconv.ParseInt(getEnv("MAX_FILE_SIZE", "10485760"), 10, 64)
 	if err != nil {
+		envErrs = append(envErrs, fmt.Errorf("MAX_FILE_SIZE must be a whole number of bytes, got %q", os.Getenv("MAX_FILE_SIZE")))
 		maxFileSize = 10485760 // Default 10MB
 	}
 
-	return &Config{
+	secrets, secretsErr := newSecretsBackend()
+	if secretsErr != nil {
+		secrets = EnvSecretsBackend{}
+	}
+
+	featureFlagsFile := getEnv("FEATURE_FLAGS_FILE", "")
+	featureFlags, featureFlagsErr := NewFeatureFlagStore(featureFlagsFile)
+
+	frontendURL := getEnv("FRONTEND_URL", "http://localhost:3000")
+	awsRegion := getEnv("AWS_REGION", "us-east-1")
+	s3Bucket := getEnv("AWS_S3_BUCKET", "")
+
+	cfg := &Config{
 		Port:              getEnv("PORT", "8000"),
-		FrontendURL:       getEnv("FRONTEND_URL", "http://localhost:3000"),
-		MongoURI:          getEnv("MONGODB_URI", "mongodb://localhost:27017"),
+		FrontendURL:       frontendURL,
+		MongoURI:          getSecret(secrets, "MONGODB_URI", "mongodb://localhost:27017"),
 		MongoDatabase:     getEnv("MONGODB_DATABASE", "property_brochure_db"),
-		AWSAccessKey:      getEnv("AWS_ACCESS_KEY_ID", ""),
-		AWSSecretKey:      getEnv("AWS_SECRET_ACCESS_KEY", ""),
-		AWSRegion:         getEnv("AWS_REGION", "us-east-1"),
-		AWSS3Bucket:       getEnv("AWS_S3_BUCKET", ""),
-		OpenAIAPIKey:      getEnv("OPENAI_API_KEY", ""),
+		AWSAccessKey:      getSecret(secrets, "AWS_ACCESS_KEY_ID", ""),
+		AWSSecretKey:      getSecret(secrets, "AWS_SECRET_ACCESS_KEY", ""),
+		AWSRegion:         awsRegion,
+		AWSS3Bucket:       s3Bucket,
+		StorageBackend:    strings.ToLower(getEnv("STORAGE_BACKEND", "s3")),
+		AzureAccountName:  getEnv("AZURE_STORAGE_ACCOUNT", ""),
+		AzureAccountKey:   getSecret(secrets, "AZURE_STORAGE_KEY", ""),
+		AzureContainer:    getEnv("AZURE_STORAGE_CONTAINER", ""),
+		LocalStoragePath:  getEnv("LOCAL_STORAGE_PATH", "uploads"),
+		OpenAIAPIKey:      getSecret(secrets, "OPENAI_API_KEY", ""),
 		MaxFileSize:       maxFileSize,
-		AllowedFileTypes:  getEnv("ALLOWED_FILE_TYPES", "image/jpeg,image/jpg,image/png,image/webp"),
+		AllowedFileTypes:  getEnv("ALLOWED_FILE_TYPES", "image/jpeg,image/jpg,image/png,image/webp,image/gif"),
+
+		MaxImagesPerProperty: getEnvInt(&envErrs, "MAX_IMAGES_PER_PROPERTY", 10),
+
+		PublicBucket: strings.EqualFold(getEnv("AWSS3_PUBLIC_BUCKET", "false"), "true"),
+
+		AWSS3ImageBucket: getEnv("AWS_S3_IMAGE_BUCKET", s3Bucket),
+		AWSS3ImageRegion: getEnv("AWS_S3_IMAGE_REGION", awsRegion),
+		AWSS3PDFBucket:   getEnv("AWS_S3_PDF_BUCKET", s3Bucket),
+		AWSS3PDFRegion:   getEnv("AWS_S3_PDF_REGION", awsRegion),
+
+		AWSS3Endpoint:       strings.TrimSuffix(getEnv("AWS_S3_ENDPOINT", ""), "/"),
+		AWSS3ForcePathStyle: strings.EqualFold(getEnv("AWS_S3_FORCE_PATH_STYLE", "false"), "true"),
+
+		CloudFrontDomain:     strings.TrimSuffix(strings.TrimPrefix(getEnv("CLOUDFRONT_DOMAIN", ""), "https://"), "/"),
+		CloudFrontKeyPairID:  getEnv("CLOUDFRONT_KEY_PAIR_ID", ""),
+		CloudFrontPrivateKey: getSecret(secrets, "CLOUDFRONT_PRIVATE_KEY", ""),
+
+		CompressionLevel: strings.ToLower(getEnv("COMPRESSION_LEVEL", "default")),
+
+		PublicCORS: CORSConfig{
+			AllowedOrigins: getEnvList("CORS_ALLOWED_ORIGINS", frontendURL),
+			AllowedMethods: getEnvList("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+			AllowedHeaders: getEnvList("CORS_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Authorization,If-None-Match"),
+			MaxAge:         getEnvInt(&envErrs, "CORS_MAX_AGE", 86400),
+		},
+		// Admin endpoints are same-origin only unless internal origins are listed explicitly
+		AdminCORS: CORSConfig{
+			AllowedOrigins: getEnvList("ADMIN_CORS_ALLOWED_ORIGINS", ""),
+			AllowedMethods: getEnvList("ADMIN_CORS_ALLOWED_METHODS", "GET,POST,DELETE,OPTIONS"),
+			AllowedHeaders: getEnvList("ADMIN_CORS_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Authorization"),
+			MaxAge:         getEnvInt(&envErrs, "ADMIN_CORS_MAX_AGE", 600),
+		},
+
+		AdminAPIToken: getSecret(secrets, "ADMIN_API_TOKEN", ""),
+
+		SwaggerEnabled: strings.EqualFold(getEnv("SWAGGER_ENABLED", "true"), "true"),
+
+		GraphiQLEnabled: strings.EqualFold(getEnv("GRAPHIQL_ENABLED", "true"), "true"),
+
+		MongoPoolMin:           getEnvInt(&envErrs, "MONGODB_POOL_MIN", 5),
+		MongoPoolMax:           getEnvInt(&envErrs, "MONGODB_POOL_MAX", 50),
+		MongoConnectTimeoutSec: getEnvInt(&envErrs, "MONGODB_CONNECT_TIMEOUT_SEC", 10),
+		MongoSocketTimeoutSec:  getEnvInt(&envErrs, "MONGODB_SOCKET_TIMEOUT_SEC", 30),
+
+		WorkerPoolSize: getEnvInt(&envErrs, "WORKER_POOL_SIZE", 3),
+
+		ArabicFontPath:   getEnv("ARABIC_TTF_PATH", "fonts/NotoNaskhArabic-Regular.ttf"),
+		BodyFontPath:     getEnv("BODY_TTF_PATH", "fonts/Roboto-Regular.ttf"),
+		UrduFontPath:     getEnv("URDU_FONT_PATH", "fonts/NafeesNastaleeq.ttf"),
+		RequireFonts:     strings.EqualFold(getEnv("REQUIRE_FONTS", "false"), "true"),
+		BrandLogoURL:     getEnv("BRAND_LOGO_URL", ""),
+		CoverAspectRatio: getEnv("COVER_ASPECT_RATIO", "16:9"),
+		PDFToImagePath:   getEnv("PDFTOIMAGE_PATH", "pdftoppm"),
+
+		ThemePresetsPath: getEnv("THEME_PRESETS_PATH", "themes.yaml"),
+
+		SectionHeaderPattern: getEnv("SECTION_HEADER_PATTERN", "solid"),
+
+		GeocoderURL:       getEnv("GEOCODER_URL", "https://nominatim.openstreetmap.org"),
+		GeocoderUserAgent: getEnv("GEOCODER_USER_AGENT", "property-brochure-backend"),
+
+		ModerationEnabled: strings.EqualFold(getEnv("MODERATION_ENABLED", "false"), "true"),
+
+		PDFOptimize:            strings.EqualFold(getEnv("PDF_OPTIMIZE", "false"), "true"),
+		PDFOptimizeThresholdMB: getEnvInt(&envErrs, "PDF_OPTIMIZE_THRESHOLD_MB", 2),
+		GhostscriptPath:        getEnv("GHOSTSCRIPT_PATH", "gs"),
+		PDFToolTimeoutSec:      getEnvInt(&envErrs, "PDF_TOOL_TIMEOUT_SEC", 60),
+		PDFLinearizeCmd:        getEnv("PDFLINEARIZE_CMD", ""),
+
+		GoogleMapsStaticAPIKey: getSecret(secrets, "GOOGLE_MAPS_STATIC_API_KEY", ""),
+
+		IncludeFeaturedListing: strings.EqualFold(getEnv("INCLUDE_FEATURED_LISTING", "false"), "true"),
+
+		EncryptionEnabled: strings.EqualFold(getEnv("ENCRYPTION_ENABLED", "false"), "true"),
+		DataEncryptionKey: getSecret(secrets, "DATA_ENCRYPTION_KEY", ""),
+
+		FeatureFlagsFile: featureFlagsFile,
+		FeatureFlags:     featureFlags,
+		featureFlagsErr:  featureFlagsErr,
+
+		Secrets:    secrets,
+		secretsErr: secretsErr,
+	}
+	// Read only after every getEnvInt above has run
+	cfg.envErrs = envErrs
+	return cfg
+}
+
+// awsRegionPattern matches region identifiers such as us-east-1, eu-north-1 or us-gov-west-1
+var awsRegionPattern = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-\d$`)
+
+// Validate checks every required and constrained field and returns all problems at once
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.secretsErr != nil {
+		errs = append(errs, fmt.Errorf("Vault secrets backend: %w", c.secretsErr))
+	}
+	if c.featureFlagsErr != nil {
+		errs = append(errs, fmt.Errorf("FEATURE_FLAGS_FILE: %w", c.featureFlagsErr))
+	}
+	errs = append(errs, c.envErrs...)
+
+	if c.MongoURI == "" {
+		errs = append(errs, errors.New("MONGODB_URI is required"))
+	}
+	if c.MongoPoolMin < 0 || c.MongoPoolMax < 1 || c.MongoPoolMin > c.MongoPoolMax {
+		errs = append(errs, fmt.Errorf("MongoDB pool sizes must satisfy 0 <= MONGODB_POOL_MIN <= MONGODB_POOL_MAX and MONGODB_POOL_MAX >= 1, got %d and %d", c.MongoPoolMin, c.MongoPoolMax))
+	}
+	if c.MongoConnectTimeoutSec < 1 {
+		errs = append(errs, fmt.Errorf("MONGODB_CONNECT_TIMEOUT_SEC must be at least 1, got %d", c.MongoConnectTimeoutSec))
+	}
+	if c.MongoSocketTimeoutSec < 0 {
+		errs = append(errs, fmt.Errorf("MONGODB_SOCKET_TIMEOUT_SEC must not be negative, got %d", c.MongoSocketTimeoutSec))
+	}
+	switch c.StorageBackend {
+	case "s3":
+		if c.AWSAccessKey == "" || c.AWSSecretKey == "" {
+			errs = append(errs, errors.New("AWS credentials are required (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY)"))
+		}
+		if c.AWSS3ImageBucket == "" || c.AWSS3PDFBucket == "" {
+			errs = append(errs, errors.New("AWS_S3_BUCKET is required unless both AWS_S3_IMAGE_BUCKET and AWS_S3_PDF_BUCKET are set"))
+		}
+		if !awsRegionPattern.MatchString(c.AWSRegion) {
+			errs = append(errs, fmt.Errorf("AWS_REGION %q is not a valid AWS region", c.AWSRegion))
+		}
+		for _, region := range []struct{ env, value string }{
+			{"AWS_S3_IMAGE_REGION", c.AWSS3ImageRegion},
+			{"AWS_S3_PDF_REGION", c.AWSS3PDFRegion},
+		} {
+			if region.value != c.AWSRegion && !awsRegionPattern.MatchString(region.value) {
+				errs = append(errs, fmt.Errorf("%s %q is not a valid AWS region", region.env, region.value))
+			}
+		}
+		if c.CloudFrontDomain != "" && (c.CloudFrontKeyPairID == "" || c.CloudFrontPrivateKey == "") {
+			errs = append(errs, errors.New("CloudFront signing is required when CLOUDFRONT_DOMAIN is set (CLOUDFRONT_KEY_PAIR_ID, CLOUDFRONT_PRIVATE_KEY)"))
+		}
+	case "azure":
+		if c.AzureAccountName == "" || c.AzureAccountKey == "" {
+			errs = append(errs, errors.New("Azure credentials are required (AZURE_STORAGE_ACCOUNT, AZURE_STORAGE_KEY)"))
+		}
+		if c.AzureContainer == "" {
+			errs = append(errs, errors.New("AZURE_STORAGE_CONTAINER is required"))
+		}
+	case "local":
+		if c.LocalStoragePath == "" {
+			errs = append(errs, errors.New("LOCAL_STORAGE_PATH is required"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("STORAGE_BACKEND must be \"s3\", \"azure\" or \"local\", got %q", c.StorageBackend))
+	}
+	if c.OpenAIAPIKey == "" {
+		errs = append(errs, errors.New("OPENAI_API_KEY is required"))
+	}
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT must be a number between 1 and 65535, got %q", c.Port))
+	}
+	if c.MaxFileSize <= 0 {
+		errs = append(errs, fmt.Errorf("MAX_FILE_SIZE must be positive, got %d", c.MaxFileSize))
+	}
+	if c.MaxImagesPerProperty < 1 {
+		errs = append(errs, fmt.Errorf("MAX_IMAGES_PER_PROPERTY must be at least 1, got %d", c.MaxImagesPerProperty))
+	}
+	if err := validateMIMEList(c.AllowedFileTypes); err != nil {
+		errs = append(errs, fmt.Errorf("ALLOWED_FILE_TYPES %w", err))
+	}
+	for name, policy := range map[string]CORSConfig{"CORS": c.PublicCORS, "ADMIN_CORS": c.AdminCORS} {
+		for _, origin := range policy.AllowedOrigins {
+			if origin == "*" {
+				errs = append(errs, fmt.Errorf("%s_ALLOWED_ORIGINS must list explicit origins, \"*\" is not allowed with credentials", name))
+			}
+		}
+		if policy.MaxAge < 0 {
+			errs = append(errs, fmt.Errorf("%s_MAX_AGE must not be negative, got %d", name, policy.MaxAge))
+		}
 	}
+	switch c.CompressionLevel {
+	case "default", "best-speed", "best-compression":
+	default:
+		errs = append(errs, fmt.Errorf("COMPRESSION_LEVEL must be \"default\", \"best-speed\" or \"best-compression\", got %q", c.CompressionLevel))
+	}
+
+	switch c.SectionHeaderPattern {
+	case "solid", "gradient", "lines":
+	default:
+		errs = append(errs, fmt.Errorf("SECTION_HEADER_PATTERN must be \"solid\", \"gradient\" or \"lines\", got %q", c.SectionHeaderPattern))
+	}
+
+	if c.WorkerPoolSize < 1 {
+		errs = append(errs, fmt.Errorf("WORKER_POOL_SIZE must be at least 1, got %d", c.WorkerPoolSize))
+	}
+	if c.PDFOptimizeThresholdMB < 0 {
+		errs = append(errs, fmt.Errorf("PDF_OPTIMIZE_THRESHOLD_MB must not be negative, got %d", c.PDFOptimizeThresholdMB))
+	}
+	if c.PDFToolTimeoutSec < 1 {
+		errs = append(errs, fmt.Errorf("PDF_TOOL_TIMEOUT_SEC must be at least 1, got %d", c.PDFToolTimeoutSec))
+	}
+	if c.AdminAPIToken != "" && len(c.AdminAPIToken) < minAdminAPITokenLength {
+		errs = append(errs, fmt.Errorf("ADMIN_API_TOKEN must be at least %d characters", minAdminAPITokenLength))
+	}
+	if c.EncryptionEnabled && len(c.DataEncryptionKey) < minDataEncryptionKeyLength {
+		errs = append(errs, fmt.Errorf("DATA_ENCRYPTION_KEY must be at least %d characters when ENCRYPTION_ENABLED is true", minDataEncryptionKeyLength))
+	}
+	errs = append(errs, c.checkFonts()...)
+
+	return errors.Join(errs...)
+}
+
+// checkFonts reports brochure fonts that cannot be read. Brochures then fall back to the core fonts,
+// which cannot render Arabic, so this is only logged unless REQUIRE_FONTS=true.
+func (c *Config) checkFonts() []error {
+	var errs []error
+	for _, font := range []struct{ env, path string }{
+		{"ARABIC_TTF_PATH", c.ArabicFontPath},
+		{"BODY_TTF_PATH", c.BodyFontPath},
+	} {
+		err := errors.New("not set")
+		if font.path != "" {
+			var info os.FileInfo
+			if info, err = os.Stat(font.path); err == nil && info.IsDir() {
+				err = fmt.Errorf("%s is a directory", font.path)
+			}
+		}
+		if err == nil {
+			continue
+		}
+		if c.RequireFonts {
+			errs = append(errs, fmt.Errorf("%s: font is not accessible: %w", font.env, err))
+		} else {
+			log.Printf("Warning: %s: font is not accessible, brochures fall back to the core fonts: %v", font.env, err)
+		}
+	}
+	return errs
+}
+
+// validateMIMEList ensures the value is a non-empty comma-separated list of type/subtype entries
+func validateMIMEList(list string) error {
+	if strings.TrimSpace(list) == "" {
+		return errors.New("must not be empty")
+	}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		parts := strings.Split(entry, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("contains invalid MIME type %q", entry)
+		}
+	}
+	return nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -55,3 +454,29 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList splits a comma-separated variable into its non-empty, trimmed entries
+func getEnvList(key, defaultValue string) []string {
+	var list []string
+	for _, entry := range strings.Split(getEnv(key, defaultValue), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// getEnvInt parses an integer variable, returning the default when it is unset. A malformed value also
+// returns the default and is recorded in errs, so Validate rejects it instead of silently ignoring it.
+func getEnvInt(errs *[]error, key string, defaultValue int) int {
+	raw := getEnv(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s must be a whole number, got %q", key, raw))
+		return defaultValue
+	}
+	return value
+}
+
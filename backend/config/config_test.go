@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateReportsMalformedNumbers(t *testing.T) {
+	t.Setenv("MAX_FILE_SIZE", "10MB")
+	t.Setenv("WORKER_POOL_SIZE", "four")
+	t.Setenv("MONGODB_POOL_MAX", " 50 ")
+
+	cfg := LoadConfig()
+	if cfg.MaxFileSize != 10485760 || cfg.WorkerPoolSize != 3 {
+		t.Errorf("MaxFileSize, WorkerPoolSize = %d, %d, want the defaults 10485760, 3", cfg.MaxFileSize, cfg.WorkerPoolSize)
+	}
+	if cfg.MongoPoolMax != 50 {
+		t.Errorf("MongoPoolMax = %d, want 50 with the spaces trimmed", cfg.MongoPoolMax)
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate accepted malformed numeric variables")
+	}
+	for _, want := range []string{`MAX_FILE_SIZE must be a whole number of bytes, got "10MB"`, `WORKER_POOL_SIZE must be a whole number, got "four"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, want it to report %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "MONGODB_POOL_MAX") {
+		t.Errorf("Validate() = %v, want MONGODB_POOL_MAX accepted", err)
+	}
+}
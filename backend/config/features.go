@@ -0,0 +1,150 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// FeatureFlagsReloadInterval is how often the feature flags file is checked for changes
+const FeatureFlagsReloadInterval = 60 * time.Second
+
+// FeatureFlags switches brochure features on and off without a redeployment. Flags missing from the
+// file stay enabled.
+type FeatureFlags struct {
+	// EnableArabic allows Arabic and bilingual brochures
+	EnableArabic bool `json:"enableArabic"`
+	// EnableWatermark is reserved for brochure watermarks: it is read from the file so existing flag
+	// files keep working, but has no effect until the renderer draws watermarks
+	EnableWatermark bool `json:"enableWatermark"`
+	// Optional brochure pages
+	EnableVirtualTourPage bool `json:"enableVirtualTourPage"`
+	EnableFloorPlanPage   bool `json:"enableFloorPlanPage"`
+	EnableCompsSection    bool `json:"enableCompsSection"`
+	// EnableAsync accepts async=true submissions
+	EnableAsync bool `json:"enableAsync"`
+	// EnableGraphQL serves /graphql and /graphiql
+	EnableGraphQL bool `json:"enableGraphQL"`
+}
+
+// DefaultFeatureFlags enables every feature
+func DefaultFeatureFlags() FeatureFlags {
+	return FeatureFlags{
+		EnableArabic:          true,
+		EnableWatermark:       true,
+		EnableVirtualTourPage: true,
+		EnableFloorPlanPage:   true,
+		EnableCompsSection:    true,
+		EnableAsync:           true,
+		EnableGraphQL:         true,
+	}
+}
+
+// FeatureFlagStore holds the current feature flags, reloaded from a JSON file by Watch
+type FeatureFlagStore struct {
+	path string
+
+	mu      sync.RWMutex
+	flags   FeatureFlags
+	modTime time.Time
+}
+
+// NewFeatureFlagStore reads the flags from the JSON file at path; an empty path enables every feature
+func NewFeatureFlagStore(path string) (*FeatureFlagStore, error) {
+	s := &FeatureFlagStore{path: path, flags: DefaultFeatureFlags()}
+	if path == "" {
+		return s, nil
+	}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Flags returns the current flags; a nil store enables every feature
+func (s *FeatureFlagStore) Flags() FeatureFlags {
+	if s == nil {
+		return DefaultFeatureFlags()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.flags
+}
+
+// PageEnabled reports whether an optional brochure page ("virtualTour", "floorPlan" or "comps") is enabled
+func (s *FeatureFlagStore) PageEnabled(page string) bool {
+	flags := s.Flags()
+	switch page {
+	case "virtualTour":
+		return flags.EnableVirtualTourPage
+	case "floorPlan":
+		return flags.EnableFloorPlanPage
+	case "comps":
+		return flags.EnableCompsSection
+	}
+	return true
+}
+
+// Watch reloads the flags file every interval, when it has changed, until ctx is done. A file that
+// fails to load is logged and the previous flags are kept.
+func (s *FeatureFlagStore) Watch(ctx context.Context, interval time.Duration) {
+	if s == nil || s.path == "" {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.reload(); err != nil {
+					log.Printf("Error reloading feature flags, keeping the previous ones: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// reload reads the flags file if it was modified since the last load
+func (s *FeatureFlagStore) reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read feature flags: %w", err)
+	}
+	s.mu.RLock()
+	unchanged := info.ModTime().Equal(s.modTime)
+	s.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read feature flags: %w", err)
+	}
+	flags := DefaultFeatureFlags()
+	if err := json.Unmarshal(data, &flags); err != nil {
+		// Remember the broken version so it is reported once rather than on every check
+		s.mu.Lock()
+		s.modTime = info.ModTime()
+		s.mu.Unlock()
+		return fmt.Errorf("failed to parse feature flags %s: %w", s.path, err)
+	}
+
+	s.mu.Lock()
+	changed := !s.modTime.IsZero() && flags != s.flags
+	s.flags = flags
+	s.modTime = info.ModTime()
+	s.mu.Unlock()
+
+	if changed {
+		log.Printf("Reloaded feature flags from %s: %+v", s.path, flags)
+	}
+	return nil
+}
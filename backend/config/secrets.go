@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// VaultSecretsTTL is how long secrets read from Vault are served from memory before they are read again
+const VaultSecretsTTL = 5 * time.Minute
+
+// SecretsBackend resolves secret settings such as API keys, named like their environment variables
+type SecretsBackend interface {
+	GetSecret(key string) (string, error)
+}
+
+// EnvSecretsBackend reads secrets from environment variables
+type EnvSecretsBackend struct{}
+
+var _ SecretsBackend = EnvSecretsBackend{}
+
+func (EnvSecretsBackend) GetSecret(key string) (string, error) {
+	return os.Getenv(key), nil
+}
+
+// VaultSecretsBackend reads secrets from the fields of one HashiCorp Vault KV secret. The secret is cached
+// for VaultSecretsTTL; when a refresh fails, the cached values are kept until Vault can be read again.
+type VaultSecretsBackend struct {
+	client *vault.Client
+	path   string
+
+	mu        sync.Mutex
+	secrets   map[string]string
+	fetchedAt time.Time
+}
+
+var _ SecretsBackend = (*VaultSecretsBackend)(nil)
+
+// NewVaultSecretsBackend connects to the Vault server at addr and reads the secret at path, so that an
+// unreachable server or a bad token is reported at startup. An empty token uses VAULT_TOKEN.
+func NewVaultSecretsBackend(addr, token, path string) (*VaultSecretsBackend, error) {
+	if path == "" {
+		return nil, errors.New("VAULT_SECRETS_PATH is required when VAULT_ADDR is set")
+	}
+
+	cfg := vault.DefaultConfig()
+	cfg.Address = addr
+	client, err := vault.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vault client: %w", err)
+	}
+	if token != "" {
+		client.SetToken(token)
+	}
+
+	backend := &VaultSecretsBackend{client: client, path: path}
+	if _, err := backend.GetSecret(""); err != nil {
+		return nil, err
+	}
+	return backend, nil
+}
+
+// GetSecret returns the field named key of the Vault secret, or an empty string when it has no such field
+func (b *VaultSecretsBackend) GetSecret(key string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.secrets == nil || time.Since(b.fetchedAt) > VaultSecretsTTL {
+		secrets, err := b.read()
+		if err != nil {
+			if b.secrets == nil {
+				return "", err
+			}
+			log.Printf("Error refreshing Vault secrets, using cached values: %v", err)
+		} else {
+			b.secrets = secrets
+			b.fetchedAt = time.Now()
+		}
+	}
+	return b.secrets[key], nil
+}
+
+// read fetches the secret's fields; KV version 2 engines nest them under "data"
+func (b *VaultSecretsBackend) read() (map[string]string, error) {
+	secret, err := b.client.Logical().Read(b.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Vault secret %s: %w", b.path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("Vault secret %s does not exist", b.path)
+	}
+
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok && data["metadata"] != nil {
+		data = nested
+	}
+
+	secrets := make(map[string]string, len(data))
+	for key, value := range data {
+		if s, ok := value.(string); ok {
+			secrets[key] = s
+		} else if value != nil {
+			secrets[key] = fmt.Sprint(value)
+		}
+	}
+	return secrets, nil
+}
+
+// newSecretsBackend uses Vault when VAULT_ADDR is set and the environment otherwise
+func newSecretsBackend() (SecretsBackend, error) {
+	addr := getEnv("VAULT_ADDR", "")
+	if addr == "" {
+		return EnvSecretsBackend{}, nil
+	}
+	backend, err := NewVaultSecretsBackend(addr, getEnv("VAULT_TOKEN", ""), getEnv("VAULT_SECRETS_PATH", ""))
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Reading secrets from Vault (%s)", addr)
+	return backend, nil
+}
+
+// getSecret reads a secret from the backend, falling back to the environment when the backend does not hold it
+func getSecret(secrets SecretsBackend, key, defaultValue string) string {
+	value, err := secrets.GetSecret(key)
+	if err != nil {
+		log.Printf("Error reading secret %s, using the environment: %v", key, err)
+	}
+	if value != "" {
+		return value
+	}
+	return getEnv(key, defaultValue)
+}
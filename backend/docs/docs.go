@@ -0,0 +1,3563 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/api/address/validate": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "address"
+                ],
+                "summary": "Validate and normalize an address",
+                "parameters": [
+                    {
+                        "description": "Address to check",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PostalAddress"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AddressValidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid address",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "AI service failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Address validation not available",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/events": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns at most 1000 events (property created, PDF generated or regenerated, property deleted), newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List audit events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only events of this property",
+                        "name": "propertyId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest timestamp (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest timestamp (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid property ID or timestamp",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing admin token (ERR_UNAUTHORIZED)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid admin token or admin API disabled (ERR_FORBIDDEN)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/fonts": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Upload a brand font",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "TrueType font (.ttf or TrueType-flavoured .otf, max 2MB)",
+                        "name": "font",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Display name, defaults to the file name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ar"
+                        ],
+                        "type": "string",
+                        "default": "en",
+                        "description": "Text the font replaces: en (body) or ar (Arabic)",
+                        "name": "language",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.FontResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid font",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing admin token (ERR_UNAUTHORIZED)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid admin token or admin API disabled (ERR_FORBIDDEN)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Font larger than 2MB",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Not a .ttf or .otf file",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Upload or database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/openai-costs": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Totals are kept in memory and reset when the server restarts. Models without a known price are counted at no cost.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get estimated OpenAI costs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenAICostsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing admin token (ERR_UNAUTHORIZED)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid admin token or admin API disabled (ERR_FORBIDDEN)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/properties": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete listings in bulk",
+                "parameters": [
+                    {
+                        "description": "IDs of the listings to delete (1-100)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or property ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing admin token (ERR_UNAUTHORIZED)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid admin token or admin API disabled (ERR_FORBIDDEN)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/properties/refresh-urls": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Refresh expiring pre-signed URLs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefreshURLsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing admin token (ERR_UNAUTHORIZED)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid admin token or admin API disabled (ERR_FORBIDDEN)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/property-of-week": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Brochures show the featured listing as a cross-sell when INCLUDE_FEATURED_LISTING=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set the property of the week",
+                "parameters": [
+                    {
+                        "description": "Listing ID and tagline (max 120 characters)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyOfTheWeekRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyOfTheWeekResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or password-protected listing",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing admin token (ERR_UNAUTHORIZED)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid admin token or admin API disabled (ERR_FORBIDDEN)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/agents": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "agents"
+                ],
+                "summary": "List agent profiles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "agents"
+                ],
+                "summary": "Create an agent profile",
+                "parameters": [
+                    {
+                        "description": "Agent contact details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/agents/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "agents"
+                ],
+                "summary": "Get an agent profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Agent ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Agent not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "agents"
+                ],
+                "summary": "Update an agent profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Agent ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Agent contact details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Agent not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "agents"
+                ],
+                "summary": "Delete an agent profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Agent ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Agent not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/compare": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Compare two listings",
+                "parameters": [
+                    {
+                        "description": "The two property IDs to compare",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CompareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CompareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or property IDs",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "A listing is password protected",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Generation or database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upload failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jobs/{id}": {
+            "get": {
+                "description": "Jobs are kept in memory for 24 hours after they finish and are lost on restart.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Get an async brochure job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown job",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/properties/search": {
+            "get": {
+                "description": "Empty filters are ignored; city matches case-insensitively. Listings stored without a status count as active.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Search listings",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Lowest price",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Highest price",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exact number of bedrooms",
+                        "name": "bedrooms",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "pending",
+                            "sold"
+                        ],
+                        "type": "string",
+                        "description": "Listing status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertySearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or pagination parameter",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property": {
+            "post": {
+                "description": "Uploads the photos, generates localized AI content and renders the requested brochure PDFs.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Create a listing and generate its brochures",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing title",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent description used as input for the AI copy",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Asking price",
+                        "name": "price",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Dollar",
+                        "description": "Currency name or code",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Street address",
+                        "name": "address",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State or region",
+                        "name": "state",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postal code",
+                        "name": "zipCode",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Amenities; defaults to the usual amenities of the property type when empty",
+                        "name": "amenities[]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Property photos; the first one is the cover (at least one for active listings, at most MAX_IMAGES_PER_PROPERTY, default 10)",
+                        "name": "images[]",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stored agent profile (see /api/agents); replaces the agent name, email, phone and website fields",
+                        "name": "agentId",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent name, required without agentId",
+                        "name": "agentName",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent email, required without agentId",
+                        "name": "agentEmail",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent phone, required without agentId",
+                        "name": "agentPhone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agency website (http or https URL)",
+                        "name": "agentWebsite",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Secondary agent names, shown on the contact card (max 2)",
+                        "name": "secondaryAgentName[]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Secondary agent emails, parallel to the names",
+                        "name": "secondaryAgentEmail[]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Secondary agent phones, parallel to the names",
+                        "name": "secondaryAgentPhone[]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Co-listing agent names, shown with the primary agent in a Listed By row (max 2)",
+                        "name": "coAgentName[]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Co-listing agent emails, parallel to the names",
+                        "name": "coAgentEmail[]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Co-listing agent phones, parallel to the names",
+                        "name": "coAgentPhone[]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Floor plan image URL",
+                        "name": "floorPlanURL",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Floor plan width in metres",
+                        "name": "floorPlanWidth",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Floor plan height in metres",
+                        "name": "floorPlanHeight",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Virtual tour link",
+                        "name": "virtualTourURL",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Video tour link, shown as a play button on the cover",
+                        "name": "videoURL",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Property type, used for comparable sales",
+                        "name": "propertyType",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add AI-estimated comparable sales",
+                        "name": "includeComps",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Space to describe virtually staged, e.g. unfurnished living room (max 300 characters); adds a labeled AI description to the English investment page",
+                        "name": "virtualStaging",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "default": 20,
+                        "description": "Mortgage down payment percentage",
+                        "name": "downPaymentPct",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "default": 7,
+                        "description": "Mortgage interest rate percentage",
+                        "name": "interestRate",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Mortgage term in years (1-50)",
+                        "name": "termYears",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add a tear-off contact strip",
+                        "name": "printMode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Render for print shops: original images, no compression, CMYK-approximated brand colors",
+                        "name": "printReady",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also generate the side-by-side bilingual brochure (requires en and ar)",
+                        "name": "bilingual",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Render on landscape pages",
+                        "name": "landscape",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "A4",
+                            "Letter",
+                            "Legal"
+                        ],
+                        "type": "string",
+                        "default": "A4",
+                        "description": "Paper size",
+                        "name": "pageSize",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON array of page names, each at most once, e.g. [\\",
+                        "name": "pageOrder",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 15,
+                        "description": "Page margin in millimetres (5-30)",
+                        "name": "marginMm",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "en",
+                                "ar",
+                                "ur"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Brochure languages",
+                        "name": "languages[]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Investment section title (English)",
+                        "name": "additionalSectionTitle",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Investment section content (English)",
+                        "name": "additionalSectionContent",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Investment section title (Arabic)",
+                        "name": "additionalSectionTitleAr",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Investment section content (Arabic)",
+                        "name": "additionalSectionContentAr",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Closing message (English, max 500 characters)",
+                        "name": "thankYouMessageEn",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Closing message (Arabic, max 500 characters)",
+                        "name": "thankYouMessageAr",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "White-label closing: agency logo URL, shown instead of the thank-you message",
+                        "name": "closingLogoURL",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "White-label closing: headline (max 80 characters)",
+                        "name": "closingHeadline",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "White-label closing: message (max 500 characters)",
+                        "name": "closingMessage",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "White-label closing: Instagram profile URL",
+                        "name": "closingInstagramURL",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "White-label closing: Facebook page URL",
+                        "name": "closingFacebookURL",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "White-label closing: LinkedIn page URL",
+                        "name": "closingLinkedInURL",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "White-label closing: agency website URL",
+                        "name": "closingWebsite",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of an uploaded agency font (POST /api/admin/fonts)",
+                        "name": "fontId",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Theme preset bundling colors, fonts and cover layout, e.g. luxury, modern, coastal or corporate",
+                        "name": "themePreset",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Encrypt the brochures (AES-128) with this password, 4-127 printable ASCII characters",
+                        "name": "pdfPassword",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude in decimal degrees, shown on a cover map (requires longitude)",
+                        "name": "latitude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude in decimal degrees, shown on a cover map (requires latitude)",
+                        "name": "longitude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Fill a missing city, state, zip code or coordinates by geocoding the address, and the bedrooms of an apartment from its description",
+                        "name": "enrichMissingFields",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Normalize the address first and reject it when it is unlikely to exist",
+                        "name": "validateAddress",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of bedrooms",
+                        "name": "bedrooms",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "pending",
+                            "sold"
+                        ],
+                        "type": "string",
+                        "default": "active",
+                        "description": "Listing status",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue brochure generation and return a job to poll at /api/jobs/{id}",
+                        "name": "async",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the submission and estimate its cost; nothing is uploaded, generated or stored",
+                        "name": "dryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run result",
+                        "schema": {
+                            "$ref": "#/definitions/models.DryRunResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Queued (async mode)",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid form data (ERR_VALIDATION)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Image too large (ERR_FILE_TOO_LARGE)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Image type not allowed (ERR_INVALID_TYPE)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Cover image is unavailable, or the description violates the content policy (ERR_CONTENT_POLICY)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "PDF generation or database failure (ERR_PDF_GENERATION, ERR_MONGO_INSERT)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Storage upload or AI generation failure (ERR_S3_UPLOAD, ERR_AI_GENERATION)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Job queue is full or the server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Get a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyDetailResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response body"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Invalid property ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Update a listing's price or status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The price changed concurrently; retry the update",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}/duplicate": {
+            "post": {
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Duplicate a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID of the listing to copy",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password for the copy's brochures, required when the listing is password protected",
+                        "name": "pdfPassword",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, missing password or the listing no longer passes validation",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Cover image is unavailable, or the description violates the content policy (ERR_CONTENT_POLICY)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Generation or database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upload or AI generation failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}/image/{index}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Remove a photo from a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Zero-based image index",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyImagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or index",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The images changed concurrently",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}/images": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Page through a listing's photos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 8,
+                        "description": "Images per page (1-50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyGalleryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or pagination parameter",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database or URL signing failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Add photos to a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photos to append (listing total at most MAX_IMAGES_PER_PROPERTY)",
+                        "name": "images[]",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyImagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, form data or image limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Image limit reached by a concurrent update",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Image too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Image type not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upload failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}/preview": {
+            "get": {
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Get the cover page preview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid property ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Listing is password protected (ERR_FORBIDDEN)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Preview rendering failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}/price-history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Get a listing's price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid property ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}/set-cover-image": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Choose the cover photo of a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Zero-based index of the new cover",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetCoverImageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyImagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, body or index",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The images changed concurrently",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}/share-link": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Create a share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Link lifetime (1-720 hours, default 72)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/property/{id}/translate": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "properties"
+                ],
+                "summary": "Add a brochure language to a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Language to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddLanguageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, body or language",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Language already generated, or the listing is password protected",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "PDF generation or database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "AI generation or storage upload failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/share/{token}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Open a share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Redirect to the English brochure instead of returning JSON",
+                        "name": "redirect",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedPropertyResponse"
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to the English brochure"
+                    },
+                    "404": {
+                        "description": "Unknown link, listing deleted or no English brochure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Link expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database or URL signing failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/{userId}/favorites": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "List a user's bookmarks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FavoritesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database or URL signing failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/{userId}/favorites/{propertyId}": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Bookmark a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "propertyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Already bookmarked",
+                        "schema": {
+                            "$ref": "#/definitions/models.FavoriteResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Bookmarked",
+                        "schema": {
+                            "$ref": "#/definitions/models.FavoriteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user or property ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Remove a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "propertyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FavoriteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user or property ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Property not found or not in the user's favorites",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database failure",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{path}": {
+            "get": {
+                "description": "Only registered when STORAGE_BACKEND=local.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Download a locally stored file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Object key",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Link lifetime in seconds, capped at 7 days",
+                        "name": "ttl",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "inline",
+                            "attachment"
+                        ],
+                        "type": "string",
+                        "description": "Content-Disposition type",
+                        "name": "disposition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suggested file name",
+                        "name": "filename",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file path",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "File not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Link has expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "File could not be read",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.OpenAICostsResponse": {
+            "type": "object",
+            "properties": {
+                "estimatedCostUSD": {
+                    "type": "number"
+                },
+                "perRequestHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OpenAIUsageEntry"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "totalTokensUsed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.RefreshURLsResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/services.RefreshResult"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AIContent": {
+            "type": "object",
+            "properties": {
+                "arabicDescription": {
+                    "type": "string"
+                },
+                "englishDescription": {
+                    "type": "string"
+                },
+                "keyHighlights": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.AddLanguageRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddressValidationResponse": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number"
+                },
+                "corrected": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "normalized": {
+                    "$ref": "#/definitions/models.PostalAddress"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Agent": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AgentInfo": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "website": {
+                    "description": "Optional agency website, rendered as a link on the contact card",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AgentRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AgentResponse": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "$ref": "#/definitions/models.Agent"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AgentsResponse": {
+            "type": "object",
+            "properties": {
+                "agents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Agent"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.BulkDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.BulkDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                },
+                "notFound": {
+                    "type": "integer"
+                },
+                "s3Errors": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ComparableSale": {
+            "type": "object",
+            "properties": {
+                "addressStub": {
+                    "type": "string"
+                },
+                "daysOnMarket": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CompareRequest": {
+            "type": "object",
+            "properties": {
+                "propertyIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CompareResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "pdfDownloadUrl": {
+                    "type": "string"
+                },
+                "pdfViewUrl": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.DryRunResponse": {
+            "type": "object",
+            "properties": {
+                "estimatedOpenAICost": {
+                    "type": "string",
+                    "example": "$0.0021"
+                },
+                "estimatedPDFSizeKB": {
+                    "type": "integer"
+                },
+                "imageCount": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "validationErrors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "validationPassed": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "ERR_VALIDATION",
+                "ERR_FILE_TOO_LARGE",
+                "ERR_INVALID_TYPE",
+                "ERR_S3_UPLOAD",
+                "ERR_AI_GENERATION",
+                "ERR_PDF_GENERATION",
+                "ERR_MONGO_INSERT",
+                "ERR_NOT_FOUND",
+                "ERR_CONFLICT",
+                "ERR_RATE_LIMITED",
+                "ERR_CIRCUIT_OPEN",
+                "ERR_CONTENT_POLICY",
+                "ERR_UNAUTHORIZED",
+                "ERR_FORBIDDEN",
+                "ERR_GONE",
+                "ERR_UNAVAILABLE",
+                "ERR_INTERNAL"
+            ],
+            "x-enum-varnames": [
+                "ErrCodeValidation",
+                "ErrCodeFileTooLarge",
+                "ErrCodeInvalidType",
+                "ErrCodeS3Upload",
+                "ErrCodeAIGeneration",
+                "ErrCodePDFGeneration",
+                "ErrCodeMongoInsert",
+                "ErrCodeNotFound",
+                "ErrCodeConflict",
+                "ErrCodeRateLimited",
+                "ErrCodeCircuitOpen",
+                "ErrCodeContentPolicy",
+                "ErrCodeUnauthorized",
+                "ErrCodeForbidden",
+                "ErrCodeGone",
+                "ErrCodeUnavailable",
+                "ErrCodeInternal"
+            ]
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "errorCode": {
+                    "description": "ErrorCode classifies the failure for clients, e.g. ERR_VALIDATION or ERR_NOT_FOUND",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ErrorCode"
+                        }
+                    ]
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Event": {
+            "type": "object",
+            "properties": {
+                "agentEmail": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EventsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Event"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.FavoriteResponse": {
+            "type": "object",
+            "properties": {
+                "favoritesCount": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.FavoritesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "properties": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Property"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.FontResponse": {
+            "type": "object",
+            "properties": {
+                "fontId": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.JobResponse": {
+            "type": "object",
+            "properties": {
+                "job": {
+                    "$ref": "#/definitions/models.PropertyJob"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.LocalizedContent": {
+            "type": "object",
+            "properties": {
+                "additionalSectionContent": {
+                    "type": "string"
+                },
+                "additionalSectionTitle": {
+                    "type": "string"
+                },
+                "addressLabel": {
+                    "type": "string"
+                },
+                "agentLabel": {
+                    "type": "string"
+                },
+                "amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "amenitiesLabel": {
+                    "type": "string"
+                },
+                "amenityIcon": {
+                    "description": "AmenityIcon maps displayed amenity names to icon types (\"wifi\", \"pool\", \"parking\", \"gym\", \"garden\")",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "cityLabel": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "highlights": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "keyHighlightsLabel": {
+                    "type": "string"
+                },
+                "priceLabel": {
+                    "type": "string"
+                },
+                "propertyDescriptionLabel": {
+                    "type": "string"
+                },
+                "propertyGalleryLabel": {
+                    "type": "string"
+                },
+                "stateLabel": {
+                    "type": "string"
+                },
+                "thankYouMessage": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "zipCodeLabel": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MortgageDetails": {
+            "type": "object",
+            "properties": {
+                "downPaymentPct": {
+                    "type": "number"
+                },
+                "interestRate": {
+                    "type": "number"
+                },
+                "termYears": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PostalAddress": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "zipCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PriceEntry": {
+            "type": "object",
+            "properties": {
+                "changedAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PriceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "priceHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceEntry"
+                    }
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Property": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "agentId": {
+                    "description": "AgentID references the stored agent profile the listing was submitted with; AgentInfo keeps a\nsnapshot of its contact details so reads need no lookup",
+                    "type": "string"
+                },
+                "agentInfo": {
+                    "$ref": "#/definitions/models.AgentInfo"
+                },
+                "aiContent": {
+                    "$ref": "#/definitions/models.AIContent"
+                },
+                "amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "arabicContent": {
+                    "$ref": "#/definitions/models.LocalizedContent"
+                },
+                "bedrooms": {
+                    "type": "integer"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "coListingAgents": {
+                    "description": "CoListingAgents are up to two co-agents the listing (and its commission) is split with, listed with\nthe primary agent in a \"Listed By\" row above the contact card",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgentInfo"
+                    }
+                },
+                "comparableSales": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComparableSale"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "englishContent": {
+                    "$ref": "#/definitions/models.LocalizedContent"
+                },
+                "enriched": {
+                    "description": "Enriched marks listings with fields auto-populated by enrichMissingFields (city, state, zip code, coordinates and apartment bedrooms)",
+                    "type": "boolean"
+                },
+                "favoritesCount": {
+                    "description": "FavoritesCount is the number of users who bookmarked the listing",
+                    "type": "integer"
+                },
+                "floorPlanHeight": {
+                    "type": "number"
+                },
+                "floorPlanUrl": {
+                    "description": "Optional floor plan (dimensions in metres)",
+                    "type": "string"
+                },
+                "floorPlanWidth": {
+                    "type": "number"
+                },
+                "fontId": {
+                    "description": "FontID selects an uploaded agency font for the brochures",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imageUrls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "isPasswordProtected": {
+                    "description": "IsPasswordProtected marks confidential listings whose brochures are encrypted; the password is not stored",
+                    "type": "boolean"
+                },
+                "landscape": {
+                    "description": "Landscape renders the English and Arabic brochures on A4 landscape pages",
+                    "type": "boolean"
+                },
+                "languages": {
+                    "description": "Languages lists the brochure languages that were generated (\"en\", \"ar\", \"ur\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "latitude": {
+                    "description": "Optional coordinates of the property, shown on a static map on the cover",
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "marginMm": {
+                    "description": "MarginMm overrides the default 15mm page margins on all sides (5-30)",
+                    "type": "integer"
+                },
+                "mortgage": {
+                    "$ref": "#/definitions/models.MortgageDetails"
+                },
+                "pageOrder": {
+                    "description": "PageOrder optionally overrides the brochure page sequence, e.g. [\"cover\",\"gallery\",\"details\",\"contact\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pageSize": {
+                    "description": "PageSize is the paper size of the brochures: \"A4\" (default), \"Letter\" or \"Legal\"",
+                    "type": "string"
+                },
+                "pdfUrl": {
+                    "type": "string"
+                },
+                "pdfUrlArabic": {
+                    "type": "string"
+                },
+                "pdfUrlBilingual": {
+                    "description": "Optional side-by-side English/Arabic brochure",
+                    "type": "string"
+                },
+                "pdfUrlEnglish": {
+                    "type": "string"
+                },
+                "pdfUrlUrdu": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "priceHistory": {
+                    "description": "PriceHistory lists the asking prices of the listing, newest first, capped at MaxPriceHistory entries",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceEntry"
+                    }
+                },
+                "printMode": {
+                    "description": "PrintMode adds a tear-off contact strip for physical print-outs",
+                    "type": "boolean"
+                },
+                "printReady": {
+                    "description": "PrintReady renders the brochures for print shops: original images, no compression and\nCMYK-approximated brand colors",
+                    "type": "boolean"
+                },
+                "propertyType": {
+                    "type": "string"
+                },
+                "secondaryAgents": {
+                    "description": "SecondaryAgents are up to two further agents shown after the primary agent on the contact card",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgentInfo"
+                    }
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the listing's market status (PropertyStatusActive, ...); listings stored without one are active",
+                    "type": "string"
+                },
+                "themePreset": {
+                    "description": "ThemePreset names the theme preset (colors, fonts and cover layout) of the brochures",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "urduContent": {
+                    "description": "Optional Urdu copy and brochure, rendered right-to-left in Nastaliq",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LocalizedContent"
+                        }
+                    ]
+                },
+                "videoUrl": {
+                    "description": "Optional video tour, linked from a play button on the brochure cover",
+                    "type": "string"
+                },
+                "virtualStaging": {
+                    "description": "Optional virtual staging brief (e.g. \"unfurnished living room, Scandinavian style\") and the\nAI-written description of the staged space, shown on the English investment page",
+                    "type": "string"
+                },
+                "virtualStagingDescription": {
+                    "type": "string"
+                },
+                "virtualTourUrl": {
+                    "description": "Optional virtual tour link (Matterport, YouTube, ...)",
+                    "type": "string"
+                },
+                "whiteLabelClosing": {
+                    "description": "Optional agency-branded closing that replaces the thank-you message on the contact page",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.WhiteLabelClosing"
+                        }
+                    ]
+                },
+                "zipCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PropertyDetailResponse": {
+            "type": "object",
+            "properties": {
+                "property": {
+                    "$ref": "#/definitions/models.Property"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.PropertyGalleryResponse": {
+            "type": "object",
+            "properties": {
+                "imageUrls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "totalCount": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PropertyImagesResponse": {
+            "type": "object",
+            "properties": {
+                "imageUrls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.PropertyJob": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/models.PropertyResponse"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PropertyOfTheWeek": {
+            "type": "object",
+            "properties": {
+                "propertyId": {
+                    "type": "string"
+                },
+                "tagline": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PropertyOfTheWeekRequest": {
+            "type": "object",
+            "properties": {
+                "propertyId": {
+                    "type": "string"
+                },
+                "tagline": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PropertyOfTheWeekResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "propertyOfTheWeek": {
+                    "$ref": "#/definitions/models.PropertyOfTheWeek"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.PropertyResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "passwordProtected": {
+                    "description": "PasswordProtected notes that the PDF URLs serve encrypted brochures that open only with pdfPassword",
+                    "type": "boolean"
+                },
+                "pdfDownloadUrl": {
+                    "type": "string"
+                },
+                "pdfDownloadUrlArabic": {
+                    "type": "string"
+                },
+                "pdfDownloadUrlBilingual": {
+                    "type": "string"
+                },
+                "pdfDownloadUrlEnglish": {
+                    "type": "string"
+                },
+                "pdfDownloadUrlUrdu": {
+                    "type": "string"
+                },
+                "pdfUrl": {
+                    "description": "Legacy field",
+                    "type": "string"
+                },
+                "pdfUrlArabic": {
+                    "type": "string"
+                },
+                "pdfUrlEnglish": {
+                    "type": "string"
+                },
+                "pdfUrlUrdu": {
+                    "type": "string"
+                },
+                "pdfViewUrl": {
+                    "type": "string"
+                },
+                "pdfViewUrlArabic": {
+                    "type": "string"
+                },
+                "pdfViewUrlBilingual": {
+                    "type": "string"
+                },
+                "pdfViewUrlEnglish": {
+                    "type": "string"
+                },
+                "pdfViewUrlUrdu": {
+                    "type": "string"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "shareLinks": {
+                    "description": "ShareLinks are the listing's public landing page links, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShareLink"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "description": "Warnings lists non-fatal problems, such as gallery images that could not be loaded",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.PropertySearchResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "properties": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Property"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PropertyUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SetCoverImageRequest": {
+            "type": "object",
+            "properties": {
+                "imageIndex": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ShareLink": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "viewCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ShareLinkRequest": {
+            "type": "object",
+            "properties": {
+                "expiresInHours": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SharedProperty": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "agentInfo": {
+                    "$ref": "#/definitions/models.AgentInfo"
+                },
+                "amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "arabicContent": {
+                    "$ref": "#/definitions/models.LocalizedContent"
+                },
+                "bedrooms": {
+                    "type": "integer"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "coListingAgents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgentInfo"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "englishContent": {
+                    "$ref": "#/definitions/models.LocalizedContent"
+                },
+                "floorPlanUrl": {
+                    "type": "string"
+                },
+                "imageUrls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mortgage": {
+                    "$ref": "#/definitions/models.MortgageDetails"
+                },
+                "pdfUrlArabic": {
+                    "type": "string"
+                },
+                "pdfUrlBilingual": {
+                    "type": "string"
+                },
+                "pdfUrlEnglish": {
+                    "type": "string"
+                },
+                "pdfUrlUrdu": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "propertyType": {
+                    "type": "string"
+                },
+                "secondaryAgents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgentInfo"
+                    }
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "urduContent": {
+                    "$ref": "#/definitions/models.LocalizedContent"
+                },
+                "videoUrl": {
+                    "type": "string"
+                },
+                "virtualTourUrl": {
+                    "type": "string"
+                },
+                "zipCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SharedPropertyResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "property": {
+                    "$ref": "#/definitions/models.SharedProperty"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "viewCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WhiteLabelClosing": {
+            "type": "object",
+            "properties": {
+                "facebookUrl": {
+                    "type": "string"
+                },
+                "headline": {
+                    "type": "string"
+                },
+                "instagramUrl": {
+                    "type": "string"
+                },
+                "linkedInUrl": {
+                    "type": "string"
+                },
+                "logoUrl": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.OpenAIUsageEntry": {
+            "type": "object",
+            "properties": {
+                "completionTokens": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "estimatedCostUSD": {
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "promptTokens": {
+                    "type": "integer"
+                },
+                "purpose": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.RefreshResult": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "refreshed": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Admin API token (ADMIN_API_TOKEN) as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "",
+	BasePath:         "/",
+	Schemes:          []string{},
+	Title:            "Property Brochure API",
+	Description:      "Creates property listings and generates AI-written English and Arabic PDF brochures.",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
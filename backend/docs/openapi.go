@@ -0,0 +1,9 @@
+package docs
+
+import _ "embed"
+
+// OpenAPISpec is the handwritten OpenAPI 3.0 description of the multipart listing submission, served at
+// /api/openapi.yaml. Unlike the rest of this package it is not generated by swag.
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
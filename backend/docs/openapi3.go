@@ -0,0 +1,23 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+)
+
+// OpenAPI3 converts the Swagger 2.0 spec swag generates from the handler annotations to OpenAPI 3.0,
+// which is what /swagger/doc.json serves. Unlike the rest of this package it is not generated by swag.
+func OpenAPI3() ([]byte, error) {
+	var doc2 openapi2.T
+	if err := json.Unmarshal([]byte(SwaggerInfo.ReadDoc()), &doc2); err != nil {
+		return nil, fmt.Errorf("failed to read the Swagger 2.0 spec: %w", err)
+	}
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the spec to OpenAPI 3.0: %w", err)
+	}
+	return json.Marshal(doc3)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// minAddressConfidence is the confidence below which validateAddress submissions are rejected
+const minAddressConfidence = 0.5
+
+// maxAddressFieldLength bounds each address field sent for validation
+const maxAddressFieldLength = 200
+
+// ValidateAddress normalizes an address (typos, casing, abbreviations, missing components) and rates how
+// likely it exists
+//
+// @Summary      Validate and normalize an address
+// @Tags         address
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.PostalAddress  true  "Address to check"
+// @Success      200      {object}  models.AddressValidationResponse
+// @Failure      400      {object}  models.ErrorResponse  "Missing or invalid address"
+// @Failure      502      {object}  models.ErrorResponse  "AI service failure"
+// @Failure      503      {object}  models.ErrorResponse  "Address validation not available"
+// @Router       /api/address/validate [post]
+func (h *PropertyHandler) ValidateAddress(c *fiber.Ctx) error {
+	var req models.PostalAddress
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+	}
+
+	normalized, err := h.normalizeAddress(req)
+	if err != nil {
+		return err
+	}
+	corrected := normalized.Corrected
+	if corrected == nil {
+		corrected = []string{}
+	}
+	return c.JSON(models.AddressValidationResponse{
+		Success:    true,
+		Normalized: normalized.PostalAddress,
+		Confidence: normalized.Confidence,
+		Corrected:  corrected,
+	})
+}
+
+// normalizeAddress checks the address fields and runs them through the address service
+func (h *PropertyHandler) normalizeAddress(address models.PostalAddress) (*services.NormalizedAddress, error) {
+	if h.addresses == nil {
+		return nil, models.NewAPIError(models.ErrCodeUnavailable, "Address validation is not available", errors.New("the AI service cannot normalize addresses"))
+	}
+	for name, value := range map[string]string{
+		"address":  address.Address,
+		"city":     address.City,
+		"state":    address.State,
+		"zip code": address.ZipCode,
+	} {
+		if len(value) > maxAddressFieldLength || !isPrintableText(value) {
+			return nil, models.NewAPIError(models.ErrCodeValidation, "Invalid address", fmt.Errorf("%s must be valid text of at most %d characters", name, maxAddressFieldLength))
+		}
+	}
+
+	normalized, err := h.addresses.ValidateAndNormalize(address)
+	if errors.Is(err, services.ErrAddressRequired) {
+		return nil, models.NewAPIError(models.ErrCodeValidation, "Address is required", err)
+	}
+	if err != nil {
+		log.Printf("Error validating address: %v", err)
+		return nil, models.NewAPIError(models.ErrCodeAIGeneration, "Failed to validate address", err)
+	}
+	return normalized, nil
+}
+
+// applyAddressValidation replaces a submission's address with its normalized form, rejecting addresses
+// below minAddressConfidence
+func (h *PropertyHandler) applyAddressValidation(req *models.PropertyRequest) error {
+	normalized, err := h.normalizeAddress(models.PostalAddress{
+		Address: req.Address,
+		City:    req.City,
+		State:   req.State,
+		ZipCode: req.ZipCode,
+	})
+	if err != nil {
+		return err
+	}
+	if normalized.Confidence < minAddressConfidence {
+		return models.NewAPIError(models.ErrCodeValidation, "Address could not be validated",
+			fmt.Errorf("confidence %.2f is below %.2f; check the address or submit without validateAddress", normalized.Confidence, minAddressConfidence))
+	}
+
+	req.Address = normalized.Address
+	req.City = normalized.City
+	req.State = normalized.State
+	req.ZipCode = normalized.ZipCode
+	if len(normalized.Corrected) > 0 {
+		log.Printf("Normalized address fields: %s", strings.Join(normalized.Corrected, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxTaglineLength is the longest property of the week tagline, in characters
+const maxTaglineLength = 120
+
+type AdminHandler struct {
+	urlRefresh *services.URLRefreshService
+	featured   *services.FeaturedListingService
+	events     *services.EventService
+	openai     *services.OpenAIService
+}
+
+func NewAdminHandler(urlRefresh *services.URLRefreshService, featured *services.FeaturedListingService, events *services.EventService, openai *services.OpenAIService) *AdminHandler {
+	return &AdminHandler{urlRefresh: urlRefresh, featured: featured, events: events, openai: openai}
+}
+
+// RefreshURLsResponse reports the outcome of a URL refresh run
+type RefreshURLsResponse struct {
+	Success bool                    `json:"success"`
+	Message string                  `json:"message"`
+	Result  *services.RefreshResult `json:"result"`
+}
+
+// OpenAICostsResponse reports the OpenAI token usage and estimated cost since startup
+type OpenAICostsResponse struct {
+	Success bool `json:"success"`
+	services.OpenAICostReport
+}
+
+// RefreshURLs regenerates stored pre-signed URLs that expire within the next 48 hours
+//
+// @Summary      Refresh expiring pre-signed URLs
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  handlers.RefreshURLsResponse
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Failure      401  {object}  models.ErrorResponse  "Missing admin token (ERR_UNAUTHORIZED)"
+// @Failure      403  {object}  models.ErrorResponse  "Invalid admin token or admin API disabled (ERR_FORBIDDEN)"
+// @Security     AdminToken
+// @Router       /api/admin/properties/refresh-urls [post]
+func (h *AdminHandler) RefreshURLs(c *fiber.Ctx) error {
+	result, err := h.urlRefresh.RefreshExpiring(c.UserContext(), services.URLRefreshWindow)
+	if err != nil {
+		log.Printf("Error refreshing property URLs: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to refresh property URLs", err)
+	}
+
+	return c.JSON(RefreshURLsResponse{
+		Success: true,
+		Message: "Property URLs refreshed",
+		Result:  result,
+	})
+}
+
+// SetPropertyOfTheWeek features a listing, with a promotional tagline, on the contact page of every brochure
+//
+// @Summary      Set the property of the week
+// @Description  Brochures show the featured listing as a cross-sell when INCLUDE_FEATURED_LISTING=true
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.PropertyOfTheWeekRequest  true  "Listing ID and tagline (max 120 characters)"
+// @Success      200  {object}  models.PropertyOfTheWeekResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid request or password-protected listing"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Failure      401  {object}  models.ErrorResponse  "Missing admin token (ERR_UNAUTHORIZED)"
+// @Failure      403  {object}  models.ErrorResponse  "Invalid admin token or admin API disabled (ERR_FORBIDDEN)"
+// @Security     AdminToken
+// @Router       /api/admin/property-of-week [post]
+func (h *AdminHandler) SetPropertyOfTheWeek(c *fiber.Ctx) error {
+	var req models.PropertyOfTheWeekRequest
+	if err := c.BodyParser(&req); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+	}
+
+	propertyID, err := primitive.ObjectIDFromHex(req.PropertyID)
+	if err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid property ID", err)
+	}
+	tagline := strings.TrimSpace(req.Tagline)
+	if utf8.RuneCountInString(tagline) > maxTaglineLength {
+		return models.NewAPIError(models.ErrCodeValidation, "Tagline is too long", fmt.Errorf("tagline must be at most %d characters", maxTaglineLength))
+	}
+
+	featured, err := h.featured.SetPropertyOfTheWeek(propertyID, tagline)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return models.NewAPIError(models.ErrCodeNotFound, "Property not found", errors.New("no property with ID " + req.PropertyID))
+	case errors.Is(err, services.ErrFeaturedListingProtected):
+		return models.NewAPIError(models.ErrCodeValidation, "Property cannot be featured", err)
+	case err != nil:
+		log.Printf("Error setting property of the week: %v", err)
+		return models.NewAPIError(models.ErrCodeMongoInsert, "Failed to set property of the week", err)
+	}
+
+	return c.JSON(models.PropertyOfTheWeekResponse{
+		Success:           true,
+		Message:           "Property of the week updated",
+		PropertyOfTheWeek: featured,
+	})
+}
+
+// GetEvents returns the audit log, optionally narrowed to one listing and a time range
+//
+// @Summary      List audit events
+// @Description  Returns at most 1000 events (property created, PDF generated or regenerated, property deleted), newest first
+// @Tags         admin
+// @Produce      json
+// @Param        propertyId  query     string  false  "Only events of this property"
+// @Param        from        query     string  false  "Earliest timestamp (RFC 3339)"
+// @Param        to          query     string  false  "Latest timestamp (RFC 3339)"
+// @Success      200  {object}  models.EventsResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid property ID or timestamp"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Failure      401  {object}  models.ErrorResponse  "Missing admin token (ERR_UNAUTHORIZED)"
+// @Failure      403  {object}  models.ErrorResponse  "Invalid admin token or admin API disabled (ERR_FORBIDDEN)"
+// @Security     AdminToken
+// @Router       /api/admin/events [get]
+func (h *AdminHandler) GetEvents(c *fiber.Ctx) error {
+	var filter services.EventFilter
+	if id := c.Query("propertyId"); id != "" {
+		propertyID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid property ID", err)
+		}
+		filter.PropertyID = propertyID
+	}
+	for _, bound := range []struct {
+		param string
+		value *time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	} {
+		raw := c.Query(bound.param)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid " + bound.param + " timestamp", fmt.Errorf("%s must be an RFC 3339 timestamp such as 2024-01-02T15:04:05Z", bound.param))
+		}
+		*bound.value = parsed
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid time range", errors.New("to must not be before from"))
+	}
+
+	events, err := h.events.Events(filter)
+	if err != nil {
+		log.Printf("Error querying events: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to load events", err)
+	}
+
+	return c.JSON(models.EventsResponse{
+		Success: true,
+		Count:   len(events),
+		Events:  events,
+	})
+}
+
+// GetOpenAICosts returns the tokens used by OpenAI chat completions since the server started, their cost
+// estimated from the models' list prices, and the last 100 completions
+//
+// @Summary      Get estimated OpenAI costs
+// @Description  Totals are kept in memory and reset when the server restarts. Models without a known price are counted at no cost.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  handlers.OpenAICostsResponse
+// @Failure      401  {object}  models.ErrorResponse  "Missing admin token (ERR_UNAUTHORIZED)"
+// @Failure      403  {object}  models.ErrorResponse  "Invalid admin token or admin API disabled (ERR_FORBIDDEN)"
+// @Security     AdminToken
+// @Router       /api/admin/openai-costs [get]
+func (h *AdminHandler) GetOpenAICosts(c *fiber.Ctx) error {
+	return c.JSON(OpenAICostsResponse{
+		Success:          true,
+		OpenAICostReport: h.openai.Costs(),
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"property-brochure-backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestRefreshURLs(t *testing.T) {
+	th := newTestHandler(t)
+	h := NewAdminHandler(services.NewURLRefreshService(th.mongoService, th.storage), nil, nil, nil)
+	th.app.Post("/api/admin/properties/refresh-urls", h.RefreshURLs)
+
+	// A full page of listings with expired brochure URLs, then a last page of one
+	pages := [][]bson.D{make([]bson.D, 100), make([]bson.D, 1)}
+	for _, page := range pages {
+		for i := range page {
+			property := storedProperty()
+			property.PDFUrl = signedStorageURL("brochures/" + property.ID.Hex() + ".pdf")
+			page[i] = propertyDocument(t, property)
+		}
+		th.mongo.on("find", "properties", mtest.CreateCursorResponse(0, "test.properties", mtest.FirstBatch, page...))
+		th.mongo.onWrite("update", "properties", len(page))
+	}
+
+	resp, raw := th.do(t, httptest.NewRequest(http.MethodPost, "/api/admin/properties/refresh-urls", nil))
+	var body RefreshURLsResponse
+	decode(t, raw, &body)
+	if resp.StatusCode != fiber.StatusOK || body.Result == nil || body.Result.Checked != 101 || body.Result.Refreshed != 101 {
+		t.Fatalf("status %d, result %+v, want 101 listings checked and refreshed", resp.StatusCode, body.Result)
+	}
+
+	finds := th.mongo.sent("find properties")
+	if len(finds) != 2 {
+		t.Fatalf("sent %d finds, want one per page", len(finds))
+	}
+	if limit := finds[0].Lookup("limit").AsInt64(); limit != 100 {
+		t.Errorf("page limit = %d, want 100", limit)
+	}
+	if _, ok := finds[0].Lookup("filter", "_id").DocumentOK(); ok {
+		t.Errorf("first page filter = %s, want no _id bound", finds[0].Lookup("filter"))
+	}
+	last := pages[0][99][0].Value.(primitive.ObjectID)
+	if after, ok := finds[1].Lookup("filter", "_id", "$gt").ObjectIDOK(); !ok || after != last {
+		t.Errorf("second page filter = %s, want _id after %s", finds[1].Lookup("filter"), last.Hex())
+	}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateAgent stores an agent profile that submissions can reference with agentId
+//
+// @Summary      Create an agent profile
+// @Tags         agents
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.AgentRequest  true  "Agent contact details"
+// @Success      201      {object}  models.AgentResponse
+// @Failure      400      {object}  models.ErrorResponse  "Invalid body"
+// @Failure      500      {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/agents [post]
+func (h *PropertyHandler) CreateAgent(c *fiber.Ctx) error {
+	req, err := parseAgentRequest(c)
+	if err != nil {
+		return err
+	}
+
+	agent, err := h.agents.Create(req)
+	if err != nil {
+		log.Printf("Error creating agent: %v", err)
+		return models.NewAPIError(models.ErrCodeMongoInsert, "Failed to save agent", err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(models.AgentResponse{
+		Success: true,
+		Message: "Agent created",
+		Agent:   agent,
+	})
+}
+
+// ListAgents returns every agent profile, sorted by name
+//
+// @Summary      List agent profiles
+// @Tags         agents
+// @Produce      json
+// @Success      200  {object}  models.AgentsResponse
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/agents [get]
+func (h *PropertyHandler) ListAgents(c *fiber.Ctx) error {
+	agents, err := h.agents.List()
+	if err != nil {
+		log.Printf("Error listing agents: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to load agents", err)
+	}
+	return c.JSON(models.AgentsResponse{
+		Success: true,
+		Count:   len(agents),
+		Agents:  agents,
+	})
+}
+
+// GetAgent returns an agent profile
+//
+// @Summary      Get an agent profile
+// @Tags         agents
+// @Produce      json
+// @Param        id   path      string  true  "Agent ID"
+// @Success      200  {object}  models.AgentResponse
+// @Failure      404  {object}  models.ErrorResponse  "Agent not found"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/agents/{id} [get]
+func (h *PropertyHandler) GetAgent(c *fiber.Ctx) error {
+	agent, err := h.agents.Get(c.Params("id"))
+	if err != nil {
+		return agentLookupError(err)
+	}
+	return c.JSON(models.AgentResponse{
+		Success: true,
+		Agent:   agent,
+	})
+}
+
+// UpdateAgent replaces the contact details of an agent profile. Listings submitted earlier keep the
+// details they were created with.
+//
+// @Summary      Update an agent profile
+// @Tags         agents
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string               true  "Agent ID"
+// @Param        request  body      models.AgentRequest  true  "Agent contact details"
+// @Success      200      {object}  models.AgentResponse
+// @Failure      400      {object}  models.ErrorResponse  "Invalid body"
+// @Failure      404      {object}  models.ErrorResponse  "Agent not found"
+// @Failure      500      {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/agents/{id} [put]
+func (h *PropertyHandler) UpdateAgent(c *fiber.Ctx) error {
+	req, err := parseAgentRequest(c)
+	if err != nil {
+		return err
+	}
+
+	agent, err := h.agents.Update(c.Params("id"), req)
+	if err != nil {
+		return agentLookupError(err)
+	}
+	return c.JSON(models.AgentResponse{
+		Success: true,
+		Message: "Agent updated",
+		Agent:   agent,
+	})
+}
+
+// DeleteAgent removes an agent profile. Listings that referenced it keep their copy of its details.
+//
+// @Summary      Delete an agent profile
+// @Tags         agents
+// @Produce      json
+// @Param        id   path      string  true  "Agent ID"
+// @Success      200  {object}  models.AgentResponse
+// @Failure      404  {object}  models.ErrorResponse  "Agent not found"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/agents/{id} [delete]
+func (h *PropertyHandler) DeleteAgent(c *fiber.Ctx) error {
+	if err := h.agents.Delete(c.Params("id")); err != nil {
+		return agentLookupError(err)
+	}
+	return c.JSON(models.AgentResponse{
+		Success: true,
+		Message: "Agent deleted",
+	})
+}
+
+// applyAgentProfile replaces the agent fields of a submission with the stored profile it references
+func (h *PropertyHandler) applyAgentProfile(req *models.PropertyRequest) error {
+	agent, err := h.agents.Get(req.AgentID)
+	if errors.Is(err, services.ErrAgentNotFound) {
+		return models.NewAPIError(models.ErrCodeValidation, "Unknown agent", fmt.Errorf("no agent profile has ID %s", req.AgentID))
+	}
+	if err != nil {
+		log.Printf("Error loading agent %s: %v", req.AgentID, err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to load agent", err)
+	}
+
+	req.AgentName = agent.Name
+	req.AgentEmail = agent.Email
+	req.AgentPhone = agent.Phone
+	req.AgentWebsite = agent.Website
+	return nil
+}
+
+// parseAgentRequest reads and validates the JSON body of an agent profile
+func parseAgentRequest(c *fiber.Ctx) (models.AgentRequest, error) {
+	var req models.AgentRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return req, models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.TrimSpace(req.Email)
+	req.Phone = strings.TrimSpace(req.Phone)
+	req.Website = strings.TrimSpace(req.Website)
+
+	for name, value := range map[string]string{"name": req.Name, "email": req.Email, "phone": req.Phone} {
+		if value == "" || !isPrintableText(value) {
+			return req, models.NewAPIError(models.ErrCodeValidation, "Validation failed", fmt.Errorf("agent %s is required", name))
+		}
+	}
+	if req.Website != "" && !isHTTPURL(req.Website) {
+		return req, models.NewAPIError(models.ErrCodeValidation, "Validation failed", errors.New("agent website must be a valid http(s) URL"))
+	}
+	return req, nil
+}
+
+// agentLookupError maps an agent service error to its API error
+func agentLookupError(err error) error {
+	if errors.Is(err, services.ErrAgentNotFound) {
+		return models.NewAPIError(models.ErrCodeNotFound, "Agent not found", nil)
+	}
+	log.Printf("Error loading agent: %v", err)
+	return models.NewAPIError(models.ErrCodeInternal, "Failed to load agent", err)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxBulkDeleteIDs caps the listings deleted by one bulk delete call
+const maxBulkDeleteIDs = 100
+
+// BulkDelete removes up to 100 listings with their brochures, cached previews, share links and
+// favorites. Images are only deleted from storage when no remaining listing reuses them. Unknown IDs
+// are counted as notFound; storage objects that could not be deleted are counted as s3Errors and do
+// not fail the call, since the listings are already gone.
+//
+// @Summary      Delete listings in bulk
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.BulkDeleteRequest  true  "IDs of the listings to delete (1-100)"
+// @Success      200      {object}  models.BulkDeleteResponse
+// @Failure      400      {object}  models.ErrorResponse  "Invalid body or property ID"
+// @Failure      500      {object}  models.ErrorResponse  "Database failure"
+// @Failure      401      {object}  models.ErrorResponse  "Missing admin token (ERR_UNAUTHORIZED)"
+// @Failure      403      {object}  models.ErrorResponse  "Invalid admin token or admin API disabled (ERR_FORBIDDEN)"
+// @Security     AdminToken
+// @Router       /api/admin/properties [delete]
+func (h *PropertyHandler) BulkDelete(c *fiber.Ctx) error {
+	var req models.BulkDeleteRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+	}
+	if len(req.IDs) == 0 {
+		return models.NewAPIError(models.ErrCodeValidation, "No property IDs", errors.New("ids must list at least one property ID"))
+	}
+	if len(req.IDs) > maxBulkDeleteIDs {
+		return models.NewAPIError(models.ErrCodeValidation, "Too many property IDs", fmt.Errorf("at most %d property IDs can be deleted per call", maxBulkDeleteIDs))
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(req.IDs))
+	seen := make(map[primitive.ObjectID]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid property ID", fmt.Errorf("%q is not a valid property ID", id))
+		}
+		if !seen[objectID] {
+			seen[objectID] = true
+			ids = append(ids, objectID)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	collection := h.mongoService.GetCollection("properties")
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		log.Printf("Error loading properties to delete: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to load properties", err)
+	}
+	var properties []models.Property
+	if err := cursor.All(ctx, &properties); err != nil {
+		log.Printf("Error decoding properties to delete: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to load properties", err)
+	}
+
+	response := models.BulkDeleteResponse{Success: true, NotFound: len(ids) - len(properties)}
+	if len(properties) == 0 {
+		return c.JSON(response)
+	}
+
+	found := make([]primitive.ObjectID, len(properties))
+	for i := range properties {
+		found[i] = properties[i].ID
+	}
+	result, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": found}})
+	if err != nil {
+		log.Printf("Error deleting properties: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to delete properties", err)
+	}
+	response.Deleted = result.DeletedCount
+
+	for i := range properties {
+		// The event is attributed to the agent, so it needs the plain text email
+		if err := h.decryptProperty(&properties[i]); err != nil {
+			log.Printf("Error decrypting deleted property: %v", err)
+		}
+		h.emitEvent(models.EventPropertyDeleted, &properties[i], map[string]interface{}{
+			"title": properties[i].Title,
+		})
+	}
+	if err := h.shareLinks.DeleteForProperties(ctx, found...); err != nil {
+		log.Printf("Error deleting share links of deleted properties: %v", err)
+	}
+	if err := h.favorites.DeleteForProperties(ctx, found...); err != nil {
+		log.Printf("Error deleting favorites of deleted properties: %v", err)
+	}
+
+	// The listings are gone, so any image still referenced belongs to a listing that was kept
+	var keys, imageKeys []string
+	queued := map[string]bool{}
+	for i := range properties {
+		keys = append(keys, h.brochureKeys(&properties[i])...)
+		for _, imageURL := range properties[i].ImageURLs {
+			// Deduplicated uploads share one object between the deleted listings too
+			if key, ok := h.unusedImageKey(ctx, imageURL); ok && !queued[key] {
+				queued[key] = true
+				imageKeys = append(imageKeys, key)
+			}
+		}
+	}
+	keys = append(keys, imageKeys...)
+
+	if err := h.storage.DeleteObjects(keys); err != nil {
+		log.Printf("Error deleting storage objects of %d deleted properties: %v", len(properties), err)
+		response.S3Errors = len(keys)
+		return c.JSON(response)
+	}
+	if len(imageKeys) > 0 {
+		if err := h.images.ForgetKeys(imageKeys); err != nil {
+			log.Printf("Error removing image hash records of deleted properties: %v", err)
+		}
+	}
+	return c.JSON(response)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBulkDelete(t *testing.T) {
+	first, second := storedProperty(), storedProperty()
+	second.PDFUrl = mockStorageURL + "brochures/5-en.pdf"
+	second.PDFUrlEnglish = second.PDFUrl
+	second.PDFUrlArabic = mockStorageURL + "brochures/6-ar.pdf"
+	missing := primitive.NewObjectID()
+	body := fmt.Sprintf(`{"ids": [%q, %q, %q, %q]}`, first.ID.Hex(), second.ID.Hex(), missing.Hex(), first.ID.Hex())
+
+	// expectDeletion answers the commands of deleting both listings, whose shared image no other listing uses
+	expectDeletion := func(th *testHandler) {
+		th.mongo.onFind("properties", propertyDocument(t, first), propertyDocument(t, second))
+		th.mongo.onWrite("delete", "properties", 2)
+		th.mongo.onWrite("delete", "shareLinks", 0)
+		th.mongo.onWrite("delete", "favorites", 0)
+		th.mongo.onCount("properties", 0)
+		th.mongo.onWrite("delete", "image_hashes", 1)
+	}
+
+	t.Run("deletes the listings", func(t *testing.T) {
+		th := newTestHandler(t)
+		expectDeletion(th)
+		th.storage.On("DeleteObjects", mock.Anything).Return(nil).Once()
+
+		resp, respBody := th.do(t, jsonRequest(http.MethodDelete, "/api/admin/properties", body))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, respBody)
+		}
+		var result models.BulkDeleteResponse
+		decode(t, respBody, &result)
+		if result.Deleted != 2 || result.NotFound != 1 || result.S3Errors != 0 {
+			t.Errorf("result = %+v, want 2 deleted and 1 not found", result)
+		}
+		deleted := strings.Join(th.storage.deletedKeys(), ",")
+		for _, key := range []string{"brochures/2-en.pdf", "brochures/3-ar.pdf", "brochures/5-en.pdf", "brochures/6-ar.pdf"} {
+			if !strings.Contains(deleted, key) {
+				t.Errorf("deleted %q, want %s among them", deleted, key)
+			}
+		}
+		if strings.Count(deleted, "properties/image-1.png") != 1 {
+			t.Errorf("deleted %q, want the shared image deleted once", deleted)
+		}
+		if !received(th.mongo, "delete image_hashes") {
+			t.Error("the hash record of the deleted image was kept")
+		}
+	})
+
+	t.Run("storage failure", func(t *testing.T) {
+		th := newTestHandler(t)
+		expectDeletion(th)
+		th.storage.On("DeleteObjects", mock.Anything).Return(errors.New("bucket unavailable")).Once()
+
+		resp, respBody := th.do(t, jsonRequest(http.MethodDelete, "/api/admin/properties", body))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, respBody)
+		}
+		var result models.BulkDeleteResponse
+		decode(t, respBody, &result)
+		if result.Deleted != 2 || result.S3Errors != len(th.storage.deletedKeys()) {
+			t.Errorf("result = %+v, want 2 deleted with every storage object counted as an error", result)
+		}
+		if received(th.mongo, "delete image_hashes") {
+			t.Error("removed the hash record of an image that is still stored")
+		}
+	})
+
+	t.Run("none found", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties")
+
+		resp, respBody := th.do(t, jsonRequest(http.MethodDelete, "/api/admin/properties", fmt.Sprintf(`{"ids": [%q]}`, missing.Hex())))
+		var result models.BulkDeleteResponse
+		decode(t, respBody, &result)
+		if resp.StatusCode != fiber.StatusOK || result.Deleted != 0 || result.NotFound != 1 {
+			t.Errorf("status = %d, result = %+v, want 200 with 1 not found", resp.StatusCode, result)
+		}
+		if received(th.mongo, "delete properties") {
+			t.Error("sent a delete without any listing to delete")
+		}
+	})
+
+	for name, body := range map[string]string{
+		"no IDs":         `{"ids": []}`,
+		"invalid ID":     `{"ids": ["not-an-id"]}`,
+		"malformed body": `{"ids":`,
+		"too many IDs":   `{"ids": [` + strings.Repeat(`"`+missing.Hex()+`",`, maxBulkDeleteIDs) + `"` + missing.Hex() + `"]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			th := newTestHandler(t)
+
+			resp, respBody := th.do(t, jsonRequest(http.MethodDelete, "/api/admin/properties", body))
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("status = %d, want 400: %s", resp.StatusCode, respBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CompareProperties generates a PDF comparing two listings side by side and uploads it to storage.
+// Comparison PDFs are not linked to either listing, so their URLs are not refreshed when they expire.
+// Password-protected listings are refused, since the comparison PDF would expose them unencrypted.
+//
+// @Summary      Compare two listings
+// @Tags         properties
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CompareRequest  true  "The two property IDs to compare"
+// @Success      201      {object}  models.CompareResponse
+// @Failure      400      {object}  models.ErrorResponse  "Invalid body or property IDs"
+// @Failure      403      {object}  models.ErrorResponse  "A listing is password protected"
+// @Failure      404      {object}  models.ErrorResponse  "Property not found"
+// @Failure      500      {object}  models.ErrorResponse  "Generation or database failure"
+// @Failure      502      {object}  models.ErrorResponse  "Upload failure"
+// @Router       /api/compare [post]
+func (h *PropertyHandler) CompareProperties(c *fiber.Ctx) error {
+	var req models.CompareRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+	}
+	if len(req.PropertyIDs) != 2 {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid property IDs", errors.New("propertyIds must contain exactly two IDs"))
+	}
+
+	var properties [2]*models.Property
+	for i, id := range req.PropertyIDs {
+		property, err := h.findProperty(id)
+		if err != nil {
+			return h.propertyLookupError(err)
+		}
+		if property.IsPasswordProtected {
+			return models.NewAPIError(models.ErrCodeForbidden, "Listing is password protected", fmt.Errorf("property %s has password-protected brochures and cannot be compared", id))
+		}
+		properties[i] = property
+	}
+
+	log.Printf("Generating comparison PDF for %s and %s...", req.PropertyIDs[0], req.PropertyIDs[1])
+	pdfData, err := h.pdfService.GenerateComparisonBrochure(properties[0], properties[1])
+	if err != nil {
+		log.Printf("Error generating comparison PDF: %v", err)
+		return models.NewAPIError(models.ErrCodePDFGeneration, "Failed to generate comparison PDF", err)
+	}
+
+	urls, err := h.storage.UploadPDFWithUrls(pdfData, "comparison_"+properties[0].Title+"_vs_"+properties[1].Title)
+	if err != nil {
+		log.Printf("Error uploading comparison PDF: %v", err)
+		return models.NewAPIError(models.ErrCodeS3Upload, "Failed to upload comparison PDF", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.CompareResponse{
+		Success:        true,
+		Message:        "Comparison PDF generated successfully",
+		PDFViewUrl:     urls.ViewUrl,
+		PDFDownloadUrl: urls.DownloadUrl,
+	})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSetCoverImage(t *testing.T) {
+	property := storedProperty()
+	property.ImageURLs = []string{
+		mockStorageURL + "properties/image-1.png",
+		mockStorageURL + "properties/image-2.png",
+		mockStorageURL + "properties/image-3.png",
+	}
+	coverPath := "/api/property/" + property.ID.Hex() + "/set-cover-image"
+
+	t.Run("moves the image to the front", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onWrite("update", "properties", 1)
+
+		resp, body := th.do(t, jsonRequest(http.MethodPost, coverPath, `{"imageIndex": 2}`))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		var result models.PropertyImagesResponse
+		decode(t, body, &result)
+		want := []string{property.ImageURLs[2], property.ImageURLs[0], property.ImageURLs[1]}
+		if strings.Join(result.ImageURLs, ",") != strings.Join(want, ",") {
+			t.Errorf("images = %v, want %v", result.ImageURLs, want)
+		}
+	})
+
+	t.Run("already the cover", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+
+		resp, body := th.do(t, jsonRequest(http.MethodPost, coverPath, `{"imageIndex": 0}`))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		if received(th.mongo, "update properties") {
+			t.Error("saved an unchanged image order")
+		}
+	})
+
+	t.Run("images changed concurrently", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onWrite("update", "properties", 0)
+
+		resp, body := th.do(t, jsonRequest(http.MethodPost, coverPath, `{"imageIndex": 1}`))
+		if resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("status = %d, want 409: %s", resp.StatusCode, body)
+		}
+	})
+
+	for name, body := range map[string]string{
+		"index out of range": `{"imageIndex": 3}`,
+		"negative index":     `{"imageIndex": -1}`,
+		"missing index":      `{}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			th := newTestHandler(t)
+			th.mongo.onFind("properties", propertyDocument(t, property))
+
+			resp, respBody := th.do(t, jsonRequest(http.MethodPost, coverPath, body))
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("status = %d, want 400: %s", resp.StatusCode, respBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+)
+
+const (
+	// brochureOverheadKB approximates a brochure without photos: embedded fonts, text pages and QR codes
+	brochureOverheadKB = 300
+
+	// brochureImageRatio is the typical size of an embedded photo relative to its upload, after
+	// re-encoding and PDF optimization
+	brochureImageRatio = 0.8
+)
+
+// dryRunProperty runs the validation of a submission and estimates its cost without uploading images,
+// calling OpenAI, rendering brochures or storing the listing. Validation failures are listed in the
+// response instead of being returned as errors.
+func (h *PropertyHandler) dryRunProperty(req *models.PropertyRequest, images []*multipart.FileHeader) *models.DryRunResponse {
+	var problems []string
+	addProblem := func(err error) {
+		var apiErr *models.APIError
+		if errors.As(err, &apiErr) && apiErr.Err != nil {
+			err = apiErr.Err
+		}
+		problems = append(problems, err.Error())
+	}
+
+	if err := h.checkImageCount(req, len(images)); err != nil {
+		addProblem(err)
+	}
+	if len(req.Amenities) == 0 && req.PropertyType != "" {
+		req.Amenities = services.DefaultAmenitiesFor(req.PropertyType)
+	}
+	if err := h.validateRequest(req); err != nil {
+		addProblem(err)
+	}
+	if req.FontID != "" {
+		if _, err := h.fonts.LoadFont(req.FontID); errors.Is(err, services.ErrFontNotFound) {
+			addProblem(fmt.Errorf("no uploaded font has ID %s", req.FontID))
+		}
+	}
+
+	var imageBytes int64
+	for _, fileHeader := range images {
+		if fileHeader.Size > h.maxFileSize {
+			addProblem(fmt.Errorf("File %s is too large", fileHeader.Filename))
+		}
+		if !h.isAllowedFileType(fileHeader.Header.Get("Content-Type")) {
+			addProblem(fmt.Errorf("File %s has invalid type", fileHeader.Filename))
+		}
+		imageBytes += fileHeader.Size
+	}
+
+	// Comparable sales are only generated while the section is switched on
+	estimated := *req
+	estimated.IncludeComps = req.IncludeComps && h.features.Flags().EnableCompsSection
+
+	return &models.DryRunResponse{
+		Success:             true,
+		ValidationPassed:    len(problems) == 0,
+		ValidationErrors:    problems,
+		EstimatedOpenAICost: fmt.Sprintf("$%.4f", h.openaiService.EstimateSubmissionCost(&estimated)),
+		ImageCount:          len(images),
+		EstimatedPDFSizeKB:  brochureOverheadKB + int(float64(imageBytes)*brochureImageRatio/1024),
+	}
+}
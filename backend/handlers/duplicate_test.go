@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDuplicateProperty(t *testing.T) {
+	t.Run("copies the listing with its images", func(t *testing.T) {
+		th := newTestHandler(t)
+		source := storedProperty()
+		th.mongo.onFind("properties", propertyDocument(t, source))
+		th.expectSubmission()
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodPost, "/api/property/"+source.ID.Hex()+"/duplicate", nil))
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("status = %d, want 201: %s", resp.StatusCode, body)
+		}
+		var created models.PropertyResponse
+		decode(t, body, &created)
+		if created.PropertyID == "" || created.PropertyID == source.ID.Hex() {
+			t.Errorf("property ID = %q, want a new listing", created.PropertyID)
+		}
+		th.ai.AssertCalled(t, "GeneratePropertyContent", "Marina View Apartment (Copy)", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		th.storage.AssertNotCalled(t, "UploadFile", mock.Anything, mock.Anything, mock.Anything)
+		if got := strings.Join(th.brochures.rendered(), ","); got != "en,ar" {
+			t.Errorf("rendered brochures = %q, want en,ar", got)
+		}
+		if !received(th.mongo, "insert properties") {
+			t.Error("the copy was not stored")
+		}
+	})
+
+	t.Run("listing without amenities", func(t *testing.T) {
+		th := newTestHandler(t)
+		source := storedProperty()
+		source.PropertyType = "Villa"
+		th.mongo.onFind("properties", propertyDocument(t, source))
+		th.expectSubmission()
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodPost, "/api/property/"+source.ID.Hex()+"/duplicate", nil))
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("status = %d, want 201: %s", resp.StatusCode, body)
+		}
+		th.ai.AssertCalled(t, "GeneratePropertyContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, services.DefaultAmenitiesFor("Villa"))
+	})
+
+	t.Run("password-protected listing without a password", func(t *testing.T) {
+		th := newTestHandler(t)
+		source := storedProperty()
+		source.IsPasswordProtected = true
+		th.mongo.onFind("properties", propertyDocument(t, source))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodPost, "/api/property/"+source.ID.Hex()+"/duplicate", nil))
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("status = %d, want 400: %s", resp.StatusCode, body)
+		}
+		if len(th.brochures.rendered()) != 0 {
+			t.Errorf("rendered %v, want nothing", th.brochures.rendered())
+		}
+	})
+
+	t.Run("password-protected listing", func(t *testing.T) {
+		th := newTestHandler(t)
+		source := storedProperty()
+		source.IsPasswordProtected = true
+		th.mongo.onFind("properties", propertyDocument(t, source))
+		th.brochures.On("EncryptPDF", mock.Anything, "s3cret").Return([]byte("%PDF-1.4 encrypted"), nil).Twice()
+		th.expectSubmission()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/property/"+source.ID.Hex()+"/duplicate", strings.NewReader("pdfPassword=s3cret"))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+		resp, body := th.do(t, req)
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("status = %d, want 201: %s", resp.StatusCode, body)
+		}
+		var created models.PropertyResponse
+		decode(t, body, &created)
+		if !created.PasswordProtected {
+			t.Error("the copy's brochures are not password protected")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties")
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodPost, "/api/property/"+primitive.NewObjectID().Hex()+"/duplicate", nil))
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("status = %d, want 404: %s", resp.StatusCode, body)
+		}
+	})
+}
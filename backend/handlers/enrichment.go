@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"log"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"strings"
+)
+
+// enrichMissingFields fills an empty city, state, zip code or coordinates by geocoding the address and,
+// when infer is set, the bedrooms of an apartment from its description, recording the filled fields on
+// the request. Lookup failures are logged and leave the request for validation to reject.
+func (h *PropertyHandler) enrichMissingFields(req *models.PropertyRequest, infer bool) {
+	h.enrichFromAddress(req)
+	if infer && services.InfersBedrooms(req) {
+		h.enrichBedrooms(req)
+	}
+	if len(req.EnrichedFields) > 0 {
+		log.Printf("Enriched missing fields: %s", strings.Join(req.EnrichedFields, ", "))
+	}
+}
+
+// enrichFromAddress fills an empty city, state, zip code or coordinates by geocoding the address
+func (h *PropertyHandler) enrichFromAddress(req *models.PropertyRequest) {
+	complete := req.City != "" && req.State != "" && req.ZipCode != "" && req.Latitude != nil
+	if h.geocoder == nil || req.Address == "" || complete {
+		return
+	}
+
+	// Whatever location details were given narrow the search
+	parts := []string{req.Address}
+	for _, part := range []string{req.City, req.State, req.ZipCode} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	geocoded, err := h.geocoder.Geocode(strings.Join(parts, ", "))
+	if err != nil {
+		log.Printf("Error geocoding address for enrichment: %v", err)
+		return
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+		found string
+	}{
+		{"city", &req.City, geocoded.City},
+		{"state", &req.State, geocoded.State},
+		{"zipCode", &req.ZipCode, geocoded.ZipCode},
+	} {
+		if *field.value == "" && field.found != "" {
+			*field.value = field.found
+			req.EnrichedFields = append(req.EnrichedFields, field.name)
+		}
+	}
+	if req.Latitude == nil && req.Longitude == nil && geocoded.Latitude != nil {
+		req.Latitude, req.Longitude = geocoded.Latitude, geocoded.Longitude
+		req.EnrichedFields = append(req.EnrichedFields, "coordinates")
+	}
+}
+
+// enrichBedrooms fills the bedrooms of an apartment from its description. Counts the description does
+// not tell, or outside what validation accepts, leave the field empty.
+func (h *PropertyHandler) enrichBedrooms(req *models.PropertyRequest) {
+	bedrooms, err := h.openaiService.InferBedrooms(req.PropertyType, req.Description)
+	if err != nil {
+		log.Printf("Error inferring bedrooms for enrichment: %v", err)
+		return
+	}
+	if bedrooms < 1 || bedrooms > maxBedrooms {
+		return
+	}
+	req.Bedrooms = bedrooms
+	req.EnrichedFields = append(req.EnrichedFields, "bedrooms")
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEnrichBedrooms(t *testing.T) {
+	tests := []struct {
+		name         string
+		fields       func(map[string]string)
+		inferred     int
+		wantInferred bool
+		wantBedrooms int32
+	}{
+		{
+			name:         "apartment without bedrooms",
+			inferred:     2,
+			wantInferred: true,
+			wantBedrooms: 2,
+		},
+		{
+			name:         "bedrooms given",
+			fields:       func(f map[string]string) { f["bedrooms"] = "3" },
+			inferred:     2,
+			wantBedrooms: 3,
+		},
+		{
+			name:     "not an apartment",
+			fields:   func(f map[string]string) { f["propertyType"] = "Villa" },
+			inferred: 2,
+		},
+		{
+			name:         "implausible count",
+			inferred:     250,
+			wantInferred: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := newTestHandler(t)
+			th.ai.On("InferBedrooms", "Apartment", mock.Anything).Return(tt.inferred, nil).Maybe()
+			th.expectSubmission()
+
+			fields := validFields()
+			fields["propertyType"] = "Apartment"
+			fields["description"] = "Two bedroom apartment with a marina view."
+			fields["enrichMissingFields"] = "true"
+			if tt.fields != nil {
+				tt.fields(fields)
+			}
+			resp, body := th.do(t, submitRequest(t, fields, pngImage))
+			if resp.StatusCode != fiber.StatusCreated {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, fiber.StatusCreated, body)
+			}
+
+			if tt.wantInferred {
+				th.ai.AssertCalled(t, "InferBedrooms", "Apartment", fields["description"])
+			} else {
+				th.ai.AssertNotCalled(t, "InferBedrooms", mock.Anything, mock.Anything)
+			}
+			inserts := th.mongo.sent("insert properties")
+			if len(inserts) != 1 {
+				t.Fatalf("sent %d property inserts, want 1", len(inserts))
+			}
+			stored := inserts[0].Lookup("documents", "0").Document()
+			bedrooms, _ := stored.Lookup("bedrooms").Int32OK()
+			if bedrooms != tt.wantBedrooms {
+				t.Errorf("stored bedrooms = %d, want %d", bedrooms, tt.wantBedrooms)
+			}
+			enriched, _ := stored.Lookup("enriched").BooleanOK()
+			if want := tt.wantInferred && tt.wantBedrooms > 0; enriched != want {
+				t.Errorf("stored enriched = %t, want %t", enriched, want)
+			}
+		})
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.expectSubmission()
+		th.ai.On("EstimateSubmissionCost", mock.Anything).Return(0.001).Maybe()
+
+		fields := validFields()
+		fields["propertyType"] = "Apartment"
+		fields["description"] = "Two bedroom apartment with a marina view."
+		fields["enrichMissingFields"] = "true"
+		fields["dryRun"] = "true"
+		resp, body := th.do(t, submitRequest(t, fields, pngImage))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", resp.StatusCode, fiber.StatusOK, body)
+		}
+		th.ai.AssertNotCalled(t, "InferBedrooms", mock.Anything, mock.Anything)
+	})
+}
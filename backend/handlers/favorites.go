@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxUserIDLength limits the user IDs favorites are stored under
+const maxUserIDLength = 128
+
+// AddFavorite bookmarks a listing for a user; bookmarking it again changes nothing
+//
+// @Summary      Bookmark a listing
+// @Tags         favorites
+// @Produce      json
+// @Param        userId      path      string  true  "User ID"
+// @Param        propertyId  path      string  true  "Property ID"
+// @Success      201  {object}  models.FavoriteResponse  "Bookmarked"
+// @Success      200  {object}  models.FavoriteResponse  "Already bookmarked"
+// @Failure      400  {object}  models.ErrorResponse  "Invalid user or property ID"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/user/{userId}/favorites/{propertyId} [post]
+func (h *PropertyHandler) AddFavorite(c *fiber.Ctx) error {
+	userID, err := favoriteUserID(c)
+	if err != nil {
+		return err
+	}
+	property, err := h.findProperty(c.Params("propertyId"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	added, err := h.favorites.Add(userID, property.ID)
+	if err != nil {
+		log.Printf("Error adding favorite: %v", err)
+		return models.NewAPIError(models.ErrCodeMongoInsert, "Failed to save favorite", err)
+	}
+	if !added {
+		return c.JSON(models.FavoriteResponse{
+			Success:        true,
+			Message:        "Property already in favorites",
+			FavoritesCount: property.FavoritesCount,
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(models.FavoriteResponse{
+		Success:        true,
+		Message:        "Property added to favorites",
+		FavoritesCount: h.adjustFavoritesCount(property, 1),
+	})
+}
+
+// RemoveFavorite deletes a user's bookmark of a listing
+//
+// @Summary      Remove a bookmark
+// @Tags         favorites
+// @Produce      json
+// @Param        userId      path      string  true  "User ID"
+// @Param        propertyId  path      string  true  "Property ID"
+// @Success      200  {object}  models.FavoriteResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid user or property ID"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found or not in the user's favorites"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/user/{userId}/favorites/{propertyId} [delete]
+func (h *PropertyHandler) RemoveFavorite(c *fiber.Ctx) error {
+	userID, err := favoriteUserID(c)
+	if err != nil {
+		return err
+	}
+	property, err := h.findProperty(c.Params("propertyId"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	removed, err := h.favorites.Remove(userID, property.ID)
+	if err != nil {
+		log.Printf("Error removing favorite: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to remove favorite", err)
+	}
+	if !removed {
+		return models.NewAPIError(models.ErrCodeNotFound, "Property not in favorites", nil)
+	}
+	return c.JSON(models.FavoriteResponse{
+		Success:        true,
+		Message:        "Property removed from favorites",
+		FavoritesCount: h.adjustFavoritesCount(property, -1),
+	})
+}
+
+// adjustFavoritesCount moves the listing's favoritesCount after a bookmark change and returns the new
+// count. The bookmark is already saved, so a failed update is logged and the count estimated from the
+// listing as loaded.
+func (h *PropertyHandler) adjustFavoritesCount(property *models.Property, delta int) int64 {
+	count, err := h.favorites.AdjustCount(property.ID, delta)
+	if err != nil {
+		log.Printf("Error updating favorites count of property %s: %v", property.ID.Hex(), err)
+		return property.FavoritesCount + int64(delta)
+	}
+	return count
+}
+
+// GetFavorites returns the listings a user bookmarked, most recent first, with freshly signed image
+// and brochure URLs. Listings deleted since they were bookmarked are left out.
+//
+// @Summary      List a user's bookmarks
+// @Tags         favorites
+// @Produce      json
+// @Param        userId  path      string  true  "User ID"
+// @Success      200  {object}  models.FavoritesResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid user ID"
+// @Failure      500  {object}  models.ErrorResponse  "Database or URL signing failure"
+// @Router       /api/user/{userId}/favorites [get]
+func (h *PropertyHandler) GetFavorites(c *fiber.Ctx) error {
+	userID, err := favoriteUserID(c)
+	if err != nil {
+		return err
+	}
+
+	ids, err := h.favorites.PropertyIDs(userID)
+	if err != nil {
+		log.Printf("Error loading favorites: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to load favorites", err)
+	}
+
+	properties := []models.Property{}
+	if len(ids) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		cursor, err := h.mongoService.GetCollection("properties").Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+		if err != nil {
+			log.Printf("Error loading favorite properties: %v", err)
+			return models.NewAPIError(models.ErrCodeInternal, "Failed to load favorites", err)
+		}
+		var found []models.Property
+		if err := cursor.All(ctx, &found); err != nil {
+			log.Printf("Error decoding favorite properties: %v", err)
+			return models.NewAPIError(models.ErrCodeInternal, "Failed to load favorites", err)
+		}
+
+		// Keep the bookmark order, which $in does not preserve
+		byID := make(map[string]models.Property, len(found))
+		for _, property := range found {
+			byID[property.ID.Hex()] = property
+		}
+		for _, id := range ids {
+			property, ok := byID[id.Hex()]
+			if !ok {
+				continue
+			}
+			if err := h.decryptProperty(&property); err != nil {
+				log.Printf("Error decrypting favorite property: %v", err)
+				return models.NewAPIError(models.ErrCodeInternal, "Failed to load favorites", err)
+			}
+			if err := h.resignPropertyURLs(&property); err != nil {
+				log.Printf("Error signing URLs of property %s: %v", property.ID.Hex(), err)
+				return models.NewAPIError(models.ErrCodeInternal, "Failed to sign property URLs", err)
+			}
+			properties = append(properties, property)
+		}
+	}
+
+	return c.JSON(models.FavoritesResponse{
+		Success:    true,
+		Count:      len(properties),
+		Properties: properties,
+	})
+}
+
+// favoriteUserID reads the userId path parameter
+func favoriteUserID(c *fiber.Ctx) (string, error) {
+	userID := strings.TrimSpace(c.Params("userId"))
+	if userID == "" || len(userID) > maxUserIDLength || !isPrintableText(userID) {
+		return "", models.NewAPIError(models.ErrCodeValidation, "Invalid user ID", fmt.Errorf("userId must be 1-%d characters", maxUserIDLength))
+	}
+	return userID, nil
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestFavorites(t *testing.T) {
+	property := storedProperty()
+	property.ImageURLs = []string{signedStorageURL("properties/image-1.png")}
+	favoritePath := "/api/user/user-1/favorites/" + property.ID.Hex()
+
+	t.Run("add", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onWrite("insert", "favorites", 1)
+		th.mongo.on("findAndModify", "properties", valueResponse(bson.D{{Key: "favoritesCount", Value: 1}}))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodPost, favoritePath, nil))
+		var result models.FavoriteResponse
+		decode(t, body, &result)
+		if resp.StatusCode != fiber.StatusCreated || result.FavoritesCount != 1 {
+			t.Errorf("status = %d, response = %+v, want 201 with one favorite", resp.StatusCode, result)
+		}
+	})
+
+	t.Run("add again", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.on("insert", "favorites", mtest.CreateWriteErrorsResponse(mtest.WriteError{Code: 11000, Message: "duplicate key"}))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodPost, favoritePath, nil))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		if received(th.mongo, "findAndModify properties") {
+			t.Error("counted a repeated bookmark")
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onWrite("delete", "favorites", 1)
+		th.mongo.on("findAndModify", "properties", valueResponse(bson.D{{Key: "favoritesCount", Value: 0}}))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodDelete, favoritePath, nil))
+		var result models.FavoriteResponse
+		decode(t, body, &result)
+		if resp.StatusCode != fiber.StatusOK || result.FavoritesCount != 0 {
+			t.Errorf("status = %d, response = %+v, want 200 with no favorites", resp.StatusCode, result)
+		}
+	})
+
+	t.Run("remove a listing not in the favorites", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onWrite("delete", "favorites", 0)
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodDelete, favoritePath, nil))
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("status = %d, want 404: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		th := newTestHandler(t)
+		deleted := primitive.NewObjectID()
+		th.mongo.onFind("favorites",
+			bson.D{{Key: "userId", Value: "user-1"}, {Key: "propertyId", Value: deleted}},
+			bson.D{{Key: "userId", Value: "user-1"}, {Key: "propertyId", Value: property.ID}},
+		)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/user/user-1/favorites", nil))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		var result models.FavoritesResponse
+		decode(t, body, &result)
+		if result.Count != 1 || len(result.Properties) != 1 || result.Properties[0].ID != property.ID {
+			t.Fatalf("favorites = %+v, want the remaining listing", result)
+		}
+		if got := result.Properties[0].ImageURLs; len(got) != 1 || got[0] != mockStorageURL+"properties/image-1.png?signed" {
+			t.Errorf("image URLs = %v, want them re-signed", got)
+		}
+	})
+
+	t.Run("invalid user ID", func(t *testing.T) {
+		th := newTestHandler(t)
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/user/"+strings.Repeat("u", maxUserIDLength+1)+"/favorites", nil))
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("status = %d, want 400: %s", resp.StatusCode, body)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FileHandler struct {
+	storage *services.LocalStorageService
+}
+
+func NewFileHandler(storage *services.LocalStorageService) *FileHandler {
+	return &FileHandler{storage: storage}
+}
+
+// ServeFile serves a locally stored file, rejecting links whose TTL has elapsed since the file was written
+//
+// @Summary      Download a locally stored file
+// @Description  Only registered when STORAGE_BACKEND=local.
+// @Tags         files
+// @Produce      octet-stream
+// @Param        path         path   string   true   "Object key"
+// @Param        ttl          query  integer  false  "Link lifetime in seconds, capped at 7 days"
+// @Param        disposition  query  string   false  "Content-Disposition type"  Enums(inline, attachment)
+// @Param        filename     query  string   false  "Suggested file name"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  models.ErrorResponse  "Invalid file path"
+// @Failure      404  {object}  models.ErrorResponse  "File not found"
+// @Failure      410  {object}  models.ErrorResponse  "Link has expired"
+// @Failure      500  {object}  models.ErrorResponse  "File could not be read"
+// @Router       /files/{path} [get]
+func (h *FileHandler) ServeFile(c *fiber.Ctx) error {
+	path, err := h.storage.Path(c.Params("*"))
+	if err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid file path", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return models.NewAPIError(models.ErrCodeNotFound, "File not found", nil)
+	}
+
+	// TTL is capped at the standard URL expiry so links can't be extended by editing the query
+	ttl := services.URLExpirationTime
+	if seconds, err := strconv.ParseInt(c.Query("ttl"), 10, 64); err == nil && seconds > 0 {
+		if requested := time.Duration(seconds) * time.Second; requested < ttl {
+			ttl = requested
+		}
+	}
+	if time.Since(info.ModTime()) > ttl {
+		return models.NewAPIError(models.ErrCodeGone, "Link has expired", nil)
+	}
+
+	switch disposition := c.Query("disposition"); disposition {
+	case "inline", "attachment":
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("%s; filename=%q", disposition, c.Query("filename")))
+	}
+
+	// The bytes go through Send rather than SendFile so the compress middleware can gzip them
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to read file", err)
+	}
+	c.Type(filepath.Ext(path))
+	return c.Send(data)
+}
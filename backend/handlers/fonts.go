@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"path/filepath"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FontHandler struct {
+	fonts *services.FontService
+}
+
+func NewFontHandler(fonts *services.FontService) *FontHandler {
+	return &FontHandler{fonts: fonts}
+}
+
+// UploadFont stores an agency brand font that submissions can select with fontId
+//
+// @Summary      Upload a brand font
+// @Tags         admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        font      formData  file    true   "TrueType font (.ttf or TrueType-flavoured .otf, max 2MB)"
+// @Param        name      formData  string  false  "Display name, defaults to the file name"
+// @Param        language  formData  string  false  "Text the font replaces: en (body) or ar (Arabic)"  Enums(en, ar)  default(en)
+// @Success      201  {object}  models.FontResponse
+// @Failure      400  {object}  models.ErrorResponse  "Missing or invalid font"
+// @Failure      413  {object}  models.ErrorResponse  "Font larger than 2MB"
+// @Failure      415  {object}  models.ErrorResponse  "Not a .ttf or .otf file"
+// @Failure      500  {object}  models.ErrorResponse  "Upload or database failure"
+// @Failure      401  {object}  models.ErrorResponse  "Missing admin token (ERR_UNAUTHORIZED)"
+// @Failure      403  {object}  models.ErrorResponse  "Invalid admin token or admin API disabled (ERR_FORBIDDEN)"
+// @Security     AdminToken
+// @Router       /api/admin/fonts [post]
+func (h *FontHandler) UploadFont(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("font")
+	if err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Font file is required", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	if ext != ".ttf" && ext != ".otf" {
+		return models.NewAPIError(models.ErrCodeInvalidType, "Invalid file type", errors.New("only .ttf and .otf fonts are accepted"))
+	}
+	if fileHeader.Size > services.MaxFontSize {
+		return models.NewAPIError(models.ErrCodeFileTooLarge, "File size exceeds maximum allowed size", errors.New("fonts must be at most 2MB"))
+	}
+
+	language := c.FormValue("language", "en")
+	if language != "en" && language != "ar" {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid language", errors.New("language must be \"en\" or \"ar\""))
+	}
+	name := strings.TrimSpace(c.FormValue("name"))
+	if name == "" {
+		name = strings.TrimSuffix(fileHeader.Filename, filepath.Ext(fileHeader.Filename))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("Error opening font file: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to read font", err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Printf("Error reading font file: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to read font", err)
+	}
+
+	if err := services.ValidateFont(data); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid font", err)
+	}
+
+	font, err := h.fonts.Upload(name, language, ext, data)
+	if err != nil {
+		log.Printf("Error uploading font: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to upload font", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.FontResponse{
+		Success: true,
+		Message: "Font uploaded successfully",
+		FontID:  font.ID.Hex(),
+	})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestGetPropertyImages(t *testing.T) {
+	property := storedProperty()
+	property.ImageURLs = nil
+	for i := 1; i <= 5; i++ {
+		property.ImageURLs = append(property.ImageURLs, signedStorageURL(fmt.Sprintf("properties/image-%d.png", i)))
+	}
+
+	tests := []struct {
+		name  string
+		query string
+
+		wantStatus int
+		wantImages []string
+		wantPages  int
+	}{
+		{
+			name:       "first page",
+			query:      "",
+			wantStatus: fiber.StatusOK,
+			wantImages: []string{"image-1", "image-2", "image-3", "image-4", "image-5"},
+			wantPages:  1,
+		},
+		{
+			name:       "middle page",
+			query:      "?page=2&limit=2",
+			wantStatus: fiber.StatusOK,
+			wantImages: []string{"image-3", "image-4"},
+			wantPages:  3,
+		},
+		{
+			name:       "last, partial page",
+			query:      "?page=3&limit=2",
+			wantStatus: fiber.StatusOK,
+			wantImages: []string{"image-5"},
+			wantPages:  3,
+		},
+		{
+			name:       "past the last page",
+			query:      "?page=4&limit=2",
+			wantStatus: fiber.StatusOK,
+			wantPages:  3,
+		},
+		{name: "page zero", query: "?page=0", wantStatus: fiber.StatusBadRequest},
+		{name: "limit too large", query: "?limit=51", wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := newTestHandler(t)
+			th.mongo.onFind("properties", propertyDocument(t, property))
+
+			resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex()+"/images"+tt.query, nil))
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantStatus != fiber.StatusOK {
+				return
+			}
+			var gallery models.PropertyGalleryResponse
+			decode(t, body, &gallery)
+			if gallery.TotalCount != 5 || gallery.TotalPages != tt.wantPages {
+				t.Errorf("total = %d images in %d pages, want 5 in %d", gallery.TotalCount, gallery.TotalPages, tt.wantPages)
+			}
+			var want []string
+			for _, name := range tt.wantImages {
+				want = append(want, mockStorageURL+"properties/"+name+".png?signed")
+			}
+			if strings.Join(gallery.ImageURLs, ",") != strings.Join(want, ",") {
+				t.Errorf("images = %v, want the re-signed %v", gallery.ImageURLs, want)
+			}
+		})
+	}
+}
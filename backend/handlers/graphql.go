@@ -0,0 +1,754 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"property-brochure-backend/middleware"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/graph-gophers/graphql-go"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Page size of Query.properties when no limit is given, and the largest accepted limit
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// GraphQLHandler serves the GraphQL API; its resolvers reuse the PropertyHandler logic behind the REST endpoints
+type GraphQLHandler struct {
+	schema *graphql.Schema
+}
+
+// NewGraphQLHandler creates the handler; deleteProperty requires adminToken like the admin API does
+func NewGraphQLHandler(properties *PropertyHandler, adminToken string) *GraphQLHandler {
+	resolver := &graphqlResolver{properties: properties, adminToken: adminToken}
+	return &GraphQLHandler{
+		schema: graphql.MustParseSchema(graphqlSchema, resolver, graphql.UseFieldResolvers()),
+	}
+}
+
+// graphqlRequest is the body of a GraphQL request, or the "operations" field of a multipart one
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// uploadsKey is the context key of the files sent with a multipart GraphQL request
+type uploadsKey struct{}
+
+// authorizationKey is the context key of the Authorization header of a GraphQL request
+type authorizationKey struct{}
+
+// Execute runs a GraphQL operation. Multipart requests follow the GraphQL multipart request
+// specification so that submitProperty can receive its images as file uploads.
+func (h *GraphQLHandler) Execute(c *fiber.Ctx) error {
+	var req graphqlRequest
+	uploads := map[string]*multipart.FileHeader{}
+
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		form, err := c.MultipartForm()
+		if err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid form data", err)
+		}
+		if err := parseMultipartOperation(form, &req, uploads); err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid GraphQL multipart request", err)
+		}
+	} else if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid GraphQL request", err)
+	}
+
+	ctx := context.WithValue(c.UserContext(), uploadsKey{}, uploads)
+	ctx = context.WithValue(ctx, authorizationKey{}, c.Get(fiber.HeaderAuthorization))
+	return c.JSON(h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
+
+// parseMultipartOperation reads the "operations" and "map" fields of a multipart request. Every variable
+// path listed in the map is set to the name of its file field, which the Upload scalar then refers to.
+func parseMultipartOperation(form *multipart.Form, req *graphqlRequest, uploads map[string]*multipart.FileHeader) error {
+	if len(form.Value["operations"]) != 1 || len(form.Value["map"]) != 1 {
+		return errors.New(`exactly one "operations" and one "map" field are required`)
+	}
+	var operation map[string]interface{}
+	if err := json.Unmarshal([]byte(form.Value["operations"][0]), &operation); err != nil {
+		return fmt.Errorf("operations: %w", err)
+	}
+	var fileMap map[string][]string
+	if err := json.Unmarshal([]byte(form.Value["map"][0]), &fileMap); err != nil {
+		return fmt.Errorf("map: %w", err)
+	}
+
+	for field, paths := range fileMap {
+		files := form.File[field]
+		if len(files) != 1 {
+			return fmt.Errorf("map refers to missing file field %q", field)
+		}
+		uploads[field] = files[0]
+		for _, path := range paths {
+			if err := setOperationPath(operation, strings.Split(path, "."), field); err != nil {
+				return fmt.Errorf("map path %q: %w", path, err)
+			}
+		}
+	}
+
+	// Round-trip through JSON to read the patched operation into the request
+	data, err := json.Marshal(operation)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, req)
+}
+
+// setOperationPath replaces the value at a dotted path such as variables.input.images.0
+func setOperationPath(node interface{}, path []string, value string) error {
+	if len(path) == 0 {
+		return errors.New("empty path")
+	}
+	switch current := node.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			current[path[0]] = value
+			return nil
+		}
+		return setOperationPath(current[path[0]], path[1:], value)
+	case []interface{}:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index < 0 || index >= len(current) {
+			return fmt.Errorf("invalid list index %q", path[0])
+		}
+		if len(path) == 1 {
+			current[index] = value
+			return nil
+		}
+		return setOperationPath(current[index], path[1:], value)
+	default:
+		return fmt.Errorf("%q does not exist", path[0])
+	}
+}
+
+// upload is the Upload scalar: the multipart field name of a file sent with the request
+type upload struct {
+	field string
+}
+
+func (upload) ImplementsGraphQLType(name string) bool {
+	return name == "Upload"
+}
+
+func (u *upload) UnmarshalGraphQL(input interface{}) error {
+	field, ok := input.(string)
+	if !ok {
+		return errors.New("Upload values must be sent as multipart files")
+	}
+	u.field = field
+	return nil
+}
+
+// graphqlResolver resolves the Query and Mutation root fields
+type graphqlResolver struct {
+	properties *PropertyHandler
+	adminToken string
+}
+
+func (r *graphqlResolver) Property(args struct{ ID graphql.ID }) (*propertyResolver, error) {
+	property, err := r.properties.findProperty(string(args.ID))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &propertyResolver{Property: *property}, nil
+}
+
+type propertiesArgs struct {
+	Filters *struct {
+		City         *string
+		State        *string
+		PropertyType *string
+		MinPrice     *float64
+		MaxPrice     *float64
+	}
+	Pagination *struct {
+		Limit  *int32
+		Offset *int32
+	}
+}
+
+func (r *graphqlResolver) Properties(args propertiesArgs) (*propertyPage, error) {
+	var filter propertyFilter
+	if f := args.Filters; f != nil {
+		filter = propertyFilter{
+			City:         stringValue(f.City),
+			State:        stringValue(f.State),
+			PropertyType: stringValue(f.PropertyType),
+			MinPrice:     f.MinPrice,
+			MaxPrice:     f.MaxPrice,
+		}
+	}
+
+	limit, offset := defaultPageSize, 0
+	if p := args.Pagination; p != nil {
+		if p.Limit != nil {
+			limit = int(*p.Limit)
+		}
+		if p.Offset != nil {
+			offset = int(*p.Offset)
+		}
+	}
+	if limit < 1 || limit > maxPageSize {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+
+	properties, total, err := r.properties.listProperties(filter, limit, offset)
+	if err != nil {
+		log.Printf("Error listing properties: %v", err)
+		return nil, err
+	}
+	page := &propertyPage{Items: make([]*propertyResolver, len(properties)), Total: int32(total)}
+	for i := range properties {
+		page.Items[i] = &propertyResolver{Property: properties[i]}
+	}
+	return page, nil
+}
+
+type agentInput struct {
+	Name    string
+	Email   string
+	Phone   string
+	Website *string
+}
+
+type whiteLabelInput struct {
+	LogoUrl      *string
+	Headline     *string
+	Message      *string
+	InstagramUrl *string
+	FacebookUrl  *string
+	LinkedInUrl  *string
+	Website      *string
+}
+
+type propertyInput struct {
+	Title           string
+	Description     *string
+	Price           float64
+	Currency        *string
+	Address         string
+	City            string
+	State           string
+	ZipCode         string
+	Latitude        *float64
+	Longitude       *float64
+	Amenities       *[]string
+	Images          []upload
+	Agent           *agentInput
+	AgentId         *graphql.ID
+	SecondaryAgents *[]agentInput
+	CoListingAgents *[]agentInput
+	FloorPlanUrl    *string
+	FloorPlanWidth  *float64
+	FloorPlanHeight *float64
+	VirtualTourUrl  *string
+	VideoUrl        *string
+	PropertyType    *string
+	Bedrooms        *int32
+	Status          *string
+	IncludeComps    *bool
+	VirtualStaging  *string
+	Mortgage        *struct {
+		DownPaymentPct *float64
+		InterestRate   *float64
+		TermYears      *int32
+	}
+	PrintMode                  *bool
+	PrintReady                 *bool
+	Bilingual                  *bool
+	Landscape                  *bool
+	PageSize                   *string
+	PageOrder                  *[]string
+	MarginMm                   *int32
+	Languages                  *[]string
+	AdditionalSectionTitle     *string
+	AdditionalSectionContent   *string
+	AdditionalSectionTitleAr   *string
+	AdditionalSectionContentAr *string
+	ThankYouMessageEn          *string
+	ThankYouMessageAr          *string
+	WhiteLabel                 *whiteLabelInput
+	FontId                     *graphql.ID
+	ThemePreset                *string
+	PdfPassword                *string
+	EnrichMissingFields        *bool
+	ValidateAddress            *bool
+}
+
+func (r *graphqlResolver) SubmitProperty(ctx context.Context, args struct{ Input propertyInput }) (*submitPropertyResult, error) {
+	in := args.Input
+
+	uploads, _ := ctx.Value(uploadsKey{}).(map[string]*multipart.FileHeader)
+	images := make([]*multipart.FileHeader, len(in.Images))
+	for i, image := range in.Images {
+		fileHeader, ok := uploads[image.field]
+		if !ok {
+			return nil, fmt.Errorf("images[%d] refers to missing file field %q", i, image.field)
+		}
+		images[i] = fileHeader
+	}
+
+	// Same defaults and trimming as the REST form parsing
+	req := models.PropertyRequest{
+		Title:       in.Title,
+		Description: stringValue(in.Description),
+		Price:       in.Price,
+		Currency:    "Dollar",
+		Address:     in.Address,
+		City:        in.City,
+		State:       in.State,
+		ZipCode:     in.ZipCode,
+		Latitude:    in.Latitude,
+		Longitude:   in.Longitude,
+
+		FloorPlanURL:   strings.TrimSpace(stringValue(in.FloorPlanUrl)),
+		VirtualTourURL: strings.TrimSpace(stringValue(in.VirtualTourUrl)),
+		VideoURL:       strings.TrimSpace(stringValue(in.VideoUrl)),
+
+		PropertyType: strings.TrimSpace(stringValue(in.PropertyType)),
+		IncludeComps: boolValue(in.IncludeComps),
+
+		VirtualStaging: strings.TrimSpace(stringValue(in.VirtualStaging)),
+
+		Mortgage: models.MortgageDetails{
+			DownPaymentPct: 20,
+			InterestRate:   7,
+			TermYears:      30,
+		},
+
+		PrintMode:  boolValue(in.PrintMode),
+		PrintReady: boolValue(in.PrintReady),
+		Bilingual:  boolValue(in.Bilingual),
+		Landscape:  boolValue(in.Landscape),
+
+		PageSize: strings.TrimSpace(stringValue(in.PageSize)),
+
+		AdditionalSectionTitle:     strings.TrimSpace(stringValue(in.AdditionalSectionTitle)),
+		AdditionalSectionContent:   strings.TrimSpace(stringValue(in.AdditionalSectionContent)),
+		AdditionalSectionTitleAr:   strings.TrimSpace(stringValue(in.AdditionalSectionTitleAr)),
+		AdditionalSectionContentAr: strings.TrimSpace(stringValue(in.AdditionalSectionContentAr)),
+
+		ThankYouMessageEn: strings.TrimSpace(stringValue(in.ThankYouMessageEn)),
+		ThankYouMessageAr: strings.TrimSpace(stringValue(in.ThankYouMessageAr)),
+
+		FontID:      strings.TrimSpace(idValue(in.FontId)),
+		AgentID:     strings.TrimSpace(idValue(in.AgentId)),
+		ThemePreset: strings.TrimSpace(stringValue(in.ThemePreset)),
+		PDFPassword: stringValue(in.PdfPassword),
+
+		EnrichMissingFields: boolValue(in.EnrichMissingFields),
+		ValidateAddress:     boolValue(in.ValidateAddress),
+
+		Status: strings.ToLower(stringValue(in.Status)),
+	}
+	if in.Agent != nil {
+		req.AgentName = in.Agent.Name
+		req.AgentEmail = in.Agent.Email
+		req.AgentPhone = in.Agent.Phone
+		req.AgentWebsite = strings.TrimSpace(stringValue(in.Agent.Website))
+	}
+	if w := in.WhiteLabel; w != nil {
+		req.WhiteLabelClosing = models.WhiteLabelClosing{
+			LogoURL:      strings.TrimSpace(stringValue(w.LogoUrl)),
+			Headline:     strings.TrimSpace(stringValue(w.Headline)),
+			Message:      strings.TrimSpace(stringValue(w.Message)),
+			InstagramURL: strings.TrimSpace(stringValue(w.InstagramUrl)),
+			FacebookURL:  strings.TrimSpace(stringValue(w.FacebookUrl)),
+			LinkedInURL:  strings.TrimSpace(stringValue(w.LinkedInUrl)),
+			Website:      strings.TrimSpace(stringValue(w.Website)),
+		}
+	}
+	if in.Bedrooms != nil {
+		req.Bedrooms = int(*in.Bedrooms)
+	}
+	if in.Currency != nil {
+		req.Currency = *in.Currency
+	}
+	if in.Amenities != nil {
+		req.Amenities = *in.Amenities
+	}
+	if in.SecondaryAgents != nil {
+		for _, agent := range *in.SecondaryAgents {
+			req.SecondaryAgents = append(req.SecondaryAgents, models.AgentInfo{
+				Name:  strings.TrimSpace(agent.Name),
+				Email: strings.TrimSpace(agent.Email),
+				Phone: strings.TrimSpace(agent.Phone),
+			})
+		}
+	}
+	if in.CoListingAgents != nil {
+		for _, agent := range *in.CoListingAgents {
+			req.CoListingAgents = append(req.CoListingAgents, models.AgentInfo{
+				Name:  strings.TrimSpace(agent.Name),
+				Email: strings.TrimSpace(agent.Email),
+				Phone: strings.TrimSpace(agent.Phone),
+			})
+		}
+	}
+	if in.FloorPlanWidth != nil {
+		req.FloorPlanWidth = *in.FloorPlanWidth
+	}
+	if in.FloorPlanHeight != nil {
+		req.FloorPlanHeight = *in.FloorPlanHeight
+	}
+	if m := in.Mortgage; m != nil {
+		if m.DownPaymentPct != nil {
+			req.Mortgage.DownPaymentPct = *m.DownPaymentPct
+		}
+		if m.InterestRate != nil {
+			req.Mortgage.InterestRate = *m.InterestRate
+		}
+		if m.TermYears != nil {
+			req.Mortgage.TermYears = int(*m.TermYears)
+		}
+	}
+	if req.FloorPlanWidth < 0 || req.FloorPlanHeight < 0 || req.Mortgage.DownPaymentPct < 0 || req.Mortgage.InterestRate < 0 {
+		return nil, errors.New("floor plan dimensions and mortgage figures must be non-negative")
+	}
+	if in.PageOrder != nil {
+		req.PageOrder = *in.PageOrder
+	}
+	if in.MarginMm != nil {
+		req.MarginMm = int(*in.MarginMm)
+	}
+	if in.Languages != nil {
+		for _, language := range *in.Languages {
+			req.Languages = append(req.Languages, strings.ToLower(language))
+		}
+	}
+
+	// The agent profile, address validation and enrichment run in the same order as for the REST form
+	if req.AgentID != "" {
+		if err := r.properties.applyAgentProfile(&req); err != nil {
+			return nil, err
+		}
+	}
+	if req.ValidateAddress {
+		if err := r.properties.applyAddressValidation(&req); err != nil {
+			return nil, err
+		}
+	}
+	if req.EnrichMissingFields {
+		r.properties.enrichMissingFields(&req, true)
+	}
+
+	response, err := r.properties.createProperty(&req, images, nil)
+	if err != nil {
+		return nil, err
+	}
+	property, err := r.properties.findProperty(response.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+	return &submitPropertyResult{
+		Property: &propertyResolver{Property: *property},
+		Warnings: response.Warnings,
+	}, nil
+}
+
+// DeleteProperty is an admin operation: the request must send the admin API token as a Bearer token
+func (r *graphqlResolver) DeleteProperty(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	authorization, _ := ctx.Value(authorizationKey{}).(string)
+	if err := middleware.CheckAdminToken(r.adminToken, authorization); err != nil {
+		return false, err
+	}
+
+	err := r.properties.deleteProperty(string(args.ID))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		log.Printf("Error deleting property %s: %v", args.ID, err)
+		return false, err
+	}
+	return true, nil
+}
+
+type submitPropertyResult struct {
+	Property *propertyResolver
+	Warnings []string
+}
+
+type propertyPage struct {
+	Items []*propertyResolver
+	Total int32
+}
+
+// propertyResolver exposes a stored property; plain fields resolve from the embedded model,
+// the methods cover fields whose GraphQL shape differs from the model
+type propertyResolver struct {
+	models.Property
+}
+
+func (p *propertyResolver) ID() graphql.ID {
+	return graphql.ID(p.Property.ID.Hex())
+}
+
+func (p *propertyResolver) LocalizedContent(args struct{ Language string }) *models.LocalizedContent {
+	switch args.Language {
+	case "AR":
+		return &p.ArabicContent
+	case "UR":
+		return &p.UrduContent
+	}
+	return &p.EnglishContent
+}
+
+func (p *propertyResolver) PDFUrlBilingual() *string {
+	return optionalString(p.Property.PDFUrlBilingual)
+}
+
+func (p *propertyResolver) PDFUrlUrdu() *string {
+	return optionalString(p.Property.PDFUrlUrdu)
+}
+
+func (p *propertyResolver) FloorPlan() *floorPlan {
+	if p.FloorPlanURL == "" {
+		return nil
+	}
+	plan := &floorPlan{URL: p.FloorPlanURL}
+	if p.FloorPlanWidth > 0 && p.FloorPlanHeight > 0 {
+		plan.Width, plan.Height = &p.FloorPlanWidth, &p.FloorPlanHeight
+	}
+	return plan
+}
+
+func (p *propertyResolver) VirtualTourURL() *string {
+	return optionalString(p.Property.VirtualTourURL)
+}
+
+func (p *propertyResolver) VideoURL() *string {
+	return optionalString(p.Property.VideoURL)
+}
+
+func (p *propertyResolver) PropertyType() *string {
+	return optionalString(p.Property.PropertyType)
+}
+
+func (p *propertyResolver) Bedrooms() *int32 {
+	if p.Property.Bedrooms == 0 {
+		return nil
+	}
+	bedrooms := int32(p.Property.Bedrooms)
+	return &bedrooms
+}
+
+// Status resolves listings stored without a status as active
+func (p *propertyResolver) Status() string {
+	if p.Property.Status == "" {
+		return strings.ToUpper(models.PropertyStatusActive)
+	}
+	return strings.ToUpper(p.Property.Status)
+}
+
+func (p *propertyResolver) PriceHistory() []*priceEntry {
+	entries := make([]*priceEntry, len(p.Property.PriceHistory))
+	for i, entry := range p.Property.PriceHistory {
+		entries[i] = &priceEntry{Price: entry.Price, Currency: entry.Currency, ChangedAt: graphql.Time{Time: entry.ChangedAt}}
+	}
+	return entries
+}
+
+func (p *propertyResolver) VirtualStaging() *string {
+	return optionalString(p.Property.VirtualStaging)
+}
+
+func (p *propertyResolver) VirtualStagingDescription() *string {
+	return optionalString(p.Property.VirtualStagingDescription)
+}
+
+func (p *propertyResolver) AgentID() *graphql.ID {
+	return optionalID(p.Property.AgentID)
+}
+
+func (p *propertyResolver) ComparableSales() []*comparableSale {
+	sales := make([]*comparableSale, len(p.Property.ComparableSales))
+	for i, sale := range p.Property.ComparableSales {
+		sales[i] = &comparableSale{AddressStub: sale.AddressStub, Price: sale.Price, DaysOnMarket: int32(sale.DaysOnMarket)}
+	}
+	return sales
+}
+
+func (p *propertyResolver) Mortgage() *mortgage {
+	if p.Property.Mortgage == nil {
+		return nil
+	}
+	m := *p.Property.Mortgage
+	return &mortgage{
+		DownPaymentPct: m.DownPaymentPct,
+		InterestRate:   m.InterestRate,
+		TermYears:      int32(m.TermYears),
+		MonthlyPayment: services.MonthlyMortgagePayment(p.Price, m),
+	}
+}
+
+func (p *propertyResolver) PageSize() *string {
+	return optionalString(p.Property.PageSize)
+}
+
+func (p *propertyResolver) MarginMm() *int32 {
+	if p.Property.MarginMm == 0 {
+		return nil
+	}
+	margin := int32(p.Property.MarginMm)
+	return &margin
+}
+
+func (p *propertyResolver) Languages() []string {
+	languages := make([]string, len(p.Property.Languages))
+	for i, language := range p.Property.Languages {
+		languages[i] = strings.ToUpper(language)
+	}
+	return languages
+}
+
+func (p *propertyResolver) WhiteLabel() *whiteLabel {
+	w := p.WhiteLabelClosing
+	if w == nil || w.IsZero() {
+		return nil
+	}
+	return &whiteLabel{
+		LogoURL:      optionalString(w.LogoURL),
+		Headline:     optionalString(w.Headline),
+		Message:      optionalString(w.Message),
+		InstagramURL: optionalString(w.InstagramURL),
+		FacebookURL:  optionalString(w.FacebookURL),
+		LinkedInURL:  optionalString(w.LinkedInURL),
+		Website:      optionalString(w.Website),
+	}
+}
+
+func (p *propertyResolver) FontID() *graphql.ID {
+	return optionalID(p.Property.FontID)
+}
+
+func (p *propertyResolver) ThemePreset() *string {
+	return optionalString(p.Property.ThemePreset)
+}
+
+func (p *propertyResolver) PasswordProtected() bool {
+	return p.IsPasswordProtected
+}
+
+func (p *propertyResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: p.Property.CreatedAt}
+}
+
+func (p *propertyResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: p.Property.UpdatedAt}
+}
+
+type floorPlan struct {
+	URL    string
+	Width  *float64
+	Height *float64
+}
+
+type comparableSale struct {
+	AddressStub  string
+	Price        float64
+	DaysOnMarket int32
+}
+
+type priceEntry struct {
+	Price     float64
+	Currency  string
+	ChangedAt graphql.Time
+}
+
+type whiteLabel struct {
+	LogoURL      *string
+	Headline     *string
+	Message      *string
+	InstagramURL *string
+	FacebookURL  *string
+	LinkedInURL  *string
+	Website      *string
+}
+
+type mortgage struct {
+	DownPaymentPct float64
+	InterestRate   float64
+	TermYears      int32
+	MonthlyPayment float64
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func idValue(id *graphql.ID) string {
+	if id == nil {
+		return ""
+	}
+	return string(*id)
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalID(s string) *graphql.ID {
+	if s == "" {
+		return nil
+	}
+	id := graphql.ID(s)
+	return &id
+}
+
+// GraphiQL serves an in-browser IDE for the GraphQL endpoint
+func (h *GraphQLHandler) GraphiQL(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(graphiqlPage)
+}
+
+const graphiqlPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Property Brochure GraphiQL</title>
+	<link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css" />
+	<style>body { margin: 0; height: 100vh; } #graphiql { height: 100vh; }</style>
+</head>
+<body>
+	<div id="graphiql">Loading...</div>
+	<script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+	<script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+	<script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+	<script>
+		const fetcher = GraphiQL.createFetcher({ url: '/graphql' });
+		ReactDOM.createRoot(document.getElementById('graphiql')).render(React.createElement(GraphiQL, { fetcher }));
+	</script>
+</body>
+</html>
+`
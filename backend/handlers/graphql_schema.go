@@ -0,0 +1,286 @@
+package handlers
+
+// graphqlSchema mirrors the REST API: the same fields, validation rules and brochure generation
+const graphqlSchema = `
+schema {
+	query: Query
+	mutation: Mutation
+}
+
+scalar Time
+
+# A file sent with the request following the GraphQL multipart request specification
+scalar Upload
+
+type Query {
+	# A single listing; null when no listing has the ID
+	property(id: ID!): Property
+	# Listings matching the filters, newest first (limit defaults to 20, at most 100)
+	properties(filters: PropertyFilters, pagination: Pagination): PropertyPage!
+}
+
+type Mutation {
+	# Creates a listing and generates its brochures, like POST /api/property
+	submitProperty(input: PropertyInput!): SubmitPropertyResult!
+	# Deletes a listing and its brochures; false when no listing has the ID. Requires the admin API token
+	# as "Authorization: Bearer <token>"
+	deleteProperty(id: ID!): Boolean!
+}
+
+enum Language {
+	EN
+	AR
+	UR
+}
+
+# Market status of a listing
+enum PropertyStatus {
+	ACTIVE
+	PENDING
+	SOLD
+}
+
+input PropertyFilters {
+	city: String
+	state: String
+	propertyType: String
+	minPrice: Float
+	maxPrice: Float
+}
+
+input Pagination {
+	limit: Int
+	offset: Int
+}
+
+input AgentInput {
+	name: String!
+	email: String!
+	phone: String!
+	website: String
+}
+
+# Agency-branded closing that replaces the thank-you message on the contact page
+input WhiteLabelInput {
+	logoUrl: String
+	# At most 80 characters
+	headline: String
+	# At most 500 characters
+	message: String
+	instagramUrl: String
+	facebookUrl: String
+	linkedInUrl: String
+	website: String
+}
+
+# Defaults: 20% down, 7% interest, 30 years
+input MortgageInput {
+	downPaymentPct: Float
+	interestRate: Float
+	termYears: Int
+}
+
+input PropertyInput {
+	title: String!
+	description: String
+	price: Float!
+	currency: String
+	address: String!
+	city: String!
+	state: String!
+	zipCode: String!
+	# Decimal degrees, both or neither; places a location map on the cover
+	latitude: Float
+	longitude: Float
+	amenities: [String!]
+	# Property photos; the first one is the cover (at least one for active listings, at most MAX_IMAGES_PER_PROPERTY)
+	images: [Upload!]!
+	# Required unless agentId is given
+	agent: AgentInput
+	# A stored agent profile (see /api/agents), whose contact details replace agent
+	agentId: ID
+	# Secondary agents on the contact card (max 2)
+	secondaryAgents: [AgentInput!]
+	# Co-listing agents, shown with the primary agent in a Listed By row (max 2)
+	coListingAgents: [AgentInput!]
+	floorPlanUrl: String
+	floorPlanWidth: Float
+	floorPlanHeight: Float
+	virtualTourUrl: String
+	videoUrl: String
+	propertyType: String
+	bedrooms: Int
+	# Defaults to ACTIVE
+	status: PropertyStatus
+	includeComps: Boolean
+	# Brief of the space to describe as virtually staged, e.g. "unfurnished living room, Scandinavian style"
+	virtualStaging: String
+	mortgage: MortgageInput
+	printMode: Boolean
+	# Original images, no compression and CMYK-approximated brand colors for print shops
+	printReady: Boolean
+	bilingual: Boolean
+	landscape: Boolean
+	# A4 (default), Letter or Legal
+	pageSize: String
+	pageOrder: [String!]
+	# Page margin in millimetres (5-30)
+	marginMm: Int
+	# Defaults to both languages
+	languages: [Language!]
+	additionalSectionTitle: String
+	additionalSectionContent: String
+	additionalSectionTitleAr: String
+	additionalSectionContentAr: String
+	thankYouMessageEn: String
+	thankYouMessageAr: String
+	whiteLabel: WhiteLabelInput
+	# An uploaded agency font (see POST /api/admin/fonts)
+	fontId: ID
+	# A theme preset such as luxury, modern, coastal or corporate
+	themePreset: String
+	# Encrypts the brochures for confidential listings; never stored
+	pdfPassword: String
+	# Fills a missing city, state, zip code or coordinates by geocoding the address, and the bedrooms of
+	# an apartment from its description
+	enrichMissingFields: Boolean
+	# Normalizes the address first and rejects it below 0.5 confidence
+	validateAddress: Boolean
+}
+
+type SubmitPropertyResult {
+	property: Property!
+	# Non-fatal problems, such as gallery images that could not be loaded
+	warnings: [String!]!
+}
+
+type PropertyPage {
+	items: [Property!]!
+	total: Int!
+}
+
+type Property {
+	id: ID!
+	title: String!
+	description: String!
+	price: Float!
+	currency: String!
+	address: String!
+	city: String!
+	state: String!
+	zipCode: String!
+	latitude: Float
+	longitude: Float
+	amenities: [String!]!
+	imageUrls: [String!]!
+	agentInfo: Agent!
+	secondaryAgents: [Agent!]!
+	coListingAgents: [Agent!]!
+	agentId: ID
+	aiContent: AIContent!
+	localizedContent(language: Language!): LocalizedContent!
+	pdfUrl: String!
+	pdfUrlEnglish: String!
+	pdfUrlArabic: String!
+	pdfUrlBilingual: String
+	pdfUrlUrdu: String
+	floorPlan: FloorPlan
+	virtualTourUrl: String
+	videoUrl: String
+	propertyType: String
+	bedrooms: Int
+	status: PropertyStatus!
+	# Asking prices, newest first
+	priceHistory: [PriceEntry!]!
+	virtualStaging: String
+	virtualStagingDescription: String
+	comparableSales: [ComparableSale!]!
+	mortgage: Mortgage
+	printMode: Boolean!
+	printReady: Boolean!
+	landscape: Boolean!
+	pageSize: String
+	pageOrder: [String!]!
+	marginMm: Int
+	languages: [Language!]!
+	whiteLabel: WhiteLabel
+	fontId: ID
+	themePreset: String
+	# The brochures are encrypted and open only with the password given at submission
+	passwordProtected: Boolean!
+	# Some fields were filled by enrichMissingFields
+	enriched: Boolean!
+	createdAt: Time!
+	updatedAt: Time!
+}
+
+type Agent {
+	name: String!
+	email: String!
+	phone: String!
+	website: String!
+}
+
+type AIContent {
+	englishDescription: String!
+	arabicDescription: String!
+	keyHighlights: [String!]!
+}
+
+type LocalizedContent {
+	title: String!
+	description: String!
+	priceLabel: String!
+	addressLabel: String!
+	cityLabel: String!
+	stateLabel: String!
+	zipCodeLabel: String!
+	highlights: [String!]!
+	amenitiesLabel: String!
+	amenities: [String!]!
+	agentLabel: String!
+	propertyDescriptionLabel: String!
+	keyHighlightsLabel: String!
+	propertyGalleryLabel: String!
+	additionalSectionTitle: String!
+	additionalSectionContent: String!
+	thankYouMessage: String!
+}
+
+type WhiteLabel {
+	logoUrl: String
+	headline: String
+	message: String
+	instagramUrl: String
+	facebookUrl: String
+	linkedInUrl: String
+	website: String
+}
+
+type PriceEntry {
+	price: Float!
+	currency: String!
+	changedAt: Time!
+}
+
+# Floor plan image; dimensions in metres
+type FloorPlan {
+	url: String!
+	width: Float
+	height: Float
+}
+
+# AI-generated, illustrative comparable sale (estimated market data, not a real transaction)
+type ComparableSale {
+	addressStub: String!
+	price: Float!
+	daysOnMarket: Int!
+}
+
+type Mortgage {
+	downPaymentPct: Float!
+	interestRate: Float!
+	termYears: Int!
+	monthlyPayment: Float!
+}
+`
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// graphqlNames maps the REST form fields of a submission to the PropertyInput fields that carry them
+var graphqlNames = map[string]string{
+	"agentName":      "agent",
+	"agentEmail":     "agent",
+	"agentPhone":     "agent",
+	"agentWebsite":   "agent",
+	"floorPlanURL":   "floorPlanUrl",
+	"virtualTourURL": "virtualTourUrl",
+	"videoURL":       "videoUrl",
+}
+
+// exec runs a GraphQL operation against the handler's schema and decodes its data
+func (h *GraphQLHandler) exec(t *testing.T, query string, data interface{}) {
+	t.Helper()
+	resp := h.schema.Exec(context.Background(), query, "", nil)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("query failed: %v", resp.Errors)
+	}
+	if err := json.Unmarshal(resp.Data, data); err != nil {
+		t.Fatalf("failed to decode %s: %v", resp.Data, err)
+	}
+}
+
+// TestGraphQLSchemaCoversSubmissionFields keeps submitProperty in step with the REST form: every
+// PropertyRequest field needs a PropertyInput counterpart
+func TestGraphQLSchemaCoversSubmissionFields(t *testing.T) {
+	h := NewGraphQLHandler(newTestHandler(t).PropertyHandler, "")
+	var data struct {
+		Type struct {
+			InputFields []struct{ Name string }
+		} `json:"__type"`
+	}
+	h.exec(t, `{ __type(name: "PropertyInput") { inputFields { name } } }`, &data)
+	inputs := map[string]bool{}
+	for _, field := range data.Type.InputFields {
+		inputs[field.Name] = true
+	}
+
+	fields := reflect.TypeOf(models.PropertyRequest{})
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		name, tagged := field.Tag.Lookup("form")
+		switch {
+		case name == "-":
+			continue
+		case !tagged:
+			// Nested inputs such as the mortgage and the agents are named after the struct field
+			name = strings.ToLower(field.Name[:1]) + field.Name[1:]
+			if field.Name == "WhiteLabelClosing" {
+				name = "whiteLabel"
+			}
+		}
+		name = strings.TrimSuffix(name, "[]")
+		if mapped, ok := graphqlNames[name]; ok {
+			name = mapped
+		}
+		if !inputs[name] {
+			t.Errorf("PropertyRequest.%s has no PropertyInput.%s", field.Name, name)
+		}
+	}
+}
+
+func TestGraphQLProperty(t *testing.T) {
+	th := newTestHandler(t)
+	h := NewGraphQLHandler(th.PropertyHandler, "")
+
+	property := storedProperty()
+	latitude, longitude := 25.08, 55.14
+	property.Latitude, property.Longitude = &latitude, &longitude
+	property.Bedrooms = 2
+	property.Status = models.PropertyStatusPending
+	property.PriceHistory = []models.PriceEntry{{Price: 2450000, Currency: "AED", ChangedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}}
+	property.VirtualStaging = "unfurnished living room"
+	property.WhiteLabelClosing = &models.WhiteLabelClosing{Headline: "Thank you"}
+	property.AgentID = "agent-1"
+	property.FontID = "font-1"
+	property.ThemePreset = "luxury"
+	property.IsPasswordProtected = true
+	th.mongo.onFind("properties", propertyDocument(t, property))
+
+	var data struct {
+		Property struct {
+			Latitude          *float64
+			Longitude         *float64
+			Bedrooms          *int
+			Status            string
+			PriceHistory      []struct{ Price float64 }
+			VirtualStaging    *string
+			WhiteLabel        *struct{ Headline, Message *string }
+			AgentID           *string `json:"agentId"`
+			FontID            *string `json:"fontId"`
+			ThemePreset       *string
+			PasswordProtected bool
+			Enriched          bool
+		}
+	}
+	h.exec(t, `{ property(id: "`+property.ID.Hex()+`") {
+		latitude longitude bedrooms status priceHistory { price } virtualStaging
+		whiteLabel { headline message } agentId fontId themePreset passwordProtected enriched
+	} }`, &data)
+
+	got := data.Property
+	if got.Latitude == nil || *got.Latitude != latitude || got.Longitude == nil || *got.Longitude != longitude {
+		t.Errorf("coordinates = %v, %v, want %v, %v", got.Latitude, got.Longitude, latitude, longitude)
+	}
+	if got.Bedrooms == nil || *got.Bedrooms != 2 {
+		t.Errorf("bedrooms = %v, want 2", got.Bedrooms)
+	}
+	if got.Status != "PENDING" {
+		t.Errorf("status = %q, want PENDING", got.Status)
+	}
+	if len(got.PriceHistory) != 1 || got.PriceHistory[0].Price != 2450000 {
+		t.Errorf("priceHistory = %+v, want the 2450000 entry", got.PriceHistory)
+	}
+	if got.VirtualStaging == nil || *got.VirtualStaging != property.VirtualStaging {
+		t.Errorf("virtualStaging = %v, want %q", got.VirtualStaging, property.VirtualStaging)
+	}
+	if got.WhiteLabel == nil || got.WhiteLabel.Headline == nil || *got.WhiteLabel.Headline != "Thank you" || got.WhiteLabel.Message != nil {
+		t.Errorf("whiteLabel = %+v, want only the headline", got.WhiteLabel)
+	}
+	if got.AgentID == nil || *got.AgentID != "agent-1" || got.FontID == nil || *got.FontID != "font-1" || got.ThemePreset == nil || *got.ThemePreset != "luxury" {
+		t.Errorf("agentId, fontId, themePreset = %v, %v, %v, want agent-1, font-1, luxury", got.AgentID, got.FontID, got.ThemePreset)
+	}
+	if !got.PasswordProtected || got.Enriched {
+		t.Errorf("passwordProtected, enriched = %t, %t, want true, false", got.PasswordProtected, got.Enriched)
+	}
+}
+
+func TestGraphQLDeletePropertyRequiresAdminToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		wantError     string
+	}{
+		{name: "no token", wantError: "Admin token required"},
+		{name: "wrong token", authorization: "Bearer guess", wantError: "Invalid admin token"},
+		{name: "admin token", authorization: "Bearer s3cret-admin-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := newTestHandler(t)
+			th.app.Post("/graphql", NewGraphQLHandler(th.PropertyHandler, "s3cret-admin-token").Execute)
+			th.mongo.onFind("properties")
+
+			req := jsonRequest(http.MethodPost, "/graphql", `{"query": "mutation { deleteProperty(id: \"`+primitive.NewObjectID().Hex()+`\") }"}`)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			_, raw := th.do(t, req)
+			var body struct {
+				Data   *struct{ DeleteProperty bool }
+				Errors []struct{ Message string }
+			}
+			decode(t, raw, &body)
+
+			if tt.wantError != "" {
+				if len(body.Errors) != 1 || !strings.HasPrefix(body.Errors[0].Message, tt.wantError) {
+					t.Errorf("errors = %+v, want %q", body.Errors, tt.wantError)
+				}
+				if received(th.mongo, "find properties") {
+					t.Error("looked up the listing without the admin token")
+				}
+				return
+			}
+			if len(body.Errors) > 0 || body.Data == nil || body.Data.DeleteProperty {
+				t.Errorf("body = %s, want deleteProperty false for an unknown listing", raw)
+			}
+			if !received(th.mongo, "find properties") {
+				t.Error("did not look up the listing")
+			}
+		})
+	}
+}
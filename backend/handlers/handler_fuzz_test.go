@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// FuzzSubmitProperty sends fuzzed form fields through the Fiber handler, covering the multipart parsing,
+// the price and number parsing and the pageOrder JSON decoding. Every submission must either be created
+// or be rejected with a 400 ErrorResponse before anything is uploaded or rendered.
+func FuzzSubmitProperty(f *testing.F) {
+	f.Add("Marina View Apartment", "2450000", "Swimming Pool", `["cover","gallery"]`, "en", "2", "25.08", "cover.png")
+	f.Add("Marina View Apartment", "2.45e6", "", "", "ar", "", "", "cover.png")
+	f.Add("Marina View Apartment", "NaN", "Gym", `["cover"]`, "en", "2", "25.08", "cover.png")
+	f.Add("Marina View Apartment", "Inf", "Gym", "", "en", "", "", "cover.png")
+	f.Add("Marina View Apartment", "-1", "Gym", "", "en", "", "", "cover.png")
+	f.Add("Marina View Apartment", "2450000abc", "Gym", "", "en", "two", "north", "cover.png")
+	f.Add("Marina View Apartment", "2450000", "Gym", `["cover","cover"]`, "en", "", "", "cover.png")
+	f.Add("Marina View Apartment", "2450000", "Gym", `{"cover":1}`, "en", "", "", "cover.png")
+	f.Add("Marina View Apartment", "2450000", "Gym", `["unknown"]`, "fr", "-3", "91", "cover.png")
+	f.Add("Marina\x00View", "2450000", "\xff\xfe", `[`, "ur", "", "", "\"cover\r\n.png")
+	f.Add("", "", "", "", "", "", "", "")
+
+	f.Fuzz(func(t *testing.T, title, price, amenity, pageOrder, language, bedrooms, latitude, filename string) {
+		submitFuzzedProperty(t, title, price, amenity, pageOrder, language, bedrooms, latitude, filename)
+	})
+}
+
+// submitFuzzedProperty submits one fuzzed listing and checks the outcome
+func submitFuzzedProperty(t *testing.T, title, price, amenity, pageOrder, language, bedrooms, latitude, filename string) {
+	th := newTestHandler(t)
+	th.expectSubmission()
+
+	fields := validFields()
+	fields["title"] = title
+	fields["price"] = price
+	fields["amenities[]"] = amenity
+	fields["pageOrder"] = pageOrder
+	fields["languages[]"] = language
+	fields["bedrooms"] = bedrooms
+	fields["latitude"] = latitude
+	image := pngImage
+	image.filename = filename
+
+	resp, body := th.do(t, submitRequest(t, fields, image))
+	switch resp.StatusCode {
+	case fiber.StatusCreated:
+		var created models.PropertyResponse
+		if err := json.Unmarshal(body, &created); err != nil || !created.Success || created.PropertyID == "" {
+			t.Fatalf("created response = %s, want a PropertyResponse", body)
+		}
+		if title == "" {
+			t.Errorf("created a listing without a title")
+		}
+		var parsed float64
+		if err := json.Unmarshal([]byte(price), &parsed); err == nil && (math.IsNaN(parsed) || parsed <= 0) {
+			t.Errorf("created a listing with price %q", price)
+		}
+	case fiber.StatusBadRequest:
+		var errResp models.ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil || errResp.Success {
+			t.Fatalf("error response = %s, want an ErrorResponse", body)
+		}
+		if rendered := th.brochures.rendered(); len(rendered) != 0 {
+			t.Errorf("rejected submission rendered %v", rendered)
+		}
+		th.storage.AssertNotCalled(t, "UploadFile", mock.Anything, mock.Anything, mock.Anything)
+	default:
+		t.Fatalf("status = %d, want 201 or 400: %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,520 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"log"
+	"math/rand"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"property-brochure-backend/client"
+	"property-brochure-backend/docs"
+	"property-brochure-backend/middleware"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/minio"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// integrationMongo and integrationStorage are the MongoDB and MinIO containers the integration tests run
+// against. They are only started when the INTEGRATION_TEST environment variable is set, which needs a
+// Docker daemon.
+var (
+	integrationMongo   *services.MongoDBService
+	integrationStorage *services.S3Service
+)
+
+// integrationBucket holds the images and brochures of the integration tests
+const integrationBucket = "brochures"
+
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	if os.Getenv("INTEGRATION_TEST") == "" {
+		return m.Run()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	mongoContainer, err := mongodb.Run(ctx, "mongo:7")
+	if err != nil {
+		log.Printf("Failed to start MongoDB container: %v", err)
+		return 1
+	}
+	defer terminate(mongoContainer)
+	uri, err := mongoContainer.ConnectionString(ctx)
+	if err != nil {
+		log.Printf("Failed to get MongoDB connection string: %v", err)
+		return 1
+	}
+	mongoService, err := services.NewMongoDBService(uri, "brochure_integration", services.MongoPoolConfig{
+		MaxPoolSize:    10,
+		ConnectTimeout: 10 * time.Second,
+		SocketTimeout:  30 * time.Second,
+	})
+	if err != nil {
+		log.Printf("Failed to connect to MongoDB at %s: %v", uri, err)
+		return 1
+	}
+	defer mongoService.Close()
+	if err := mongoService.InitIndexes(ctx); err != nil {
+		log.Printf("Failed to create MongoDB indexes: %v", err)
+		return 1
+	}
+
+	minioContainer, err := minio.Run(ctx, "minio/minio:RELEASE.2024-01-16T16-07-38Z")
+	if err != nil {
+		log.Printf("Failed to start MinIO container: %v", err)
+		return 1
+	}
+	defer terminate(minioContainer)
+	storage, err := newMinIOStorage(ctx, minioContainer)
+	if err != nil {
+		log.Printf("Failed to set up MinIO storage: %v", err)
+		return 1
+	}
+
+	integrationMongo = mongoService
+	integrationStorage = storage
+	return m.Run()
+}
+
+// terminate stops a test container, logging failures since the tests have already run
+func terminate(container testcontainers.Container) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := container.Terminate(ctx); err != nil {
+		log.Printf("Failed to terminate container: %v", err)
+	}
+}
+
+// newMinIOStorage creates integrationBucket in the MinIO container and an S3Service that stores both
+// images and PDFs in it through the endpoint override
+func newMinIOStorage(ctx context.Context, container *minio.MinioContainer) (*services.S3Service, error) {
+	address, err := container.ConnectionString(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bucket := services.S3BucketConfig{
+		Name:           integrationBucket,
+		Region:         "us-east-1",
+		Endpoint:       "http://" + address,
+		ForcePathStyle: true,
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:           aws.String(bucket.Region),
+		Endpoint:         aws.String(bucket.Endpoint),
+		S3ForcePathStyle: aws.Bool(true),
+		Credentials:      credentials.NewStaticCredentials(container.Username, container.Password, ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s3.New(sess).CreateBucketWithContext(ctx, &s3.CreateBucketInput{Bucket: aws.String(bucket.Name)}); err != nil {
+		return nil, fmt.Errorf("failed to create bucket %s: %w", bucket.Name, err)
+	}
+
+	return services.NewS3Service(container.Username, container.Password, bucket, bucket, false)
+}
+
+// integrationHandler is a PropertyHandler on the MongoDB and MinIO containers, with the AI and PDF
+// generators mocked so no API key or fonts are needed
+type integrationHandler struct {
+	*PropertyHandler
+	app     *fiber.App
+	storage *services.S3Service
+}
+
+func newIntegrationHandler(t *testing.T) *integrationHandler {
+	t.Helper()
+	if integrationMongo == nil {
+		t.Skip("set INTEGRATION_TEST to run against MongoDB and MinIO containers")
+	}
+
+	ih := &integrationHandler{storage: integrationStorage}
+	ai, brochures := &mockAI{}, &mockBrochures{}
+	expectAI(ai)
+	// The presets shipped in themes.yaml are known, as they are to the server
+	presets, err := services.LoadThemePresets("../themes.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range presets {
+		brochures.On("HasThemePreset", name).Return(true).Maybe()
+	}
+	expectBrochures(brochures)
+	ih.PropertyHandler = NewPropertyHandler(integrationMongo, integrationStorage, ai, brochures,
+		1<<20, "image/jpeg,image/png", 10, nil, nil, nil, nil, nil, nil, nil)
+	ih.app = fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	ih.app.Post("/api/property", ih.SubmitProperty)
+	ih.app.Get("/api/property/:id", ih.GetProperty)
+	return ih
+}
+
+// submit creates a listing with the given photo and returns its ID
+func (ih *integrationHandler) submit(t *testing.T, photo formImage) string {
+	t.Helper()
+	resp, err := ih.app.Test(submitRequest(t, validFields(), photo), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var created models.PropertyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated || created.PropertyID == "" {
+		t.Fatalf("status = %d, response = %+v, want a created listing", resp.StatusCode, created)
+	}
+	return created.PropertyID
+}
+
+// get fetches a listing through GET /api/property/:id
+func (ih *integrationHandler) get(t *testing.T, id string) *models.Property {
+	t.Helper()
+	resp, err := ih.app.Test(httptest.NewRequest(http.MethodGet, "/api/property/"+id, nil), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	var detail models.PropertyDetailResponse
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return detail.Property
+}
+
+// stored reports whether the object behind a URL is in MinIO
+func (ih *integrationHandler) stored(t *testing.T, rawURL string) bool {
+	t.Helper()
+	key, err := ih.storage.KeyFromURL(rawURL)
+	if err != nil {
+		t.Fatalf("URL %s has no storage key: %v", rawURL, err)
+	}
+	_, err = ih.storage.GetObject(key)
+	if err != nil && !errors.Is(err, services.ErrObjectNotFound) {
+		t.Fatalf("failed to read %s: %v", key, err)
+	}
+	return err == nil
+}
+
+// fetchStatus requests a URL the way a browser opening a brochure link would
+func fetchStatus(t *testing.T, rawURL string) int {
+	t.Helper()
+	resp, err := http.Get(rawURL)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", rawURL, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+// jpegImage encodes a small photo, so the upload goes through JPEG orientation normalization
+func jpegImage(t *testing.T) formImage {
+	t.Helper()
+	photo := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 48; y++ {
+			photo.Set(x, y, color.RGBA{R: uint8(x * 4), G: uint8(y * 5), B: 160, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, photo, nil); err != nil {
+		t.Fatal(err)
+	}
+	return formImage{filename: "living-room.jpg", contentType: "image/jpeg", data: buf.Bytes()}
+}
+
+func TestIntegrationPropertyLifecycle(t *testing.T) {
+	ih := newIntegrationHandler(t)
+	photo := jpegImage(t)
+
+	// Two listings with the same photo share one stored image
+	firstID := ih.submit(t, photo)
+	secondID := ih.submit(t, photo)
+
+	first := ih.get(t, firstID)
+	second := ih.get(t, secondID)
+	if first.Title != validFields()["title"] || first.AgentInfo.Email != validFields()["agentEmail"] {
+		t.Errorf("listing = %+v, want the submitted fields", first)
+	}
+	if len(first.ImageURLs) != 1 || len(second.ImageURLs) != 1 {
+		t.Fatalf("image URLs = %v and %v, want one each", first.ImageURLs, second.ImageURLs)
+	}
+	firstImage, _ := ih.storage.KeyFromURL(first.ImageURLs[0])
+	secondImage, _ := ih.storage.KeyFromURL(second.ImageURLs[0])
+	if firstImage != secondImage {
+		t.Errorf("image keys = %s and %s, want the duplicate photo stored once", firstImage, secondImage)
+	}
+	for _, rawURL := range []string{first.ImageURLs[0], first.PDFUrlEnglish, first.PDFUrlArabic} {
+		if !ih.stored(t, rawURL) {
+			t.Errorf("%s is not in storage", rawURL)
+		}
+	}
+
+	t.Run("URL regeneration", func(t *testing.T) {
+		property := ih.get(t, firstID)
+		if err := ih.resignPropertyURLs(property); err != nil {
+			t.Fatalf("resignPropertyURLs failed: %v", err)
+		}
+		for _, rawURL := range append([]string{property.PDFUrlEnglish, property.PDFUrlArabic}, property.ImageURLs...) {
+			if !ih.stored(t, rawURL) {
+				t.Errorf("re-signed URL %s does not resolve to the stored object", rawURL)
+			}
+		}
+		if !strings.Contains(property.PDFUrlEnglish, "disposition=inline") {
+			t.Errorf("re-signed brochure URL %s lost its inline disposition", property.PDFUrlEnglish)
+		}
+
+		// A window longer than the URL lifetime makes every stored URL due for a refresh
+		result, err := services.NewURLRefreshService(integrationMongo, ih.storage).RefreshExpiring(context.Background(), services.URLExpirationTime+time.Hour)
+		if err != nil {
+			t.Fatalf("RefreshExpiring failed: %v", err)
+		}
+		if result.Checked < 2 || result.Failed != 0 {
+			t.Errorf("refresh result = %+v, want both listings checked without failures", result)
+		}
+		refreshed := ih.get(t, firstID)
+		for _, rawURL := range append([]string{refreshed.PDFUrlEnglish, refreshed.PDFUrlArabic}, refreshed.ImageURLs...) {
+			if status := fetchStatus(t, rawURL); status != http.StatusOK {
+				t.Errorf("GET %s = %d, want the refreshed pre-signed URL to be accepted by MinIO", rawURL, status)
+			}
+		}
+	})
+
+	t.Run("deletion keeps shared images", func(t *testing.T) {
+		if err := ih.deleteProperty(firstID); err != nil {
+			t.Fatalf("deleteProperty failed: %v", err)
+		}
+		if _, err := ih.findProperty(firstID); !errors.Is(err, mongo.ErrNoDocuments) {
+			t.Errorf("findProperty after deletion = %v, want mongo.ErrNoDocuments", err)
+		}
+		if ih.stored(t, first.PDFUrlEnglish) || ih.stored(t, first.PDFUrlArabic) {
+			t.Error("brochures of the deleted listing are still stored")
+		}
+		if !ih.stored(t, second.ImageURLs[0]) {
+			t.Error("deleted the photo the other listing still uses")
+		}
+	})
+
+	t.Run("deletion removes unused images", func(t *testing.T) {
+		if err := ih.deleteProperty(secondID); err != nil {
+			t.Fatalf("deleteProperty failed: %v", err)
+		}
+		if ih.stored(t, second.ImageURLs[0]) {
+			t.Error("photo of the last listing using it is still stored")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		records, err := integrationMongo.GetCollection("image_hashes").CountDocuments(ctx, bson.M{"s3_key": secondImage})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if records != 0 {
+			t.Errorf("%d image hash records remain for %s, want none", records, secondImage)
+		}
+	})
+}
+
+func TestIntegrationLargeObjectUpload(t *testing.T) {
+	if integrationStorage == nil {
+		t.Skip("set INTEGRATION_TEST to run against MongoDB and MinIO containers")
+	}
+
+	// A synthetic 6MB brochure: two parts, the second smaller than MultipartThreshold
+	data := make([]byte, 6*1024*1024)
+	rand.New(rand.NewSource(1)).Read(data)
+	copy(data, "%PDF-1.4\n")
+	key := fmt.Sprintf("brochures/large-%d.pdf", time.Now().UnixNano())
+
+	if err := integrationStorage.UploadLargeObject(key, data, "application/pdf"); err != nil {
+		t.Fatalf("UploadLargeObject failed: %v", err)
+	}
+	defer integrationStorage.DeleteObjects([]string{key})
+
+	stored, err := integrationStorage.GetObject(key)
+	if err != nil {
+		t.Fatalf("GetObject failed: %v", err)
+	}
+	if !bytes.Equal(stored, data) {
+		t.Errorf("stored %d bytes that differ from the %d uploaded", len(stored), len(data))
+	}
+}
+
+// TestIntegrationOpenAPISubmission checks docs/openapi.yaml against the live endpoint: a form built from
+// the examples of the spec, sent with the generated client, must pass request validation, create a
+// listing, and get a response the spec describes.
+func TestIntegrationOpenAPISubmission(t *testing.T) {
+	ih := newIntegrationHandler(t)
+
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromData(docs.OpenAPISpec)
+	if err != nil {
+		t.Fatalf("failed to load openapi.yaml: %v", err)
+	}
+	if err := spec.Validate(loader.Context); err != nil {
+		t.Fatalf("openapi.yaml is invalid: %v", err)
+	}
+
+	server := httptest.NewServer(adaptor.FiberApp(ih.app))
+	defer server.Close()
+	spec.Servers = openapi3.Servers{{URL: server.URL}}
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, contentType := exampleSubmission(t, spec, jpegImage(t))
+	req, err := client.NewSubmitPropertyRequestWithBody(server.URL, contentType, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, pathParams, err := router.FindRoute(req)
+	if err != nil {
+		t.Fatalf("POST /api/property is not in the spec: %v", err)
+	}
+	// The validator only reads binary parts with a decoder registered for their content type, and
+	// would read every text part as a string
+	openapi3filter.RegisterBodyDecoder("image/jpeg", openapi3filter.FileBodyDecoder)
+	defer openapi3filter.UnregisterBodyDecoder("image/jpeg")
+	plain := openapi3filter.RegisteredBodyDecoder("text/plain")
+	openapi3filter.RegisterBodyDecoder("text/plain", formFieldDecoder)
+	defer openapi3filter.RegisterBodyDecoder("text/plain", plain)
+	requestInput := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		// Filling in defaults would rewrite the body, which the validator cannot do for multipart forms
+		Options: &openapi3filter.Options{SkipSettingDefaults: true, MultiError: true},
+	}
+	if err := openapi3filter.ValidateRequest(context.Background(), requestInput); err != nil {
+		t.Fatalf("the example submission does not match the spec: %v", err)
+	}
+
+	c, err := client.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.SubmitPropertyWithBodyWithResponse(context.Background(), contentType, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.JSON201 == nil || resp.JSON201.PropertyId == nil {
+		t.Fatalf("status = %d, body = %s, want a created listing", resp.StatusCode(), resp.Body)
+	}
+	if err := openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 resp.StatusCode(),
+		Header:                 resp.HTTPResponse.Header,
+		Body:                   io.NopCloser(bytes.NewReader(resp.Body)),
+	}); err != nil {
+		t.Errorf("the response does not match the spec: %v", err)
+	}
+	if err := ih.deleteProperty(*resp.JSON201.PropertyId); err != nil {
+		t.Errorf("deleteProperty failed: %v", err)
+	}
+}
+
+// exampleSubmission builds a multipart submission from the example of every PropertyRequest field in the
+// spec, with photo as the only image
+func exampleSubmission(t *testing.T, spec *openapi3.T, photo formImage) ([]byte, string) {
+	t.Helper()
+	schema := spec.Components.Schemas["PropertyRequest"].Value
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, name := range componentNames(schema.Properties) {
+		example := schema.Properties[name].Value.Example
+		values, ok := example.([]interface{})
+		if !ok && example != nil {
+			values = []interface{}{example}
+		}
+		for _, value := range values {
+			if number, ok := value.(float64); ok {
+				value = strconv.FormatFloat(number, 'f', -1, 64)
+			}
+			if err := writer.WriteField(name, fmt.Sprint(value)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="images[]"; filename=%q`, photo.filename))
+	header.Set("Content-Type", photo.contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(photo.data); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return body.Bytes(), writer.FormDataContentType()
+}
+
+// formFieldDecoder reads a text part of a multipart form as the type of its schema, the way the handler
+// parses form values
+func formFieldDecoder(body io.Reader, _ http.Header, schema *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (interface{}, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	switch value := string(data); {
+	case schema.Value.Type.Is("number"), schema.Value.Type.Is("integer"):
+		return strconv.ParseFloat(value, 64)
+	case schema.Value.Type.Is("boolean"):
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
+
+// componentNames returns the names of a schema's properties in a stable order
+func componentNames(properties openapi3.Schemas) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"property-brochure-backend/models"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// jobRetention is how long finished async jobs stay queryable
+const jobRetention = 24 * time.Hour
+
+// jobStore keeps async brochure jobs in memory; they do not survive a restart
+type jobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*models.PropertyJob
+}
+
+func newJobStore() *jobStore {
+	return &jobStore{jobs: make(map[string]*models.PropertyJob)}
+}
+
+// create registers a queued job, pruning finished jobs past their retention
+func (s *jobStore) create() *models.PropertyJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, job := range s.jobs {
+		finished := job.Status == models.JobStatusSucceeded || job.Status == models.JobStatusFailed
+		if finished && now.Sub(job.UpdatedAt) > jobRetention {
+			delete(s.jobs, id)
+		}
+	}
+
+	job := &models.PropertyJob{
+		ID:        uuid.New().String(),
+		Status:    models.JobStatusQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.jobs[job.ID] = job
+	return job
+}
+
+// update applies fn to the stored job under the lock
+func (s *jobStore) update(id string, fn func(job *models.PropertyJob)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		fn(job)
+		job.UpdatedAt = time.Now()
+	}
+}
+
+// remove forgets a job that never made it onto the queue
+func (s *jobStore) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.jobs, id)
+}
+
+// get returns a copy of the job, safe to serialize while workers update the original
+func (s *jobStore) get(id string) (models.PropertyJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return models.PropertyJob{}, false
+	}
+	return *job, true
+}
+
+// enqueueProperty queues createProperty on the worker pool and returns the job tracking it
+func (h *PropertyHandler) enqueueProperty(req *models.PropertyRequest, images []*multipart.FileHeader) (*models.PropertyJob, error) {
+	// Fiber releases the multipart form when the handler returns, so the job needs its own copy of the files
+	detached, err := detachUploads(images)
+	if err != nil {
+		return nil, err
+	}
+
+	job := h.jobs.create()
+	queued := *job
+	err = h.workers.Submit(func() {
+		defer removeDetachedUploads(detached)
+		h.jobs.update(job.ID, func(j *models.PropertyJob) { j.Status = models.JobStatusRunning })
+
+		response, err := h.createProperty(req, detached.File["file"], nil)
+		if err != nil {
+			log.Printf("Error in async brochure job %s: %v", job.ID, err)
+			h.jobs.update(job.ID, func(j *models.PropertyJob) {
+				j.Status = models.JobStatusFailed
+				j.Error = err.Error()
+			})
+			return
+		}
+
+		h.jobs.update(job.ID, func(j *models.PropertyJob) {
+			j.Status = models.JobStatusSucceeded
+			j.Result = response
+		})
+	})
+	if err != nil {
+		removeDetachedUploads(detached)
+		h.jobs.remove(job.ID)
+		return nil, err
+	}
+
+	return &queued, nil
+}
+
+// detachUploads copies uploaded files into temporary files that outlive the request, so queued jobs
+// hold no image data in memory. The copies are streamed straight from the upload to disk; the caller
+// removes them with removeDetachedUploads once the job is done.
+func detachUploads(files []*multipart.FileHeader) (*multipart.Form, error) {
+	if len(files) == 0 {
+		return &multipart.Form{}, nil
+	}
+
+	body, pipe := io.Pipe()
+	writer := multipart.NewWriter(pipe)
+	go func() {
+		pipe.CloseWithError(writeUploads(writer, files))
+	}()
+
+	// Without any memory budget every file part is spooled to a temporary file
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(0)
+	body.CloseWithError(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy uploads: %w", err)
+	}
+	return form, nil
+}
+
+// writeUploads writes the files as parts of one field, which keeps the copies in their original order
+func writeUploads(writer *multipart.Writer, files []*multipart.FileHeader) error {
+	for _, fileHeader := range files {
+		header := make(textproto.MIMEHeader)
+		for key, values := range fileHeader.Header {
+			header[key] = values
+		}
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+			"name":     "file",
+			"filename": fileHeader.Filename,
+		}))
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", fileHeader.Filename, err)
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", fileHeader.Filename, err)
+		}
+		_, err = io.Copy(part, file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", fileHeader.Filename, err)
+		}
+	}
+	return writer.Close()
+}
+
+// removeDetachedUploads deletes the temporary files of a finished or rejected job
+func removeDetachedUploads(form *multipart.Form) {
+	if err := form.RemoveAll(); err != nil {
+		log.Printf("Error removing spooled uploads: %v", err)
+	}
+}
+
+// GetJob reports the status of an async brochure job
+//
+// @Summary      Get an async brochure job
+// @Description  Jobs are kept in memory for 24 hours after they finish and are lost on restart.
+// @Tags         properties
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  models.JobResponse
+// @Failure      404  {object}  models.ErrorResponse  "Unknown job"
+// @Router       /api/jobs/{id} [get]
+func (h *PropertyHandler) GetJob(c *fiber.Ctx) error {
+	job, ok := h.jobs.get(c.Params("id"))
+	if !ok {
+		return models.NewAPIError(models.ErrCodeNotFound, "Job not found", nil)
+	}
+
+	return c.JSON(models.JobResponse{
+		Success: true,
+		Job:     &job,
+	})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"os"
+	"testing"
+)
+
+func TestDetachUploads(t *testing.T) {
+	images := []formImage{
+		{filename: "cover.jpg", contentType: "image/jpeg", data: bytes.Repeat([]byte{0xff}, 64<<10)},
+		pngImage,
+		{filename: "garden.png", contentType: "image/png", data: bytes.Repeat([]byte{0x89}, 3<<10)},
+	}
+	req := submitRequest(t, validFields(), images...)
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatal(err)
+	}
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+
+	detached, err := detachUploads(req.MultipartForm.File["images[]"])
+	if err != nil {
+		t.Fatalf("detachUploads failed: %v", err)
+	}
+	// The request's own form goes away with the request
+	if err := req.MultipartForm.RemoveAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := detached.File["file"]
+	if len(files) != len(images) {
+		t.Fatalf("detached %d files, want %d", len(files), len(images))
+	}
+	for i, image := range images {
+		if files[i].Filename != image.filename || files[i].Header.Get("Content-Type") != image.contentType {
+			t.Errorf("file %d = %s (%s), want %s (%s)", i, files[i].Filename, files[i].Header.Get("Content-Type"), image.filename, image.contentType)
+		}
+		if got := readUpload(t, files[i]); !bytes.Equal(got, image.data) {
+			t.Errorf("file %d has %d bytes, want the %d uploaded bytes", i, len(got), len(image.data))
+		}
+	}
+	if spooled, _ := os.ReadDir(tempDir); len(spooled) == 0 {
+		t.Error("the copies are not spooled to temporary files")
+	}
+
+	removeDetachedUploads(detached)
+	if spooled, _ := os.ReadDir(tempDir); len(spooled) != 0 {
+		t.Errorf("%d temporary files remain after removeDetachedUploads", len(spooled))
+	}
+}
+
+func TestDetachUploadsWithoutFiles(t *testing.T) {
+	detached, err := detachUploads(nil)
+	if err != nil {
+		t.Fatalf("detachUploads failed: %v", err)
+	}
+	if len(detached.File["file"]) != 0 {
+		t.Errorf("detached %d files, want none", len(detached.File["file"]))
+	}
+	removeDetachedUploads(detached)
+}
+
+// readUpload returns the contents of an uploaded file
+func readUpload(t *testing.T, header *multipart.FileHeader) []byte {
+	t.Helper()
+	file, err := header.Open()
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", header.Filename, err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", header.Filename, err)
+	}
+	return data
+}
+
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"fmt"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// The mocks below follow the mockery conventions: a return value may be a function of the call's
+// arguments, e.g. .Return(func(key string) string { ... }), for results that depend on the input.
+
+// mockStorageURL prefixes the URLs of mockStorage objects
+const mockStorageURL = "https://storage.test/"
+
+// mockStorage is a services.StorageService
+type mockStorage struct {
+	mock.Mock
+}
+
+// newMockStorage returns a mockStorage whose URL helpers map each key to mockStorageURL + key. Uploads,
+// reads and deletions are expected by each test.
+func newMockStorage() *mockStorage {
+	s := &mockStorage{}
+	s.On("PublicURL", mock.Anything).Return(func(key string) string { return mockStorageURL + key }).Maybe()
+	s.On("KeyFromURL", mock.Anything).Return(mockKeyFromURL, nil).Maybe()
+	s.On("GeneratePresignedURL", mock.Anything, mock.Anything).Return(func(key string, _ time.Duration) (string, error) {
+		return mockStorageURL + key + "?signed", nil
+	}).Maybe()
+	s.On("GeneratePresignedURLWithDisposition", mock.Anything, mock.Anything, mock.Anything).Return(func(key string, _ time.Duration, _ string) (string, error) {
+		return mockStorageURL + key + "?signed", nil
+	}).Maybe()
+	return s
+}
+
+// mockKeyFromURL resolves the key of a mockStorage URL
+func mockKeyFromURL(rawURL string) (string, error) {
+	key, ok := strings.CutPrefix(rawURL, mockStorageURL)
+	key, _, _ = strings.Cut(key, "?")
+	if !ok || key == "" {
+		return "", fmt.Errorf("URL %s does not reference a stored object", rawURL)
+	}
+	return key, nil
+}
+
+// deletedKeys returns the keys of every DeleteObjects call, in order
+func (s *mockStorage) deletedKeys() []string {
+	var keys []string
+	for _, call := range s.Calls {
+		if call.Method == "DeleteObjects" {
+			keys = append(keys, call.Arguments.Get(0).([]string)...)
+		}
+	}
+	return keys
+}
+
+func (s *mockStorage) UploadFile(file multipart.File, header *multipart.FileHeader, folder string) (string, error) {
+	args := s.Called(file, header, folder)
+	if fn, ok := args.Get(0).(func(multipart.File, *multipart.FileHeader, string) (string, error)); ok {
+		return fn(file, header, folder)
+	}
+	return args.String(0), args.Error(1)
+}
+
+func (s *mockStorage) UploadPDF(data []byte, filename string) (string, error) {
+	args := s.Called(data, filename)
+	if fn, ok := args.Get(0).(func([]byte, string) (string, error)); ok {
+		return fn(data, filename)
+	}
+	return args.String(0), args.Error(1)
+}
+
+func (s *mockStorage) UploadPDFWithUrls(data []byte, filename string) (*services.PDFUrls, error) {
+	args := s.Called(data, filename)
+	if fn, ok := args.Get(0).(func([]byte, string) (*services.PDFUrls, error)); ok {
+		return fn(data, filename)
+	}
+	urls, _ := args.Get(0).(*services.PDFUrls)
+	return urls, args.Error(1)
+}
+
+func (s *mockStorage) DeleteObjects(keys []string) error {
+	return s.Called(keys).Error(0)
+}
+
+func (s *mockStorage) GeneratePresignedURL(key string, expiration time.Duration) (string, error) {
+	args := s.Called(key, expiration)
+	if fn, ok := args.Get(0).(func(string, time.Duration) (string, error)); ok {
+		return fn(key, expiration)
+	}
+	return args.String(0), args.Error(1)
+}
+
+func (s *mockStorage) GeneratePresignedURLWithDisposition(key string, expiration time.Duration, disposition string) (string, error) {
+	args := s.Called(key, expiration, disposition)
+	if fn, ok := args.Get(0).(func(string, time.Duration, string) (string, error)); ok {
+		return fn(key, expiration, disposition)
+	}
+	return args.String(0), args.Error(1)
+}
+
+func (s *mockStorage) PublicURL(key string) string {
+	args := s.Called(key)
+	if fn, ok := args.Get(0).(func(string) string); ok {
+		return fn(key)
+	}
+	return args.String(0)
+}
+
+func (s *mockStorage) KeyFromURL(rawURL string) (string, error) {
+	args := s.Called(rawURL)
+	if fn, ok := args.Get(0).(func(string) (string, error)); ok {
+		return fn(rawURL)
+	}
+	return args.String(0), args.Error(1)
+}
+
+func (s *mockStorage) PutObject(key string, data []byte, contentType string) error {
+	return s.Called(key, data, contentType).Error(0)
+}
+
+func (s *mockStorage) GetObject(key string) ([]byte, error) {
+	args := s.Called(key)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// mockBrochures is a services.BrochureGenerator
+type mockBrochures struct {
+	mock.Mock
+}
+
+// brochureLanguages names the brochure generators by the language they render
+var brochureLanguages = map[string]string{
+	"GenerateEnglishBrochure":    "en",
+	"GenerateArabicBrochure":     "ar",
+	"GenerateUrduBrochure":       "ur",
+	"GenerateBilingualBrochure":  "bilingual",
+	"GenerateComparisonBrochure": "comparison",
+}
+
+// rendered returns the languages of the brochures generated so far, in order
+func (b *mockBrochures) rendered() []string {
+	var languages []string
+	for _, call := range b.Calls {
+		if language, ok := brochureLanguages[call.Method]; ok {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+func (b *mockBrochures) GenerateEnglishBrochure(property *models.Property) ([]byte, error) {
+	args := b.Called(property)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (b *mockBrochures) GenerateArabicBrochure(property *models.Property) ([]byte, error) {
+	args := b.Called(property)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (b *mockBrochures) GenerateUrduBrochure(property *models.Property) ([]byte, error) {
+	args := b.Called(property)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (b *mockBrochures) GenerateBilingualBrochure(property *models.Property) ([]byte, error) {
+	args := b.Called(property)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (b *mockBrochures) GenerateCoverPagePreview(property *models.Property) ([]byte, error) {
+	args := b.Called(property)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (b *mockBrochures) GenerateComparisonBrochure(left, right *models.Property) ([]byte, error) {
+	args := b.Called(left, right)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (b *mockBrochures) CheckCoverImage(property *models.Property) error {
+	return b.Called(property).Error(0)
+}
+
+func (b *mockBrochures) HasThemePreset(name string) bool {
+	return b.Called(name).Bool(0)
+}
+
+func (b *mockBrochures) EncryptPDF(data []byte, password string) ([]byte, error) {
+	args := b.Called(data, password)
+	encrypted, _ := args.Get(0).([]byte)
+	return encrypted, args.Error(1)
+}
+
+// mockAI is a services.AIContentGenerator
+type mockAI struct {
+	mock.Mock
+}
+
+func (a *mockAI) GeneratePropertyContent(title, description, price, currency string, amenities []string) (*services.AIGeneratedContent, error) {
+	args := a.Called(title, description, price, currency, amenities)
+	content, _ := args.Get(0).(*services.AIGeneratedContent)
+	return content, args.Error(1)
+}
+
+func (a *mockAI) GenerateLocalizedContent(title, description, price, currency string, amenities []string, languages []string) (*services.LocalizedContentGenerated, error) {
+	args := a.Called(title, description, price, currency, amenities, languages)
+	if fn, ok := args.Get(0).(func(string, string, string, string, []string, []string) (*services.LocalizedContentGenerated, error)); ok {
+		return fn(title, description, price, currency, amenities, languages)
+	}
+	content, _ := args.Get(0).(*services.LocalizedContentGenerated)
+	return content, args.Error(1)
+}
+
+func (a *mockAI) GenerateComparableSales(city, state string, price float64, propertyType string) (*services.ComparableSales, error) {
+	args := a.Called(city, state, price, propertyType)
+	sales, _ := args.Get(0).(*services.ComparableSales)
+	return sales, args.Error(1)
+}
+
+func (a *mockAI) GenerateVirtualStagingDescription(space, propertyType string) (string, error) {
+	args := a.Called(space, propertyType)
+	return args.String(0), args.Error(1)
+}
+
+func (a *mockAI) InferBedrooms(propertyType, description string) (int, error) {
+	args := a.Called(propertyType, description)
+	return args.Int(0), args.Error(1)
+}
+
+func (a *mockAI) EstimateSubmissionCost(req *models.PropertyRequest) float64 {
+	return a.Called(req).Get(0).(float64)
+}
+
+var (
+	_ services.StorageService     = (*mockStorage)(nil)
+	_ services.BrochureGenerator  = (*mockBrochures)(nil)
+	_ services.AIContentGenerator = (*mockAI)(nil)
+)
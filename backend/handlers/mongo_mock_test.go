@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+// mongoMock is a MongoDB deployment that answers each command with the responses registered for its
+// command name and collection, so tests do not depend on the order in which a handler queries. The
+// responses of a command are used in order and the last one is repeated.
+type mongoMock struct {
+	mu        sync.Mutex
+	responses map[string][]bson.D
+	commands  []string
+	documents []bson.Raw
+	updates   chan description.Topology
+}
+
+// newMongoMock connects a client to a new mongoMock and returns its "test" database
+func newMongoMock(t *testing.T) (*mongoMock, *mongo.Database) {
+	t.Helper()
+	m := &mongoMock{responses: map[string][]bson.D{}}
+
+	opts := options.Client()
+	opts.Deployment = m
+	client, err := mongo.Connect(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("failed to connect to the mock deployment: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		client.Disconnect(ctx)
+	})
+	return m, client.Database("test")
+}
+
+// on registers the responses to a command on a collection, e.g. on("find", "properties", ...)
+func (m *mongoMock) on(command, collection string, responses ...bson.D) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := command + " " + collection
+	m.responses[key] = append(m.responses[key], responses...)
+}
+
+// onFind answers finds on a collection with the given documents in a single batch
+func (m *mongoMock) onFind(collection string, docs ...bson.D) {
+	m.on("find", collection, mtest.CreateCursorResponse(0, "test."+collection, mtest.FirstBatch, docs...))
+}
+
+// onCount answers CountDocuments on a collection, which runs as an aggregation, with n
+func (m *mongoMock) onCount(collection string, n int) {
+	m.on("aggregate", collection, mtest.CreateCursorResponse(0, "test."+collection, mtest.FirstBatch, bson.D{{Key: "n", Value: n}}))
+}
+
+// onWrite answers an insert, update or delete on a collection with the number of documents it affected
+func (m *mongoMock) onWrite(command, collection string, n int) {
+	elems := []bson.E{{Key: "n", Value: n}}
+	if command == "update" {
+		elems = append(elems, bson.E{Key: "nModified", Value: n})
+	}
+	m.on(command, collection, mtest.CreateSuccessResponse(elems...))
+}
+
+// received returns the commands sent so far, as "command collection"
+func (m *mongoMock) received() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.commands...)
+}
+
+// sent returns the documents of the commands sent so far with the given "command collection"
+func (m *mongoMock) sent(key string) []bson.Raw {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var documents []bson.Raw
+	for i, command := range m.commands {
+		if command == key {
+			documents = append(documents, m.documents[i])
+		}
+	}
+	return documents
+}
+
+// respond records a command and returns the response registered for it
+func (m *mongoMock) respond(key string, document bson.Raw) bson.D {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.commands = append(m.commands, key)
+	m.documents = append(m.documents, document)
+
+	queued := m.responses[key]
+	if len(queued) == 0 {
+		return mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "no mock response for " + key})
+	}
+	if len(queued) > 1 {
+		m.responses[key] = queued[1:]
+	}
+	return queued[0]
+}
+
+var (
+	_ driver.Deployment   = (*mongoMock)(nil)
+	_ driver.Server       = (*mongoMock)(nil)
+	_ driver.Connector    = (*mongoMock)(nil)
+	_ driver.Disconnector = (*mongoMock)(nil)
+	_ driver.Subscriber   = (*mongoMock)(nil)
+	_ driver.Connection   = (*mockConnection)(nil)
+)
+
+func (m *mongoMock) SelectServer(context.Context, description.ServerSelector) (driver.Server, error) {
+	return m, nil
+}
+
+func (m *mongoMock) Kind() description.TopologyKind {
+	return description.Single
+}
+
+func (m *mongoMock) Connection(context.Context) (driver.Connection, error) {
+	return &mockConnection{mock: m}, nil
+}
+
+func (m *mongoMock) RTTMonitor() driver.RTTMonitor {
+	return zeroRTTMonitor{}
+}
+
+func (m *mongoMock) Connect() error {
+	return nil
+}
+
+func (m *mongoMock) Disconnect(context.Context) error {
+	if m.updates != nil {
+		close(m.updates)
+	}
+	return nil
+}
+
+// Subscribe reports a topology with sessions, so the driver uses implicit sessions as with a real server
+func (m *mongoMock) Subscribe() (*driver.Subscription, error) {
+	if m.updates == nil {
+		m.updates = make(chan description.Topology, 1)
+		m.updates <- description.Topology{SessionTimeoutMinutesPtr: &mockSessionTimeout}
+	}
+	return &driver.Subscription{Updates: m.updates}, nil
+}
+
+func (m *mongoMock) Unsubscribe(*driver.Subscription) error {
+	return nil
+}
+
+var mockSessionTimeout int64 = 30
+
+// mockConnection is one checked out connection to a mongoMock: it reads the command of each request and
+// answers it with the registered response
+type mockConnection struct {
+	mock     *mongoMock
+	pending  string
+	document bson.Raw
+}
+
+func (c *mockConnection) WriteWireMessage(_ context.Context, wm []byte) error {
+	key, document, err := readCommand(wm)
+	if err != nil {
+		return err
+	}
+	c.pending, c.document = key, document
+	return nil
+}
+
+func (c *mockConnection) ReadWireMessage(context.Context) ([]byte, error) {
+	response, err := bson.Marshal(c.mock.respond(c.pending, c.document))
+	if err != nil {
+		return nil, err
+	}
+
+	index, wm := wiremessage.AppendHeaderStart(nil, wiremessage.NextRequestID(), 0, wiremessage.OpMsg)
+	wm = wiremessage.AppendMsgFlags(wm, 0)
+	wm = wiremessage.AppendMsgSectionType(wm, wiremessage.SingleDocument)
+	wm = append(wm, response...)
+	return bsoncore.UpdateLength(wm, index, int32(len(wm[index:]))), nil
+}
+
+func (c *mockConnection) Description() description.Server {
+	return description.Server{
+		CanonicalAddr:            mockAddress,
+		MaxDocumentSize:          16 * 1024 * 1024,
+		MaxMessageSize:           48 * 1000 * 1000,
+		MaxBatchCount:            100000,
+		SessionTimeoutMinutesPtr: &mockSessionTimeout,
+		Kind:                     description.RSPrimary,
+		WireVersion:              &description.VersionRange{Max: 21},
+	}
+}
+
+func (c *mockConnection) Close() error               { return nil }
+func (c *mockConnection) ID() string                 { return "<mock connection>" }
+func (c *mockConnection) ServerConnectionID() *int64 { return nil }
+func (c *mockConnection) DriverConnectionID() uint64 { return 0 }
+func (c *mockConnection) Address() address.Address   { return mockAddress }
+func (c *mockConnection) Stale() bool                { return false }
+
+const mockAddress = address.Address("mock:27017")
+
+// readCommand returns the command document of an OP_MSG request with its key, "command collection", e.g.
+// "find properties"; commands that do not name a collection are keyed by their name alone. Document
+// sequences, in which the driver sends the documents of inserts, updates and deletes, are added to the
+// command as arrays, e.g. "documents" for an insert.
+func readCommand(wm []byte) (string, bson.Raw, error) {
+	_, _, _, opcode, rem, ok := wiremessage.ReadHeader(wm)
+	if !ok || opcode != wiremessage.OpMsg {
+		return "", nil, errors.New("mock deployment only reads OP_MSG requests")
+	}
+	if _, rem, ok = wiremessage.ReadMsgFlags(rem); !ok {
+		return "", nil, errors.New("malformed OP_MSG flags")
+	}
+
+	var command bsoncore.Document
+	sequences := map[string][]bsoncore.Document{}
+	for len(rem) > 0 {
+		var section wiremessage.SectionType
+		if section, rem, ok = wiremessage.ReadMsgSectionType(rem); !ok {
+			return "", nil, errors.New("malformed OP_MSG section")
+		}
+		if section == wiremessage.DocumentSequence {
+			var identifier string
+			var docs []bsoncore.Document
+			if identifier, docs, rem, ok = wiremessage.ReadMsgSectionDocumentSequence(rem); !ok {
+				return "", nil, errors.New("malformed OP_MSG document sequence")
+			}
+			sequences[identifier] = docs
+			continue
+		}
+		if command, rem, ok = wiremessage.ReadMsgSectionSingleDocument(rem); !ok {
+			return "", nil, errors.New("malformed OP_MSG command")
+		}
+	}
+	if command == nil {
+		return "", nil, errors.New("OP_MSG request without a command")
+	}
+
+	first, err := command.IndexErr(0)
+	if err != nil {
+		return "", nil, fmt.Errorf("empty command: %w", err)
+	}
+	key := first.Key()
+	if collection, ok := first.Value().StringValueOK(); ok {
+		key += " " + collection
+	}
+
+	// The documents are appended to a new buffer, as the driver reuses the request's
+	index, document := bsoncore.AppendDocumentStart(nil)
+	elements, _ := command.Elements()
+	for _, element := range elements {
+		document = append(document, element...)
+	}
+	for identifier, docs := range sequences {
+		values := make([]bsoncore.Value, len(docs))
+		for i, doc := range docs {
+			values[i] = bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc}
+		}
+		document = bsoncore.AppendArrayElement(document, identifier, bsoncore.BuildArray(nil, values...))
+	}
+	document, _ = bsoncore.AppendDocumentEnd(document, index)
+	return key, bson.Raw(document), nil
+}
+
+// zeroRTTMonitor reports no round-trip time, as the mock deployment answers immediately
+type zeroRTTMonitor struct{}
+
+func (zeroRTTMonitor) EWMA() time.Duration { return 0 }
+func (zeroRTTMonitor) Min() time.Duration  { return 0 }
+func (zeroRTTMonitor) P90() time.Duration  { return 0 }
+func (zeroRTTMonitor) Stats() string       { return "" }
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"property-brochure-backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPropertyPreview(t *testing.T) {
+	t.Run("renders and caches the cover", func(t *testing.T) {
+		th := newTestHandler(t)
+		property := storedProperty()
+		cacheKey := fmt.Sprintf("previews/%s-%d.jpg", property.ID.Hex(), property.UpdatedAt.Unix())
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.storage.On("GetObject", cacheKey).Return(nil, services.ErrObjectNotFound)
+		th.brochures.On("GenerateCoverPagePreview", mock.Anything).Return([]byte("preview"), nil)
+		th.storage.On("PutObject", cacheKey, []byte("preview"), "image/jpeg").Return(nil)
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex()+"/preview", nil))
+		if resp.StatusCode != fiber.StatusOK || resp.Header.Get(fiber.HeaderContentType) != "image/jpeg" {
+			t.Fatalf("status = %d, content type %q, want a 200 JPEG: %s", resp.StatusCode, resp.Header.Get(fiber.HeaderContentType), body)
+		}
+	})
+
+	t.Run("serves the cached cover", func(t *testing.T) {
+		th := newTestHandler(t)
+		property := storedProperty()
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.storage.On("GetObject", mock.Anything).Return([]byte("cached preview"), nil)
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex()+"/preview", nil))
+		if resp.StatusCode != fiber.StatusOK || string(body) != "cached preview" {
+			t.Errorf("status = %d, body %q, want the cached preview", resp.StatusCode, body)
+		}
+		th.brochures.AssertNotCalled(t, "GenerateCoverPagePreview", mock.Anything)
+	})
+
+	t.Run("password-protected listing", func(t *testing.T) {
+		th := newTestHandler(t)
+		property := storedProperty()
+		property.IsPasswordProtected = true
+		th.mongo.onFind("properties", propertyDocument(t, property))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex()+"/preview", nil))
+		if resp.StatusCode != fiber.StatusForbidden {
+			t.Errorf("status = %d, want 403: %s", resp.StatusCode, body)
+		}
+		th.brochures.AssertNotCalled(t, "GenerateCoverPagePreview", mock.Anything)
+		th.storage.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
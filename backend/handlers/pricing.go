@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpdateProperty changes a listing's price, currency or status. A new price or currency is prepended to
+// the price history. Brochure PDFs are not regenerated; they keep the previous price until the brochures
+// are regenerated separately. The update only applies while the listing still has the price and
+// currency it was loaded with, so concurrent updates cannot record a history entry from a stale price.
+//
+// @Summary      Update a listing's price or status
+// @Tags         properties
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                        true  "Property ID"
+// @Param        request  body      models.PropertyUpdateRequest  true  "Fields to change"
+// @Success      200      {object}  models.PropertyDetailResponse
+// @Failure      400      {object}  models.ErrorResponse  "Invalid ID or body"
+// @Failure      404      {object}  models.ErrorResponse  "Property not found"
+// @Failure      409      {object}  models.ErrorResponse  "The price changed concurrently; retry the update"
+// @Failure      500      {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/property/{id} [patch]
+func (h *PropertyHandler) UpdateProperty(c *fiber.Ctx) error {
+	var req models.PropertyUpdateRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+	}
+	if req.Price == nil && req.Currency == nil && req.Status == nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Nothing to update", errors.New("set price, currency or status"))
+	}
+	if req.Price != nil && (!isFinite(*req.Price) || *req.Price <= 0) {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid price", errors.New("price must be greater than 0"))
+	}
+	if req.Currency != nil {
+		currency := strings.TrimSpace(*req.Currency)
+		if currency == "" || !isPrintableText(currency) {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid currency", errors.New("currency must be a non-empty currency name or code"))
+		}
+		req.Currency = &currency
+	}
+	if req.Status != nil {
+		status := strings.ToLower(strings.TrimSpace(*req.Status))
+		if !isPropertyStatus(status) {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid status", fmt.Errorf("status must be %q, %q or %q", models.PropertyStatusActive, models.PropertyStatusPending, models.PropertyStatusSold))
+		}
+		req.Status = &status
+	}
+
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	now := time.Now()
+	set := bson.M{"updatedAt": now}
+	if req.Status != nil {
+		set["status"] = *req.Status
+	}
+	price, currency := property.Price, property.Currency
+	if req.Price != nil {
+		price = *req.Price
+	}
+	if req.Currency != nil {
+		currency = *req.Currency
+	}
+	update := bson.M{"$set": set}
+	if price != property.Price || currency != property.Currency {
+		entries := []models.PriceEntry{{Price: price, Currency: currency, ChangedAt: now}}
+		if len(property.PriceHistory) == 0 {
+			// Listings created before price tracking start their history with the original price
+			entries = append(entries, models.PriceEntry{Price: property.Price, Currency: property.Currency, ChangedAt: property.CreatedAt})
+		}
+		set["price"] = price
+		set["currency"] = currency
+		update["$push"] = bson.M{"priceHistory": bson.M{
+			"$each":     entries,
+			"$position": 0,
+			"$slice":    models.MaxPriceHistory,
+		}}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var updated models.Property
+	err = h.mongoService.GetCollection("properties").FindOneAndUpdate(ctx,
+		bson.M{"_id": property.ID, "price": property.Price, "currency": property.Currency}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.NewAPIError(models.ErrCodeConflict, "Property was changed concurrently", errors.New("its price or currency changed, or it was deleted, since it was loaded; retry the update"))
+	}
+	if err == nil {
+		err = h.decryptProperty(&updated)
+	}
+	if err != nil {
+		log.Printf("Error updating property: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to update property", err)
+	}
+
+	return c.JSON(models.PropertyDetailResponse{
+		Success:  true,
+		Property: &updated,
+	})
+}
+
+// GetPriceHistory returns every recorded asking price of a listing, newest first
+//
+// @Summary      Get a listing's price history
+// @Tags         properties
+// @Produce      json
+// @Param        id   path      string  true  "Property ID"
+// @Success      200  {object}  models.PriceHistoryResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid property ID"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/property/{id}/price-history [get]
+func (h *PropertyHandler) GetPriceHistory(c *fiber.Ctx) error {
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	history := property.PriceHistory
+	if len(history) == 0 {
+		// Listings created before price tracking only know their current price
+		history = []models.PriceEntry{{Price: property.Price, Currency: property.Currency, ChangedAt: property.CreatedAt}}
+	}
+
+	return c.JSON(models.PriceHistoryResponse{
+		Success:      true,
+		PropertyID:   property.ID.Hex(),
+		PriceHistory: history,
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestUpdateProperty(t *testing.T) {
+	property := storedProperty()
+
+	t.Run("price change", func(t *testing.T) {
+		th := newTestHandler(t)
+		updated := *property
+		updated.Price = 2300000
+		updated.PriceHistory = []models.PriceEntry{{Price: 2300000, Currency: "AED"}, {Price: property.Price, Currency: "AED"}}
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.on("findAndModify", "properties", valueResponse(propertyDocument(t, &updated)))
+
+		resp, body := th.do(t, jsonRequest(http.MethodPatch, "/api/property/"+property.ID.Hex(), `{"price": 2300000}`))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		var detail models.PropertyDetailResponse
+		decode(t, body, &detail)
+		if detail.Property == nil || detail.Property.Price != 2300000 || len(detail.Property.PriceHistory) != 2 {
+			t.Errorf("property = %+v, want the new price with the previous one in the history", detail.Property)
+		}
+	})
+
+	t.Run("concurrent price change", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.on("findAndModify", "properties", mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}))
+
+		resp, body := th.do(t, jsonRequest(http.MethodPatch, "/api/property/"+property.ID.Hex(), `{"price": 2300000}`))
+		if resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("status = %d, want 409: %s", resp.StatusCode, body)
+		}
+		updates := th.mongo.sent("findAndModify properties")
+		if len(updates) != 1 {
+			t.Fatalf("sent %d updates, want 1", len(updates))
+		}
+		query := updates[0].Lookup("query")
+		if price, ok := query.Document().Lookup("price").DoubleOK(); !ok || price != property.Price {
+			t.Errorf("update filter = %s, want the loaded price %v", query, property.Price)
+		}
+		if currency, ok := query.Document().Lookup("currency").StringValueOK(); !ok || currency != property.Currency {
+			t.Errorf("update filter = %s, want the loaded currency %q", query, property.Currency)
+		}
+	})
+
+	t.Run("status", func(t *testing.T) {
+		th := newTestHandler(t)
+		updated := *property
+		updated.Status = models.PropertyStatusSold
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.on("findAndModify", "properties", valueResponse(propertyDocument(t, &updated)))
+
+		resp, body := th.do(t, jsonRequest(http.MethodPatch, "/api/property/"+property.ID.Hex(), `{"status": " Sold "}`))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		var detail models.PropertyDetailResponse
+		decode(t, body, &detail)
+		if detail.Property == nil || detail.Property.Status != models.PropertyStatusSold {
+			t.Errorf("property = %+v, want it sold", detail.Property)
+		}
+	})
+
+	for name, body := range map[string]string{
+		"nothing to update": `{}`,
+		"invalid price":     `{"price": -5}`,
+		"invalid currency":  `{"currency": "  "}`,
+		"invalid status":    `{"status": "archived"}`,
+		"malformed body":    `{"price":`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			th := newTestHandler(t)
+
+			resp, respBody := th.do(t, jsonRequest(http.MethodPatch, "/api/property/"+property.ID.Hex(), body))
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("status = %d, want 400: %s", resp.StatusCode, respBody)
+			}
+			if commands := th.mongo.received(); len(commands) != 0 {
+				t.Errorf("sent %v, want the request rejected before the database", commands)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties")
+
+		resp, body := th.do(t, jsonRequest(http.MethodPatch, "/api/property/"+property.ID.Hex(), `{"price": 2300000}`))
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("status = %d, want 404: %s", resp.StatusCode, body)
+		}
+		if received(th.mongo, "findAndModify properties") {
+			t.Error("updated a listing that was not found")
+		}
+	})
+}
@@ -2,54 +2,249 @@ package handlers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"mime/multipart"
+	"net/url"
+	"property-brochure-backend/config"
 	"property-brochure-backend/models"
 	"property-brochure-backend/services"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxThankYouMessageLength limits agent-written closing messages so they fit below the contact card
+const maxThankYouMessageLength = 500
+
+// maxVirtualStagingLength limits the virtual staging brief sent to the AI
+const maxVirtualStagingLength = 300
+
+// maxClosingHeadlineLength keeps the white-label closing headline on one line
+const maxClosingHeadlineLength = 80
+
+// maxSecondaryAgents caps secondary agents so the contact card holds at most three agents
+const maxSecondaryAgents = 2
+
+// maxCoListingAgents caps the co-agents a listing is split with, listed with the primary agent in the Listed By row
+const maxCoListingAgents = 2
+
+// maxAmenities caps the amenities list, which is rendered as a grid on one page
+const maxAmenities = 50
+
+// maxAmenityLength limits a single amenity label
+const maxAmenityLength = 100
+
+// Page sizes of the image gallery endpoint
+const (
+	defaultGalleryPageSize = 8
+	maxGalleryPageSize     = 50
+)
+
+// maxBedrooms bounds the bedroom count of a listing
+const maxBedrooms = 100
+
+// PDF password length limits; the PDF standard truncates passwords after 127 bytes
+const (
+	minPDFPasswordLength = 4
+	maxPDFPasswordLength = 127
 )
 
 type PropertyHandler struct {
-	mongoService  *services.MongoDBService
-	s3Service     *services.S3Service
-	openaiService *services.OpenAIService
-	pdfService    *services.PDFService
+	mongoService  services.MongoStorage
+	storage       services.StorageService
+	images        *services.ImageDedupService
+	openaiService services.AIContentGenerator
+	pdfService    services.BrochureGenerator
 	maxFileSize   int64
 	allowedTypes  string
+
+	// maxImages caps the photos stored on one listing
+	maxImages int
+
+	// fonts resolves the uploaded agency font a submission selects
+	fonts services.FontLoader
+
+	// geocoder fills missing address fields for enrichMissingFields; nil disables enrichment
+	geocoder services.Geocoder
+
+	// addresses normalizes addresses for validateAddress; nil when the AI service cannot
+	addresses *services.AddressService
+
+	// moderator rejects offensive descriptions before anything is generated or stored; nil disables it
+	moderator services.ContentModerator
+
+	// Async submissions run on the worker pool and are tracked in jobs
+	workers *services.WorkerPool
+	jobs    *jobStore
+
+	// events records the audit trail of created and deleted listings and their brochures
+	events *services.EventService
+
+	// features gates Arabic brochures, async submissions and comparable sales; nil enables them
+	features *config.FeatureFlagStore
+
+	// shareLinks stores the public landing page links of listings
+	shareLinks *services.ShareLinkService
+
+	// favorites stores the listings users bookmarked
+	favorites *services.FavoriteService
+
+	// agents stores the agent profiles submissions reference with agentId
+	agents *services.AgentService
+
+	// encryption encrypts agent emails and phone numbers at rest; nil stores them in plain text
+	encryption *services.EncryptionService
 }
 
 func NewPropertyHandler(
-	mongo *services.MongoDBService,
-	s3 *services.S3Service,
-	openai *services.OpenAIService,
-	pdf *services.PDFService,
+	mongo services.MongoStorage,
+	storage services.StorageService,
+	openai services.AIContentGenerator,
+	pdf services.BrochureGenerator,
 	maxFileSize int64,
 	allowedTypes string,
+	maxImages int,
+	workers *services.WorkerPool,
+	fonts services.FontLoader,
+	geocoder services.Geocoder,
+	moderator services.ContentModerator,
+	events *services.EventService,
+	features *config.FeatureFlagStore,
+	encryption *services.EncryptionService,
 ) *PropertyHandler {
-	return &PropertyHandler{
+	h := &PropertyHandler{
 		mongoService:  mongo,
-		s3Service:     s3,
+		storage:       storage,
+		images:        services.NewImageDedupService(mongo, storage),
 		openaiService: openai,
 		pdfService:    pdf,
 		maxFileSize:   maxFileSize,
 		allowedTypes:  allowedTypes,
+		maxImages:     maxImages,
+
+		fonts:     fonts,
+		geocoder:  geocoder,
+		moderator: moderator,
+
+		workers: workers,
+		jobs:    newJobStore(),
+
+		events:   events,
+		features: features,
+
+		shareLinks: services.NewShareLinkService(mongo),
+		favorites:  services.NewFavoriteService(mongo),
+		agents:     services.NewAgentService(mongo, encryption),
+		encryption: encryption,
 	}
+	if normalizer, ok := openai.(services.AddressNormalizer); ok {
+		h.addresses = services.NewAddressService(normalizer)
+	}
+	return h
 }
 
+// SubmitProperty stores a new listing, generates its AI copy and brochures, and uploads everything to storage
+//
+// @Summary      Create a listing and generate its brochures
+// @Description  Uploads the photos, generates localized AI content and renders the requested brochure PDFs.
+// @Tags         properties
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        title                       formData  string    true   "Listing title"
+// @Param        description                 formData  string    false  "Agent description used as input for the AI copy"
+// @Param        price                       formData  number    true   "Asking price"
+// @Param        currency                    formData  string    false  "Currency name or code"  default(Dollar)
+// @Param        address                     formData  string    true   "Street address"
+// @Param        city                        formData  string    true   "City"
+// @Param        state                       formData  string    true   "State or region"
+// @Param        zipCode                     formData  string    true   "Postal code"
+// @Param        amenities[]                 formData  []string  false  "Amenities; defaults to the usual amenities of the property type when empty"  collectionFormat(multi)
+// @Param        images[]                    formData  file      true   "Property photos; the first one is the cover (at least one for active listings, at most MAX_IMAGES_PER_PROPERTY, default 10)"
+// @Param        agentId                     formData  string    false  "Stored agent profile (see /api/agents); replaces the agent name, email, phone and website fields"
+// @Param        agentName                   formData  string    false  "Agent name, required without agentId"
+// @Param        agentEmail                  formData  string    false  "Agent email, required without agentId"
+// @Param        agentPhone                  formData  string    false  "Agent phone, required without agentId"
+// @Param        agentWebsite                formData  string    false  "Agency website (http or https URL)"
+// @Param        secondaryAgentName[]        formData  []string  false  "Secondary agent names, shown on the contact card (max 2)"  collectionFormat(multi)
+// @Param        secondaryAgentEmail[]       formData  []string  false  "Secondary agent emails, parallel to the names"  collectionFormat(multi)
+// @Param        secondaryAgentPhone[]       formData  []string  false  "Secondary agent phones, parallel to the names"  collectionFormat(multi)
+// @Param        coAgentName[]               formData  []string  false  "Co-listing agent names, shown with the primary agent in a Listed By row (max 2)"  collectionFormat(multi)
+// @Param        coAgentEmail[]              formData  []string  false  "Co-listing agent emails, parallel to the names"  collectionFormat(multi)
+// @Param        coAgentPhone[]              formData  []string  false  "Co-listing agent phones, parallel to the names"  collectionFormat(multi)
+// @Param        floorPlanURL                formData  string    false  "Floor plan image URL"
+// @Param        floorPlanWidth              formData  number    false  "Floor plan width in metres"
+// @Param        floorPlanHeight             formData  number    false  "Floor plan height in metres"
+// @Param        virtualTourURL              formData  string    false  "Virtual tour link"
+// @Param        videoURL                    formData  string    false  "Video tour link, shown as a play button on the cover"
+// @Param        propertyType                formData  string    false  "Property type, used for comparable sales"
+// @Param        includeComps                formData  boolean   false  "Add AI-estimated comparable sales"
+// @Param        virtualStaging              formData  string    false  "Space to describe virtually staged, e.g. unfurnished living room (max 300 characters); adds a labeled AI description to the English investment page"
+// @Param        downPaymentPct              formData  number    false  "Mortgage down payment percentage"  default(20)
+// @Param        interestRate                formData  number    false  "Mortgage interest rate percentage"  default(7)
+// @Param        termYears                   formData  integer   false  "Mortgage term in years (1-50)"  default(30)
+// @Param        printMode                   formData  boolean   false  "Add a tear-off contact strip"
+// @Param        printReady                  formData  boolean   false  "Render for print shops: original images, no compression, CMYK-approximated brand colors"
+// @Param        bilingual                   formData  boolean   false  "Also generate the side-by-side bilingual brochure (requires en and ar)"
+// @Param        landscape                   formData  boolean   false  "Render on landscape pages"
+// @Param        pageSize                    formData  string    false  "Paper size"  Enums(A4, Letter, Legal)  default(A4)
+// @Param        pageOrder                   formData  string    false  "JSON array of page names, each at most once, e.g. [\"cover\",\"gallery\",\"details\",\"contact\"]; pages are cover, details, investment, gallery, virtualTour, comps, floorPlan and contact"
+// @Param        marginMm                    formData  integer   false  "Page margin in millimetres (5-30)"  default(15)
+// @Param        languages[]                 formData  []string  false  "Brochure languages"  Enums(en, ar, ur)  collectionFormat(multi)
+// @Param        additionalSectionTitle      formData  string    false  "Investment section title (English)"
+// @Param        additionalSectionContent    formData  string    false  "Investment section content (English)"
+// @Param        additionalSectionTitleAr    formData  string    false  "Investment section title (Arabic)"
+// @Param        additionalSectionContentAr  formData  string    false  "Investment section content (Arabic)"
+// @Param        thankYouMessageEn           formData  string    false  "Closing message (English, max 500 characters)"
+// @Param        thankYouMessageAr           formData  string    false  "Closing message (Arabic, max 500 characters)"
+// @Param        closingLogoURL              formData  string    false  "White-label closing: agency logo URL, shown instead of the thank-you message"
+// @Param        closingHeadline             formData  string    false  "White-label closing: headline (max 80 characters)"
+// @Param        closingMessage              formData  string    false  "White-label closing: message (max 500 characters)"
+// @Param        closingInstagramURL         formData  string    false  "White-label closing: Instagram profile URL"
+// @Param        closingFacebookURL          formData  string    false  "White-label closing: Facebook page URL"
+// @Param        closingLinkedInURL          formData  string    false  "White-label closing: LinkedIn page URL"
+// @Param        closingWebsite              formData  string    false  "White-label closing: agency website URL"
+// @Param        fontId                      formData  string    false  "ID of an uploaded agency font (POST /api/admin/fonts)"
+// @Param        themePreset                 formData  string    false  "Theme preset bundling colors, fonts and cover layout, e.g. luxury, modern, coastal or corporate"
+// @Param        pdfPassword                 formData  string    false  "Encrypt the brochures (AES-128) with this password, 4-127 printable ASCII characters"
+// @Param        latitude                    formData  number    false  "Latitude in decimal degrees, shown on a cover map (requires longitude)"
+// @Param        longitude                   formData  number    false  "Longitude in decimal degrees, shown on a cover map (requires latitude)"
+// @Param        enrichMissingFields         formData  boolean   false  "Fill a missing city, state, zip code or coordinates by geocoding the address, and the bedrooms of an apartment from its description"
+// @Param        validateAddress             formData  boolean   false  "Normalize the address first and reject it when it is unlikely to exist"
+// @Param        bedrooms                    formData  int       false  "Number of bedrooms"
+// @Param        status                      formData  string    false  "Listing status"  Enums(active, pending, sold)  default(active)
+// @Param        async                       formData  boolean   false  "Queue brochure generation and return a job to poll at /api/jobs/{id}"
+// @Param        dryRun                      formData  boolean   false  "Only validate the submission and estimate its cost; nothing is uploaded, generated or stored"
+// @Success      201  {object}  models.PropertyResponse
+// @Success      200  {object}  models.DryRunResponse  "Dry run result"
+// @Success      202  {object}  models.JobResponse    "Queued (async mode)"
+// @Failure      400  {object}  models.ErrorResponse  "Invalid form data (ERR_VALIDATION)"
+// @Failure      413  {object}  models.ErrorResponse  "Image too large (ERR_FILE_TOO_LARGE)"
+// @Failure      415  {object}  models.ErrorResponse  "Image type not allowed (ERR_INVALID_TYPE)"
+// @Failure      422  {object}  models.ErrorResponse  "Cover image is unavailable, or the description violates the content policy (ERR_CONTENT_POLICY)"
+// @Failure      500  {object}  models.ErrorResponse  "PDF generation or database failure (ERR_PDF_GENERATION, ERR_MONGO_INSERT)"
+// @Failure      502  {object}  models.ErrorResponse  "Storage upload or AI generation failure (ERR_S3_UPLOAD, ERR_AI_GENERATION)"
+// @Failure      503  {object}  models.ErrorResponse  "Job queue is full or the server is shutting down"
+// @Router       /api/property [post]
 func (h *PropertyHandler) SubmitProperty(c *fiber.Ctx) error {
 	// Parse multipart form
 	form, err := c.MultipartForm()
 	if err != nil {
 		log.Printf("Error parsing form: %v", err)
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Invalid form data",
-			Error:   err.Error(),
-		})
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid form data", err)
 	}
 
 	// Extract form values
@@ -64,15 +259,115 @@ func (h *PropertyHandler) SubmitProperty(c *fiber.Ctx) error {
 		AgentName:   c.FormValue("agentName"),
 		AgentEmail:  c.FormValue("agentEmail"),
 		AgentPhone:  c.FormValue("agentPhone"),
+
+		FloorPlanURL:   strings.TrimSpace(c.FormValue("floorPlanURL")),
+		VirtualTourURL: strings.TrimSpace(c.FormValue("virtualTourURL")),
+		VideoURL:       strings.TrimSpace(c.FormValue("videoURL")),
+
+		PropertyType: strings.TrimSpace(c.FormValue("propertyType")),
+		IncludeComps: c.FormValue("includeComps") == "true",
+
+		VirtualStaging: strings.TrimSpace(c.FormValue("virtualStaging")),
+
+		Mortgage: models.MortgageDetails{
+			DownPaymentPct: 20,
+			InterestRate:   7,
+			TermYears:      30,
+		},
+
+		PrintMode:  c.FormValue("printMode") == "true",
+		PrintReady: c.FormValue("printReady") == "true",
+		Bilingual:  c.FormValue("bilingual") == "true",
+		Landscape:  c.FormValue("landscape") == "true",
+
+		PageSize: strings.TrimSpace(c.FormValue("pageSize")),
+
+		AdditionalSectionTitle:     strings.TrimSpace(c.FormValue("additionalSectionTitle")),
+		AdditionalSectionContent:   strings.TrimSpace(c.FormValue("additionalSectionContent")),
+		AdditionalSectionTitleAr:   strings.TrimSpace(c.FormValue("additionalSectionTitleAr")),
+		AdditionalSectionContentAr: strings.TrimSpace(c.FormValue("additionalSectionContentAr")),
+
+		ThankYouMessageEn: strings.TrimSpace(c.FormValue("thankYouMessageEn")),
+		ThankYouMessageAr: strings.TrimSpace(c.FormValue("thankYouMessageAr")),
+
+		WhiteLabelClosing: models.WhiteLabelClosing{
+			LogoURL:      strings.TrimSpace(c.FormValue("closingLogoURL")),
+			Headline:     strings.TrimSpace(c.FormValue("closingHeadline")),
+			Message:      strings.TrimSpace(c.FormValue("closingMessage")),
+			InstagramURL: strings.TrimSpace(c.FormValue("closingInstagramURL")),
+			FacebookURL:  strings.TrimSpace(c.FormValue("closingFacebookURL")),
+			LinkedInURL:  strings.TrimSpace(c.FormValue("closingLinkedInURL")),
+			Website:      strings.TrimSpace(c.FormValue("closingWebsite")),
+		},
+
+		AgentWebsite: strings.TrimSpace(c.FormValue("agentWebsite")),
+
+		FontID: strings.TrimSpace(c.FormValue("fontId")),
+
+		AgentID: strings.TrimSpace(c.FormValue("agentId")),
+
+		ThemePreset: strings.TrimSpace(c.FormValue("themePreset")),
+
+		PDFPassword: c.FormValue("pdfPassword"),
+
+		EnrichMissingFields: c.FormValue("enrichMissingFields") == "true",
+		ValidateAddress:     c.FormValue("validateAddress") == "true",
+
+		Status: strings.ToLower(strings.TrimSpace(c.FormValue("status"))),
 	}
 
 	// Parse price
 	if _, err := fmt.Sscanf(c.FormValue("price"), "%f", &req.Price); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Invalid price format",
-			Error:   err.Error(),
-		})
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid price format", err)
+	}
+
+	// Parse optional floor plan dimensions (metres) and mortgage assumptions
+	for field, target := range map[string]*float64{
+		"floorPlanWidth":  &req.FloorPlanWidth,
+		"floorPlanHeight": &req.FloorPlanHeight,
+		"downPaymentPct":  &req.Mortgage.DownPaymentPct,
+		"interestRate":    &req.Mortgage.InterestRate,
+	} {
+		if value := c.FormValue(field); value != "" {
+			if _, err := fmt.Sscanf(value, "%f", target); err != nil || *target < 0 {
+				return models.NewAPIError(models.ErrCodeValidation, fmt.Sprintf("Invalid %s format", field), fmt.Errorf("%s must be a non-negative number", field))
+			}
+		}
+	}
+
+	if value := c.FormValue("termYears"); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &req.Mortgage.TermYears); err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid termYears format", err)
+		}
+	}
+	if value := c.FormValue("marginMm"); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &req.MarginMm); err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid marginMm format", err)
+		}
+	}
+	for field, target := range map[string]**float64{
+		"latitude":  &req.Latitude,
+		"longitude": &req.Longitude,
+	} {
+		if value := c.FormValue(field); value != "" {
+			var parsed float64
+			if _, err := fmt.Sscanf(value, "%f", &parsed); err != nil {
+				return models.NewAPIError(models.ErrCodeValidation, fmt.Sprintf("Invalid %s format", field), fmt.Errorf("%s must be a number in decimal degrees", field))
+			}
+			*target = &parsed
+		}
+	}
+	if value := c.FormValue("bedrooms"); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &req.Bedrooms); err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid bedrooms format", err)
+		}
+	}
+
+	// Optional page order as a JSON array of page names
+	if value := c.FormValue("pageOrder"); value != "" {
+		if err := json.Unmarshal([]byte(value), &req.PageOrder); err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid pageOrder format", errors.New("pageOrder must be a JSON array of page names"))
+		}
 	}
 
 	// Get amenities
@@ -80,62 +375,157 @@ func (h *PropertyHandler) SubmitProperty(c *fiber.Ctx) error {
 		req.Amenities = amenities
 	}
 
-	// Validate required fields
-	if err := h.validateRequest(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Validation failed",
-			Error:   err.Error(),
-		})
+	// Get secondary and co-listing agents from the parallel name/email/phone lists
+	if req.SecondaryAgents, err = formAgents(form, "secondaryAgent"); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid secondary agents", err)
+	}
+	if req.CoListingAgents, err = formAgents(form, "coAgent"); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid co-listing agents", err)
 	}
 
-	// Upload images to S3
-	imageURLs := []string{}
-	if images, ok := form.File["images[]"]; ok {
-		for _, fileHeader := range images {
-			// Validate file size
-			if fileHeader.Size > h.maxFileSize {
-				return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-					Success: false,
-					Message: "File size exceeds maximum allowed size",
-					Error:   fmt.Sprintf("File %s is too large", fileHeader.Filename),
-				})
-			}
+	// A stored agent profile takes the place of the inline agent fields
+	if req.AgentID != "" {
+		if err := h.applyAgentProfile(&req); err != nil {
+			return err
+		}
+	}
 
-			// Validate file type
-			if !h.isAllowedFileType(fileHeader.Header.Get("Content-Type")) {
-				return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-					Success: false,
-					Message: "Invalid file type",
-					Error:   fmt.Sprintf("File %s has invalid type", fileHeader.Filename),
-				})
-			}
+	// Get requested brochure languages (defaults to English and Arabic)
+	if languages, ok := form.Value["languages[]"]; ok {
+		req.Languages = languages
+	}
 
-			// Open file
-			file, err := fileHeader.Open()
-			if err != nil {
-				log.Printf("Error opening file: %v", err)
-				return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-					Success: false,
-					Message: "Failed to process image",
-					Error:   err.Error(),
-				})
+	// A dry run makes no OpenAI calls, so the address is not normalized
+	dryRun := c.FormValue("dryRun") == "true"
+	if req.ValidateAddress && !dryRun {
+		if err := h.applyAddressValidation(&req); err != nil {
+			return err
+		}
+	}
+	if req.EnrichMissingFields {
+		h.enrichMissingFields(&req, !dryRun)
+	}
+
+	if dryRun {
+		return c.JSON(h.dryRunProperty(&req, form.File["images[]"]))
+	}
+
+	// Rejected before any enrichment or upload work, in both sync and async mode
+	if err := h.checkImageCount(&req, len(form.File["images[]"])); err != nil {
+		return err
+	}
+
+	// In async mode the request is validated now and the brochures are generated on the worker pool
+	if c.FormValue("async") == "true" {
+		if !h.features.Flags().EnableAsync {
+			return models.NewAPIError(models.ErrCodeValidation, "Async submissions are disabled", errors.New("submit without async=true"))
+		}
+		if err := h.validateRequest(&req); err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Validation failed", err)
+		}
+		if err := h.moderateDescription(&req); err != nil {
+			return err
+		}
+
+		job, err := h.enqueueProperty(&req, form.File["images[]"])
+		if err != nil {
+			log.Printf("Error queueing property: %v", err)
+			code := models.ErrCodeInternal
+			if errors.Is(err, services.ErrQueueFull) || errors.Is(err, services.ErrPoolClosed) {
+				code = models.ErrCodeUnavailable
 			}
-			defer file.Close()
-
-			// Upload to S3
-			url, err := h.s3Service.UploadFile(file, fileHeader, "properties")
-			if err != nil {
-				log.Printf("Error uploading to S3: %v", err)
-				return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-					Success: false,
-					Message: "Failed to upload image",
-					Error:   err.Error(),
-				})
+			return models.NewAPIError(code, "Failed to queue property", err)
+		}
+
+		c.Location("/api/jobs/" + job.ID)
+		return c.Status(fiber.StatusAccepted).JSON(models.JobResponse{
+			Success: true,
+			Message: "Property queued for brochure generation",
+			Job:     job,
+		})
+	}
+
+	response, err := h.createProperty(&req, form.File["images[]"], nil)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// createProperty validates the request, uploads the images, generates the AI content and brochures and
+// stores the listing. It is shared by the REST and GraphQL APIs; failures are *models.APIError values.
+func (h *PropertyHandler) createProperty(req *models.PropertyRequest, images []*multipart.FileHeader, reusedImageURLs []string) (*models.PropertyResponse, error) {
+	// Listings created without amenities, from any API, get the defaults of their property type
+	if len(req.Amenities) == 0 && req.PropertyType != "" {
+		req.Amenities = services.DefaultAmenitiesFor(req.PropertyType)
+	}
+
+	// Validate required fields
+	if err := h.validateRequest(req); err != nil {
+		return nil, models.NewAPIError(models.ErrCodeValidation, "Validation failed", err)
+	}
+	if err := h.moderateDescription(req); err != nil {
+		return nil, err
+	}
+	if req.FontID != "" {
+		if _, err := h.fonts.LoadFont(req.FontID); err != nil {
+			if errors.Is(err, services.ErrFontNotFound) {
+				return nil, models.NewAPIError(models.ErrCodeValidation, "Unknown font", fmt.Errorf("no uploaded font has ID %s", req.FontID))
 			}
+			log.Printf("Error loading font %s: %v", req.FontID, err)
+			return nil, models.NewAPIError(models.ErrCodeInternal, "Failed to load font", err)
+		}
+	}
+
+	// Objects written to storage by this request; deleted again if a later step fails. The hash records of
+	// new images are only stored with the listing, so no other submission reuses an image rolled back here.
+	uploadedKeys := []string{}
+	imageRecords := []models.ImageHash{}
+	succeeded := false
+	defer func() {
+		if !succeeded && len(uploadedKeys) > 0 {
+			h.rollbackUploads(uploadedKeys)
+		}
+	}()
+
+	// Upload images to object storage; images already stored (duplicated listings) are kept as they are
+	imageURLs := append([]string{}, reusedImageURLs...)
+	if err := h.checkImageCount(req, len(reusedImageURLs)+len(images)); err != nil {
+		return nil, err
+	}
+
+	for _, fileHeader := range images {
+		// Validate file size
+		if fileHeader.Size > h.maxFileSize {
+			return nil, models.NewAPIError(models.ErrCodeFileTooLarge, "File size exceeds maximum allowed size", fmt.Errorf("File %s is too large", fileHeader.Filename))
+		}
+
+		// Validate file type
+		if !h.isAllowedFileType(fileHeader.Header.Get("Content-Type")) {
+			return nil, models.NewAPIError(models.ErrCodeInvalidType, "Invalid file type", fmt.Errorf("File %s has invalid type", fileHeader.Filename))
+		}
+
+		// Open file
+		file, err := fileHeader.Open()
+		if err != nil {
+			log.Printf("Error opening file: %v", err)
+			return nil, models.NewAPIError(models.ErrCodeInternal, "Failed to process image", err)
+		}
+		defer file.Close()
 
-			imageURLs = append(imageURLs, url)
+		// Upload to object storage, reusing identical images uploaded before
+		url, record, err := h.images.UploadFile(file, fileHeader, "properties")
+		if err != nil {
+			log.Printf("Error uploading image: %v", err)
+			return nil, models.NewAPIError(models.ErrCodeS3Upload, "Failed to upload image", err)
+		}
+		if record != nil {
+			uploadedKeys = append(uploadedKeys, record.S3Key)
+			imageRecords = append(imageRecords, *record)
 		}
+
+		imageURLs = append(imageURLs, url)
 	}
 
 	// Generate AI content (legacy for backward compatibility)
@@ -149,21 +539,18 @@ func (h *PropertyHandler) SubmitProperty(c *fiber.Ctx) error {
 	)
 	if err != nil {
 		log.Printf("Error generating AI content: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Failed to generate AI content",
-			Error:   err.Error(),
-		})
+		return nil, models.NewAPIError(models.ErrCodeAIGeneration, "Failed to generate AI content", err)
 	}
 
-	// Generate fully localized content for English and Arabic
-	log.Println("Generating localized content for English and Arabic...")
+	// Generate fully localized content for the requested languages
+	log.Printf("Generating localized content for %v...", req.Languages)
 	localizedContent, err := h.openaiService.GenerateLocalizedContent(
 		req.Title,
 		req.Description,
 		fmt.Sprintf("%.2f", req.Price),
 		req.Currency,
 		req.Amenities,
+		req.Languages,
 	)
 	if err != nil {
 		log.Printf("Error generating localized content: %v", err)
@@ -186,9 +573,10 @@ func (h *PropertyHandler) SubmitProperty(c *fiber.Ctx) error {
 		Amenities:   req.Amenities,
 		ImageURLs:   imageURLs,
 		AgentInfo: models.AgentInfo{
-			Name:  req.AgentName,
-			Email: req.AgentEmail,
-			Phone: req.AgentPhone,
+			Name:    req.AgentName,
+			Email:   req.AgentEmail,
+			Phone:   req.AgentPhone,
+			Website: req.AgentWebsite,
 		},
 		AIContent: models.AIContent{
 			EnglishDescription: aiContent.EnglishDescription,
@@ -197,98 +585,231 @@ func (h *PropertyHandler) SubmitProperty(c *fiber.Ctx) error {
 		},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+
+		FloorPlanURL:    req.FloorPlanURL,
+		FloorPlanWidth:  req.FloorPlanWidth,
+		FloorPlanHeight: req.FloorPlanHeight,
+		VirtualTourURL:  req.VirtualTourURL,
+		VideoURL:        req.VideoURL,
+		Latitude:        req.Latitude,
+		Longitude:       req.Longitude,
+		PropertyType:    req.PropertyType,
+		VirtualStaging:  req.VirtualStaging,
+		Mortgage:        &req.Mortgage,
+		PrintMode:       req.PrintMode,
+		PrintReady:      req.PrintReady,
+		Landscape:       req.Landscape,
+		PageSize:        req.PageSize,
+		PageOrder:       req.PageOrder,
+		Languages:       req.Languages,
+		SecondaryAgents: req.SecondaryAgents,
+		CoListingAgents: req.CoListingAgents,
+		MarginMm:        req.MarginMm,
+		FontID:          req.FontID,
+		AgentID:         req.AgentID,
+		ThemePreset:     req.ThemePreset,
+
+		IsPasswordProtected: req.PDFPassword != "",
+		Enriched:            len(req.EnrichedFields) > 0,
+
+		Bedrooms: req.Bedrooms,
+		Status:   req.Status,
+	}
+	if property.Status == "" {
+		property.Status = models.PropertyStatusActive
 	}
+	if !req.WhiteLabelClosing.IsZero() {
+		closing := req.WhiteLabelClosing
+		property.WhiteLabelClosing = &closing
+	}
+	// The asking price at listing time starts the price history
+	property.PriceHistory = []models.PriceEntry{{Price: property.Price, Currency: property.Currency, ChangedAt: property.CreatedAt}}
 
 	// Add localized content if available
-	if localizedContent != nil {
-		property.EnglishContent = models.LocalizedContent{
-			Title:                    localizedContent.EnglishContent.Title,
-			Description:              localizedContent.EnglishContent.Description,
-			PriceLabel:               localizedContent.EnglishContent.PriceLabel,
-			AddressLabel:             localizedContent.EnglishContent.AddressLabel,
-			CityLabel:                localizedContent.EnglishContent.CityLabel,
-			StateLabel:               localizedContent.EnglishContent.StateLabel,
-			ZipCodeLabel:             localizedContent.EnglishContent.ZipCodeLabel,
-			Highlights:               localizedContent.EnglishContent.Highlights,
-			AmenitiesLabel:           localizedContent.EnglishContent.AmenitiesLabel,
-			Amenities:                localizedContent.EnglishContent.TranslatedAmenities,
-			AgentLabel:               localizedContent.EnglishContent.AgentLabel,
-			PropertyDescriptionLabel: localizedContent.EnglishContent.PropertyDescriptionLabel,
-			KeyHighlightsLabel:       localizedContent.EnglishContent.KeyHighlightsLabel,
-			PropertyGalleryLabel:     localizedContent.EnglishContent.PropertyGalleryLabel,
-		}
-		property.ArabicContent = models.LocalizedContent{
-			Title:                    localizedContent.ArabicContent.Title,
-			Description:              localizedContent.ArabicContent.Description,
-			PriceLabel:               localizedContent.ArabicContent.PriceLabel,
-			AddressLabel:             localizedContent.ArabicContent.AddressLabel,
-			CityLabel:                localizedContent.ArabicContent.CityLabel,
-			StateLabel:               localizedContent.ArabicContent.StateLabel,
-			ZipCodeLabel:             localizedContent.ArabicContent.ZipCodeLabel,
-			Highlights:               localizedContent.ArabicContent.Highlights,
-			AmenitiesLabel:           localizedContent.ArabicContent.AmenitiesLabel,
-			Amenities:                localizedContent.ArabicContent.TranslatedAmenities,
-			AgentLabel:               localizedContent.ArabicContent.AgentLabel,
-			PropertyDescriptionLabel: localizedContent.ArabicContent.PropertyDescriptionLabel,
-			KeyHighlightsLabel:       localizedContent.ArabicContent.KeyHighlightsLabel,
-			PropertyGalleryLabel:     localizedContent.ArabicContent.PropertyGalleryLabel,
-		}
-	}
-
-	// Generate English PDF brochure
-	log.Println("Generating English PDF brochure...")
-	pdfDataEnglish, err := h.pdfService.GenerateEnglishBrochure(property)
-	if err != nil {
-		log.Printf("Error generating English PDF: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Failed to generate English PDF",
-			Error:   err.Error(),
-		})
+	if localizedContent != nil && hasLanguage(req.Languages, "en") {
+		property.EnglishContent = newLocalizedContent(localizedContent.EnglishContent)
+	}
+	if localizedContent != nil && hasLanguage(req.Languages, "ar") {
+		property.ArabicContent = newLocalizedContent(localizedContent.ArabicContent)
+	}
+	if localizedContent != nil && hasLanguage(req.Languages, "ur") {
+		property.UrduContent = newLocalizedContent(localizedContent.UrduContent)
 	}
 
-	// Generate Arabic PDF brochure
-	log.Println("Generating Arabic PDF brochure...")
-	pdfDataArabic, err := h.pdfService.GenerateArabicBrochure(property)
-	if err != nil {
-		log.Printf("Error generating Arabic PDF: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Failed to generate Arabic PDF",
-			Error:   err.Error(),
-		})
+	// Icons for the amenity grid, matched on the English names the agent entered
+	property.EnglishContent.AmenityIcon = services.AmenityIcons(property.EnglishContent.Amenities, req.Amenities)
+	property.ArabicContent.AmenityIcon = services.AmenityIcons(property.ArabicContent.Amenities, req.Amenities)
+	property.UrduContent.AmenityIcon = services.AmenityIcons(property.UrduContent.Amenities, req.Amenities)
+
+	// Agent-written investment section takes precedence over the AI copy
+	if req.AdditionalSectionTitle != "" || req.AdditionalSectionContent != "" {
+		property.EnglishContent.AdditionalSectionTitle = req.AdditionalSectionTitle
+		property.EnglishContent.AdditionalSectionContent = req.AdditionalSectionContent
+		if property.EnglishContent.AdditionalSectionTitle == "" {
+			property.EnglishContent.AdditionalSectionTitle = "Investment Opportunity"
+		}
+	}
+	if req.AdditionalSectionTitleAr != "" || req.AdditionalSectionContentAr != "" {
+		property.ArabicContent.AdditionalSectionTitle = req.AdditionalSectionTitleAr
+		property.ArabicContent.AdditionalSectionContent = req.AdditionalSectionContentAr
+		if property.ArabicContent.AdditionalSectionTitle == "" {
+			property.ArabicContent.AdditionalSectionTitle = "فرصة استثمارية"
+		}
 	}
 
-	// Upload English PDF to S3
-	log.Println("Uploading English PDF to S3...")
-	titleEnglish := property.Title + "_en"
-	pdfUrlsEnglish, err := h.s3Service.UploadPDFWithUrls(pdfDataEnglish, titleEnglish)
-	if err != nil {
-		log.Printf("Error uploading English PDF: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Failed to upload English PDF",
-			Error:   err.Error(),
-		})
+	// Agent-written closing messages take precedence over the AI copy
+	if req.ThankYouMessageEn != "" {
+		property.EnglishContent.ThankYouMessage = req.ThankYouMessageEn
+	}
+	if req.ThankYouMessageAr != "" {
+		property.ArabicContent.ThankYouMessage = req.ThankYouMessageAr
 	}
 
-	// Upload Arabic PDF to S3
-	log.Println("Uploading Arabic PDF to S3...")
-	titleArabic := property.Title + "_ar"
-	pdfUrlsArabic, err := h.s3Service.UploadPDFWithUrls(pdfDataArabic, titleArabic)
-	if err != nil {
-		log.Printf("Error uploading Arabic PDF: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Failed to upload Arabic PDF",
-			Error:   err.Error(),
-		})
+	// Verify the cover up front, as a broken cover is fatal; broken gallery images become warnings
+	if err := h.pdfService.CheckCoverImage(property); err != nil {
+		log.Printf("Error checking images: %v", err)
+		return nil, models.NewAPIError(models.ErrCodeValidation, "Cover image is unavailable", err).WithStatus(fiber.StatusUnprocessableEntity)
+	}
+
+	// Optional comparable sales (illustrative AI estimates), skipped while the section is switched off
+	if req.IncludeComps && h.features.Flags().EnableCompsSection {
+		log.Println("Generating comparable sales...")
+		comps, err := h.openaiService.GenerateComparableSales(req.City, req.State, req.Price, req.PropertyType)
+		if err != nil {
+			log.Printf("Error generating comparable sales, omitting section: %v", err)
+		} else {
+			for _, sale := range comps.Sales {
+				property.ComparableSales = append(property.ComparableSales, models.ComparableSale{
+					AddressStub:  sale.AddressStub,
+					Price:        sale.Price,
+					DaysOnMarket: sale.DaysOnMarket,
+				})
+			}
+		}
+	}
+
+	// Optional virtual staging description; like comparable sales, the section is left out when it fails
+	if req.VirtualStaging != "" {
+		log.Println("Generating virtual staging description...")
+		staging, err := h.openaiService.GenerateVirtualStagingDescription(req.VirtualStaging, req.PropertyType)
+		if err != nil {
+			log.Printf("Error generating virtual staging description, omitting section: %v", err)
+		} else {
+			property.VirtualStagingDescription = staging
+		}
+	}
+
+	// Generate and upload a brochure per requested language
+	var pdfUrlsEnglish, pdfUrlsArabic *services.PDFUrls
+	if hasLanguage(req.Languages, "en") {
+		log.Println("Generating English PDF brochure...")
+		pdfDataEnglish, err := h.pdfService.GenerateEnglishBrochure(property)
+		if err != nil {
+			log.Printf("Error generating English PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodePDFGeneration, "Failed to generate English PDF", err)
+		}
+		pdfDataEnglish, err = h.protectPDF(pdfDataEnglish, req.PDFPassword, "English")
+		if err != nil {
+			log.Printf("Error encrypting English PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodePDFGeneration, "Failed to encrypt English PDF", err)
+		}
+
+		log.Println("Uploading English PDF to storage...")
+		pdfUrlsEnglish, err = h.storage.UploadPDFWithUrls(pdfDataEnglish, property.Title+"_en")
+		if err != nil {
+			log.Printf("Error uploading English PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodeS3Upload, "Failed to upload English PDF", err)
+		}
+		h.trackUpload(&uploadedKeys, pdfUrlsEnglish.ViewUrl)
+	}
+
+	if hasLanguage(req.Languages, "ar") {
+		log.Println("Generating Arabic PDF brochure...")
+		pdfDataArabic, err := h.pdfService.GenerateArabicBrochure(property)
+		if err != nil {
+			log.Printf("Error generating Arabic PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodePDFGeneration, "Failed to generate Arabic PDF", err)
+		}
+		pdfDataArabic, err = h.protectPDF(pdfDataArabic, req.PDFPassword, "Arabic")
+		if err != nil {
+			log.Printf("Error encrypting Arabic PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodePDFGeneration, "Failed to encrypt Arabic PDF", err)
+		}
+
+		log.Println("Uploading Arabic PDF to storage...")
+		pdfUrlsArabic, err = h.storage.UploadPDFWithUrls(pdfDataArabic, property.Title+"_ar")
+		if err != nil {
+			log.Printf("Error uploading Arabic PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodeS3Upload, "Failed to upload Arabic PDF", err)
+		}
+		h.trackUpload(&uploadedKeys, pdfUrlsArabic.ViewUrl)
 	}
 
-	// Store both PDFs' URLs
-	property.PDFUrl = pdfUrlsEnglish.ViewUrl // Store view URL as default (English for backward compatibility)
-	property.PDFUrlEnglish = pdfUrlsEnglish.ViewUrl
-	property.PDFUrlArabic = pdfUrlsArabic.ViewUrl
+	var pdfUrlsUrdu *services.PDFUrls
+	if hasLanguage(req.Languages, "ur") {
+		log.Println("Generating Urdu PDF brochure...")
+		pdfDataUrdu, err := h.pdfService.GenerateUrduBrochure(property)
+		if err != nil {
+			log.Printf("Error generating Urdu PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodePDFGeneration, "Failed to generate Urdu PDF", err)
+		}
+		pdfDataUrdu, err = h.protectPDF(pdfDataUrdu, req.PDFPassword, "Urdu")
+		if err != nil {
+			log.Printf("Error encrypting Urdu PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodePDFGeneration, "Failed to encrypt Urdu PDF", err)
+		}
+
+		log.Println("Uploading Urdu PDF to storage...")
+		pdfUrlsUrdu, err = h.storage.UploadPDFWithUrls(pdfDataUrdu, property.Title+"_ur")
+		if err != nil {
+			log.Printf("Error uploading Urdu PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodeS3Upload, "Failed to upload Urdu PDF", err)
+		}
+		h.trackUpload(&uploadedKeys, pdfUrlsUrdu.ViewUrl)
+	}
+
+	// Optional side-by-side bilingual brochure
+	var pdfUrlsBilingual *services.PDFUrls
+	if req.Bilingual {
+		log.Println("Generating bilingual PDF brochure...")
+		pdfDataBilingual, err := h.pdfService.GenerateBilingualBrochure(property)
+		if err != nil {
+			log.Printf("Error generating bilingual PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodePDFGeneration, "Failed to generate bilingual PDF", err)
+		}
+		pdfDataBilingual, err = h.protectPDF(pdfDataBilingual, req.PDFPassword, "bilingual")
+		if err != nil {
+			log.Printf("Error encrypting bilingual PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodePDFGeneration, "Failed to encrypt bilingual PDF", err)
+		}
+
+		pdfUrlsBilingual, err = h.storage.UploadPDFWithUrls(pdfDataBilingual, property.Title+"_bilingual")
+		if err != nil {
+			log.Printf("Error uploading bilingual PDF: %v", err)
+			return nil, models.NewAPIError(models.ErrCodeS3Upload, "Failed to upload bilingual PDF", err)
+		}
+		h.trackUpload(&uploadedKeys, pdfUrlsBilingual.ViewUrl)
+		property.PDFUrlBilingual = pdfUrlsBilingual.ViewUrl
+	}
+
+	// Store the generated PDFs' URLs; the default is English when available (backward compatibility)
+	defaultUrls := pdfUrlsEnglish
+	if defaultUrls == nil {
+		defaultUrls = pdfUrlsArabic
+	}
+	if defaultUrls == nil {
+		defaultUrls = pdfUrlsUrdu
+	}
+	property.PDFUrl = defaultUrls.ViewUrl
+	if pdfUrlsEnglish != nil {
+		property.PDFUrlEnglish = pdfUrlsEnglish.ViewUrl
+	}
+	if pdfUrlsArabic != nil {
+		property.PDFUrlArabic = pdfUrlsArabic.ViewUrl
+	}
+	if pdfUrlsUrdu != nil {
+		property.PDFUrlUrdu = pdfUrlsUrdu.ViewUrl
+	}
 
 	// Save to MongoDB
 	log.Println("Saving to MongoDB...")
@@ -296,62 +817,1281 @@ func (h *PropertyHandler) SubmitProperty(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	_, err = collection.InsertOne(ctx, property)
+	property.CityLower = strings.ToLower(strings.TrimSpace(property.City))
+	if property.Latitude != nil && property.Longitude != nil {
+		property.Location = models.NewGeoPoint(*property.Latitude, *property.Longitude)
+	}
+	stored := property
+	if h.encryption != nil {
+		if stored, err = h.encryption.EncryptProperty(property); err != nil {
+			log.Printf("Error encrypting property: %v", err)
+			return nil, models.NewAPIError(models.ErrCodeInternal, "Failed to encrypt agent details", err)
+		}
+	}
+	_, err = collection.InsertOne(ctx, stored)
 	if err != nil {
 		log.Printf("Error saving to MongoDB: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Success: false,
-			Message: "Failed to save property",
-			Error:   err.Error(),
-		})
+		return nil, models.NewAPIError(models.ErrCodeMongoInsert, "Failed to save property", err)
+	}
+
+	succeeded = true
+	h.recordImages(imageRecords)
+
+	h.emitEvent(models.EventPropertyCreated, property, map[string]interface{}{
+		"title":     property.Title,
+		"languages": req.Languages,
+	})
+	for _, brochure := range []struct {
+		language string
+		urls     *services.PDFUrls
+	}{
+		{"en", pdfUrlsEnglish},
+		{"ar", pdfUrlsArabic},
+		{"ur", pdfUrlsUrdu},
+		{"bilingual", pdfUrlsBilingual},
+	} {
+		if brochure.urls != nil {
+			h.emitEvent(models.EventPDFGenerated, property, map[string]interface{}{
+				"language":          brochure.language,
+				"passwordProtected": property.IsPasswordProtected,
+			})
+		}
+	}
+
+	// Build the response with the PDF URLs of each generated language
+	response := models.PropertyResponse{
+		Success:        true,
+		Message:        "Property listing created successfully",
+		PropertyID:     property.ID.Hex(),
+		PDFUrl:         defaultUrls.ViewUrl,     // Default URL (English when generated, for backward compatibility)
+		PDFViewUrl:     defaultUrls.ViewUrl,     // Legacy: Opens in browser
+		PDFDownloadUrl: defaultUrls.DownloadUrl, // Legacy: Forces download
+	}
+	if pdfUrlsEnglish != nil {
+		response.PDFUrlEnglish = pdfUrlsEnglish.ViewUrl
+		response.PDFViewUrlEnglish = pdfUrlsEnglish.ViewUrl
+		response.PDFDownloadUrlEnglish = pdfUrlsEnglish.DownloadUrl
+	}
+	if pdfUrlsArabic != nil {
+		response.PDFUrlArabic = pdfUrlsArabic.ViewUrl
+		response.PDFViewUrlArabic = pdfUrlsArabic.ViewUrl
+		response.PDFDownloadUrlArabic = pdfUrlsArabic.DownloadUrl
+	}
+	if pdfUrlsUrdu != nil {
+		response.PDFUrlUrdu = pdfUrlsUrdu.ViewUrl
+		response.PDFViewUrlUrdu = pdfUrlsUrdu.ViewUrl
+		response.PDFDownloadUrlUrdu = pdfUrlsUrdu.DownloadUrl
+	}
+	if len(property.ImageWarnings) > 0 {
+		response.Warnings = property.ImageWarnings
 	}
+	if property.IsPasswordProtected {
+		response.PasswordProtected = true
+		response.Message += "; the brochures are password protected"
+	}
+	if pdfUrlsBilingual != nil {
+		response.PDFViewUrlBilingual = pdfUrlsBilingual.ViewUrl
+		response.PDFDownloadUrlBilingual = pdfUrlsBilingual.DownloadUrl
+	}
+
+	return &response, nil
+}
+
+// newLocalizedContent converts generated copy into the stored form; the investment section and closing
+// message are set from the agent's input separately
+func newLocalizedContent(data services.LocalizedContentData) models.LocalizedContent {
+	return models.LocalizedContent{
+		Title:                    data.Title,
+		Description:              data.Description,
+		PriceLabel:               data.PriceLabel,
+		AddressLabel:             data.AddressLabel,
+		CityLabel:                data.CityLabel,
+		StateLabel:               data.StateLabel,
+		ZipCodeLabel:             data.ZipCodeLabel,
+		Highlights:               data.Highlights,
+		AmenitiesLabel:           data.AmenitiesLabel,
+		Amenities:                data.TranslatedAmenities,
+		AgentLabel:               data.AgentLabel,
+		PropertyDescriptionLabel: data.PropertyDescriptionLabel,
+		KeyHighlightsLabel:       data.KeyHighlightsLabel,
+		PropertyGalleryLabel:     data.PropertyGalleryLabel,
+	}
+}
+
+// protectPDF encrypts a brochure when the listing has a password; the password itself is never logged
+func (h *PropertyHandler) protectPDF(data []byte, password, label string) ([]byte, error) {
+	if password == "" {
+		return data, nil
+	}
+	log.Printf("Encrypting %s PDF for a password-protected listing...", label)
+	return h.pdfService.EncryptPDF(data, password)
+}
+
+// trackUpload records the storage key behind an uploaded object's URL for rollback
+func (h *PropertyHandler) trackUpload(keys *[]string, objectURL string) {
+	key, err := h.storage.KeyFromURL(objectURL)
+	if err != nil {
+		log.Printf("Cannot track uploaded object for rollback: %v", err)
+		return
+	}
+	*keys = append(*keys, key)
+}
+
+// rollbackUploads deletes objects orphaned by a failed submission. They have no image hash records yet,
+// see recordImages, so no other listing can have reused them.
+func (h *PropertyHandler) rollbackUploads(keys []string) {
+	log.Printf("Submission failed, rolling back %d uploaded objects", len(keys))
+	if err := h.storage.DeleteObjects(keys); err != nil {
+		log.Printf("Error rolling back uploaded objects %v: %v", keys, err)
+	}
+}
+
+// recordImages stores the hash records of the new images of a saved listing. A failure only costs
+// deduplication of those images, so it is logged.
+func (h *PropertyHandler) recordImages(records []models.ImageHash) {
+	if err := h.images.Record(records); err != nil {
+		log.Printf("Error recording image hashes: %v", err)
+	}
+}
 
-	// Return success response with both English and Arabic PDF URLs
-	return c.Status(fiber.StatusCreated).JSON(models.PropertyResponse{
-		Success:               true,
-		Message:               "Property listing created successfully",
-		PropertyID:            property.ID.Hex(),
-		PDFUrl:                pdfUrlsEnglish.ViewUrl,     // Default URL (English for backward compatibility)
-		PDFUrlEnglish:         pdfUrlsEnglish.ViewUrl,     // English PDF view URL
-		PDFUrlArabic:          pdfUrlsArabic.ViewUrl,      // Arabic PDF view URL
-		PDFViewUrl:            pdfUrlsEnglish.ViewUrl,     // Legacy: Opens in browser
-		PDFDownloadUrl:        pdfUrlsEnglish.DownloadUrl, // Legacy: Forces download
-		PDFViewUrlEnglish:     pdfUrlsEnglish.ViewUrl,     // English view URL
-		PDFViewUrlArabic:      pdfUrlsArabic.ViewUrl,      // Arabic view URL
-		PDFDownloadUrlEnglish: pdfUrlsEnglish.DownloadUrl, // English download URL
-		PDFDownloadUrlArabic:  pdfUrlsArabic.DownloadUrl,  // Arabic download URL
+// GetProperty returns a stored listing. The ETag is a hash of the response body, so clients and CDNs
+// can revalidate with If-None-Match and receive 304 Not Modified until anything in the response changes,
+// including writes that leave updatedAt alone (re-signed URLs, favorites counts).
+//
+// @Summary      Get a listing
+// @Tags         properties
+// @Produce      json
+// @Param        id             path    string  true   "Property ID"
+// @Param        If-None-Match  header  string  false  "ETag from a previous response"
+// @Success      200  {object}  models.PropertyDetailResponse
+// @Success      304  "Not modified"
+// @Header       200  {string}  ETag  "Hash of the response body"
+// @Failure      400  {object}  models.ErrorResponse  "Invalid property ID"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/property/{id} [get]
+func (h *PropertyHandler) GetProperty(c *fiber.Ctx) error {
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	body, err := json.Marshal(models.PropertyDetailResponse{
+		Success:  true,
+		Property: property,
 	})
+	if err != nil {
+		log.Printf("Error encoding property %s: %v", property.ID.Hex(), err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to encode property", err)
+	}
+
+	etag := bodyETag(body)
+	c.Set(fiber.HeaderETag, etag)
+	if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(body)
 }
 
-func (h *PropertyHandler) validateRequest(req *models.PropertyRequest) error {
-	if req.Title == "" {
-		return fmt.Errorf("title is required")
+// bodyETag is a strong ETag of a response body
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// SearchProperties returns one page of listings matching the query filters, newest first
+//
+// @Summary      Search listings
+// @Description  Empty filters are ignored; city matches case-insensitively. Listings stored without a status count as active.
+// @Tags         properties
+// @Produce      json
+// @Param        minPrice  query     number  false  "Lowest price"
+// @Param        maxPrice  query     number  false  "Highest price"
+// @Param        city      query     string  false  "City"
+// @Param        bedrooms  query     int     false  "Exact number of bedrooms"
+// @Param        status    query     string  false  "Listing status"  Enums(active, pending, sold)
+// @Param        limit     query     int     false  "Page size (1-100)"  default(20)
+// @Param        offset    query     int     false  "Number of results to skip"  default(0)
+// @Success      200  {object}  models.PropertySearchResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid filter or pagination parameter"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/properties/search [get]
+func (h *PropertyHandler) SearchProperties(c *fiber.Ctx) error {
+	filter := propertyFilter{
+		City:   strings.TrimSpace(c.Query("city")),
+		Status: strings.ToLower(strings.TrimSpace(c.Query("status"))),
 	}
-	if req.Price <= 0 {
-		return fmt.Errorf("price must be greater than 0")
+	if filter.Status != "" && !isPropertyStatus(filter.Status) {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid status", fmt.Errorf("status must be %q, %q or %q", models.PropertyStatusActive, models.PropertyStatusPending, models.PropertyStatusSold))
 	}
-	if req.Address == "" {
-		return fmt.Errorf("address is required")
+
+	for param, target := range map[string]**float64{
+		"minPrice": &filter.MinPrice,
+		"maxPrice": &filter.MaxPrice,
+	} {
+		if value := c.Query(param); value != "" {
+			price, err := strconv.ParseFloat(value, 64)
+			if err != nil || !isFinite(price) || price < 0 {
+				return models.NewAPIError(models.ErrCodeValidation, "Invalid "+param, fmt.Errorf("%s must be a non-negative number", param))
+			}
+			*target = &price
+		}
 	}
-	if req.City == "" {
-		return fmt.Errorf("city is required")
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid price range", errors.New("minPrice must not exceed maxPrice"))
 	}
-	if req.State == "" {
-		return fmt.Errorf("state is required")
+	if value := c.Query("bedrooms"); value != "" {
+		bedrooms, err := strconv.Atoi(value)
+		if err != nil || bedrooms < 0 {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid bedrooms", errors.New("bedrooms must be a non-negative integer"))
+		}
+		filter.Bedrooms = &bedrooms
 	}
-	if req.ZipCode == "" {
-		return fmt.Errorf("zip code is required")
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultPageSize)))
+	if err != nil || limit < 1 || limit > maxPageSize {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid limit", fmt.Errorf("limit must be between 1 and %d", maxPageSize))
 	}
-	if req.AgentName == "" {
-		return fmt.Errorf("agent name is required")
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid offset", errors.New("offset must be a non-negative integer"))
 	}
-	if req.AgentEmail == "" {
-		return fmt.Errorf("agent email is required")
+
+	properties, total, err := h.listProperties(filter, limit, offset)
+	if err != nil {
+		log.Printf("Error searching properties: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to search properties", err)
 	}
-	if req.AgentPhone == "" {
-		return fmt.Errorf("agent phone is required")
+
+	return c.JSON(models.PropertySearchResponse{
+		Success:    true,
+		Properties: properties,
+		TotalCount: total,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// etagMatches reports whether an If-None-Match header lists the given ETag (weak comparison)
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
 	}
-	return nil
+	return false
+}
+
+// GetPropertyPreview returns the brochure cover page rendered as a JPEG thumbnail
+//
+// @Summary      Get the cover page preview
+// @Tags         properties
+// @Produce      jpeg
+// @Param        id   path      string  true  "Property ID"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  models.ErrorResponse  "Invalid property ID"
+// @Failure      403  {object}  models.ErrorResponse  "Listing is password protected (ERR_FORBIDDEN)"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      500  {object}  models.ErrorResponse  "Preview rendering failure"
+// @Router       /api/property/{id}/preview [get]
+func (h *PropertyHandler) GetPropertyPreview(c *fiber.Ctx) error {
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+	// An unencrypted cover image would bypass the brochure password
+	if property.IsPasswordProtected {
+		return models.NewAPIError(models.ErrCodeForbidden, "Listing is password protected", fmt.Errorf("property %s has password-protected brochures and has no preview", property.ID.Hex()))
+	}
+
+	// Previews are cached per revision so an update naturally produces a fresh thumbnail
+	cacheKey := fmt.Sprintf("previews/%s-%d.jpg", property.ID.Hex(), property.UpdatedAt.Unix())
+
+	preview, err := h.storage.GetObject(cacheKey)
+	if err != nil {
+		if !errors.Is(err, services.ErrObjectNotFound) {
+			log.Printf("Error reading cached preview %s: %v", cacheKey, err)
+		}
+
+		preview, err = h.pdfService.GenerateCoverPagePreview(property)
+		if err != nil {
+			log.Printf("Error generating preview: %v", err)
+			return models.NewAPIError(models.ErrCodePDFGeneration, "Failed to generate preview", err)
+		}
+
+		if err := h.storage.PutObject(cacheKey, preview, "image/jpeg"); err != nil {
+			log.Printf("Error caching preview %s: %v", cacheKey, err)
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, "image/jpeg")
+	return c.Send(preview)
+}
+
+// GetPropertyImages returns one page of a listing's images. The URLs are signed again from their storage
+// keys, so they are valid even when the stored ones have expired.
+//
+// @Summary      Page through a listing's photos
+// @Tags         properties
+// @Produce      json
+// @Param        id     path      string  true   "Property ID"
+// @Param        page   query     int     false  "Page number, starting at 1"  default(1)
+// @Param        limit  query     int     false  "Images per page (1-50)"  default(8)
+// @Success      200  {object}  models.PropertyGalleryResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid ID or pagination parameter"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      500  {object}  models.ErrorResponse  "Database or URL signing failure"
+// @Router       /api/property/{id}/images [get]
+func (h *PropertyHandler) GetPropertyImages(c *fiber.Ctx) error {
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page < 1 {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid page", errors.New("page must be a positive integer"))
+	}
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultGalleryPageSize)))
+	if err != nil || limit < 1 || limit > maxGalleryPageSize {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid limit", fmt.Errorf("limit must be between 1 and %d", maxGalleryPageSize))
+	}
+
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	total := len(property.ImageURLs)
+	start := (page - 1) * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	imageURLs := make([]string, 0, end-start)
+	for _, stored := range property.ImageURLs[start:end] {
+		signed, err := h.presignedURL(stored)
+		if err != nil {
+			log.Printf("Error signing image of property %s: %v", property.ID.Hex(), err)
+			return models.NewAPIError(models.ErrCodeInternal, "Failed to sign image URLs", err)
+		}
+		imageURLs = append(imageURLs, signed)
+	}
+
+	return c.JSON(models.PropertyGalleryResponse{
+		Success:    true,
+		ImageURLs:  imageURLs,
+		Page:       page,
+		Limit:      limit,
+		TotalCount: total,
+		TotalPages: (total + limit - 1) / limit,
+	})
+}
+
+// checkImageCount enforces the image limit of a submission with count photos; active listings, the
+// default status, need at least one
+func (h *PropertyHandler) checkImageCount(req *models.PropertyRequest, count int) error {
+	if count > h.maxImages {
+		return models.NewAPIError(models.ErrCodeValidation, "Too many images", fmt.Errorf("a property can have at most %d images, %d were submitted", h.maxImages, count))
+	}
+	if count == 0 && (req.Status == "" || req.Status == models.PropertyStatusActive) {
+		return models.NewAPIError(models.ErrCodeValidation, "No images provided", errors.New("active listings need at least one image in images[]"))
+	}
+	return nil
+}
+
+// presignedURL signs a fresh URL for the object behind a stored, possibly expired, URL, keeping its
+// view or download disposition; permanent public URLs are returned as they are
+func (h *PropertyHandler) presignedURL(stored string) (string, error) {
+	return services.ResignURL(h.storage, stored)
+}
+
+// resignPropertyURLs replaces the stored image and brochure URLs of a listing with fresh ones
+func (h *PropertyHandler) resignPropertyURLs(property *models.Property) error {
+	for _, stored := range []*string{&property.PDFUrl, &property.PDFUrlEnglish, &property.PDFUrlArabic, &property.PDFUrlBilingual, &property.PDFUrlUrdu} {
+		if *stored == "" {
+			continue
+		}
+		signed, err := h.presignedURL(*stored)
+		if err != nil {
+			return err
+		}
+		*stored = signed
+	}
+	for i, stored := range property.ImageURLs {
+		signed, err := h.presignedURL(stored)
+		if err != nil {
+			return err
+		}
+		property.ImageURLs[i] = signed
+	}
+	return nil
+}
+
+// AddImages uploads additional photos and appends them to an existing listing.
+// Brochure PDFs are not regenerated; they keep the previous photos until the brochures are regenerated separately.
+//
+// @Summary      Add photos to a listing
+// @Tags         properties
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id        path      string  true  "Property ID"
+// @Param        images[]  formData  file    true  "Photos to append (listing total at most MAX_IMAGES_PER_PROPERTY)"
+// @Success      200  {object}  models.PropertyImagesResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid ID, form data or image limit exceeded"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      409  {object}  models.ErrorResponse  "Image limit reached by a concurrent update"
+// @Failure      413  {object}  models.ErrorResponse  "Image too large"
+// @Failure      415  {object}  models.ErrorResponse  "Image type not allowed"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Failure      502  {object}  models.ErrorResponse  "Upload failure"
+// @Router       /api/property/{id}/images [patch]
+func (h *PropertyHandler) AddImages(c *fiber.Ctx) error {
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		log.Printf("Error parsing form: %v", err)
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid form data", err)
+	}
+
+	images := form.File["images[]"]
+	if len(images) == 0 {
+		return models.NewAPIError(models.ErrCodeValidation, "No images provided", errors.New("at least one file is required in images[]"))
+	}
+	if len(property.ImageURLs)+len(images) > h.maxImages {
+		return models.NewAPIError(models.ErrCodeValidation, "Too many images", fmt.Errorf("a property can have at most %d images, it already has %d", h.maxImages, len(property.ImageURLs)))
+	}
+
+	// Objects written to storage by this request; deleted again if a later step fails. The hash records of
+	// new images are only stored with the listing, so no other submission reuses an image rolled back here.
+	uploadedKeys := []string{}
+	imageRecords := []models.ImageHash{}
+	succeeded := false
+	defer func() {
+		if !succeeded && len(uploadedKeys) > 0 {
+			h.rollbackUploads(uploadedKeys)
+		}
+	}()
+
+	newURLs := []string{}
+	for _, fileHeader := range images {
+		// Validate file size
+		if fileHeader.Size > h.maxFileSize {
+			return models.NewAPIError(models.ErrCodeFileTooLarge, "File size exceeds maximum allowed size", fmt.Errorf("File %s is too large", fileHeader.Filename))
+		}
+
+		// Validate file type
+		if !h.isAllowedFileType(fileHeader.Header.Get("Content-Type")) {
+			return models.NewAPIError(models.ErrCodeInvalidType, "Invalid file type", fmt.Errorf("File %s has invalid type", fileHeader.Filename))
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			log.Printf("Error opening file: %v", err)
+			return models.NewAPIError(models.ErrCodeInternal, "Failed to process image", err)
+		}
+		defer file.Close()
+
+		// Upload to object storage, reusing identical images uploaded before
+		url, record, err := h.images.UploadFile(file, fileHeader, "properties")
+		if err != nil {
+			log.Printf("Error uploading image: %v", err)
+			return models.NewAPIError(models.ErrCodeS3Upload, "Failed to upload image", err)
+		}
+		if record != nil {
+			uploadedKeys = append(uploadedKeys, record.S3Key)
+			imageRecords = append(imageRecords, *record)
+		}
+
+		newURLs = append(newURLs, url)
+	}
+
+	collection := h.mongoService.GetCollection("properties")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The size condition keeps the limit even when images are added concurrently
+	filter := bson.M{
+		"_id": property.ID,
+		"$expr": bson.M{"$lte": bson.A{
+			bson.M{"$size": bson.M{"$ifNull": bson.A{"$imageUrls", bson.A{}}}},
+			h.maxImages - len(newURLs),
+		}},
+	}
+	update := bson.M{
+		"$push": bson.M{"imageUrls": bson.M{"$each": newURLs}},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+
+	var updated models.Property
+	err = collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.NewAPIError(models.ErrCodeConflict, "Too many images", fmt.Errorf("the property reached the limit of %d images", h.maxImages))
+	}
+	if err != nil {
+		log.Printf("Error appending images: %v", err)
+		return models.NewAPIError(models.ErrCodeMongoInsert, "Failed to save images", err)
+	}
+
+	succeeded = true
+	h.recordImages(imageRecords)
+
+	return c.JSON(models.PropertyImagesResponse{
+		Success:   true,
+		Message:   "Images added; regenerate the brochures to include them in the PDFs",
+		ImageURLs: updated.ImageURLs,
+	})
+}
+
+// RemoveImage deletes the image at the given index from a listing.
+// The stored object is only deleted when no other listing reuses it (see ImageDedupService).
+//
+// @Summary      Remove a photo from a listing
+// @Tags         properties
+// @Produce      json
+// @Param        id     path      string   true  "Property ID"
+// @Param        index  path      integer  true  "Zero-based image index"
+// @Success      200  {object}  models.PropertyImagesResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid ID or index"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      409  {object}  models.ErrorResponse  "The images changed concurrently"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/property/{id}/image/{index} [delete]
+func (h *PropertyHandler) RemoveImage(c *fiber.Ctx) error {
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	index, err := strconv.Atoi(c.Params("index"))
+	if err != nil || index < 0 || index >= len(property.ImageURLs) {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid image index", fmt.Errorf("index must be between 0 and %d", len(property.ImageURLs)-1))
+	}
+	if index == 0 && len(property.ImageURLs) == 1 {
+		return models.NewAPIError(models.ErrCodeValidation, "Cannot remove the cover image", errors.New("the cover image can only be removed when another image can replace it"))
+	}
+	imageURL := property.ImageURLs[index]
+
+	collection := h.mongoService.GetCollection("properties")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Null out the element (only if it still holds the same URL), then pull the null
+	elementField := fmt.Sprintf("imageUrls.%d", index)
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": property.ID, elementField: imageURL},
+		bson.M{"$unset": bson.M{elementField: ""}},
+	)
+	if err == nil && result.MatchedCount == 0 {
+		return models.NewAPIError(models.ErrCodeConflict, "Images changed", errors.New("the image list was modified concurrently, reload and try again"))
+	}
+	var updated models.Property
+	if err == nil {
+		err = collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": property.ID},
+			bson.M{
+				"$pull": bson.M{"imageUrls": nil},
+				"$set":  bson.M{"updatedAt": time.Now()},
+			},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&updated)
+	}
+	if err != nil {
+		log.Printf("Error removing image: %v", err)
+		return models.NewAPIError(models.ErrCodeMongoInsert, "Failed to remove image", err)
+	}
+
+	// The listing no longer references the image; delete the object unless another listing reuses it
+	h.deleteUnusedImage(ctx, imageURL)
+
+	return c.JSON(models.PropertyImagesResponse{
+		Success:   true,
+		Message:   "Image removed; regenerate the brochures to update the PDFs",
+		ImageURLs: updated.ImageURLs,
+	})
+}
+
+// SetCoverImage moves the image at the given index to the front of the listing's images, making it the cover.
+// Only the stored order changes; brochure PDFs keep the previous cover until they are regenerated.
+//
+// @Summary      Choose the cover photo of a listing
+// @Tags         properties
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                      true  "Property ID"
+// @Param        request  body      models.SetCoverImageRequest  true  "Zero-based index of the new cover"
+// @Success      200  {object}  models.PropertyImagesResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid ID, body or index"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      409  {object}  models.ErrorResponse  "The images changed concurrently"
+// @Failure      500  {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/property/{id}/set-cover-image [post]
+func (h *PropertyHandler) SetCoverImage(c *fiber.Ctx) error {
+	var req models.SetCoverImageRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+	}
+
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+	if req.ImageIndex == nil || *req.ImageIndex < 0 || *req.ImageIndex >= len(property.ImageURLs) {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid image index", fmt.Errorf("imageIndex must be between 0 and %d", len(property.ImageURLs)-1))
+	}
+	index := *req.ImageIndex
+	if index == 0 {
+		return c.JSON(models.PropertyImagesResponse{
+			Success:   true,
+			Message:   "Image is already the cover",
+			ImageURLs: property.ImageURLs,
+		})
+	}
+
+	reordered := make([]string, 0, len(property.ImageURLs))
+	reordered = append(reordered, property.ImageURLs[index])
+	reordered = append(reordered, property.ImageURLs[:index]...)
+	reordered = append(reordered, property.ImageURLs[index+1:]...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Only replace the list it was computed from, so concurrent image changes are not lost
+	result, err := h.mongoService.GetCollection("properties").UpdateOne(ctx,
+		bson.M{"_id": property.ID, "imageUrls": property.ImageURLs},
+		bson.M{"$set": bson.M{"imageUrls": reordered, "updatedAt": time.Now()}},
+	)
+	if err != nil {
+		log.Printf("Error reordering images: %v", err)
+		return models.NewAPIError(models.ErrCodeMongoInsert, "Failed to save image order", err)
+	}
+	if result.MatchedCount == 0 {
+		return models.NewAPIError(models.ErrCodeConflict, "Images changed", errors.New("the image list was modified concurrently, reload and try again"))
+	}
+
+	return c.JSON(models.PropertyImagesResponse{
+		Success:   true,
+		Message:   "Cover image changed; regenerate the brochures to update the PDFs",
+		ImageURLs: reordered,
+	})
+}
+
+// DuplicateProperty clones a listing for a similar unit: the copy keeps the original's details, settings and
+// stored images but gets fresh AI content and newly generated brochures. Passwords are not stored, so copying a
+// password-protected listing requires a pdfPassword for the copy's brochures.
+//
+// @Summary      Duplicate a listing
+// @Tags         properties
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        id           path      string  true   "Property ID of the listing to copy"
+// @Param        pdfPassword  formData  string  false  "Password for the copy's brochures, required when the listing is password protected"
+// @Success      201  {object}  models.PropertyResponse
+// @Failure      400  {object}  models.ErrorResponse  "Invalid ID, missing password or the listing no longer passes validation"
+// @Failure      404  {object}  models.ErrorResponse  "Property not found"
+// @Failure      422  {object}  models.ErrorResponse  "Cover image is unavailable, or the description violates the content policy (ERR_CONTENT_POLICY)"
+// @Failure      500  {object}  models.ErrorResponse  "Generation or database failure"
+// @Failure      502  {object}  models.ErrorResponse  "Upload or AI generation failure"
+// @Router       /api/property/{id}/duplicate [post]
+func (h *PropertyHandler) DuplicateProperty(c *fiber.Ctx) error {
+	source, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	req := duplicateRequest(source)
+	req.PDFPassword = c.FormValue("pdfPassword")
+	if source.IsPasswordProtected && req.PDFPassword == "" {
+		return models.NewAPIError(models.ErrCodeValidation, "Password required", errors.New("the listing is password protected, pass pdfPassword to protect the copy"))
+	}
+	response, err := h.createProperty(&req, nil, source.ImageURLs)
+	if err != nil {
+		return err
+	}
+
+	response.Message = "Property listing duplicated successfully"
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// duplicateRequest rebuilds the submission behind a stored listing, titled as a copy. Generated output
+// (AI content, comparable sales and PDF URLs) is left out so the pipeline produces it again.
+func duplicateRequest(source *models.Property) models.PropertyRequest {
+	req := models.PropertyRequest{
+		Title:        source.Title + " (Copy)",
+		Description:  source.Description,
+		Price:        source.Price,
+		Currency:     source.Currency,
+		Address:      source.Address,
+		City:         source.City,
+		State:        source.State,
+		ZipCode:      source.ZipCode,
+		Amenities:    source.Amenities,
+		AgentName:    source.AgentInfo.Name,
+		AgentEmail:   source.AgentInfo.Email,
+		AgentPhone:   source.AgentInfo.Phone,
+		AgentWebsite: source.AgentInfo.Website,
+		AgentID:      source.AgentID,
+
+		SecondaryAgents: source.SecondaryAgents,
+		CoListingAgents: source.CoListingAgents,
+
+		FloorPlanURL:    source.FloorPlanURL,
+		FloorPlanWidth:  source.FloorPlanWidth,
+		FloorPlanHeight: source.FloorPlanHeight,
+		VirtualTourURL:  source.VirtualTourURL,
+		VideoURL:        source.VideoURL,
+
+		Latitude:  source.Latitude,
+		Longitude: source.Longitude,
+
+		PropertyType: source.PropertyType,
+		IncludeComps: len(source.ComparableSales) > 0,
+
+		VirtualStaging: source.VirtualStaging,
+
+		Mortgage: models.MortgageDetails{
+			DownPaymentPct: 20,
+			InterestRate:   7,
+			TermYears:      30,
+		},
+
+		PrintMode:  source.PrintMode,
+		PrintReady: source.PrintReady,
+		Bilingual:  source.PDFUrlBilingual != "",
+		Landscape:  source.Landscape,
+		PageSize:   source.PageSize,
+		PageOrder:  source.PageOrder,
+		MarginMm:   source.MarginMm,
+		Languages:  source.Languages,
+		FontID:     source.FontID,
+
+		ThemePreset: source.ThemePreset,
+
+		AdditionalSectionTitle:     source.EnglishContent.AdditionalSectionTitle,
+		AdditionalSectionContent:   source.EnglishContent.AdditionalSectionContent,
+		AdditionalSectionTitleAr:   source.ArabicContent.AdditionalSectionTitle,
+		AdditionalSectionContentAr: source.ArabicContent.AdditionalSectionContent,
+
+		ThankYouMessageEn: source.EnglishContent.ThankYouMessage,
+		ThankYouMessageAr: source.ArabicContent.ThankYouMessage,
+
+		Bedrooms: source.Bedrooms,
+	}
+	if source.Mortgage != nil {
+		req.Mortgage = *source.Mortgage
+	}
+	if source.WhiteLabelClosing != nil {
+		req.WhiteLabelClosing = *source.WhiteLabelClosing
+	}
+	return req
+}
+
+// deleteUnusedImage deletes a removed image's object when no listing references it any more.
+// Failures are logged only: the image is already detached from the listing.
+func (h *PropertyHandler) deleteUnusedImage(ctx context.Context, imageURL string) {
+	key, ok := h.unusedImageKey(ctx, imageURL)
+	if !ok {
+		return
+	}
+
+	if err := h.storage.DeleteObjects([]string{key}); err != nil {
+		log.Printf("Error deleting removed image %s: %v", key, err)
+		return
+	}
+	if err := h.images.ForgetKeys([]string{key}); err != nil {
+		log.Printf("Error removing image hash record of %s: %v", key, err)
+	}
+}
+
+// unusedImageKey returns the storage key of a detached image when no listing references it any more
+func (h *PropertyHandler) unusedImageKey(ctx context.Context, imageURL string) (string, bool) {
+	key, err := h.storage.KeyFromURL(imageURL)
+	if err != nil {
+		log.Printf("Cannot resolve storage key of removed image: %v", err)
+		return "", false
+	}
+
+	// URLs are re-signed over time, so match other listings by the key in the URL path
+	inUse, err := h.mongoService.GetCollection("properties").CountDocuments(ctx, bson.M{
+		"imageUrls": bson.M{"$regex": "/" + regexp.QuoteMeta(key) + `(\?|$)`},
+	})
+	if err != nil {
+		log.Printf("Error checking whether image %s is still in use: %v", key, err)
+		return "", false
+	}
+	return key, inUse == 0
+}
+
+var errInvalidPropertyID = errors.New("invalid property ID")
+
+// findProperty loads a property by its hex ObjectID
+func (h *PropertyHandler) findProperty(id string) (*models.Property, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errInvalidPropertyID
+	}
+
+	collection := h.mongoService.GetCollection("properties")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var property models.Property
+	if err := collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&property); err != nil {
+		return nil, err
+	}
+	if err := h.decryptProperty(&property); err != nil {
+		return nil, err
+	}
+	return &property, nil
+}
+
+// decryptProperty decrypts the agent details of a stored listing when encryption is enabled
+func (h *PropertyHandler) decryptProperty(property *models.Property) error {
+	if h.encryption == nil {
+		return nil
+	}
+	if err := h.encryption.DecryptProperty(property); err != nil {
+		return fmt.Errorf("failed to decrypt property %s: %w", property.ID.Hex(), err)
+	}
+	return nil
+}
+
+// propertyFilter narrows a property listing; empty fields do not filter
+type propertyFilter struct {
+	City         string
+	State        string
+	PropertyType string
+	MinPrice     *float64
+	MaxPrice     *float64
+	Bedrooms     *int
+	Status       string
+}
+
+// listProperties returns one page of properties matching the filter, newest first, and the total number of matches
+func (h *PropertyHandler) listProperties(filter propertyFilter, limit, offset int) ([]models.Property, int64, error) {
+	query := bson.M{}
+	if filter.City != "" {
+		query["cityLower"] = strings.ToLower(strings.TrimSpace(filter.City))
+	}
+	for field, value := range map[string]string{
+		"state":        filter.State,
+		"propertyType": filter.PropertyType,
+	} {
+		if value != "" {
+			query[field] = bson.M{"$regex": "^" + regexp.QuoteMeta(value) + "$", "$options": "i"}
+		}
+	}
+	price := bson.M{}
+	if filter.MinPrice != nil {
+		price["$gte"] = *filter.MinPrice
+	}
+	if filter.MaxPrice != nil {
+		price["$lte"] = *filter.MaxPrice
+	}
+	if len(price) > 0 {
+		query["price"] = price
+	}
+	if filter.Bedrooms != nil {
+		query["bedrooms"] = *filter.Bedrooms
+	}
+	if filter.Status == models.PropertyStatusActive {
+		// Listings stored before statuses were introduced have none and count as active
+		query["status"] = bson.M{"$in": bson.A{models.PropertyStatusActive, nil}}
+	} else if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	collection := h.mongoService.GetCollection("properties")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	properties := []models.Property{}
+	if err := cursor.All(ctx, &properties); err != nil {
+		return nil, 0, err
+	}
+	for i := range properties {
+		if err := h.decryptProperty(&properties[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return properties, total, nil
+}
+
+// deleteProperty removes a listing together with its brochures and cached preview.
+// Images are only deleted from storage when no other listing reuses them.
+func (h *PropertyHandler) deleteProperty(id string) error {
+	property, err := h.findProperty(id)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := h.mongoService.GetCollection("properties").DeleteOne(ctx, bson.M{"_id": property.ID}); err != nil {
+		return err
+	}
+	h.emitEvent(models.EventPropertyDeleted, property, map[string]interface{}{
+		"title": property.Title,
+	})
+	if err := h.shareLinks.DeleteForProperties(ctx, property.ID); err != nil {
+		log.Printf("Error deleting share links of property %s: %v", property.ID.Hex(), err)
+	}
+	if err := h.favorites.DeleteForProperties(ctx, property.ID); err != nil {
+		log.Printf("Error deleting favorites of property %s: %v", property.ID.Hex(), err)
+	}
+
+	if err := h.storage.DeleteObjects(h.brochureKeys(property)); err != nil {
+		log.Printf("Error deleting brochures of property %s: %v", property.ID.Hex(), err)
+	}
+
+	for _, imageURL := range property.ImageURLs {
+		h.deleteUnusedImage(ctx, imageURL)
+	}
+	return nil
+}
+
+// brochureKeys returns the storage keys of a listing's brochures and cached preview
+func (h *PropertyHandler) brochureKeys(property *models.Property) []string {
+	keys := []string{fmt.Sprintf("previews/%s-%d.jpg", property.ID.Hex(), property.UpdatedAt.Unix())}
+	seen := map[string]bool{}
+	for _, pdfURL := range []string{property.PDFUrl, property.PDFUrlEnglish, property.PDFUrlArabic, property.PDFUrlBilingual, property.PDFUrlUrdu} {
+		if pdfURL == "" {
+			continue
+		}
+		key, err := h.storage.KeyFromURL(pdfURL)
+		if err != nil {
+			log.Printf("Cannot resolve storage key of brochure %s: %v", pdfURL, err)
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// moderateDescription rejects descriptions the content moderator flags, before anything is stored
+func (h *PropertyHandler) moderateDescription(req *models.PropertyRequest) error {
+	if h.moderator == nil || strings.TrimSpace(req.Description) == "" {
+		return nil
+	}
+
+	result, err := h.moderator.ModerateText(req.Description)
+	if err != nil {
+		log.Printf("Error moderating description: %v", err)
+		return models.NewAPIError(models.ErrCodeAIGeneration, "Failed to moderate description", err)
+	}
+	if result.Flagged {
+		log.Printf("Rejected description flagged for %s (score %.2f)", result.Category, result.Score)
+		return models.NewAPIError(models.ErrCodeContentPolicy, "Content policy violation",
+			fmt.Errorf("the description was flagged for %s content (score %.2f)", result.Category, result.Score))
+	}
+	return nil
+}
+
+// emitEvent records an audit event for the listing, attributed to its agent
+func (h *PropertyHandler) emitEvent(eventType string, property *models.Property, metadata map[string]interface{}) {
+	h.events.Emit(models.Event{
+		EventType:  eventType,
+		PropertyID: property.ID,
+		AgentEmail: property.AgentInfo.Email,
+		Metadata:   metadata,
+	})
+}
+
+// propertyLookupError converts a findProperty error into the matching API error
+func (h *PropertyHandler) propertyLookupError(err error) error {
+	switch {
+	case errors.Is(err, errInvalidPropertyID):
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid property ID", err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return models.NewAPIError(models.ErrCodeNotFound, "Property not found", nil)
+	default:
+		log.Printf("Error loading property: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to load property", err)
+	}
+}
+
+func (h *PropertyHandler) validateRequest(req *models.PropertyRequest) error {
+	if req.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if !isFinite(req.Price) || req.Price <= 0 {
+		return fmt.Errorf("price must be greater than 0")
+	}
+	for name, value := range map[string]float64{
+		"floor plan width":  req.FloorPlanWidth,
+		"floor plan height": req.FloorPlanHeight,
+		"down payment":      req.Mortgage.DownPaymentPct,
+		"interest rate":     req.Mortgage.InterestRate,
+	} {
+		if !isFinite(value) {
+			return fmt.Errorf("%s must be a finite number", name)
+		}
+	}
+	for name, value := range map[string]string{
+		"title":       req.Title,
+		"description": req.Description,
+		"currency":    req.Currency,
+		"address":     req.Address,
+		"city":        req.City,
+		"state":       req.State,
+		"zip code":    req.ZipCode,
+		"agent name":  req.AgentName,
+		"agent email": req.AgentEmail,
+		"agent phone": req.AgentPhone,
+	} {
+		if !isPrintableText(value) {
+			return fmt.Errorf("%s must be valid UTF-8 text without null bytes", name)
+		}
+	}
+	if len(req.Amenities) > maxAmenities {
+		return fmt.Errorf("at most %d amenities are allowed", maxAmenities)
+	}
+	if req.PDFPassword != "" && !isValidPDFPassword(req.PDFPassword) {
+		return fmt.Errorf("PDF password must be %d-%d printable ASCII characters", minPDFPasswordLength, maxPDFPasswordLength)
+	}
+	for _, amenity := range req.Amenities {
+		if !isPrintableText(amenity) || utf8.RuneCountInString(amenity) > maxAmenityLength {
+			return fmt.Errorf("amenities must be valid text of at most %d characters", maxAmenityLength)
+		}
+	}
+	if req.Address == "" {
+		return fmt.Errorf("address is required")
+	}
+	if req.City == "" {
+		return fmt.Errorf("city is required")
+	}
+	if req.State == "" {
+		return fmt.Errorf("state is required")
+	}
+	if req.ZipCode == "" {
+		return fmt.Errorf("zip code is required")
+	}
+	if req.AgentName == "" {
+		return fmt.Errorf("agent name is required")
+	}
+	if req.AgentEmail == "" {
+		return fmt.Errorf("agent email is required")
+	}
+	if req.AgentPhone == "" {
+		return fmt.Errorf("agent phone is required")
+	}
+	if req.FloorPlanURL != "" && !isHTTPURL(req.FloorPlanURL) {
+		return fmt.Errorf("floor plan URL must be a valid http(s) URL")
+	}
+	if req.AgentWebsite != "" && !isHTTPURL(req.AgentWebsite) {
+		return fmt.Errorf("agent website must be a valid http(s) URL")
+	}
+	if req.VirtualTourURL != "" && !isHTTPURL(req.VirtualTourURL) {
+		return fmt.Errorf("virtual tour URL must be a valid http(s) URL")
+	}
+	if req.VideoURL != "" && !isHTTPURL(req.VideoURL) {
+		return fmt.Errorf("video URL must be a valid http(s) URL")
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return fmt.Errorf("latitude and longitude must be given together")
+	}
+	if req.Latitude != nil && (!isFinite(*req.Latitude) || *req.Latitude < -90 || *req.Latitude > 90) {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if req.Longitude != nil && (!isFinite(*req.Longitude) || *req.Longitude < -180 || *req.Longitude > 180) {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	switch req.PageSize {
+	case "":
+		req.PageSize = "A4"
+	case "A4", "Letter", "Legal":
+	default:
+		return fmt.Errorf("page size must be one of A4, Letter or Legal")
+	}
+	if err := services.ValidatePageOrder(req.PageOrder); err != nil {
+		return err
+	}
+	if req.MarginMm != 0 && (req.MarginMm < services.MinMarginMm || req.MarginMm > services.MaxMarginMm) {
+		return fmt.Errorf("margin must be between %d and %d mm", services.MinMarginMm, services.MaxMarginMm)
+	}
+	if req.ThemePreset != "" && !h.pdfService.HasThemePreset(req.ThemePreset) {
+		return fmt.Errorf("unknown theme preset %q", req.ThemePreset)
+	}
+	if req.Mortgage.DownPaymentPct >= 100 {
+		return fmt.Errorf("down payment must be less than 100%%")
+	}
+	if req.Mortgage.InterestRate > 100 {
+		return fmt.Errorf("interest rate must be between 0 and 100")
+	}
+	if req.Mortgage.TermYears < 1 || req.Mortgage.TermYears > 50 {
+		return fmt.Errorf("mortgage term must be between 1 and 50 years")
+	}
+	if req.AdditionalSectionTitle != "" && req.AdditionalSectionContent == "" {
+		return fmt.Errorf("additional section content is required when a title is given")
+	}
+	if req.AdditionalSectionTitleAr != "" && req.AdditionalSectionContentAr == "" {
+		return fmt.Errorf("Arabic additional section content is required when a title is given")
+	}
+	if len(req.SecondaryAgents) > maxSecondaryAgents {
+		return fmt.Errorf("at most %d secondary agents are allowed", maxSecondaryAgents)
+	}
+	for i, agent := range req.SecondaryAgents {
+		if agent.Name == "" || agent.Email == "" || agent.Phone == "" {
+			return fmt.Errorf("secondary agent %d requires a name, email and phone", i+1)
+		}
+	}
+	if len(req.CoListingAgents) > maxCoListingAgents {
+		return fmt.Errorf("at most %d co-listing agents are allowed", maxCoListingAgents)
+	}
+	for i, agent := range req.CoListingAgents {
+		if agent.Name == "" || agent.Email == "" || agent.Phone == "" {
+			return fmt.Errorf("co-listing agent %d requires a name, email and phone", i+1)
+		}
+	}
+	if utf8.RuneCountInString(req.ThankYouMessageEn) > maxThankYouMessageLength {
+		return fmt.Errorf("English thank-you message must be at most %d characters", maxThankYouMessageLength)
+	}
+	if utf8.RuneCountInString(req.ThankYouMessageAr) > maxThankYouMessageLength {
+		return fmt.Errorf("Arabic thank-you message must be at most %d characters", maxThankYouMessageLength)
+	}
+	if !isPrintableText(req.VirtualStaging) || utf8.RuneCountInString(req.VirtualStaging) > maxVirtualStagingLength {
+		return fmt.Errorf("virtual staging must be valid text of at most %d characters", maxVirtualStagingLength)
+	}
+	if err := validateWhiteLabelClosing(req.WhiteLabelClosing); err != nil {
+		return err
+	}
+	arabicEnabled := h.features.Flags().EnableArabic
+	if len(req.Languages) == 0 {
+		req.Languages = []string{"en", "ar"}
+		if !arabicEnabled {
+			req.Languages = []string{"en"}
+		}
+	}
+	for _, lang := range req.Languages {
+		if lang != "en" && lang != "ar" && lang != "ur" {
+			return fmt.Errorf("languages must contain only \"en\", \"ar\" or \"ur\"")
+		}
+	}
+	if !arabicEnabled && (hasLanguage(req.Languages, "ar") || req.Bilingual) {
+		return fmt.Errorf("Arabic and bilingual brochures are disabled")
+	}
+	if req.Bilingual && !(hasLanguage(req.Languages, "en") && hasLanguage(req.Languages, "ar")) {
+		return fmt.Errorf("bilingual brochure requires both English and Arabic")
+	}
+	if req.Bedrooms < 0 || req.Bedrooms > maxBedrooms {
+		return fmt.Errorf("bedrooms must be between 0 and %d", maxBedrooms)
+	}
+	if req.Status != "" && !isPropertyStatus(req.Status) {
+		return fmt.Errorf("status must be %q, %q or %q", models.PropertyStatusActive, models.PropertyStatusPending, models.PropertyStatusSold)
+	}
+	return nil
+}
+
+// isPropertyStatus reports whether status is a known listing status
+func isPropertyStatus(status string) bool {
+	switch status {
+	case models.PropertyStatusActive, models.PropertyStatusPending, models.PropertyStatusSold:
+		return true
+	}
+	return false
+}
+
+// hasLanguage reports whether lang is among the requested brochure languages
+func hasLanguage(languages []string, lang string) bool {
+	for _, l := range languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// isFinite reports whether the value is neither NaN nor infinite; fmt.Sscanf accepts "nan" and "inf"
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// isPrintableText reports whether the value is valid UTF-8 without null bytes, which the PDF fonts cannot render
+func isPrintableText(value string) bool {
+	return utf8.ValidString(value) && !strings.ContainsRune(value, 0)
+}
+
+// isValidPDFPassword limits passwords to printable ASCII, which every PDF reader encodes the same way
+func isValidPDFPassword(password string) bool {
+	if len(password) < minPDFPasswordLength || len(password) > maxPDFPasswordLength {
+		return false
+	}
+	for i := 0; i < len(password); i++ {
+		if password[i] < 0x20 || password[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// validateWhiteLabelClosing checks the optional white-label closing fields
+func validateWhiteLabelClosing(closing models.WhiteLabelClosing) error {
+	if !isPrintableText(closing.Headline) || utf8.RuneCountInString(closing.Headline) > maxClosingHeadlineLength {
+		return fmt.Errorf("closing headline must be valid text of at most %d characters", maxClosingHeadlineLength)
+	}
+	if !isPrintableText(closing.Message) || utf8.RuneCountInString(closing.Message) > maxThankYouMessageLength {
+		return fmt.Errorf("closing message must be valid text of at most %d characters", maxThankYouMessageLength)
+	}
+	for name, value := range map[string]string{
+		"closing logo URL":      closing.LogoURL,
+		"closing Instagram URL": closing.InstagramURL,
+		"closing Facebook URL":  closing.FacebookURL,
+		"closing LinkedIn URL":  closing.LinkedInURL,
+		"closing website":       closing.Website,
+	} {
+		if value != "" && !isHTTPURL(value) {
+			return fmt.Errorf("%s must be a valid http(s) URL", name)
+		}
+	}
+	return nil
+}
+
+// formAgents reads the agents sent as the parallel <prefix>Name[], <prefix>Email[] and <prefix>Phone[] lists
+func formAgents(form *multipart.Form, prefix string) ([]models.AgentInfo, error) {
+	names := form.Value[prefix+"Name[]"]
+	emails := form.Value[prefix+"Email[]"]
+	phones := form.Value[prefix+"Phone[]"]
+	if len(emails) != len(names) || len(phones) != len(names) {
+		return nil, fmt.Errorf("%[1]sName[], %[1]sEmail[] and %[1]sPhone[] must have the same number of entries", prefix)
+	}
+	var agents []models.AgentInfo
+	for i := range names {
+		agents = append(agents, models.AgentInfo{
+			Name:  strings.TrimSpace(names[i]),
+			Email: strings.TrimSpace(emails[i]),
+			Phone: strings.TrimSpace(phones[i]),
+		})
+	}
+	return agents, nil
+}
+
+// isHTTPURL reports whether the value is an absolute http or https URL
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
 
 func (h *PropertyHandler) isAllowedFileType(contentType string) bool {
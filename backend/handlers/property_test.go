@@ -0,0 +1,634 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"property-brochure-backend/middleware"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// fakeMongo serves the collections of a mock deployment's database
+type fakeMongo struct {
+	db *mongo.Database
+}
+
+func (m fakeMongo) GetCollection(name string) *mongo.Collection {
+	return m.db.Collection(name)
+}
+
+// testHandler is a PropertyHandler wired to testify mocks and a mongoMock deployment
+type testHandler struct {
+	*PropertyHandler
+	app       *fiber.App
+	mongo     *mongoMock
+	storage   *mockStorage
+	brochures *mockBrochures
+	ai        *mockAI
+}
+
+func newTestHandler(t *testing.T) *testHandler {
+	t.Helper()
+	mongoMock, db := newMongoMock(t)
+	th := &testHandler{
+		mongo:     mongoMock,
+		storage:   newMockStorage(),
+		brochures: &mockBrochures{},
+		ai:        &mockAI{},
+	}
+	th.PropertyHandler = NewPropertyHandler(fakeMongo{db}, th.storage, th.ai, th.brochures,
+		1<<20, "image/jpeg,image/png", 10, nil, nil, nil, nil, nil, nil, nil)
+	t.Cleanup(func() {
+		th.storage.AssertExpectations(t)
+		th.brochures.AssertExpectations(t)
+		th.ai.AssertExpectations(t)
+	})
+
+	th.app = fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	th.app.Post("/api/property", th.SubmitProperty)
+	th.app.Get("/api/property/:id", th.GetProperty)
+	th.app.Patch("/api/property/:id", th.UpdateProperty)
+	th.app.Get("/api/properties/search", th.SearchProperties)
+	th.app.Get("/api/property/:id/preview", th.GetPropertyPreview)
+	th.app.Get("/api/property/:id/images", th.GetPropertyImages)
+	th.app.Post("/api/property/:id/duplicate", th.DuplicateProperty)
+	th.app.Post("/api/property/:id/share-link", th.CreateShareLink)
+	th.app.Get("/api/share/:token", th.GetSharedProperty)
+	th.app.Get("/api/user/:userId/favorites", th.GetFavorites)
+	th.app.Post("/api/user/:userId/favorites/:propertyId", th.AddFavorite)
+	th.app.Delete("/api/user/:userId/favorites/:propertyId", th.RemoveFavorite)
+	th.app.Post("/api/property/:id/set-cover-image", th.SetCoverImage)
+	th.app.Delete("/api/admin/properties", th.BulkDelete)
+	return th
+}
+
+// do sends the request to the handler's app and returns the response with its body
+func (th *testHandler) do(t *testing.T, req *http.Request) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := th.app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	return resp, body
+}
+
+// expectSubmission lets every step of a submission succeed, for the calls a test has not set up itself:
+// the image hash lookup finds nothing, uploads are stored and brochures are rendered as placeholder PDFs
+func (th *testHandler) expectSubmission() {
+	th.mongo.onFind("image_hashes")
+	th.mongo.onWrite("insert", "image_hashes", 1)
+	th.mongo.onWrite("insert", "properties", 1)
+	expectUploads(th.storage)
+	expectBrochures(th.brochures)
+	expectAI(th.ai)
+}
+
+// expectUploads stores images as properties/image-N and brochures as brochures/N-<filename>.pdf, numbered
+// across both, and lets deletions succeed
+func expectUploads(storage *mockStorage) {
+	uploads := 0
+	storage.On("UploadFile", mock.Anything, mock.Anything, mock.Anything).Return(func(_ multipart.File, header *multipart.FileHeader, folder string) (string, error) {
+		uploads++
+		return fmt.Sprintf("%s%s/image-%d%s", mockStorageURL, folder, uploads, filepath.Ext(header.Filename)), nil
+	}).Maybe()
+	storage.On("UploadPDFWithUrls", mock.Anything, mock.Anything).Return(func(_ []byte, filename string) (*services.PDFUrls, error) {
+		uploads++
+		url := fmt.Sprintf("%sbrochures/%d-%s.pdf", mockStorageURL, uploads, filename)
+		return &services.PDFUrls{ViewUrl: url, DownloadUrl: url}, nil
+	}).Maybe()
+	storage.On("DeleteObjects", mock.Anything).Return(nil).Maybe()
+}
+
+// expectBrochures renders every brochure as a placeholder PDF and finds all images available
+func expectBrochures(brochures *mockBrochures) {
+	for method, language := range brochureLanguages {
+		if method == "GenerateComparisonBrochure" {
+			brochures.On(method, mock.Anything, mock.Anything).Return([]byte("%PDF-1.4 "+language), nil).Maybe()
+			continue
+		}
+		brochures.On(method, mock.Anything).Return([]byte("%PDF-1.4 "+language), nil).Maybe()
+	}
+	brochures.On("CheckCoverImage", mock.Anything).Return(nil).Maybe()
+	brochures.On("HasThemePreset", mock.Anything).Return(false).Maybe()
+}
+
+// expectAI returns fixed copy for the legacy and localized content and virtual staging, and infers no bedrooms
+func expectAI(ai *mockAI) {
+	ai.On("InferBedrooms", mock.Anything, mock.Anything).Return(0, nil).Maybe()
+	ai.On("GenerateVirtualStagingDescription", mock.Anything, mock.Anything).Return("Light oak furniture and linen upholstery.", nil).Maybe()
+	ai.On("GeneratePropertyContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&services.AIGeneratedContent{
+		EnglishDescription: "A bright apartment overlooking the marina.",
+		ArabicDescription:  "شقة مشرقة تطل على المرسى.",
+		KeyHighlights:      []string{"Marina view"},
+	}, nil).Maybe()
+	ai.On("GenerateLocalizedContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(title, _, _, _ string, _, _ []string) (*services.LocalizedContentGenerated, error) {
+			return &services.LocalizedContentGenerated{
+				EnglishContent: services.LocalizedContentData{Title: title, Description: "A bright apartment overlooking the marina."},
+				ArabicContent:  services.LocalizedContentData{Title: "شقة المرسى", Description: "شقة مشرقة تطل على المرسى."},
+			}, nil
+		}).Maybe()
+}
+
+// formImage is an image part of a submission
+type formImage struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+// pngImage is the 8-byte PNG signature, enough for the upload path, which does not decode PNGs
+var pngImage = formImage{filename: "cover.png", contentType: "image/png", data: []byte("\x89PNG\r\n\x1a\n")}
+
+// validFields are the form fields of a valid submission
+func validFields() map[string]string {
+	return map[string]string{
+		"title":      "Marina View Apartment",
+		"price":      "2450000",
+		"currency":   "AED",
+		"address":    "Marina Promenade, Tower 3",
+		"city":       "Dubai",
+		"state":      "Dubai",
+		"zipCode":    "00000",
+		"agentName":  "Sara Haddad",
+		"agentEmail": "sara@example.com",
+		"agentPhone": "+971 50 123 4567",
+	}
+}
+
+// submitRequest builds a multipart POST /api/property request
+func submitRequest(t *testing.T, fields map[string]string, images ...formImage) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, image := range images {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="images[]"; filename=%q`, image.filename))
+		header.Set("Content-Type", image.contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write(image.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/property", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// propertyDocument encodes a listing as the document the mock deployment returns for it
+func propertyDocument(t *testing.T, property *models.Property) bson.D {
+	t.Helper()
+	raw, err := bson.Marshal(property)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc bson.D
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// signedStorageURL is a pre-signed mockStorage URL, which handlers sign again before returning it
+func signedStorageURL(key string) string {
+	return mockStorageURL + key + "?X-Amz-Date=20240501T120000Z&X-Amz-Expires=604800"
+}
+
+// storedProperty is a listing with one image and English and Arabic brochures in mockStorage
+func storedProperty() *models.Property {
+	return &models.Property{
+		ID:            primitive.NewObjectID(),
+		Title:         "Marina View Apartment",
+		Price:         2450000,
+		Currency:      "AED",
+		Address:       "Marina Promenade, Tower 3",
+		City:          "Dubai",
+		State:         "Dubai",
+		ZipCode:       "00000",
+		ImageURLs:     []string{mockStorageURL + "properties/image-1.png"},
+		AgentInfo:     models.AgentInfo{Name: "Sara Haddad", Email: "sara@example.com", Phone: "+971 50 123 4567"},
+		PDFUrl:        mockStorageURL + "brochures/2-en.pdf",
+		PDFUrlEnglish: mockStorageURL + "brochures/2-en.pdf",
+		PDFUrlArabic:  mockStorageURL + "brochures/3-ar.pdf",
+		CreatedAt:     time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// jsonRequest builds a request with a JSON body
+func jsonRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return req
+}
+
+// decode unmarshals a response body, failing the test when it is not the expected JSON
+func decode(t *testing.T, body []byte, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("failed to decode response %s: %v", body, err)
+	}
+}
+
+// received reports whether the mock deployment received the command
+func received(m *mongoMock, command string) bool {
+	for _, got := range m.received() {
+		if got == command {
+			return true
+		}
+	}
+	return false
+}
+
+// valueResponse answers a findAndModify with the document it returns, or with none when doc is nil
+func valueResponse(doc interface{}) bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "value", Value: doc})
+}
+
+func TestSubmitProperty(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields func(map[string]string)
+		images []formImage
+		setup  func(th *testHandler)
+
+		wantStatus     int
+		wantRendered   []string
+		wantRolledBack int
+		wantWarnings   []string
+	}{
+		{
+			name:         "created",
+			images:       []formImage{pngImage},
+			wantStatus:   fiber.StatusCreated,
+			wantRendered: []string{"en", "ar"},
+		},
+		{
+			name: "with coordinates",
+			fields: func(f map[string]string) {
+				f["latitude"] = "25.08"
+				f["longitude"] = "55.14"
+			},
+			images:       []formImage{pngImage},
+			wantStatus:   fiber.StatusCreated,
+			wantRendered: []string{"en", "ar"},
+		},
+		{
+			name:         "English only",
+			fields:       func(f map[string]string) { f["languages[]"] = "en" },
+			images:       []formImage{pngImage},
+			wantStatus:   fiber.StatusCreated,
+			wantRendered: []string{"en"},
+		},
+		{
+			name:   "localized content failure falls back to the legacy copy",
+			images: []formImage{pngImage},
+			setup: func(th *testHandler) {
+				th.ai.On("GenerateLocalizedContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("rate limited")).Once()
+			},
+			wantStatus:   fiber.StatusCreated,
+			wantRendered: []string{"en", "ar"},
+		},
+		{
+			name:   "broken gallery image becomes a warning",
+			images: []formImage{pngImage},
+			setup: func(th *testHandler) {
+				th.brochures.On("GenerateEnglishBrochure", mock.Anything).Run(func(args mock.Arguments) {
+					property := args.Get(0).(*models.Property)
+					property.ImageWarnings = append(property.ImageWarnings, "Gallery image 1 is unavailable and was replaced with a placeholder")
+				}).Return([]byte("%PDF-1.4 en"), nil).Once()
+			},
+			wantStatus:   fiber.StatusCreated,
+			wantRendered: []string{"en", "ar"},
+			wantWarnings: []string{"Gallery image 1 is unavailable and was replaced with a placeholder"},
+		},
+		{
+			name:   "broken cover image",
+			images: []formImage{pngImage},
+			setup: func(th *testHandler) {
+				th.brochures.On("CheckCoverImage", mock.Anything).Return(errors.New("404 Not Found")).Once()
+			},
+			wantStatus:     fiber.StatusUnprocessableEntity,
+			wantRolledBack: 1,
+		},
+		{
+			name:   "image recorded by a concurrent submission",
+			images: []formImage{pngImage},
+			setup: func(th *testHandler) {
+				th.mongo.on("insert", "image_hashes", mtest.CreateWriteErrorsResponse(mtest.WriteError{Code: 11000, Message: "duplicate key"}))
+			},
+			wantStatus:   fiber.StatusCreated,
+			wantRendered: []string{"en", "ar"},
+		},
+		{
+			name:       "missing title",
+			fields:     func(f map[string]string) { delete(f, "title") },
+			images:     []formImage{pngImage},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:       "invalid price",
+			fields:     func(f map[string]string) { f["price"] = "free" },
+			images:     []formImage{pngImage},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:       "repeated page in pageOrder",
+			fields:     func(f map[string]string) { f["pageOrder"] = `["cover","gallery","gallery"]` },
+			images:     []formImage{pngImage},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:       "no images",
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:       "invalid image type",
+			images:     []formImage{{filename: "notes.txt", contentType: "text/plain", data: []byte("notes")}},
+			wantStatus: fiber.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "oversized image",
+			images:     []formImage{{filename: "huge.png", contentType: "image/png", data: make([]byte, 1<<20+1)}},
+			wantStatus: fiber.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "storage failure",
+			images: []formImage{pngImage},
+			setup: func(th *testHandler) {
+				th.storage.On("UploadFile", mock.Anything, mock.Anything, "properties").Return("", errors.New("bucket unavailable")).Once()
+			},
+			wantStatus: fiber.StatusBadGateway,
+		},
+		{
+			name:   "AI failure rolls back the images",
+			images: []formImage{pngImage},
+			setup: func(th *testHandler) {
+				th.ai.On("GeneratePropertyContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("OpenAI unavailable")).Once()
+			},
+			wantStatus:     fiber.StatusBadGateway,
+			wantRolledBack: 1,
+		},
+		{
+			name:   "database failure rolls back the images and brochures",
+			images: []formImage{pngImage},
+			setup: func(th *testHandler) {
+				th.mongo.on("insert", "properties", mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "insert failed"}))
+			},
+			wantStatus:     fiber.StatusInternalServerError,
+			wantRendered:   []string{"en", "ar"},
+			wantRolledBack: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := newTestHandler(t)
+			if tt.setup != nil {
+				tt.setup(th)
+			}
+			th.expectSubmission()
+
+			fields := validFields()
+			if tt.fields != nil {
+				tt.fields(fields)
+			}
+			resp, body := th.do(t, submitRequest(t, fields, tt.images...))
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if got := strings.Join(th.brochures.rendered(), ","); got != strings.Join(tt.wantRendered, ",") {
+				t.Errorf("rendered brochures = %q, want %q", got, strings.Join(tt.wantRendered, ","))
+			}
+			if deleted := th.storage.deletedKeys(); len(deleted) != tt.wantRolledBack {
+				t.Errorf("rolled back %d objects (%v), want %d", len(deleted), deleted, tt.wantRolledBack)
+			}
+			commands := strings.Join(th.mongo.received(), ",")
+			if resp.StatusCode != fiber.StatusCreated && strings.Contains(commands, "insert image_hashes") {
+				t.Errorf("sent %s, want no image hash records for a failed submission", commands)
+			}
+			if resp.StatusCode == fiber.StatusCreated && !strings.Contains(commands, "insert properties,insert image_hashes") {
+				t.Errorf("sent %s, want the image hashes recorded after the listing", commands)
+			}
+			if resp.StatusCode != fiber.StatusCreated {
+				var errResp models.ErrorResponse
+				if err := json.Unmarshal(body, &errResp); err != nil || errResp.Success {
+					t.Errorf("error response = %s, want an ErrorResponse", body)
+				}
+				return
+			}
+
+			var created models.PropertyResponse
+			decode(t, body, &created)
+			if !created.Success || created.PropertyID == "" || created.PDFUrl == "" {
+				t.Errorf("response = %+v, want a created listing with a brochure URL", created)
+			}
+			inserts := th.mongo.sent("insert properties")
+			if len(inserts) != 1 {
+				t.Fatalf("sent %d property inserts, want 1", len(inserts))
+			}
+			stored := inserts[0].Lookup("documents", "0").Document()
+			if city := stored.Lookup("cityLower").StringValue(); city != "dubai" {
+				t.Errorf("stored cityLower = %q, want dubai", city)
+			}
+			var location *models.GeoPoint
+			if raw, ok := stored.Lookup("location").DocumentOK(); ok {
+				if err := bson.Unmarshal(raw, &location); err != nil {
+					t.Fatalf("failed to decode location %s: %v", raw, err)
+				}
+			}
+			if fields["latitude"] == "" && location != nil {
+				t.Errorf("stored location = %+v, want none without coordinates", location)
+			}
+			if fields["latitude"] != "" && (location == nil || location.Type != "Point" || fmt.Sprint(location.Coordinates) != "[55.14 25.08]") {
+				t.Errorf("stored location = %+v, want the GeoJSON point [55.14 25.08]", location)
+			}
+			if fmt.Sprint(created.Warnings) != fmt.Sprint(tt.wantWarnings) {
+				t.Errorf("warnings = %q, want %q", created.Warnings, tt.wantWarnings)
+			}
+			th.storage.AssertNumberOfCalls(t, "UploadFile", 1)
+			th.storage.AssertNumberOfCalls(t, "UploadPDFWithUrls", len(tt.wantRendered))
+		})
+	}
+}
+
+func TestGetProperty(t *testing.T) {
+	property := storedProperty()
+
+	t.Run("found", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex(), nil))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		var detail models.PropertyDetailResponse
+		decode(t, body, &detail)
+		if detail.Property == nil || detail.Property.ID != property.ID || detail.Property.Title != property.Title {
+			t.Errorf("property = %+v, want %s", detail.Property, property.ID.Hex())
+		}
+		if resp.Header.Get(fiber.HeaderETag) == "" {
+			t.Error("response has no ETag")
+		}
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+
+		first, _ := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex(), nil))
+		req := httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex(), nil)
+		req.Header.Set(fiber.HeaderIfNoneMatch, first.Header.Get(fiber.HeaderETag))
+		if resp, body := th.do(t, req); resp.StatusCode != fiber.StatusNotModified {
+			t.Errorf("status = %d, want 304: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("changes without updatedAt get a new ETag", func(t *testing.T) {
+		th := newTestHandler(t)
+		// The URL refresher and favorites counter leave updatedAt as it is
+		refreshed := *property
+		refreshed.ImageURLs = []string{mockStorageURL + "properties/image-1.png?signature=fresh"}
+		refreshed.FavoritesCount = 3
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onFind("properties", propertyDocument(t, &refreshed))
+
+		first, _ := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex(), nil))
+		req := httptest.NewRequest(http.MethodGet, "/api/property/"+property.ID.Hex(), nil)
+		req.Header.Set(fiber.HeaderIfNoneMatch, first.Header.Get(fiber.HeaderETag))
+		resp, body := th.do(t, req)
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200 with the refreshed listing: %s", resp.StatusCode, body)
+		}
+		if resp.Header.Get(fiber.HeaderETag) == first.Header.Get(fiber.HeaderETag) {
+			t.Error("ETag did not change with the response")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties")
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/"+primitive.NewObjectID().Hex(), nil))
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("status = %d, want 404: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		th := newTestHandler(t)
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/property/not-an-id", nil))
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("status = %d, want 400: %s", resp.StatusCode, body)
+		}
+		if commands := th.mongo.received(); len(commands) != 0 {
+			t.Errorf("sent %v, want no database commands", commands)
+		}
+	})
+}
+
+func TestDeleteProperty(t *testing.T) {
+	t.Run("deletes the brochures and unused images", func(t *testing.T) {
+		th := newTestHandler(t)
+		property := storedProperty()
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onWrite("delete", "properties", 1)
+		th.mongo.onWrite("delete", "shareLinks", 0)
+		th.mongo.onWrite("delete", "favorites", 0)
+		th.mongo.onCount("properties", 0)
+		th.mongo.onWrite("delete", "image_hashes", 1)
+		th.storage.On("DeleteObjects", mock.Anything).Return(nil)
+
+		if err := th.deleteProperty(property.ID.Hex()); err != nil {
+			t.Fatalf("deleteProperty failed: %v", err)
+		}
+		want := []string{
+			fmt.Sprintf("previews/%s-%d.jpg", property.ID.Hex(), property.UpdatedAt.Unix()),
+			"brochures/2-en.pdf",
+			"brochures/3-ar.pdf",
+			"properties/image-1.png",
+		}
+		if got := strings.Join(th.storage.deletedKeys(), ","); got != strings.Join(want, ",") {
+			t.Errorf("deleted %q, want %q", got, strings.Join(want, ","))
+		}
+		for _, command := range []string{"delete shareLinks", "delete favorites", "delete image_hashes"} {
+			if !received(th.mongo, command) {
+				t.Errorf("%s was not sent", command)
+			}
+		}
+	})
+
+	t.Run("keeps images other listings use", func(t *testing.T) {
+		th := newTestHandler(t)
+		property := storedProperty()
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onWrite("delete", "properties", 1)
+		th.mongo.onWrite("delete", "shareLinks", 0)
+		th.mongo.onWrite("delete", "favorites", 0)
+		th.mongo.onCount("properties", 1)
+		th.storage.On("DeleteObjects", mock.Anything).Return(nil)
+
+		if err := th.deleteProperty(property.ID.Hex()); err != nil {
+			t.Fatalf("deleteProperty failed: %v", err)
+		}
+		for _, key := range th.storage.deletedKeys() {
+			if strings.HasPrefix(key, "properties/") {
+				t.Errorf("deleted image %s, which another listing uses", key)
+			}
+		}
+		if received(th.mongo, "delete image_hashes") {
+			t.Error("removed the hash record of an image still in use")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties")
+
+		if err := th.deleteProperty(primitive.NewObjectID().Hex()); !errors.Is(err, mongo.ErrNoDocuments) {
+			t.Errorf("error = %v, want mongo.ErrNoDocuments", err)
+		}
+		th.storage.AssertNotCalled(t, "DeleteObjects", mock.Anything)
+	})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestSearchProperties(t *testing.T) {
+	t.Run("one page of matches", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onCount("properties", 3)
+		th.mongo.onFind("properties", propertyDocument(t, storedProperty()), propertyDocument(t, storedProperty()))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/properties/search?city=Dubai&minPrice=1000000&bedrooms=2&status=active&limit=2", nil))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		var result models.PropertySearchResponse
+		decode(t, body, &result)
+		if len(result.Properties) != 2 || result.TotalCount != 3 || result.Limit != 2 || result.Offset != 0 {
+			t.Errorf("result = %d listings of %d (limit %d, offset %d), want 2 of 3 (limit 2, offset 0)",
+				len(result.Properties), result.TotalCount, result.Limit, result.Offset)
+		}
+	})
+
+	for name, query := range map[string]string{
+		"inverted price range": "minPrice=2000000&maxPrice=1000000",
+		"negative price":       "minPrice=-1",
+		"invalid bedrooms":     "bedrooms=two",
+		"invalid status":       "status=archived",
+		"limit too large":      "limit=101",
+		"negative offset":      "offset=-1",
+	} {
+		t.Run(name, func(t *testing.T) {
+			th := newTestHandler(t)
+
+			resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/properties/search?"+query, nil))
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("status = %d, want 400: %s", resp.StatusCode, body)
+			}
+		})
+	}
+
+	t.Run("database failure", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.on("aggregate", "properties", mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "count failed"}))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/properties/search?city=Dubai", nil))
+		if resp.StatusCode != fiber.StatusInternalServerError {
+			t.Errorf("status = %d, want 500: %s", resp.StatusCode, body)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateShareLink issues a public link to a listing's landing page, valid for 72 hours unless the body
+// sets expiresInHours. The response lists all of the listing's links, the new one first.
+//
+// @Summary      Create a share link
+// @Tags         properties
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                   true   "Property ID"
+// @Param        request  body      models.ShareLinkRequest  false  "Link lifetime (1-720 hours, default 72)"
+// @Success      201      {object}  models.PropertyResponse
+// @Failure      400      {object}  models.ErrorResponse  "Invalid ID or body"
+// @Failure      404      {object}  models.ErrorResponse  "Property not found"
+// @Failure      500      {object}  models.ErrorResponse  "Database failure"
+// @Router       /api/property/{id}/share-link [post]
+func (h *PropertyHandler) CreateShareLink(c *fiber.Ctx) error {
+	var req models.ShareLinkRequest
+	if len(c.Body()) > 0 {
+		if err := json.Unmarshal(c.Body(), &req); err != nil {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+		}
+	}
+	ttl := services.DefaultShareLinkTTL
+	if req.ExpiresInHours != 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+		if req.ExpiresInHours < 0 || ttl > services.MaxShareLinkTTL {
+			return models.NewAPIError(models.ErrCodeValidation, "Invalid expiresInHours", fmt.Errorf("expiresInHours must be between 1 and %d", int(services.MaxShareLinkTTL.Hours())))
+		}
+	}
+
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	if _, err := h.shareLinks.Create(property.ID, ttl); err != nil {
+		log.Printf("Error creating share link: %v", err)
+		return models.NewAPIError(models.ErrCodeMongoInsert, "Failed to create share link", err)
+	}
+	links, err := h.shareLinks.ForProperty(property.ID)
+	if err != nil {
+		log.Printf("Error loading share links: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to load share links", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.PropertyResponse{
+		Success:    true,
+		Message:    "Share link created",
+		PropertyID: property.ID.Hex(),
+		ShareLinks: links,
+	})
+}
+
+// GetSharedProperty opens a share link: it counts the view and returns the listing's public details,
+// or with redirect=pdf redirects to the English brochure, opened in the browser
+//
+// @Summary      Open a share link
+// @Tags         share
+// @Produce      json
+// @Param        token     path      string  true   "Share link token"
+// @Param        redirect  query     string  false  "Redirect to the English brochure instead of returning JSON"  Enums(pdf)
+// @Success      200       {object}  models.SharedPropertyResponse
+// @Success      302       "Redirect to the English brochure"
+// @Failure      404       {object}  models.ErrorResponse  "Unknown link, listing deleted or no English brochure"
+// @Failure      410       {object}  models.ErrorResponse  "Link expired"
+// @Failure      500       {object}  models.ErrorResponse  "Database or URL signing failure"
+// @Router       /api/share/{token} [get]
+func (h *PropertyHandler) GetSharedProperty(c *fiber.Ctx) error {
+	link, err := h.shareLinks.View(c.Params("token"))
+	switch {
+	case errors.Is(err, services.ErrShareLinkNotFound):
+		return models.NewAPIError(models.ErrCodeNotFound, "Share link not found", nil)
+	case errors.Is(err, services.ErrShareLinkExpired):
+		return models.NewAPIError(models.ErrCodeGone, "Share link expired", nil)
+	case err != nil:
+		log.Printf("Error opening share link: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to open share link", err)
+	}
+
+	property, err := h.findProperty(link.PropertyID.Hex())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.NewAPIError(models.ErrCodeNotFound, "Share link not found", errors.New("the listing was deleted"))
+	}
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	if c.Query("redirect") == "pdf" {
+		if property.PDFUrlEnglish == "" {
+			return models.NewAPIError(models.ErrCodeNotFound, "No English brochure", errors.New("the listing has no English brochure"))
+		}
+		viewURL, err := services.ViewURL(h.storage, property.PDFUrlEnglish, property.Title+"_en")
+		if err != nil {
+			log.Printf("Error signing English brochure of property %s: %v", property.ID.Hex(), err)
+			return models.NewAPIError(models.ErrCodeInternal, "Failed to sign brochure URL", err)
+		}
+		return c.Redirect(viewURL, fiber.StatusFound)
+	}
+
+	return c.JSON(models.SharedPropertyResponse{
+		Success:   true,
+		Property:  models.NewSharedProperty(property),
+		ViewCount: link.ViewCount,
+		ExpiresAt: link.ExpiresAt,
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestShareLinks(t *testing.T) {
+	property := storedProperty()
+	property.PDFUrlEnglish = signedStorageURL("brochures/2-en.pdf")
+	link := models.ShareLink{
+		Token:      "c2hhcmU",
+		PropertyID: property.ID,
+		ExpiresAt:  time.Now().Add(72 * time.Hour),
+		ViewCount:  1,
+		CreatedAt:  time.Now(),
+	}
+	linkDoc := bson.D{
+		{Key: "token", Value: link.Token},
+		{Key: "propertyId", Value: link.PropertyID},
+		{Key: "expiresAt", Value: link.ExpiresAt},
+		{Key: "viewCount", Value: link.ViewCount},
+		{Key: "createdAt", Value: link.CreatedAt},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.onFind("properties", propertyDocument(t, property))
+		th.mongo.onWrite("insert", "shareLinks", 1)
+		th.mongo.onFind("shareLinks", linkDoc)
+
+		resp, body := th.do(t, jsonRequest(http.MethodPost, "/api/property/"+property.ID.Hex()+"/share-link", `{"expiresInHours": 24}`))
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("status = %d, want 201: %s", resp.StatusCode, body)
+		}
+		var created models.PropertyResponse
+		decode(t, body, &created)
+		if len(created.ShareLinks) != 1 || created.ShareLinks[0].Token != link.Token {
+			t.Errorf("share links = %+v, want the stored link", created.ShareLinks)
+		}
+	})
+
+	t.Run("create with an invalid lifetime", func(t *testing.T) {
+		th := newTestHandler(t)
+
+		resp, body := th.do(t, jsonRequest(http.MethodPost, "/api/property/"+property.ID.Hex()+"/share-link", `{"expiresInHours": 721}`))
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("status = %d, want 400: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("open", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.on("findAndModify", "shareLinks", valueResponse(linkDoc))
+		th.mongo.onFind("properties", propertyDocument(t, property))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/share/"+link.Token, nil))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+		}
+		var shared models.SharedPropertyResponse
+		decode(t, body, &shared)
+		if shared.Property == nil || shared.Property.Title != property.Title || shared.ViewCount != 1 {
+			t.Errorf("response = %+v, want the listing with one view", shared)
+		}
+	})
+
+	t.Run("open the brochure", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.on("findAndModify", "shareLinks", valueResponse(linkDoc))
+		th.mongo.onFind("properties", propertyDocument(t, property))
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/share/"+link.Token+"?redirect=pdf", nil))
+		if resp.StatusCode != fiber.StatusFound || resp.Header.Get(fiber.HeaderLocation) != mockStorageURL+"brochures/2-en.pdf?signed" {
+			t.Errorf("status = %d, location %q, want a redirect to the re-signed English brochure: %s",
+				resp.StatusCode, resp.Header.Get(fiber.HeaderLocation), body)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.on("findAndModify", "shareLinks", valueResponse(nil))
+		th.mongo.onFind("shareLinks", linkDoc)
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/share/"+link.Token, nil))
+		if resp.StatusCode != fiber.StatusGone {
+			t.Errorf("status = %d, want 410: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		th := newTestHandler(t)
+		th.mongo.on("findAndModify", "shareLinks", valueResponse(nil))
+		th.mongo.onFind("shareLinks")
+
+		resp, body := th.do(t, httptest.NewRequest(http.MethodGet, "/api/share/unknown", nil))
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("status = %d, want 404: %s", resp.StatusCode, body)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"property-brochure-backend/services"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// languageNames are the log and message names of the brochure languages
+var languageNames = map[string]string{"en": "English", "ar": "Arabic", "ur": "Urdu"}
+
+// AddLanguage generates the copy and brochure of one more language for an existing listing, e.g. an
+// Arabic brochure for a listing created English-only. The English description is the base of the
+// new copy; the other brochures are left untouched.
+//
+// @Summary      Add a brochure language to a listing
+// @Tags         properties
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                     true  "Property ID"
+// @Param        request  body      models.AddLanguageRequest  true  "Language to add"
+// @Success      201      {object}  models.PropertyResponse
+// @Failure      400      {object}  models.ErrorResponse  "Invalid ID, body or language"
+// @Failure      404      {object}  models.ErrorResponse  "Property not found"
+// @Failure      409      {object}  models.ErrorResponse  "Language already generated, or the listing is password protected"
+// @Failure      500      {object}  models.ErrorResponse  "PDF generation or database failure"
+// @Failure      502      {object}  models.ErrorResponse  "AI generation or storage upload failure"
+// @Router       /api/property/{id}/translate [post]
+func (h *PropertyHandler) AddLanguage(c *fiber.Ctx) error {
+	var req models.AddLanguageRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid request body", err)
+	}
+	language := strings.ToLower(strings.TrimSpace(req.Language))
+	name, ok := languageNames[language]
+	if !ok {
+		return models.NewAPIError(models.ErrCodeValidation, "Invalid language", errors.New("language must be \"en\", \"ar\" or \"ur\""))
+	}
+	if language == "ar" && !h.features.Flags().EnableArabic {
+		return models.NewAPIError(models.ErrCodeValidation, "Arabic brochures are disabled", errors.New("the Arabic feature flag is off"))
+	}
+
+	property, err := h.findProperty(c.Params("id"))
+	if err != nil {
+		return h.propertyLookupError(err)
+	}
+
+	existing := map[string]string{"en": property.PDFUrlEnglish, "ar": property.PDFUrlArabic, "ur": property.PDFUrlUrdu}
+	if existing[language] != "" {
+		return models.NewAPIError(models.ErrCodeConflict, "Language already generated", fmt.Errorf("the listing already has its %s brochure", name))
+	}
+	if property.IsPasswordProtected {
+		// The password is never stored, so a new brochure could not be protected like the others
+		return models.NewAPIError(models.ErrCodeConflict, "Listing is password protected", errors.New("brochures of password-protected listings cannot be added later; resubmit the listing"))
+	}
+
+	base := property.EnglishContent.Description
+	if base == "" {
+		base = property.AIContent.EnglishDescription
+	}
+	if base == "" {
+		base = property.Description
+	}
+
+	log.Printf("Generating %s content for property %s...", name, property.ID.Hex())
+	generated, err := h.openaiService.GenerateLocalizedContent(
+		property.Title,
+		base,
+		fmt.Sprintf("%.2f", property.Price),
+		property.Currency,
+		property.Amenities,
+		[]string{language},
+	)
+	if err != nil {
+		log.Printf("Error generating %s content: %v", name, err)
+		return models.NewAPIError(models.ErrCodeAIGeneration, fmt.Sprintf("Failed to generate %s content", name), err)
+	}
+
+	var content *models.LocalizedContent
+	var contentField, pdfField string
+	var generate func(*models.Property) ([]byte, error)
+	switch language {
+	case "en":
+		property.EnglishContent = newLocalizedContent(generated.EnglishContent)
+		content, contentField, pdfField, generate = &property.EnglishContent, "englishContent", "pdfUrlEnglish", h.pdfService.GenerateEnglishBrochure
+	case "ar":
+		property.ArabicContent = newLocalizedContent(generated.ArabicContent)
+		content, contentField, pdfField, generate = &property.ArabicContent, "arabicContent", "pdfUrlArabic", h.pdfService.GenerateArabicBrochure
+	case "ur":
+		property.UrduContent = newLocalizedContent(generated.UrduContent)
+		content, contentField, pdfField, generate = &property.UrduContent, "urduContent", "pdfUrlUrdu", h.pdfService.GenerateUrduBrochure
+	}
+	content.AmenityIcon = services.AmenityIcons(content.Amenities, property.Amenities)
+
+	log.Printf("Generating %s PDF brochure...", name)
+	pdfData, err := generate(property)
+	if err != nil {
+		log.Printf("Error generating %s PDF: %v", name, err)
+		return models.NewAPIError(models.ErrCodePDFGeneration, fmt.Sprintf("Failed to generate %s PDF", name), err)
+	}
+	urls, err := h.storage.UploadPDFWithUrls(pdfData, property.Title+"_"+language)
+	if err != nil {
+		log.Printf("Error uploading %s PDF: %v", name, err)
+		return models.NewAPIError(models.ErrCodeS3Upload, fmt.Sprintf("Failed to upload %s PDF", name), err)
+	}
+
+	// Listings created before languages were recorded list the brochures they have
+	languages := property.Languages
+	if len(languages) == 0 {
+		for _, lang := range []string{"en", "ar", "ur"} {
+			if existing[lang] != "" {
+				languages = append(languages, lang)
+			}
+		}
+	}
+	languages = append(languages, language)
+
+	set := bson.M{
+		contentField: *content,
+		pdfField:     urls.ViewUrl,
+		"languages":  languages,
+		"updatedAt":  time.Now(),
+	}
+	if property.PDFUrl == "" {
+		set["pdfUrl"] = urls.ViewUrl
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The filter fails when a concurrent request added the language first
+	result, err := h.mongoService.GetCollection("properties").UpdateOne(ctx,
+		bson.M{"_id": property.ID, pdfField: bson.M{"$in": bson.A{"", nil}}},
+		bson.M{"$set": set})
+	if err == nil && result.MatchedCount == 0 {
+		err = errLanguageAdded
+	}
+	if err != nil {
+		var uploaded []string
+		h.trackUpload(&uploaded, urls.ViewUrl)
+		h.rollbackUploads(uploaded)
+		if errors.Is(err, errLanguageAdded) {
+			return models.NewAPIError(models.ErrCodeConflict, "Language already generated", err)
+		}
+		log.Printf("Error updating property: %v", err)
+		return models.NewAPIError(models.ErrCodeInternal, "Failed to update property", err)
+	}
+
+	h.emitEvent(models.EventPDFGenerated, property, map[string]interface{}{
+		"language":          language,
+		"passwordProtected": false,
+	})
+
+	response := models.PropertyResponse{
+		Success:    true,
+		Message:    fmt.Sprintf("%s brochure added", name),
+		PropertyID: property.ID.Hex(),
+	}
+	switch language {
+	case "en":
+		response.PDFUrlEnglish = urls.ViewUrl
+		response.PDFViewUrlEnglish = urls.ViewUrl
+		response.PDFDownloadUrlEnglish = urls.DownloadUrl
+	case "ar":
+		response.PDFUrlArabic = urls.ViewUrl
+		response.PDFViewUrlArabic = urls.ViewUrl
+		response.PDFDownloadUrlArabic = urls.DownloadUrl
+	case "ur":
+		response.PDFUrlUrdu = urls.ViewUrl
+		response.PDFViewUrlUrdu = urls.ViewUrl
+		response.PDFDownloadUrlUrdu = urls.DownloadUrl
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+var errLanguageAdded = errors.New("the language was added by another request")
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"property-brochure-backend/models"
+)
+
+// FuzzValidateRequest feeds arbitrary form values into validateRequest, which must never panic and must
+// only accept listings the brochure generators can render
+func FuzzValidateRequest(f *testing.F) {
+	f.Add("Marina View Apartment", 2450000.0, "Dubai", "sara@example.com", "en", "A4", 20.0)
+	f.Add("Marina View Apartment", math.NaN(), "Dubai", "sara@example.com", "en", "A4", 20.0)
+	f.Add("Marina View Apartment", math.Inf(1), "Dubai", "sara@example.com", "ar", "Letter", 20.0)
+	f.Add("Marina View Apartment", -1.0, "Dubai", "sara@example.com", "en", "A4", 20.0)
+	f.Add("Marina\x00View", 2450000.0, "Dubai", "sara@example.com", "en", "A4", 20.0)
+	f.Add("Marina View Apartment", 2450000.0, "\xff\xfe", "sara@example.com", "ur", "Legal", 20.0)
+	f.Add(strings.Repeat("شقة ", 10000), 2450000.0, "دبي", "sara@example.com", "ar", "A4", 99.9)
+	f.Add("", 0.0, "", "", "", "", 0.0)
+	f.Add("Marina View Apartment", 2450000.0, "Dubai", "sara@example.com", "fr", "A3", math.NaN())
+
+	brochures := &mockBrochures{}
+	expectBrochures(brochures)
+	h := &PropertyHandler{pdfService: brochures}
+	f.Fuzz(func(t *testing.T, title string, price float64, city, email, language, pageSize string, downPayment float64) {
+		req := &models.PropertyRequest{
+			Title:      title,
+			Price:      price,
+			Address:    "Marina Promenade, Tower 3",
+			City:       city,
+			State:      "Dubai",
+			ZipCode:    "00000",
+			AgentName:  "Sara Haddad",
+			AgentEmail: email,
+			AgentPhone: "+971 50 123 4567",
+			Amenities:  []string{title, city},
+			Mortgage:   models.MortgageDetails{DownPaymentPct: downPayment, InterestRate: 7, TermYears: 30},
+			PageSize:   pageSize,
+			Languages:  []string{language},
+		}
+		if err := h.validateRequest(req); err != nil {
+			return
+		}
+
+		if title == "" || city == "" || email == "" {
+			t.Errorf("accepted a listing without a title, city or agent email: %+v", req)
+		}
+		if math.IsNaN(price) || math.IsInf(price, 0) || price <= 0 {
+			t.Errorf("accepted price %v", price)
+		}
+		if math.IsNaN(downPayment) || math.IsInf(downPayment, 0) || downPayment >= 100 {
+			t.Errorf("accepted down payment %v", downPayment)
+		}
+		for _, value := range []string{title, city, email} {
+			if !utf8.ValidString(value) || strings.ContainsRune(value, 0) {
+				t.Errorf("accepted text %q, which the PDF fonts cannot render", value)
+			}
+		}
+		if !hasLanguage([]string{"en", "ar", "ur"}, language) {
+			t.Errorf("accepted language %q", language)
+		}
+		if req.PageSize != "A4" && req.PageSize != "Letter" && req.PageSize != "Legal" {
+			t.Errorf("accepted page size %q", req.PageSize)
+		}
+	})
+}
@@ -1,83 +1,261 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
 	"property-brochure-backend/config"
+	"property-brochure-backend/docs"
 	"property-brochure-backend/handlers"
 	"property-brochure-backend/middleware"
 	"property-brochure-backend/services"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/swagger"
 )
 
+// @title        Property Brochure API
+// @version      1.0
+// @description  Creates property listings and generates AI-written English and Arabic PDF brochures.
+// @BasePath     /
+//
+// @securityDefinitions.apikey  AdminToken
+// @in                          header
+// @name                        Authorization
+// @description                 Admin API token (ADMIN_API_TOKEN) as "Bearer <token>"
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 
-	// Validate required environment variables
-	if cfg.MongoURI == "" {
-		log.Fatal("MONGODB_URI is required")
-	}
-	if cfg.AWSAccessKey == "" || cfg.AWSSecretKey == "" {
-		log.Fatal("AWS credentials are required")
-	}
-	if cfg.AWSS3Bucket == "" {
-		log.Fatal("AWS_S3_BUCKET is required")
-	}
-	if cfg.OpenAIAPIKey == "" {
-		log.Fatal("OPENAI_API_KEY is required")
+	// Validate configuration, reporting every problem before exiting
+	if err := cfg.Validate(); err != nil {
+		log.Println("Invalid configuration:")
+		for _, line := range strings.Split(err.Error(), "\n") {
+			log.Printf("  - %s", line)
+		}
+		os.Exit(1)
 	}
 
 	// Initialize services
 	log.Println("Connecting to MongoDB...")
-	mongoService, err := services.NewMongoDBService(cfg.MongoURI, cfg.MongoDatabase)
+	mongoPool := services.MongoPoolConfig{
+		MinPoolSize:    uint64(cfg.MongoPoolMin),
+		MaxPoolSize:    uint64(cfg.MongoPoolMax),
+		ConnectTimeout: time.Duration(cfg.MongoConnectTimeoutSec) * time.Second,
+		SocketTimeout:  time.Duration(cfg.MongoSocketTimeoutSec) * time.Second,
+	}
+	log.Printf("MongoDB pool: min %d, max %d connections, connect timeout %s, socket timeout %s",
+		mongoPool.MinPoolSize, mongoPool.MaxPoolSize, mongoPool.ConnectTimeout, mongoPool.SocketTimeout)
+	mongoService, err := services.NewMongoDBService(cfg.MongoURI, cfg.MongoDatabase, mongoPool)
 	if err != nil {
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
 	defer mongoService.Close()
 	log.Println("Connected to MongoDB successfully")
 
-	log.Println("Initializing AWS S3 service...")
-	s3Service, err := services.NewS3Service(
-		cfg.AWSAccessKey,
-		cfg.AWSSecretKey,
-		cfg.AWSRegion,
-		cfg.AWSS3Bucket,
-	)
-	if err != nil {
-		log.Fatalf("Failed to initialize S3 service: %v", err)
+	indexCtx, cancelIndexes := context.WithTimeout(context.Background(), 30*time.Second)
+	if err := mongoService.InitIndexes(indexCtx); err != nil {
+		log.Fatalf("Failed to create MongoDB indexes: %v", err)
+	}
+	if err := mongoService.BackfillSearchFields(indexCtx); err != nil {
+		log.Fatalf("Failed to prepare the property search: %v", err)
+	}
+	cancelIndexes()
+
+	var storageService services.StorageService
+	var localStorage *services.LocalStorageService
+	switch cfg.StorageBackend {
+	case "local":
+		log.Printf("Initializing local storage at %s...", cfg.LocalStoragePath)
+		localStorage, err = services.NewLocalStorageService(
+			cfg.LocalStoragePath,
+			"http://localhost:"+cfg.Port,
+		)
+		if err != nil {
+			log.Fatalf("Failed to initialize local storage: %v", err)
+		}
+		storageService = localStorage
+		log.Println("Local storage initialized successfully")
+	case "azure":
+		log.Println("Initializing Azure Blob Storage service...")
+		storageService, err = services.NewAzureBlobService(
+			cfg.AzureAccountName,
+			cfg.AzureAccountKey,
+			cfg.AzureContainer,
+		)
+		if err != nil {
+			log.Fatalf("Failed to initialize Azure Blob Storage service: %v", err)
+		}
+		log.Println("Azure Blob Storage service initialized successfully")
+	default:
+		log.Println("Initializing AWS S3 service...")
+		s3Service, err := services.NewS3Service(
+			cfg.AWSAccessKey,
+			cfg.AWSSecretKey,
+			services.S3BucketConfig{
+				Name:           cfg.AWSS3ImageBucket,
+				Region:         cfg.AWSS3ImageRegion,
+				Endpoint:       cfg.AWSS3Endpoint,
+				ForcePathStyle: cfg.AWSS3ForcePathStyle,
+			},
+			services.S3BucketConfig{
+				Name:           cfg.AWSS3PDFBucket,
+				Region:         cfg.AWSS3PDFRegion,
+				Endpoint:       cfg.AWSS3Endpoint,
+				ForcePathStyle: cfg.AWSS3ForcePathStyle,
+			},
+			cfg.PublicBucket,
+		)
+		if err != nil {
+			log.Fatalf("Failed to initialize S3 service: %v", err)
+		}
+		if cfg.CloudFrontDomain != "" {
+			if err := s3Service.UseCloudFront(cfg.CloudFrontDomain, cfg.CloudFrontKeyPairID, cfg.CloudFrontPrivateKey); err != nil {
+				log.Fatalf("Failed to configure CloudFront: %v", err)
+			}
+			log.Printf("Serving S3 assets via CloudFront (%s)", cfg.CloudFrontDomain)
+		}
+		storageService = s3Service
+		log.Println("AWS S3 service initialized successfully")
 	}
-	log.Println("AWS S3 service initialized successfully")
 
 	log.Println("Initializing OpenAI service...")
 	openaiService := services.NewOpenAIService(cfg.OpenAIAPIKey)
 	log.Println("OpenAI service initialized successfully")
 
+	fontService := services.NewFontService(mongoService, storageService)
+	featuredService := services.NewFeaturedListingService(mongoService)
+
+	var encryptionService *services.EncryptionService
+	if cfg.EncryptionEnabled {
+		log.Println("Encrypting agent emails and phone numbers at rest")
+		encryptionService, err = services.NewEncryptionService(cfg.DataEncryptionKey)
+		if err != nil {
+			log.Fatalf("Failed to initialize encryption: %v", err)
+		}
+	}
+	eventService := services.NewEventService(mongoService, encryptionService)
+
+	themePresets := map[string]services.Preset{}
+	if cfg.ThemePresetsPath != "" {
+		themePresets, err = services.LoadThemePresets(cfg.ThemePresetsPath)
+		if err != nil {
+			log.Fatalf("Failed to load theme presets: %v", err)
+		}
+		log.Printf("Loaded theme presets: %s", strings.Join(services.ThemePresetNames(themePresets), ", "))
+	}
+
 	log.Println("Initializing PDF service...")
-	pdfService := services.NewPDFService()
+	pdfOptions := []services.PDFOption{
+		services.WithFontLoader(fontService),
+		services.WithArabicFontPath(cfg.ArabicFontPath),
+		services.WithBodyFontPath(cfg.BodyFontPath),
+		services.WithUrduFontPath(cfg.UrduFontPath),
+		services.WithBrandLogoURL(cfg.BrandLogoURL),
+		services.WithCoverAspectRatio(cfg.CoverAspectRatio),
+		services.WithPDFToImagePath(cfg.PDFToImagePath),
+		services.WithPDFToolTimeout(time.Duration(cfg.PDFToolTimeoutSec) * time.Second),
+		services.WithThemePresets(themePresets),
+		services.WithSectionHeaderPattern(cfg.SectionHeaderPattern),
+		services.WithPageToggles(cfg.FeatureFlags),
+		services.WithObjectReader(storageService),
+	}
+	if cfg.PDFOptimize {
+		log.Printf("Optimizing brochures over %dMB with %s", cfg.PDFOptimizeThresholdMB, cfg.GhostscriptPath)
+		pdfOptions = append(pdfOptions, services.WithPDFOptimization(int64(cfg.PDFOptimizeThresholdMB)<<20, cfg.GhostscriptPath))
+	}
+	if cfg.GoogleMapsStaticAPIKey != "" {
+		log.Println("Adding location maps to brochure covers")
+		pdfOptions = append(pdfOptions, services.WithStaticMaps(cfg.GoogleMapsStaticAPIKey))
+	}
+	if cfg.PDFLinearizeCmd != "" {
+		log.Printf("Linearizing brochures with %s", cfg.PDFLinearizeCmd)
+		pdfOptions = append(pdfOptions, services.WithPDFLinearization(cfg.PDFLinearizeCmd))
+	}
+	if cfg.IncludeFeaturedListing {
+		log.Println("Adding the property of the week to brochure contact pages")
+		pdfOptions = append(pdfOptions, services.WithFeaturedListing(featuredService))
+	}
+	pdfService := services.NewPDFService(pdfOptions...)
 	log.Println("PDF service initialized successfully")
 
+	var geocoder services.Geocoder
+	if cfg.GeocoderURL != "" {
+		geocoder = services.NewNominatimGeocoder(cfg.GeocoderURL, cfg.GeocoderUserAgent)
+	}
+
+	var moderator services.ContentModerator
+	if cfg.ModerationEnabled {
+		log.Println("Moderating submitted descriptions")
+		moderator = openaiService
+	}
+
+	log.Printf("Starting %d brochure workers...", cfg.WorkerPoolSize)
+	workerPool := services.NewWorkerPool(cfg.WorkerPoolSize, services.WorkerQueueSize)
+
 	// Initialize handlers
 	propertyHandler := handlers.NewPropertyHandler(
 		mongoService,
-		s3Service,
+		storageService,
 		openaiService,
 		pdfService,
 		cfg.MaxFileSize,
 		cfg.AllowedFileTypes,
+		cfg.MaxImagesPerProperty,
+		workerPool,
+		fontService,
+		geocoder,
+		moderator,
+		eventService,
+		cfg.FeatureFlags,
+		encryptionService,
 	)
 
+	graphqlHandler := handlers.NewGraphQLHandler(propertyHandler, cfg.AdminAPIToken)
+
+	urlRefreshService := services.NewURLRefreshService(mongoService, storageService)
+	adminHandler := handlers.NewAdminHandler(urlRefreshService, featuredService, eventService, openaiService)
+	fontHandler := handlers.NewFontHandler(fontService)
+
+	// Background tasks stop when the server shuts down
+	background, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	// Re-sign stored pre-signed URLs before they expire
+	urlRefreshService.Start(background, services.URLRefreshInterval)
+
+	// Pick up edits to the feature flags file without a restart
+	cfg.FeatureFlags.Watch(background, config.FeatureFlagsReloadInterval)
+
+	bodyLimits := middleware.BodyLimits{
+		// Every photo of a listing at the largest file size, plus room for the form fields
+		Multipart: int(cfg.MaxFileSize)*cfg.MaxImagesPerProperty + 1<<20,
+		JSON:      1 << 20,
+		Other:     64 << 10,
+	}
+
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
 		ErrorHandler: middleware.ErrorHandler,
-		BodyLimit:    int(cfg.MaxFileSize * 10), // Allow multiple files
+		// Server-wide ceiling; middleware.BodyLimit applies the per content type limits
+		BodyLimit: bodyLimits.Max(),
 	})
 
 	// Middleware
 	app.Use(recover.New())
 	app.Use(middleware.Logger())
-	app.Use(middleware.SetupCORS(cfg.FrontendURL))
+	app.Use(middleware.BodyLimit(bodyLimits))
+	// The frontend policy skips /api/admin, whose group applies the stricter admin policy
+	app.Use(middleware.SetupCORS(cfg.PublicCORS, func(c *fiber.Ctx) bool {
+		return strings.HasPrefix(c.Path(), "/api/admin")
+	}))
+	app.Use(compress.New(compress.Config{Level: compressionLevel(cfg.CompressionLevel)}))
 
 	// Routes
 	api := app.Group("/api")
@@ -90,14 +268,139 @@ func main() {
 		})
 	})
 
+	// Handwritten OpenAPI 3.0 spec of the multipart submission form, for client generators
+	api.Get("/openapi.yaml", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "application/yaml")
+		return c.Send(docs.OpenAPISpec)
+	})
+
 	// Property endpoints
 	api.Post("/property", propertyHandler.SubmitProperty)
+	api.Get("/property/:id", propertyHandler.GetProperty)
+	api.Patch("/property/:id", propertyHandler.UpdateProperty)
+	api.Get("/property/:id/price-history", propertyHandler.GetPriceHistory)
+	api.Get("/properties/search", propertyHandler.SearchProperties)
+	api.Get("/property/:id/preview", propertyHandler.GetPropertyPreview)
+	api.Get("/property/:id/images", propertyHandler.GetPropertyImages)
+	api.Patch("/property/:id/images", propertyHandler.AddImages)
+	api.Post("/property/:id/duplicate", propertyHandler.DuplicateProperty)
+	api.Post("/property/:id/translate", propertyHandler.AddLanguage)
+	api.Post("/property/:id/share-link", propertyHandler.CreateShareLink)
+	api.Get("/share/:token", propertyHandler.GetSharedProperty)
+	api.Post("/compare", propertyHandler.CompareProperties)
+	api.Post("/address/validate", propertyHandler.ValidateAddress)
+	api.Get("/user/:userId/favorites", propertyHandler.GetFavorites)
+	api.Post("/user/:userId/favorites/:propertyId", propertyHandler.AddFavorite)
+	api.Delete("/user/:userId/favorites/:propertyId", propertyHandler.RemoveFavorite)
+	api.Delete("/property/:id/image/:index", propertyHandler.RemoveImage)
+	api.Post("/property/:id/set-cover-image", propertyHandler.SetCoverImage)
+
+	// Agent profiles, referenced by submissions with agentId
+	api.Get("/agents", propertyHandler.ListAgents)
+	api.Post("/agents", propertyHandler.CreateAgent)
+	api.Get("/agents/:id", propertyHandler.GetAgent)
+	api.Put("/agents/:id", propertyHandler.UpdateAgent)
+	api.Delete("/agents/:id", propertyHandler.DeleteAgent)
+
+	// Async submission status
+	api.Get("/jobs/:id", propertyHandler.GetJob)
+
+	// Admin endpoints, behind the admin API token
+	if cfg.AdminAPIToken == "" {
+		log.Println("Warning: ADMIN_API_TOKEN is not set, the admin API is disabled")
+	}
+	registerAdminRoutes(api, cfg, adminHandler, propertyHandler, fontHandler)
+
+	// Locally stored files (development storage backend only)
+	if localStorage != nil {
+		fileHandler := handlers.NewFileHandler(localStorage)
+		app.Get("/files/*", fileHandler.ServeFile)
+	}
+
+	// GraphQL API, an alternative to the REST endpoints
+	graphqlEnabled := middleware.RequireFeature("GraphQL API", func() bool { return cfg.FeatureFlags.Flags().EnableGraphQL })
+	app.Post("/graphql", graphqlEnabled, graphqlHandler.Execute)
+	if cfg.GraphiQLEnabled {
+		app.Get("/graphiql", graphqlEnabled, graphqlHandler.GraphiQL)
+	}
+
+	// API documentation (Swagger UI, spec at /swagger/doc.json)
+	if cfg.SwaggerEnabled {
+		if err := registerSwaggerRoutes(app); err != nil {
+			log.Fatalf("Failed to prepare the API documentation: %v", err)
+		}
+	}
 
 	// Start server
 	log.Printf("Server starting on port %s...", cfg.Port)
-	log.Printf("CORS enabled for: %s", cfg.FrontendURL)
+	log.Printf("CORS enabled for: %s", strings.Join(cfg.PublicCORS.AllowedOrigins, ", "))
+
+	// On SIGTERM stop accepting requests, then let the workers finish every queued brochure job
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-quit
+		log.Printf("Received %s, shutting down...", sig)
+		stopBackground()
+		if err := app.ShutdownWithTimeout(30 * time.Second); err != nil {
+			log.Printf("Error shutting down server: %v", err)
+		}
+	}()
+
 	if err := app.Listen(":" + cfg.Port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+
+	log.Printf("Waiting for %d queued brochure jobs...", workerPool.Pending())
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), services.WorkerDrainTimeout)
+	defer cancelDrain()
+	if err := workerPool.Shutdown(drainCtx); err != nil {
+		log.Printf("Stopped before all brochure jobs finished: %v", err)
+	}
+	// Jobs emit events as they finish, so flush the audit log after the workers
+	eventsCtx, cancelEvents := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelEvents()
+	if err := eventService.Shutdown(eventsCtx); err != nil {
+		log.Printf("Stopped before all audit events were recorded: %v", err)
+	}
+	log.Println("Server stopped")
+}
+
+// registerSwaggerRoutes serves the Swagger UI under /swagger. swag generates Swagger 2.0, so the spec is
+// converted to OpenAPI 3.0 once and served ahead of the UI's own doc.json route.
+func registerSwaggerRoutes(app *fiber.App) error {
+	spec, err := docs.OpenAPI3()
+	if err != nil {
+		return err
+	}
+	app.Get("/swagger/doc.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(spec)
+	})
+	app.Get("/swagger/*", swagger.HandlerDefault)
+	return nil
 }
 
+// registerAdminRoutes mounts the admin endpoints under /admin with the admin CORS policy; every one of
+// them, including bulk deletion, requires the admin API token
+func registerAdminRoutes(api fiber.Router, cfg *config.Config, adminHandler *handlers.AdminHandler, propertyHandler *handlers.PropertyHandler, fontHandler *handlers.FontHandler) {
+	admin := api.Group("/admin", middleware.SetupCORS(cfg.AdminCORS, nil), middleware.RequireAdminToken(cfg.AdminAPIToken))
+	admin.Post("/properties/refresh-urls", adminHandler.RefreshURLs)
+	admin.Delete("/properties", propertyHandler.BulkDelete)
+	admin.Post("/fonts", fontHandler.UploadFont)
+	admin.Post("/property-of-week", adminHandler.SetPropertyOfTheWeek)
+	admin.Get("/events", adminHandler.GetEvents)
+	admin.Get("/openai-costs", adminHandler.GetOpenAICosts)
+}
+
+// compressionLevel maps the COMPRESSION_LEVEL setting to the compress middleware level
+func compressionLevel(level string) compress.Level {
+	switch level {
+	case "best-speed":
+		return compress.LevelBestSpeed
+	case "best-compression":
+		return compress.LevelBestCompression
+	default:
+		return compress.LevelDefault
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"property-brochure-backend/config"
+	"property-brochure-backend/handlers"
+	"property-brochure-backend/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// adminToken is the ADMIN_API_TOKEN of the admin route tests
+const adminToken = "test-admin-token-0123456789abcdef"
+
+func TestBulkDeleteRequiresAdminToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		configured    string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "no token", configured: adminToken, wantStatus: fiber.StatusUnauthorized},
+		{name: "wrong token", configured: adminToken, authorization: "Bearer not-the-token", wantStatus: fiber.StatusForbidden},
+		{name: "admin API disabled", authorization: "Bearer " + adminToken, wantStatus: fiber.StatusForbidden},
+		// The handler itself rejects the empty ID list, so the request got past the token check
+		{name: "valid token", configured: adminToken, authorization: "Bearer " + adminToken, wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+			cfg := &config.Config{AdminAPIToken: tt.configured}
+			// The handlers are never reached with their dependencies unset, except to reject the body
+			registerAdminRoutes(app.Group("/api"), cfg, &handlers.AdminHandler{}, &handlers.PropertyHandler{}, &handlers.FontHandler{})
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/admin/properties", strings.NewReader(`{"ids": []}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAdminCORSAllowsBulkDelete(t *testing.T) {
+	t.Setenv("ADMIN_CORS_ALLOWED_ORIGINS", "https://admin.internal")
+	cfg := config.LoadConfig()
+	cfg.AdminAPIToken = adminToken
+
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	registerAdminRoutes(app.Group("/api"), cfg, &handlers.AdminHandler{}, &handlers.PropertyHandler{}, &handlers.FontHandler{})
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/admin/properties", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://admin.internal")
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, http.MethodDelete)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if allowed := resp.Header.Get(fiber.HeaderAccessControlAllowMethods); !strings.Contains(allowed, http.MethodDelete) {
+		t.Errorf("preflight allows %q, want DELETE", allowed)
+	}
+}
+
+func TestSwaggerServesOpenAPI3(t *testing.T) {
+	app := fiber.New()
+	if err := registerSwaggerRoutes(app); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var spec struct {
+		OpenAPI string                     `json:"openapi"`
+		Swagger string                     `json:"swagger"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		t.Fatalf("failed to decode the spec: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.0") || spec.Swagger != "" {
+		t.Errorf("spec version = openapi %q, swagger %q, want OpenAPI 3.0", spec.OpenAPI, spec.Swagger)
+	}
+	if _, ok := spec.Paths["/api/property"]; !ok {
+		t.Error("spec does not document /api/property")
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"property-brochure-backend/models"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireAdminToken only lets requests through that send the admin API token as
+// "Authorization: Bearer <token>". Without a configured token the admin API is disabled and every
+// request is refused, so an unset ADMIN_API_TOKEN never leaves the endpoints open.
+func RequireAdminToken(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Let CORS preflights through; they carry no credentials and the CORS policy answers them
+		if c.Method() == fiber.MethodOptions {
+			return c.Next()
+		}
+		if err := CheckAdminToken(token, c.Get(fiber.HeaderAuthorization)); err != nil {
+			if errors.Is(err, models.ErrUnauthorized) {
+				c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="admin"`)
+			}
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// CheckAdminToken checks the Authorization header of a request against the admin API token, for
+// operations outside the admin routes that need the same credentials
+func CheckAdminToken(token, authorization string) error {
+	if token == "" {
+		return models.NewAPIError(models.ErrCodeForbidden, "Admin API is disabled", errors.New("ADMIN_API_TOKEN is not configured"))
+	}
+
+	sent, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || sent == "" {
+		return models.NewAPIError(models.ErrCodeUnauthorized, "Admin token required", errors.New("send the admin API token as a Bearer token"))
+	}
+	if subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+		return models.NewAPIError(models.ErrCodeForbidden, "Invalid admin token", nil)
+	}
+	return nil
+}
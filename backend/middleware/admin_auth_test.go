@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequireAdminToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		method        string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "valid token", token: "s3cret-admin-token", authorization: "Bearer s3cret-admin-token", wantStatus: fiber.StatusOK},
+		{name: "missing token", token: "s3cret-admin-token", wantStatus: fiber.StatusUnauthorized},
+		{name: "not a bearer token", token: "s3cret-admin-token", authorization: "Basic YWRtaW46YWRtaW4=", wantStatus: fiber.StatusUnauthorized},
+		{name: "wrong token", token: "s3cret-admin-token", authorization: "Bearer guess", wantStatus: fiber.StatusForbidden},
+		{name: "token prefix", token: "s3cret-admin-token", authorization: "Bearer s3cret", wantStatus: fiber.StatusForbidden},
+		{name: "admin API disabled", authorization: "Bearer ", wantStatus: fiber.StatusForbidden},
+		{name: "admin API disabled with any token", authorization: "Bearer anything", wantStatus: fiber.StatusForbidden},
+		{name: "preflight", token: "s3cret-admin-token", method: http.MethodOptions, wantStatus: fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			app.Use("/api/admin", RequireAdminToken(tt.token))
+			app.All("/api/admin/properties", func(c *fiber.Ctx) error { return c.SendString("deleted") })
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodDelete
+			}
+			req := httptest.NewRequest(method, "/api/admin/properties", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"fmt"
+	"property-brochure-backend/models"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimits are the largest request bodies accepted per content type
+type BodyLimits struct {
+	Multipart int // multipart/form-data, such as listing submissions with photos
+	JSON      int // application/json, including GraphQL queries
+	Other     int // every other content type
+}
+
+// Max is the largest of the limits, which the server must accept before BodyLimit sees the request
+func (l BodyLimits) Max() int {
+	max := l.Multipart
+	if l.JSON > max {
+		max = l.JSON
+	}
+	if l.Other > max {
+		max = l.Other
+	}
+	return max
+}
+
+// BodyLimit rejects requests whose body is larger than the limit of their content type with 413
+func BodyLimit(limits BodyLimits) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+		limit, kind := limits.Other, "request"
+		switch {
+		case strings.HasPrefix(contentType, fiber.MIMEMultipartForm):
+			limit, kind = limits.Multipart, "multipart"
+		case strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+			limit, kind = limits.JSON, "JSON"
+		}
+
+		// Chunked requests have no Content-Length; their body is already read by now
+		size := c.Request().Header.ContentLength()
+		if size < 0 {
+			size = len(c.Body())
+		}
+		if size > limit {
+			return models.NewAPIError(models.ErrCodeFileTooLarge, "Request body too large",
+				fmt.Errorf("%s bodies must be at most %d bytes", kind, limit))
+		}
+		return c.Next()
+	}
+}
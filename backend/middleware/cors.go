@@ -1,17 +1,27 @@
 package middleware
 
 import (
+	"property-brochure-backend/config"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-func SetupCORS(frontendURL string) fiber.Handler {
-	return cors.New(cors.Config{
-		AllowOrigins:     frontendURL,
-		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization",
+// SetupCORS applies the given cross-origin policy. Requests for which skip returns true
+// are left to another policy; an empty origin list rejects every cross-origin request.
+func SetupCORS(policy config.CORSConfig, skip func(c *fiber.Ctx) bool) fiber.Handler {
+	cfg := cors.Config{
+		Next:             skip,
+		AllowOrigins:     strings.Join(policy.AllowedOrigins, ","),
+		AllowMethods:     strings.Join(policy.AllowedMethods, ","),
+		AllowHeaders:     strings.Join(policy.AllowedHeaders, ","),
 		AllowCredentials: true,
-		MaxAge:           86400,
-	})
+		MaxAge:           policy.MaxAge,
+	}
+	// Without origins the cors middleware would fall back to "*"
+	if len(policy.AllowedOrigins) == 0 {
+		cfg.AllowOriginsFunc = func(origin string) bool { return false }
+	}
+	return cors.New(cfg)
 }
-
@@ -1,13 +1,27 @@
 package middleware
 
 import (
+	"errors"
 	"log"
 	"property-brochure-backend/models"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// ErrorHandler writes handler errors as an ErrorResponse. An *models.APIError is reported with its
+// code's HTTP status; framework errors are classified by their status and anything else is internal.
 func ErrorHandler(c *fiber.Ctx, err error) error {
+	// Handlers log their own failures with context before returning an APIError
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) {
+		return c.Status(apiErr.HTTPStatus()).JSON(models.ErrorResponse{
+			Success:   false,
+			Message:   apiErr.Message,
+			Error:     apiErr.Detail(),
+			ErrorCode: apiErr.Code,
+		})
+	}
+
 	code := fiber.StatusInternalServerError
 	message := "Internal Server Error"
 
@@ -22,9 +36,9 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 
 	// Return JSON error response
 	return c.Status(code).JSON(models.ErrorResponse{
-		Success: false,
-		Message: message,
-		Error:   err.Error(),
+		Success:   false,
+		Message:   message,
+		Error:     err.Error(),
+		ErrorCode: models.ErrorCodeForStatus(code),
 	})
 }
-
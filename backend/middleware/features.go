@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"errors"
+	"property-brochure-backend/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireFeature answers 404 while enabled reports the feature switched off, so a feature flag can
+// disable routes without a redeployment
+func RequireFeature(name string, enabled func() bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !enabled() {
+			return models.NewAPIError(models.ErrCodeNotFound, name+" is disabled", errors.New("feature disabled: "+name))
+		}
+		return c.Next()
+	}
+}
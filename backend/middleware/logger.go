@@ -11,8 +11,14 @@ func Logger() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
 		
-		// Process request
+		// Process request; errors are written by the error handler now so the logged status is the one sent
 		err := c.Next()
+		if err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+			err = nil
+		}
 		
 		// Log request details
 		duration := time.Since(start)
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ErrorCode is the machine-readable classification of a failure, returned as ErrorResponse.ErrorCode
+type ErrorCode string
+
+const (
+	ErrCodeValidation    ErrorCode = "ERR_VALIDATION"
+	ErrCodeFileTooLarge  ErrorCode = "ERR_FILE_TOO_LARGE"
+	ErrCodeInvalidType   ErrorCode = "ERR_INVALID_TYPE"
+	ErrCodeS3Upload      ErrorCode = "ERR_S3_UPLOAD"
+	ErrCodeAIGeneration  ErrorCode = "ERR_AI_GENERATION"
+	ErrCodePDFGeneration ErrorCode = "ERR_PDF_GENERATION"
+	ErrCodeMongoInsert   ErrorCode = "ERR_MONGO_INSERT"
+	ErrCodeNotFound      ErrorCode = "ERR_NOT_FOUND"
+	ErrCodeConflict      ErrorCode = "ERR_CONFLICT"
+	ErrCodeRateLimited   ErrorCode = "ERR_RATE_LIMITED"
+	ErrCodeCircuitOpen   ErrorCode = "ERR_CIRCUIT_OPEN"
+
+	// User-supplied text rejected by content moderation
+	ErrCodeContentPolicy ErrorCode = "ERR_CONTENT_POLICY"
+
+	// Failures outside the categories above
+	ErrCodeUnauthorized ErrorCode = "ERR_UNAUTHORIZED"
+	ErrCodeForbidden    ErrorCode = "ERR_FORBIDDEN"
+	ErrCodeGone         ErrorCode = "ERR_GONE"
+	ErrCodeUnavailable  ErrorCode = "ERR_UNAVAILABLE"
+	ErrCodeInternal     ErrorCode = "ERR_INTERNAL"
+)
+
+// errorCodeStatus is the HTTP status each error code is reported with
+var errorCodeStatus = map[ErrorCode]int{
+	ErrCodeValidation:    http.StatusBadRequest,
+	ErrCodeFileTooLarge:  http.StatusRequestEntityTooLarge,
+	ErrCodeInvalidType:   http.StatusUnsupportedMediaType,
+	ErrCodeS3Upload:      http.StatusBadGateway,
+	ErrCodeAIGeneration:  http.StatusBadGateway,
+	ErrCodePDFGeneration: http.StatusInternalServerError,
+	ErrCodeMongoInsert:   http.StatusInternalServerError,
+	ErrCodeNotFound:      http.StatusNotFound,
+	ErrCodeConflict:      http.StatusConflict,
+	ErrCodeRateLimited:   http.StatusTooManyRequests,
+	ErrCodeCircuitOpen:   http.StatusServiceUnavailable,
+	ErrCodeContentPolicy: http.StatusUnprocessableEntity,
+	ErrCodeUnauthorized:  http.StatusUnauthorized,
+	ErrCodeForbidden:     http.StatusForbidden,
+	ErrCodeGone:          http.StatusGone,
+	ErrCodeUnavailable:   http.StatusServiceUnavailable,
+	ErrCodeInternal:      http.StatusInternalServerError,
+}
+
+// HTTPStatus returns the status the code is reported with; unknown codes are internal errors
+func (c ErrorCode) HTTPStatus() int {
+	if status, ok := errorCodeStatus[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ErrorCodeForStatus classifies a bare HTTP status, such as one from a framework error
+func ErrorCodeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrCodeValidation
+	case http.StatusRequestEntityTooLarge:
+		return ErrCodeFileTooLarge
+	case http.StatusUnsupportedMediaType:
+		return ErrCodeInvalidType
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return ErrCodeNotFound
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusGone:
+		return ErrCodeGone
+	case http.StatusTooManyRequests:
+		return ErrCodeRateLimited
+	case http.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	}
+	return ErrCodeInternal
+}
+
+// APIError is a failure reported to the client: handlers return it and the ErrorHandler middleware
+// writes it as an ErrorResponse with the code's HTTP status
+type APIError struct {
+	Code    ErrorCode
+	Message string
+	Err     error
+
+	// Status overrides the code's HTTP status when set
+	Status int
+}
+
+// Sentinels for errors.Is: an APIError matches the sentinel of its code
+var (
+	ErrValidation    = &APIError{Code: ErrCodeValidation}
+	ErrFileTooLarge  = &APIError{Code: ErrCodeFileTooLarge}
+	ErrInvalidType   = &APIError{Code: ErrCodeInvalidType}
+	ErrS3Upload      = &APIError{Code: ErrCodeS3Upload}
+	ErrAIGeneration  = &APIError{Code: ErrCodeAIGeneration}
+	ErrPDFGeneration = &APIError{Code: ErrCodePDFGeneration}
+	ErrMongoInsert   = &APIError{Code: ErrCodeMongoInsert}
+	ErrNotFound      = &APIError{Code: ErrCodeNotFound}
+	ErrConflict      = &APIError{Code: ErrCodeConflict}
+	ErrRateLimited   = &APIError{Code: ErrCodeRateLimited}
+	ErrCircuitOpen   = &APIError{Code: ErrCodeCircuitOpen}
+	ErrUnauthorized  = &APIError{Code: ErrCodeUnauthorized}
+)
+
+// NewAPIError creates an error with a client-facing message; err, when not nil, is the detail
+func NewAPIError(code ErrorCode, message string, err error) *APIError {
+	return &APIError{Code: code, Message: message, Err: err}
+}
+
+// WithStatus reports the error with status instead of the code's default
+func (e *APIError) WithStatus(status int) *APIError {
+	e.Status = status
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the sentinel of the error's code
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := target.(*APIError)
+	return ok && sentinel.Message == "" && sentinel.Err == nil && sentinel.Code == e.Code
+}
+
+// HTTPStatus is the status the error is reported with
+func (e *APIError) HTTPStatus() int {
+	if e.Status != 0 {
+		return e.Status
+	}
+	return e.Code.HTTPStatus()
+}
+
+// Detail is the text of the underlying error, empty when there is none
+func (e *APIError) Detail() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
+// Extensions exposes the code in GraphQL error responses
+func (e *APIError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
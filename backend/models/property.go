@@ -15,6 +15,8 @@ type Property struct {
 	Currency       string             `bson:"currency" json:"currency"`
 	Address        string             `bson:"address" json:"address"`
 	City           string             `bson:"city" json:"city"`
+	// CityLower is the lowercased city the search filters on, so it can use an index
+	CityLower      string             `bson:"cityLower,omitempty" json:"-"`
 	State          string             `bson:"state" json:"state"`
 	ZipCode        string             `bson:"zipCode" json:"zipCode"`
 	Amenities      []string           `bson:"amenities" json:"amenities"`
@@ -28,6 +30,145 @@ type Property struct {
 	PDFUrlArabic   string             `bson:"pdfUrlArabic" json:"pdfUrlArabic"`
 	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
+
+	// Optional floor plan (dimensions in metres)
+	FloorPlanURL    string  `bson:"floorPlanUrl,omitempty" json:"floorPlanUrl,omitempty"`
+	FloorPlanWidth  float64 `bson:"floorPlanWidth,omitempty" json:"floorPlanWidth,omitempty"`
+	FloorPlanHeight float64 `bson:"floorPlanHeight,omitempty" json:"floorPlanHeight,omitempty"`
+
+	// Optional virtual tour link (Matterport, YouTube, ...)
+	VirtualTourURL string `bson:"virtualTourUrl,omitempty" json:"virtualTourUrl,omitempty"`
+
+	// Optional video tour, linked from a play button on the brochure cover
+	VideoURL string `bson:"videoUrl,omitempty" json:"videoUrl,omitempty"`
+
+	// Optional coordinates of the property, shown on a static map on the cover
+	Latitude  *float64 `bson:"latitude,omitempty" json:"latitude,omitempty"`
+	Longitude *float64 `bson:"longitude,omitempty" json:"longitude,omitempty"`
+	// Location is the coordinates as a GeoJSON point, for the 2dsphere index
+	Location *GeoPoint `bson:"location,omitempty" json:"-"`
+
+	// Optional agency-branded closing that replaces the thank-you message on the contact page
+	WhiteLabelClosing *WhiteLabelClosing `bson:"whiteLabelClosing,omitempty" json:"whiteLabelClosing,omitempty"`
+
+	PropertyType    string           `bson:"propertyType,omitempty" json:"propertyType,omitempty"`
+	ComparableSales []ComparableSale `bson:"comparableSales,omitempty" json:"comparableSales,omitempty"`
+
+	// Optional virtual staging brief (e.g. "unfurnished living room, Scandinavian style") and the
+	// AI-written description of the staged space, shown on the English investment page
+	VirtualStaging            string `bson:"virtualStaging,omitempty" json:"virtualStaging,omitempty"`
+	VirtualStagingDescription string `bson:"virtualStagingDescription,omitempty" json:"virtualStagingDescription,omitempty"`
+
+	Mortgage *MortgageDetails `bson:"mortgage,omitempty" json:"mortgage,omitempty"`
+
+	// PrintMode adds a tear-off contact strip for physical print-outs
+	PrintMode bool `bson:"printMode,omitempty" json:"printMode,omitempty"`
+
+	// PrintReady renders the brochures for print shops: original images, no compression and
+	// CMYK-approximated brand colors
+	PrintReady bool `bson:"printReady,omitempty" json:"printReady,omitempty"`
+
+	// Optional side-by-side English/Arabic brochure
+	PDFUrlBilingual string `bson:"pdfUrlBilingual,omitempty" json:"pdfUrlBilingual,omitempty"`
+
+	// Landscape renders the English and Arabic brochures on A4 landscape pages
+	Landscape bool `bson:"landscape,omitempty" json:"landscape,omitempty"`
+
+	// PageSize is the paper size of the brochures: "A4" (default), "Letter" or "Legal"
+	PageSize string `bson:"pageSize,omitempty" json:"pageSize,omitempty"`
+
+	// PageOrder optionally overrides the brochure page sequence, e.g. ["cover","gallery","details","contact"]
+	PageOrder []string `bson:"pageOrder,omitempty" json:"pageOrder,omitempty"`
+
+	// Languages lists the brochure languages that were generated ("en", "ar", "ur")
+	Languages []string `bson:"languages,omitempty" json:"languages,omitempty"`
+
+	// SecondaryAgents are up to two further agents shown after the primary agent on the contact card
+	SecondaryAgents []AgentInfo `bson:"secondaryAgents,omitempty" json:"secondaryAgents,omitempty"`
+
+	// CoListingAgents are up to two co-agents the listing (and its commission) is split with, listed with
+	// the primary agent in a "Listed By" row above the contact card
+	CoListingAgents []AgentInfo `bson:"coListingAgents,omitempty" json:"coListingAgents,omitempty"`
+
+	// MarginMm overrides the default 15mm page margins on all sides (5-30)
+	MarginMm int `bson:"marginMm,omitempty" json:"marginMm,omitempty"`
+
+	// FontID selects an uploaded agency font for the brochures
+	FontID string `bson:"fontId,omitempty" json:"fontId,omitempty"`
+
+	// AgentID references the stored agent profile the listing was submitted with; AgentInfo keeps a
+	// snapshot of its contact details so reads need no lookup
+	AgentID string `bson:"agentId,omitempty" json:"agentId,omitempty"`
+
+	// ThemePreset names the theme preset (colors, fonts and cover layout) of the brochures
+	ThemePreset string `bson:"themePreset,omitempty" json:"themePreset,omitempty"`
+
+	// IsPasswordProtected marks confidential listings whose brochures are encrypted; the password is not stored
+	IsPasswordProtected bool `bson:"isPasswordProtected,omitempty" json:"isPasswordProtected,omitempty"`
+
+	// Enriched marks listings with fields auto-populated by enrichMissingFields (city, state, zip code, coordinates and apartment bedrooms)
+	Enriched bool `bson:"enriched,omitempty" json:"enriched,omitempty"`
+
+	// Optional Urdu copy and brochure, rendered right-to-left in Nastaliq
+	UrduContent LocalizedContent `bson:"urduContent,omitempty" json:"urduContent,omitempty"`
+	PDFUrlUrdu  string           `bson:"pdfUrlUrdu,omitempty" json:"pdfUrlUrdu,omitempty"`
+
+	Bedrooms int `bson:"bedrooms,omitempty" json:"bedrooms,omitempty"`
+
+	// Status is the listing's market status (PropertyStatusActive, ...); listings stored without one are active
+	Status string `bson:"status,omitempty" json:"status,omitempty"`
+
+	// PriceHistory lists the asking prices of the listing, newest first, capped at MaxPriceHistory entries
+	PriceHistory []PriceEntry `bson:"priceHistory,omitempty" json:"priceHistory,omitempty"`
+
+	// FavoritesCount is the number of users who bookmarked the listing
+	FavoritesCount int64 `bson:"favoritesCount,omitempty" json:"favoritesCount"`
+
+	// ImageWarnings collects the images that could not be loaded while the brochures were rendered and
+	// were replaced with placeholders; it is not stored
+	ImageWarnings []string `bson:"-" json:"-"`
+}
+
+// MaxPriceHistory caps the price entries kept per listing
+const MaxPriceHistory = 50
+
+// PriceEntry records an asking price and when it took effect
+type PriceEntry struct {
+	Price     float64   `bson:"price" json:"price"`
+	Currency  string    `bson:"currency" json:"currency"`
+	ChangedAt time.Time `bson:"changedAt" json:"changedAt"`
+}
+
+// GeoPoint is a GeoJSON point; its coordinates are longitude, then latitude
+type GeoPoint struct {
+	Type        string    `bson:"type" json:"type"`
+	Coordinates []float64 `bson:"coordinates" json:"coordinates"`
+}
+
+// NewGeoPoint returns the point at the given coordinates
+func NewGeoPoint(latitude, longitude float64) *GeoPoint {
+	return &GeoPoint{Type: "Point", Coordinates: []float64{longitude, latitude}}
+}
+
+// Listing statuses
+const (
+	PropertyStatusActive  = "active"
+	PropertyStatusPending = "pending"
+	PropertyStatusSold    = "sold"
+)
+
+// MortgageDetails holds the financing assumptions used for the monthly payment estimate
+type MortgageDetails struct {
+	DownPaymentPct float64 `bson:"downPaymentPct" json:"downPaymentPct"`
+	InterestRate   float64 `bson:"interestRate" json:"interestRate"`
+	TermYears      int     `bson:"termYears" json:"termYears"`
+}
+
+// ComparableSale is an AI-generated, illustrative comparable sold property (estimated market data, not a real transaction)
+type ComparableSale struct {
+	AddressStub  string  `bson:"addressStub" json:"addressStub"`
+	Price        float64 `bson:"price" json:"price"`
+	DaysOnMarket int     `bson:"daysOnMarket" json:"daysOnMarket"`
 }
 
 // AgentInfo represents the real estate agent's contact information
@@ -35,6 +176,30 @@ type AgentInfo struct {
 	Name  string `bson:"name" json:"name"`
 	Email string `bson:"email" json:"email"`
 	Phone string `bson:"phone" json:"phone"`
+
+	// Optional agency website, rendered as a link on the contact card
+	Website string `bson:"website,omitempty" json:"website,omitempty"`
+
+	// HMAC of the email, stored on listings when emails are encrypted so they can still be looked up by
+	// agent (see services.AgentEmailFilter)
+	EmailHash string `bson:"emailHash,omitempty" json:"-"`
+}
+
+// WhiteLabelClosing brands the contact page for white-label agencies: their logo, a headline and message,
+// and links to their website and social media profiles
+type WhiteLabelClosing struct {
+	LogoURL      string `bson:"logoUrl,omitempty" json:"logoUrl,omitempty"`
+	Headline     string `bson:"headline,omitempty" json:"headline,omitempty"`
+	Message      string `bson:"message,omitempty" json:"message,omitempty"`
+	InstagramURL string `bson:"instagramUrl,omitempty" json:"instagramUrl,omitempty"`
+	FacebookURL  string `bson:"facebookUrl,omitempty" json:"facebookUrl,omitempty"`
+	LinkedInURL  string `bson:"linkedInUrl,omitempty" json:"linkedInUrl,omitempty"`
+	Website      string `bson:"website,omitempty" json:"website,omitempty"`
+}
+
+// IsZero reports whether no closing field is set
+func (w WhiteLabelClosing) IsZero() bool {
+	return w == WhiteLabelClosing{}
 }
 
 // LocalizedContent represents fully localized content for a specific language
@@ -56,6 +221,9 @@ type LocalizedContent struct {
 	AdditionalSectionTitle    string   `bson:"additionalSectionTitle" json:"additionalSectionTitle"`
 	AdditionalSectionContent  string   `bson:"additionalSectionContent" json:"additionalSectionContent"`
 	ThankYouMessage           string   `bson:"thankYouMessage" json:"thankYouMessage"`
+
+	// AmenityIcon maps displayed amenity names to icon types ("wifi", "pool", "parking", "gym", "garden")
+	AmenityIcon map[string]string `bson:"amenityIcon,omitempty" json:"amenityIcon,omitempty"`
 }
 
 // AIContent represents AI-generated content for the property (Legacy compatibility)
@@ -76,9 +244,94 @@ type PropertyRequest struct {
 	State       string   `form:"state" validate:"required"`
 	ZipCode     string   `form:"zipCode" validate:"required"`
 	Amenities   []string `form:"amenities[]"`
+
+	// Optional coordinates (decimal degrees); both or neither
+	Latitude  *float64 `form:"latitude"`
+	Longitude *float64 `form:"longitude"`
+
 	AgentName   string   `form:"agentName" validate:"required"`
 	AgentEmail  string   `form:"agentEmail" validate:"required,email"`
 	AgentPhone  string   `form:"agentPhone" validate:"required"`
+
+	// Optional floor plan (dimensions in metres)
+	FloorPlanURL    string  `form:"floorPlanURL" validate:"omitempty,url"`
+	FloorPlanWidth  float64 `form:"floorPlanWidth"`
+	FloorPlanHeight float64 `form:"floorPlanHeight"`
+
+	// Optional virtual tour link (Matterport, YouTube, ...)
+	VirtualTourURL string `form:"virtualTourURL" validate:"omitempty,url"`
+
+	// Optional video tour, linked from a play button on the brochure cover
+	VideoURL string `form:"videoURL" validate:"omitempty,url"`
+
+	PropertyType string `form:"propertyType"`
+	IncludeComps bool   `form:"includeComps"`
+
+	// Optional virtual staging brief, e.g. "unfurnished living room"; the AI describes the space staged
+	VirtualStaging string `form:"virtualStaging"`
+
+	// Mortgage calculator assumptions (defaults: 20% down, 7% interest, 30 years)
+	Mortgage MortgageDetails
+
+	PrintMode  bool `form:"printMode"`
+	PrintReady bool `form:"printReady"`
+	Bilingual  bool `form:"bilingual"`
+	Landscape  bool `form:"landscape"`
+
+	PageSize  string   `form:"pageSize"`
+	PageOrder []string `form:"pageOrder"`
+
+	// Languages selects which brochures to generate: any of "en", "ar" and "ur" (default English and Arabic)
+	Languages []string `form:"languages[]"`
+
+	// Optional agent-written investment section, used instead of the AI copy
+	AdditionalSectionTitle     string `form:"additionalSectionTitle"`
+	AdditionalSectionContent   string `form:"additionalSectionContent"`
+	AdditionalSectionTitleAr   string `form:"additionalSectionTitleAr"`
+	AdditionalSectionContentAr string `form:"additionalSectionContentAr"`
+
+	// Optional agent-written closing message on the contact page (max 500 characters each)
+	ThankYouMessageEn string `form:"thankYouMessageEn"`
+	ThankYouMessageAr string `form:"thankYouMessageAr"`
+
+	// Optional white-label closing, from the closingLogoURL, closingHeadline, closingMessage,
+	// closingInstagramURL, closingFacebookURL, closingLinkedInURL and closingWebsite fields
+	WhiteLabelClosing WhiteLabelClosing
+
+	// Secondary agents, parsed from the parallel secondaryAgentName[]/Email[]/Phone[] fields
+	SecondaryAgents []AgentInfo
+
+	// Co-listing agents, parsed from the parallel coAgentName[]/Email[]/Phone[] fields
+	CoListingAgents []AgentInfo
+
+	AgentWebsite string `form:"agentWebsite" validate:"omitempty,url"`
+
+	// Optional stored agent profile (see /api/agents); its contact details replace the agent* fields
+	AgentID string `form:"agentId"`
+
+	// Optional page margin in millimetres (5-30), 0 keeps the default
+	MarginMm int `form:"marginMm"`
+
+	// Optional uploaded agency font (see POST /api/admin/fonts)
+	FontID string `form:"fontId"`
+
+	// Optional theme preset from the presets file, e.g. "luxury" or "modern"
+	ThemePreset string `form:"themePreset"`
+
+	// Optional password the brochures are encrypted with; never stored, logged or serialized
+	PDFPassword string `form:"pdfPassword" json:"-"`
+
+	// EnrichMissingFields geocodes the address to fill an empty city, state or zip code
+	EnrichMissingFields bool `form:"enrichMissingFields"`
+
+	// EnrichedFields lists the fields enrichment filled in
+	EnrichedFields []string `form:"-"`
+
+	// ValidateAddress normalizes the address before anything else and rejects unlikely addresses
+	ValidateAddress bool `form:"validateAddress"`
+
+	Bedrooms int    `form:"bedrooms"`
+	Status   string `form:"status"`
 }
 
 // PropertyResponse represents the API response
@@ -95,6 +348,175 @@ type PropertyResponse struct {
 	PDFViewUrlArabic   string `json:"pdfViewUrlArabic,omitempty"`
 	PDFDownloadUrlEnglish string `json:"pdfDownloadUrlEnglish,omitempty"`
 	PDFDownloadUrlArabic  string `json:"pdfDownloadUrlArabic,omitempty"`
+
+	PDFViewUrlBilingual     string `json:"pdfViewUrlBilingual,omitempty"`
+	PDFDownloadUrlBilingual string `json:"pdfDownloadUrlBilingual,omitempty"`
+
+	// Warnings lists non-fatal problems, such as gallery images that could not be loaded
+	Warnings []string `json:"warnings,omitempty"`
+
+	// PasswordProtected notes that the PDF URLs serve encrypted brochures that open only with pdfPassword
+	PasswordProtected bool `json:"passwordProtected,omitempty"`
+
+	PDFUrlUrdu         string `json:"pdfUrlUrdu,omitempty"`
+	PDFViewUrlUrdu     string `json:"pdfViewUrlUrdu,omitempty"`
+	PDFDownloadUrlUrdu string `json:"pdfDownloadUrlUrdu,omitempty"`
+
+	// ShareLinks are the listing's public landing page links, newest first
+	ShareLinks []ShareLink `json:"shareLinks,omitempty"`
+}
+
+// PropertyImagesResponse returns a listing's image URLs after new images were added
+type PropertyImagesResponse struct {
+	Success   bool     `json:"success"`
+	Message   string   `json:"message"`
+	ImageURLs []string `json:"imageUrls"`
+}
+
+// PropertyGalleryResponse returns one page of a listing's images with freshly signed URLs
+type PropertyGalleryResponse struct {
+	Success    bool     `json:"success"`
+	ImageURLs  []string `json:"imageUrls"`
+	Page       int      `json:"page"`
+	Limit      int      `json:"limit"`
+	TotalCount int      `json:"totalCount"`
+	TotalPages int      `json:"totalPages"`
+}
+
+// SetCoverImageRequest selects the image that becomes the cover
+type SetCoverImageRequest struct {
+	ImageIndex *int `json:"imageIndex"`
+}
+
+// PropertyDetailResponse returns a single stored listing
+type PropertyDetailResponse struct {
+	Success  bool      `json:"success"`
+	Property *Property `json:"property"`
+}
+
+// BulkDeleteRequest lists the listings to delete in one call
+type BulkDeleteRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// BulkDeleteResponse reports how many listings were deleted, how many of the requested IDs did not
+// exist and how many storage objects could not be removed
+type BulkDeleteResponse struct {
+	Success  bool  `json:"success"`
+	Deleted  int64 `json:"deleted"`
+	NotFound int   `json:"notFound"`
+	S3Errors int   `json:"s3Errors"`
+}
+
+// AddLanguageRequest selects the brochure language added to an existing listing: "en", "ar" or "ur"
+type AddLanguageRequest struct {
+	Language string `json:"language"`
+}
+
+// PostalAddress holds the address fields of a listing, as checked by POST /api/address/validate
+type PostalAddress struct {
+	Address string `json:"address"`
+	City    string `json:"city"`
+	State   string `json:"state"`
+	ZipCode string `json:"zipCode"`
+}
+
+// AddressValidationResponse returns the normalized address, a 0-1 confidence that it exists and the
+// fields normalization changed
+type AddressValidationResponse struct {
+	Success    bool          `json:"success"`
+	Normalized PostalAddress `json:"normalized"`
+	Confidence float64       `json:"confidence"`
+	Corrected  []string      `json:"corrected"`
+}
+
+// Font is an agency brand font uploaded for use in brochures
+type Font struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name       string             `bson:"name" json:"name"`
+	Language   string             `bson:"language" json:"language"` // "en" replaces the body font, "ar" the Arabic font
+	S3Key      string             `bson:"s3Key" json:"s3Key"`
+	UploadedAt time.Time          `bson:"uploadedAt" json:"uploadedAt"`
+}
+
+// FontResponse returns the ID of an uploaded font
+type FontResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	FontID  string `json:"fontId"`
+}
+
+// CompareRequest selects the two listings of a comparison PDF
+type CompareRequest struct {
+	PropertyIDs []string `json:"propertyIds"`
+}
+
+// CompareResponse returns the URLs of a generated comparison PDF
+type CompareResponse struct {
+	Success        bool   `json:"success"`
+	Message        string `json:"message"`
+	PDFViewUrl     string `json:"pdfViewUrl"`
+	PDFDownloadUrl string `json:"pdfDownloadUrl"`
+}
+
+// PropertyOfTheWeekID is the fixed _id of the single property of the week document
+const PropertyOfTheWeekID = "current"
+
+// PropertyOfTheWeek is the listing cross-sold on the contact page of every brochure
+type PropertyOfTheWeek struct {
+	ID         string             `bson:"_id" json:"-"`
+	PropertyID primitive.ObjectID `bson:"propertyId" json:"propertyId"`
+	Tagline    string             `bson:"tagline" json:"tagline"`
+	UpdatedAt  time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// PropertyOfTheWeekRequest selects the property of the week and its promotional tagline
+type PropertyOfTheWeekRequest struct {
+	PropertyID string `json:"propertyId"`
+	Tagline    string `json:"tagline"`
+}
+
+// PropertyOfTheWeekResponse returns the stored property of the week
+type PropertyOfTheWeekResponse struct {
+	Success           bool               `json:"success"`
+	Message           string             `json:"message"`
+	PropertyOfTheWeek *PropertyOfTheWeek `json:"propertyOfTheWeek"`
+}
+
+// Brochure job statuses, in the order a job moves through them
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+// PropertyJob tracks a property submitted in async mode while its brochures are generated
+type PropertyJob struct {
+	ID        string            `json:"id"`
+	Status    string            `json:"status"`
+	Result    *PropertyResponse `json:"result,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// JobResponse returns the state of an async brochure job
+type JobResponse struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message,omitempty"`
+	Job     *PropertyJob `json:"job"`
+}
+
+// DryRunResponse reports whether a submission would be accepted and what it would roughly cost,
+// without anything having been stored or generated
+type DryRunResponse struct {
+	Success             bool     `json:"success"`
+	ValidationPassed    bool     `json:"validationPassed"`
+	ValidationErrors    []string `json:"validationErrors,omitempty"`
+	EstimatedOpenAICost string   `json:"estimatedOpenAICost" example:"$0.0021"`
+	ImageCount          int      `json:"imageCount"`
+	EstimatedPDFSizeKB  int      `json:"estimatedPDFSizeKB"`
 }
 
 // ErrorResponse represents an error response
@@ -102,5 +524,216 @@ type ErrorResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+
+	// ErrorCode classifies the failure for clients, e.g. ERR_VALIDATION or ERR_NOT_FOUND
+	ErrorCode ErrorCode `json:"errorCode"`
 }
 
+
+// ImageHash maps the SHA-256 of uploaded image bytes to the stored object, for upload deduplication
+type ImageHash struct {
+	Hash      string    `bson:"hash" json:"hash"`
+	S3Key     string    `bson:"s3_key" json:"s3Key"`
+	URL       string    `bson:"url" json:"url"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// Audit event types recorded in the "events" collection
+const (
+	EventPropertyCreated = "property.created"
+	EventPDFGenerated    = "pdf.generated"
+	EventPDFRegenerated  = "pdf.regenerated"
+	EventPropertyDeleted = "property.deleted"
+)
+
+// Event is an audit log entry for a significant action on a property
+type Event struct {
+	ID         primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	EventType  string                 `bson:"eventType" json:"eventType"`
+	PropertyID primitive.ObjectID     `bson:"propertyId" json:"propertyId"`
+	AgentEmail string                 `bson:"agentEmail,omitempty" json:"agentEmail,omitempty"`
+	Timestamp  time.Time              `bson:"timestamp" json:"timestamp"`
+	Metadata   map[string]interface{} `bson:"metadata,omitempty" json:"metadata,omitempty"`
+}
+
+// EventsResponse returns audit log entries, newest first
+type EventsResponse struct {
+	Success bool    `json:"success"`
+	Count   int     `json:"count"`
+	Events  []Event `json:"events"`
+}
+
+// PropertySearchResponse returns one page of search results and the number of listings matching the filters
+type PropertySearchResponse struct {
+	Success    bool       `json:"success"`
+	Properties []Property `json:"properties"`
+	TotalCount int64      `json:"totalCount"`
+	Limit      int        `json:"limit"`
+	Offset     int        `json:"offset"`
+}
+
+// ShareLink is a public, expiring link to a listing's landing page
+type ShareLink struct {
+	Token      string             `bson:"token" json:"token"`
+	PropertyID primitive.ObjectID `bson:"propertyId" json:"propertyId"`
+	ExpiresAt  time.Time          `bson:"expiresAt" json:"expiresAt"`
+	ViewCount  int64              `bson:"viewCount" json:"viewCount"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// ShareLinkRequest optionally sets the lifetime of a new share link
+type ShareLinkRequest struct {
+	ExpiresInHours int `json:"expiresInHours"`
+}
+
+// SharedProperty is the public view of a listing served through a share link, without internal IDs
+type SharedProperty struct {
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	Price           float64          `json:"price"`
+	Currency        string           `json:"currency"`
+	Address         string           `json:"address"`
+	City            string           `json:"city"`
+	State           string           `json:"state"`
+	ZipCode         string           `json:"zipCode"`
+	PropertyType    string           `json:"propertyType,omitempty"`
+	Bedrooms        int              `json:"bedrooms,omitempty"`
+	Status          string           `json:"status,omitempty"`
+	Amenities       []string         `json:"amenities"`
+	ImageURLs       []string         `json:"imageUrls"`
+	AgentInfo       AgentInfo        `json:"agentInfo"`
+	SecondaryAgents []AgentInfo      `json:"secondaryAgents,omitempty"`
+	CoListingAgents []AgentInfo      `json:"coListingAgents,omitempty"`
+	EnglishContent  LocalizedContent `json:"englishContent"`
+	ArabicContent   LocalizedContent `json:"arabicContent"`
+	UrduContent     LocalizedContent `json:"urduContent,omitempty"`
+	FloorPlanURL    string           `json:"floorPlanUrl,omitempty"`
+	VirtualTourURL  string           `json:"virtualTourUrl,omitempty"`
+	VideoURL        string           `json:"videoUrl,omitempty"`
+	Mortgage        *MortgageDetails `json:"mortgage,omitempty"`
+	PDFUrlEnglish   string           `json:"pdfUrlEnglish,omitempty"`
+	PDFUrlArabic    string           `json:"pdfUrlArabic,omitempty"`
+	PDFUrlBilingual string           `json:"pdfUrlBilingual,omitempty"`
+	PDFUrlUrdu      string           `json:"pdfUrlUrdu,omitempty"`
+	CreatedAt       time.Time        `json:"createdAt"`
+}
+
+// NewSharedProperty copies the public fields of a listing
+func NewSharedProperty(property *Property) *SharedProperty {
+	status := property.Status
+	if status == "" {
+		status = PropertyStatusActive
+	}
+	return &SharedProperty{
+		Title:           property.Title,
+		Description:     property.Description,
+		Price:           property.Price,
+		Currency:        property.Currency,
+		Address:         property.Address,
+		City:            property.City,
+		State:           property.State,
+		ZipCode:         property.ZipCode,
+		PropertyType:    property.PropertyType,
+		Bedrooms:        property.Bedrooms,
+		Status:          status,
+		Amenities:       property.Amenities,
+		ImageURLs:       property.ImageURLs,
+		AgentInfo:       property.AgentInfo,
+		SecondaryAgents: property.SecondaryAgents,
+		CoListingAgents: property.CoListingAgents,
+		EnglishContent:  property.EnglishContent,
+		ArabicContent:   property.ArabicContent,
+		UrduContent:     property.UrduContent,
+		FloorPlanURL:    property.FloorPlanURL,
+		VirtualTourURL:  property.VirtualTourURL,
+		VideoURL:        property.VideoURL,
+		Mortgage:        property.Mortgage,
+		PDFUrlEnglish:   property.PDFUrlEnglish,
+		PDFUrlArabic:    property.PDFUrlArabic,
+		PDFUrlBilingual: property.PDFUrlBilingual,
+		PDFUrlUrdu:      property.PDFUrlUrdu,
+		CreatedAt:       property.CreatedAt,
+	}
+}
+
+// SharedPropertyResponse returns a listing opened through a share link
+type SharedPropertyResponse struct {
+	Success   bool            `json:"success"`
+	Property  *SharedProperty `json:"property"`
+	ViewCount int64           `json:"viewCount"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+}
+
+// PropertyUpdateRequest changes a listing's price, currency or status; omitted fields are kept
+type PropertyUpdateRequest struct {
+	Price    *float64 `json:"price"`
+	Currency *string  `json:"currency"`
+	Status   *string  `json:"status"`
+}
+
+// PriceHistoryResponse returns a listing's price history, newest first, for price trend charts
+type PriceHistoryResponse struct {
+	Success      bool         `json:"success"`
+	PropertyID   string       `json:"propertyId"`
+	PriceHistory []PriceEntry `json:"priceHistory"`
+}
+
+// Favorite is a listing bookmarked by a user
+type Favorite struct {
+	UserID     string             `bson:"userId" json:"userId"`
+	PropertyID primitive.ObjectID `bson:"propertyId" json:"propertyId"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// FavoriteResponse reports a bookmark change and the listing's new favorites count
+type FavoriteResponse struct {
+	Success        bool   `json:"success"`
+	Message        string `json:"message"`
+	FavoritesCount int64  `json:"favoritesCount"`
+}
+
+// FavoritesResponse returns the listings a user bookmarked, most recent first
+type FavoritesResponse struct {
+	Success    bool       `json:"success"`
+	Count      int        `json:"count"`
+	Properties []Property `json:"properties"`
+}
+
+// Agent is a stored agent profile that submissions reference with agentId instead of repeating the
+// agent's contact details
+type Agent struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name      string             `bson:"name" json:"name"`
+	Email     string             `bson:"email" json:"email"`
+	Phone     string             `bson:"phone" json:"phone"`
+	Website   string             `bson:"website,omitempty" json:"website,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Info returns the contact details copied onto the listings that reference the agent
+func (a *Agent) Info() AgentInfo {
+	return AgentInfo{Name: a.Name, Email: a.Email, Phone: a.Phone, Website: a.Website}
+}
+
+// AgentRequest creates or replaces an agent profile
+type AgentRequest struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	Website string `json:"website,omitempty"`
+}
+
+// AgentResponse returns a created, updated or requested agent profile
+type AgentResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	Agent   *Agent `json:"agent,omitempty"`
+}
+
+// AgentsResponse returns every agent profile, sorted by name
+type AgentsResponse struct {
+	Success bool    `json:"success"`
+	Count   int     `json:"count"`
+	Agents  []Agent `json:"agents"`
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"property-brochure-backend/models"
+	"strings"
+)
+
+// ErrAddressRequired is returned when there is no street address to validate
+var ErrAddressRequired = errors.New("address is required")
+
+// NormalizedAddress is an address with typos and formatting corrected, and a 0-1 confidence that it
+// exists as given
+type NormalizedAddress struct {
+	models.PostalAddress
+	Confidence float64 `json:"confidence"`
+
+	// Corrected lists the fields normalization changed
+	Corrected []string `json:"-"`
+}
+
+// AddressNormalizer corrects a postal address into its canonical components
+type AddressNormalizer interface {
+	NormalizeAddress(input models.PostalAddress) (*NormalizedAddress, error)
+}
+
+// AddressService validates the addresses agents type in against a normalizer, such as an AI model
+type AddressService struct {
+	normalizer AddressNormalizer
+}
+
+func NewAddressService(normalizer AddressNormalizer) *AddressService {
+	return &AddressService{normalizer: normalizer}
+}
+
+// ValidateAndNormalize returns the normalized form of the address. Components the normalizer leaves
+// empty keep their input value, and the confidence is clamped to 0-1.
+func (s *AddressService) ValidateAndNormalize(input models.PostalAddress) (*NormalizedAddress, error) {
+	input = models.PostalAddress{
+		Address: strings.TrimSpace(input.Address),
+		City:    strings.TrimSpace(input.City),
+		State:   strings.TrimSpace(input.State),
+		ZipCode: strings.TrimSpace(input.ZipCode),
+	}
+	if input.Address == "" {
+		return nil, ErrAddressRequired
+	}
+
+	normalized, err := s.normalizer.NormalizeAddress(input)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+		input string
+	}{
+		{"address", &normalized.Address, input.Address},
+		{"city", &normalized.City, input.City},
+		{"state", &normalized.State, input.State},
+		{"zipCode", &normalized.ZipCode, input.ZipCode},
+	} {
+		*field.value = strings.TrimSpace(*field.value)
+		if *field.value == "" {
+			*field.value = field.input
+		}
+		if *field.value != field.input {
+			normalized.Corrected = append(normalized.Corrected, field.name)
+		}
+	}
+
+	if math.IsNaN(normalized.Confidence) {
+		normalized.Confidence = 0
+	}
+	normalized.Confidence = math.Max(0, math.Min(1, normalized.Confidence))
+	return normalized, nil
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"property-brochure-backend/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrAgentNotFound is returned when an agent ID does not match a stored agent profile
+var ErrAgentNotFound = errors.New("agent not found")
+
+// AgentService stores agent profiles in the "agents" collection. Listings copy the profile's contact
+// details when they are created, so editing or deleting a profile leaves existing listings unchanged.
+type AgentService struct {
+	mongo MongoStorage
+
+	// encryption encrypts the stored emails and phone numbers; nil stores them in plain text
+	encryption *EncryptionService
+}
+
+func NewAgentService(mongo MongoStorage, encryption *EncryptionService) *AgentService {
+	return &AgentService{mongo: mongo, encryption: encryption}
+}
+
+// Create stores a new agent profile
+func (s *AgentService) Create(req models.AgentRequest) (*models.Agent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	info, err := s.encryptContact(req)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	stored := models.Agent{
+		Name:      req.Name,
+		Email:     info.Email,
+		Phone:     info.Phone,
+		Website:   req.Website,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	result, err := s.mongo.GetCollection("agents").InsertOne(ctx, stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save agent: %w", err)
+	}
+
+	agent := stored
+	agent.ID = result.InsertedID.(primitive.ObjectID)
+	agent.Email, agent.Phone = req.Email, req.Phone
+	return &agent, nil
+}
+
+// Get loads an agent profile by ID
+func (s *AgentService) Get(id string) (*models.Agent, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrAgentNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var agent models.Agent
+	if err := s.mongo.GetCollection("agents").FindOne(ctx, bson.M{"_id": objectID}).Decode(&agent); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrAgentNotFound
+		}
+		return nil, fmt.Errorf("failed to load agent: %w", err)
+	}
+	if err := s.decryptContact(&agent); err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+// List returns every agent profile, sorted by name
+func (s *AgentService) List() ([]models.Agent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := s.mongo.GetCollection("agents").Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	agents := []models.Agent{}
+	if err := cursor.All(ctx, &agents); err != nil {
+		return nil, fmt.Errorf("failed to decode agents: %w", err)
+	}
+	for i := range agents {
+		if err := s.decryptContact(&agents[i]); err != nil {
+			return nil, err
+		}
+	}
+	return agents, nil
+}
+
+// Update replaces the contact details of an agent profile
+func (s *AgentService) Update(id string, req models.AgentRequest) (*models.Agent, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrAgentNotFound
+	}
+
+	info, err := s.encryptContact(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var agent models.Agent
+	err = s.mongo.GetCollection("agents").FindOneAndUpdate(ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{
+			"name":      req.Name,
+			"email":     info.Email,
+			"phone":     info.Phone,
+			"website":   req.Website,
+			"updatedAt": time.Now(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&agent)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrAgentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update agent: %w", err)
+	}
+	agent.Email, agent.Phone = req.Email, req.Phone
+	return &agent, nil
+}
+
+// Delete removes an agent profile; listings that referenced it keep their copy of its details
+func (s *AgentService) Delete(id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrAgentNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.mongo.GetCollection("agents").DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete agent: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrAgentNotFound
+	}
+	return nil
+}
+
+// encryptContact returns the email and phone number of a profile as they are stored
+func (s *AgentService) encryptContact(req models.AgentRequest) (models.AgentInfo, error) {
+	info := models.AgentInfo{Email: req.Email, Phone: req.Phone}
+	if s.encryption == nil {
+		return info, nil
+	}
+	if err := s.encryption.EncryptAgent(&info); err != nil {
+		return info, fmt.Errorf("failed to encrypt agent: %w", err)
+	}
+	return info, nil
+}
+
+// decryptContact decrypts the email and phone number of a stored profile in place
+func (s *AgentService) decryptContact(agent *models.Agent) error {
+	if s.encryption == nil {
+		return nil
+	}
+	info := models.AgentInfo{Email: agent.Email, Phone: agent.Phone}
+	if err := s.encryption.DecryptAgent(&info); err != nil {
+		return fmt.Errorf("failed to decrypt agent %s: %w", agent.ID.Hex(), err)
+	}
+	agent.Email, agent.Phone = info.Email, info.Phone
+	return nil
+}
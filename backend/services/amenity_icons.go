@@ -0,0 +1,117 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Amenity icon types drawn next to amenities in the brochures; anything else gets the check mark
+const (
+	AmenityIconWifi    = "wifi"
+	AmenityIconPool    = "pool"
+	AmenityIconParking = "parking"
+	AmenityIconGym     = "gym"
+	AmenityIconGarden  = "garden"
+)
+
+// amenityIconKeywords maps lower-case keywords in English amenity names to icon types.
+// It is checked in order, so "parking" wins before any later, broader match.
+var amenityIconKeywords = []struct {
+	keyword  string
+	iconType string
+}{
+	{"wifi", AmenityIconWifi},
+	{"wi-fi", AmenityIconWifi},
+	{"internet", AmenityIconWifi},
+	{"pool", AmenityIconPool},
+	{"swim", AmenityIconPool},
+	{"parking", AmenityIconParking},
+	{"garage", AmenityIconParking},
+	{"carport", AmenityIconParking},
+	{"gym", AmenityIconGym},
+	{"fitness", AmenityIconGym},
+	{"garden", AmenityIconGarden},
+	{"yard", AmenityIconGarden},
+	{"lawn", AmenityIconGarden},
+	{"landscap", AmenityIconGarden},
+}
+
+// AmenityIconType returns the icon type for an English amenity name, or "" for the default check mark
+func AmenityIconType(name string) string {
+	lower := strings.ToLower(name)
+	for _, k := range amenityIconKeywords {
+		if strings.Contains(lower, k.keyword) {
+			return k.iconType
+		}
+	}
+	return ""
+}
+
+// AmenityIcons maps each displayed amenity to its icon type. Translated names are matched through the
+// English amenity at the same position in sources, since the keywords are English.
+func AmenityIcons(names, sources []string) map[string]string {
+	icons := map[string]string{}
+	for i, name := range names {
+		iconType := AmenityIconType(name)
+		if iconType == "" && i < len(sources) {
+			iconType = AmenityIconType(sources[i])
+		}
+		if iconType != "" {
+			icons[name] = iconType
+		}
+	}
+	if len(icons) == 0 {
+		return nil
+	}
+	return icons
+}
+
+// amenityIconFor looks the amenity up in the stored icon map, falling back to its keywords for older listings
+func amenityIconFor(icons map[string]string, amenity string) string {
+	if iconType, ok := icons[amenity]; ok {
+		return iconType
+	}
+	return AmenityIconType(amenity)
+}
+
+// drawAmenityIcon draws a small green vector icon about 6mm wide, starting at x and vertically centred on y
+func (s *PDFService) drawAmenityIcon(pdf *gofpdf.Fpdf, x, y float64, iconType string) {
+	pdf.SetDrawColor(46, 125, 50)
+	pdf.SetFillColor(46, 125, 50)
+	pdf.SetLineWidth(0.5)
+
+	switch iconType {
+	case AmenityIconWifi:
+		// Three signal arcs above a dot
+		for _, r := range []float64{1.2, 2.2, 3.2} {
+			pdf.Arc(x+3, y+1.8, r, r, 0, 45, 135, "D")
+		}
+		pdf.Circle(x+3, y+1.8, 0.45, "F")
+	case AmenityIconPool:
+		// Two rows of waves
+		for _, rowY := range []float64{y - 0.6, y + 1.4} {
+			for i := 0; i < 3; i++ {
+				pdf.Arc(x+1+float64(i)*2, rowY, 1, 0.7, 0, 0, 180, "D")
+			}
+		}
+	case AmenityIconParking:
+		// A "P" in a square sign
+		pdf.Rect(x+0.5, y-2.3, 5, 5, "D")
+		pdf.Line(x+2.2, y-1.3, x+2.2, y+1.7)
+		pdf.Arc(x+2.2, y-0.45, 1.5, 0.85, 0, -90, 90, "D")
+	case AmenityIconGym:
+		// Dumbbell: a bar between two weight plates
+		pdf.Line(x+1.5, y+0.2, x+4.5, y+0.2)
+		pdf.Rect(x+0.4, y-1.3, 1.1, 3, "F")
+		pdf.Rect(x+4.5, y-1.3, 1.1, 3, "F")
+	case AmenityIconGarden:
+		// Tree: round crown on a trunk
+		pdf.Line(x+3, y+0.5, x+3, y+2.5)
+		pdf.Circle(x+3, y-0.5, 1.8, "F")
+	default:
+		pdf.SetLineWidth(0.8)
+		pdf.Line(x, y, x+2.0, y+2.0)
+		pdf.Line(x+2.0, y+2.0, x+6.0, y-1.0)
+	}
+}
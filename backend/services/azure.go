@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/google/uuid"
+)
+
+type AzureBlobService struct {
+	client     *azblob.Client
+	credential *azblob.SharedKeyCredential
+	serviceURL string
+	container  string
+}
+
+func NewAzureBlobService(accountName, accountKey, container string) (*AzureBlobService, error) {
+	cred, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure credential: %w", err)
+	}
+
+	serviceURL := fmt.Sprintf("https://%s.blob.core.windows.net/", accountName)
+	client, err := azblob.NewClientWithSharedKeyCredential(serviceURL, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure Blob client: %w", err)
+	}
+
+	return &AzureBlobService{
+		client:     client,
+		credential: cred,
+		serviceURL: serviceURL,
+		container:  container,
+	}, nil
+}
+
+func (s *AzureBlobService) UploadFile(file multipart.File, header *multipart.FileHeader, folder string) (string, error) {
+	// Read file content
+	buffer, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Generate unique blob name
+	ext := filepath.Ext(header.Filename)
+	blobName := fmt.Sprintf("%s/%s-%s%s", folder, time.Now().Format("20060102"), uuid.New().String(), ext)
+
+	if err := s.upload(blobName, buffer, header.Header.Get("Content-Type")); err != nil {
+		return "", err
+	}
+
+	// Generate SAS URL (valid for 7 days)
+	url, err := s.GeneratePresignedURL(blobName, URLExpirationTime)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate SAS URL: %w", err)
+	}
+
+	return url, nil
+}
+
+func (s *AzureBlobService) UploadPDF(data []byte, filename string) (string, error) {
+	blobName := fmt.Sprintf("brochures/%s-%s.pdf", time.Now().Format("20060102"), uuid.New().String())
+
+	if err := s.upload(blobName, data, "application/pdf"); err != nil {
+		return "", err
+	}
+
+	url, err := s.generateSASURLWithDisposition(
+		blobName,
+		URLExpirationTime,
+		fmt.Sprintf("inline; filename=\"%s.pdf\"", filename),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate SAS URL: %w", err)
+	}
+
+	return url, nil
+}
+
+func (s *AzureBlobService) UploadPDFWithUrls(data []byte, filename string) (*PDFUrls, error) {
+	blobName := fmt.Sprintf("brochures/%s-%s.pdf", time.Now().Format("20060102"), uuid.New().String())
+
+	if err := s.upload(blobName, data, "application/pdf"); err != nil {
+		return nil, err
+	}
+
+	// SAS URL for viewing (inline - opens in browser)
+	viewUrl, err := s.generateSASURLWithDisposition(
+		blobName,
+		URLExpirationTime,
+		fmt.Sprintf("inline; filename=\"%s.pdf\"", filename),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate view URL: %w", err)
+	}
+
+	// SAS URL for downloading (attachment - forces download)
+	downloadUrl, err := s.generateSASURLWithDisposition(
+		blobName,
+		URLExpirationTime,
+		fmt.Sprintf("attachment; filename=\"%s.pdf\"", filename),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate download URL: %w", err)
+	}
+
+	return &PDFUrls{
+		ViewUrl:     viewUrl,
+		DownloadUrl: downloadUrl,
+	}, nil
+}
+
+// DeleteObjects removes the given blobs from the container
+func (s *AzureBlobService) DeleteObjects(keys []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, key := range keys {
+		if _, err := s.client.DeleteBlob(ctx, s.container, key, nil); err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return fmt.Errorf("failed to delete blob %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// PutObject stores raw bytes at a fixed blob name
+func (s *AzureBlobService) PutObject(key string, data []byte, contentType string) error {
+	return s.upload(key, data, contentType)
+}
+
+// GetObject downloads the blob stored at key
+func (s *AzureBlobService) GetObject(key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	resp, err := s.client.DownloadStream(ctx, s.container, key, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to download from Azure Blob Storage: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, nil
+}
+
+// KeyFromURL extracts the blob name from a SAS URL
+func (s *AzureBlobService) KeyFromURL(rawURL string) (string, error) {
+	return keyFromURLPath(rawURL, s.container+"/")
+}
+
+// GeneratePresignedURL creates a read-only SAS URL for a private blob
+func (s *AzureBlobService) GeneratePresignedURL(key string, expiration time.Duration) (string, error) {
+	return s.generateSASURLWithDisposition(key, expiration, "")
+}
+
+// GeneratePresignedURLWithDisposition creates a read-only SAS URL that overrides the Content-Disposition
+func (s *AzureBlobService) GeneratePresignedURLWithDisposition(key string, expiration time.Duration, disposition string) (string, error) {
+	return s.generateSASURLWithDisposition(key, expiration, disposition)
+}
+
+// PublicURL returns the unsigned blob URL, readable only when the container allows public access
+func (s *AzureBlobService) PublicURL(key string) string {
+	return fmt.Sprintf("%s%s/%s", s.serviceURL, s.container, key)
+}
+
+// upload stores a buffer as a block blob with the given content type
+func (s *AzureBlobService) upload(blobName string, data []byte, contentType string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	_, err := s.client.UploadBuffer(ctx, s.container, blobName, data, &azblob.UploadBufferOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: &contentType},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload to Azure Blob Storage: %w", err)
+	}
+	return nil
+}
+
+// generateSASURLWithDisposition signs a blob URL with optional Content-Disposition override
+func (s *AzureBlobService) generateSASURLWithDisposition(key string, expiration time.Duration, disposition string) (string, error) {
+	values := sas.BlobSignatureValues{
+		Protocol:           sas.ProtocolHTTPS,
+		StartTime:          time.Now().UTC().Add(-5 * time.Minute),
+		ExpiryTime:         time.Now().UTC().Add(expiration),
+		Permissions:        (&sas.BlobPermissions{Read: true}).String(),
+		ContainerName:      s.container,
+		BlobName:           key,
+		ContentDisposition: disposition,
+	}
+
+	params, err := values.SignWithSharedKey(s.credential)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign SAS URL: %w", err)
+	}
+
+	blobURL := s.serviceURL + url.PathEscape(s.container) + "/" + (&url.URL{Path: key}).EscapedPath()
+	return blobURL + "?" + params.Encode(), nil
+}
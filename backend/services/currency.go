@@ -0,0 +1,113 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Where a currency symbol goes relative to the amount
+const (
+	SymbolPrefix = "prefix"
+	SymbolSuffix = "suffix"
+)
+
+// CurrencyFormat describes how amounts in a currency are written
+type CurrencyFormat struct {
+	Symbol            string `json:"symbol"`
+	SymbolPosition    string `json:"symbolPosition"`
+	SymbolSpacing     bool   `json:"symbolSpacing"`
+	DecimalSeparator  string `json:"decimalSeparator"`
+	GroupingSeparator string `json:"groupingSeparator"`
+	DecimalPlaces     int    `json:"decimalPlaces"`
+}
+
+//go:embed currencies.json
+var currenciesJSON []byte
+
+// currencyFormats maps ISO 4217 codes to their format, loaded from the embedded currencies.json
+var currencyFormats = mustLoadCurrencyFormats(currenciesJSON)
+
+// mustLoadCurrencyFormats parses and checks the currency table; it is embedded, so errors are programming errors
+func mustLoadCurrencyFormats(data []byte) map[string]CurrencyFormat {
+	var formats map[string]CurrencyFormat
+	if err := json.Unmarshal(data, &formats); err != nil {
+		panic(fmt.Sprintf("invalid currencies.json: %v", err))
+	}
+	for code, f := range formats {
+		if f.SymbolPosition != SymbolPrefix && f.SymbolPosition != SymbolSuffix {
+			panic(fmt.Sprintf("invalid currencies.json: %s symbolPosition %q must be %s or %s", code, f.SymbolPosition, SymbolPrefix, SymbolSuffix))
+		}
+		if f.DecimalPlaces < 0 || f.DecimalPlaces > 4 {
+			panic(fmt.Sprintf("invalid currencies.json: %s decimalPlaces must be between 0 and 4, got %d", code, f.DecimalPlaces))
+		}
+	}
+	return formats
+}
+
+// CurrencyFormatFor returns the format of a currency code; unknown codes are written as the code
+// followed by the amount with comma grouping and no decimals
+func CurrencyFormatFor(currency string) CurrencyFormat {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if code == "" {
+		code = "USD"
+	}
+	if f, ok := currencyFormats[code]; ok {
+		return f
+	}
+	return CurrencyFormat{
+		Symbol:            code,
+		SymbolPosition:    SymbolPrefix,
+		SymbolSpacing:     true,
+		DecimalSeparator:  ".",
+		GroupingSeparator: ",",
+	}
+}
+
+// Format writes the amount with the currency's symbol, separators and decimal places
+func (f CurrencyFormat) Format(amount float64) string {
+	scale := math.Pow10(f.DecimalPlaces)
+	units := math.Round(math.Abs(amount) * scale)
+	digits := strconv.FormatFloat(units, 'f', 0, 64)
+	if pad := f.DecimalPlaces + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	whole, fraction := digits[:len(digits)-f.DecimalPlaces], digits[len(digits)-f.DecimalPlaces:]
+
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(f.GroupingSeparator)
+		}
+		b.WriteRune(digit)
+	}
+	if f.DecimalPlaces > 0 {
+		b.WriteString(f.DecimalSeparator + fraction)
+	}
+	number := b.String()
+
+	sep := ""
+	if f.SymbolSpacing {
+		sep = " "
+	}
+	text := f.Symbol + sep + number
+	if f.SymbolPosition == SymbolSuffix {
+		text = number + sep + f.Symbol
+	}
+	// Amounts that round to zero are not shown as negative
+	if amount < 0 && units != 0 {
+		text = "-" + text
+	}
+	return text
+}
+
+// coreText converts UTF-8 text, such as a price with a € or £ symbol, for a cell drawn with a core font,
+// which gofpdf encodes as cp1252
+func coreText(pdf *gofpdf.Fpdf, text string) string {
+	return pdf.UnicodeTranslatorFromDescriptor("")(text)
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+//go:embed default_amenities.json
+var defaultAmenitiesJSON []byte
+
+// DefaultAmenities maps property types to the amenities listed when an agent submits none,
+// loaded from the embedded default_amenities.json
+var DefaultAmenities = mustLoadDefaultAmenities(defaultAmenitiesJSON)
+
+// mustLoadDefaultAmenities parses the defaults table; it is embedded, so errors are programming errors
+func mustLoadDefaultAmenities(data []byte) map[string][]string {
+	var amenities map[string][]string
+	if err := json.Unmarshal(data, &amenities); err != nil {
+		panic(fmt.Sprintf("invalid default_amenities.json: %v", err))
+	}
+	for propertyType, list := range amenities {
+		if len(list) == 0 {
+			panic(fmt.Sprintf("invalid default_amenities.json: %s has no amenities", propertyType))
+		}
+	}
+	return amenities
+}
+
+// DefaultAmenitiesFor returns a copy of the default amenities of a property type, matched
+// case-insensitively, or nil for types without defaults
+func DefaultAmenitiesFor(propertyType string) []string {
+	propertyType = strings.TrimSpace(propertyType)
+	for name, amenities := range DefaultAmenities {
+		if strings.EqualFold(name, propertyType) {
+			return append([]string(nil), amenities...)
+		}
+	}
+	return nil
+}
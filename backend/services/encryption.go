@@ -0,0 +1,182 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"property-brochure-backend/models"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// encryptedPrefix marks encrypted values, so values stored before encryption was enabled still read
+const encryptedPrefix = "enc:v1:"
+
+// EncryptionService encrypts personal data stored in MongoDB with AES-256-GCM
+type EncryptionService struct {
+	aead cipher.AEAD
+
+	// indexKey keys the HMAC blind index of agent emails, kept separate from the encryption key
+	indexKey []byte
+}
+
+// NewEncryptionService derives the AES-256 key from the DATA_ENCRYPTION_KEY secret
+func NewEncryptionService(secret string) (*EncryptionService, error) {
+	if secret == "" {
+		return nil, errors.New("encryption key is empty")
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	indexKey := sha256.Sum256([]byte("blind-index:" + secret))
+	return &EncryptionService{aead: aead, indexKey: indexKey[:]}, nil
+}
+
+// BlindIndex returns a deterministic HMAC-SHA256 of an email, case-insensitive, so listings can be
+// looked up by an email that is stored encrypted under a random nonce. Empty values stay empty.
+func (s *EncryptionService) BlindIndex(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, s.indexKey)
+	mac.Write([]byte(email))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// AgentEmailFilter matches the listings of the agent with the given email. When emails are encrypted
+// (encryption is not nil) it matches the blind index, or the plain text email of listings stored before
+// encryption was enabled.
+func AgentEmailFilter(encryption *EncryptionService, email string) bson.M {
+	if encryption == nil {
+		return bson.M{"agentInfo.email": email}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"agentInfo.emailHash": encryption.BlindIndex(email)},
+		bson.M{"agentInfo.email": email},
+	}}
+}
+
+// Encrypt returns the base64 ciphertext of plaintext under a random nonce. Empty values stay empty.
+func (s *EncryptionService) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return plaintext, nil
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt. Values without the encrypted prefix, stored before encryption was enabled,
+// are returned unchanged.
+func (s *EncryptionService) Decrypt(ciphertext string) (string, error) {
+	encoded, ok := strings.CutPrefix(ciphertext, encryptedPrefix)
+	if !ok {
+		return ciphertext, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+	if len(sealed) < s.aead.NonceSize() {
+		return "", errors.New("ciphertext is too short")
+	}
+	nonce, sealed := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// EncryptAgent encrypts the email and phone number of an agent
+func (s *EncryptionService) EncryptAgent(agent *models.AgentInfo) error {
+	return s.transformAgent(agent, s.Encrypt)
+}
+
+// DecryptAgent decrypts the email and phone number of an agent
+func (s *EncryptionService) DecryptAgent(agent *models.AgentInfo) error {
+	return s.transformAgent(agent, s.Decrypt)
+}
+
+// EncryptProperty returns a copy of the listing with the email and phone number of every agent
+// encrypted and the blind index of the primary agent's email set, leaving the listing itself readable
+// for the rest of the request
+func (s *EncryptionService) EncryptProperty(property *models.Property) (*models.Property, error) {
+	stored := *property
+	stored.AgentInfo.EmailHash = s.BlindIndex(property.AgentInfo.Email)
+	if err := s.EncryptAgent(&stored.AgentInfo); err != nil {
+		return nil, err
+	}
+	var err error
+	if stored.SecondaryAgents, err = s.encryptAgents(property.SecondaryAgents); err != nil {
+		return nil, err
+	}
+	if stored.CoListingAgents, err = s.encryptAgents(property.CoListingAgents); err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// encryptAgents returns an encrypted copy of a list of agents
+func (s *EncryptionService) encryptAgents(agents []models.AgentInfo) ([]models.AgentInfo, error) {
+	if agents == nil {
+		return nil, nil
+	}
+	encrypted := append([]models.AgentInfo{}, agents...)
+	for i := range encrypted {
+		if err := s.EncryptAgent(&encrypted[i]); err != nil {
+			return nil, err
+		}
+	}
+	return encrypted, nil
+}
+
+// DecryptProperty decrypts the email and phone number of every agent of a stored listing in place
+func (s *EncryptionService) DecryptProperty(property *models.Property) error {
+	if err := s.DecryptAgent(&property.AgentInfo); err != nil {
+		return err
+	}
+	for i := range property.SecondaryAgents {
+		if err := s.DecryptAgent(&property.SecondaryAgents[i]); err != nil {
+			return err
+		}
+	}
+	for i := range property.CoListingAgents {
+		if err := s.DecryptAgent(&property.CoListingAgents[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transformAgent applies an encryption or decryption to the email and phone number of an agent
+func (s *EncryptionService) transformAgent(agent *models.AgentInfo, transform func(string) (string, error)) error {
+	email, err := transform(agent.Email)
+	if err != nil {
+		return fmt.Errorf("agent email: %w", err)
+	}
+	phone, err := transform(agent.Phone)
+	if err != nil {
+		return fmt.Errorf("agent phone: %w", err)
+	}
+	agent.Email, agent.Phone = email, phone
+	return nil
+}
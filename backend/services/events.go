@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MaxEventsPerQuery caps the audit log entries one query returns
+const MaxEventsPerQuery = 1000
+
+// eventInsertTimeout bounds the background insert of a single event
+const eventInsertTimeout = 5 * time.Second
+
+// EventFilter narrows an audit log query; zero fields do not filter
+type EventFilter struct {
+	PropertyID primitive.ObjectID
+	From       time.Time
+	To         time.Time
+}
+
+// EventService records the audit trail in the "events" collection
+type EventService struct {
+	mongo MongoStorage
+
+	// encryption encrypts the stored agent emails; nil stores them in plain text
+	encryption *EncryptionService
+
+	// pending tracks inserts still running in the background
+	pending sync.WaitGroup
+}
+
+func NewEventService(mongo MongoStorage, encryption *EncryptionService) *EventService {
+	return &EventService{mongo: mongo, encryption: encryption}
+}
+
+// Emit records an event in the background so the action it describes is not slowed down by the audit log.
+// A missing timestamp is set to now; failed inserts are logged. Emit on a nil service does nothing.
+func (s *EventService) Emit(event models.Event) {
+	if s == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	if s.encryption != nil {
+		email, err := s.encryption.Encrypt(event.AgentEmail)
+		if err != nil {
+			// Record the event without the agent rather than store the email in plain text
+			log.Printf("Error encrypting agent email of %s event for property %s: %v", event.EventType, event.PropertyID.Hex(), err)
+		}
+		event.AgentEmail = email
+	}
+
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), eventInsertTimeout)
+		defer cancel()
+		if _, err := s.mongo.GetCollection("events").InsertOne(ctx, event); err != nil {
+			log.Printf("Error recording %s event for property %s: %v", event.EventType, event.PropertyID.Hex(), err)
+		}
+	}()
+}
+
+// Shutdown waits until the events emitted so far are stored, or ctx is done
+func (s *EventService) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Events returns the events matching the filter, newest first, up to MaxEventsPerQuery
+func (s *EventService) Events(filter EventFilter) ([]models.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if !filter.PropertyID.IsZero() {
+		query["propertyId"] = filter.PropertyID
+	}
+	timestamp := bson.M{}
+	if !filter.From.IsZero() {
+		timestamp["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		timestamp["$lte"] = filter.To
+	}
+	if len(timestamp) > 0 {
+		query["timestamp"] = timestamp
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetLimit(MaxEventsPerQuery)
+	cursor, err := s.mongo.GetCollection("events").Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+	if s.encryption != nil {
+		for i := range events {
+			email, err := s.encryption.Decrypt(events[i].AgentEmail)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt event %s: %w", events[i].ID.Hex(), err)
+			}
+			events[i].AgentEmail = email
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"property-brochure-backend/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FavoriteService stores the listings users bookmarked in the "favorites" collection and keeps the
+// favoritesCount of the listings in step
+type FavoriteService struct {
+	mongo MongoStorage
+}
+
+func NewFavoriteService(mongo MongoStorage) *FavoriteService {
+	return &FavoriteService{mongo: mongo}
+}
+
+// Add bookmarks the listing for the user; added is false when it already was. The caller moves the
+// listing's favoritesCount with AdjustCount.
+func (s *FavoriteService) Add(userID string, propertyID primitive.ObjectID) (added bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	favorite := models.Favorite{UserID: userID, PropertyID: propertyID, CreatedAt: time.Now()}
+	if _, err := s.mongo.GetCollection("favorites").InsertOne(ctx, favorite); err != nil {
+		// The unique userId/propertyId index rejects repeats
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save favorite: %w", err)
+	}
+	return true, nil
+}
+
+// Remove deletes the user's bookmark of the listing; removed is false when there was none. The caller
+// moves the listing's favoritesCount with AdjustCount.
+func (s *FavoriteService) Remove(userID string, propertyID primitive.ObjectID) (removed bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.mongo.GetCollection("favorites").DeleteOne(ctx, bson.M{"userId": userID, "propertyId": propertyID})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete favorite: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+	return true, nil
+}
+
+// PropertyIDs returns the listings the user bookmarked, most recent first
+func (s *FavoriteService) PropertyIDs(userID string) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := s.mongo.GetCollection("favorites").Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query favorites: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var favorites []models.Favorite
+	if err := cursor.All(ctx, &favorites); err != nil {
+		return nil, fmt.Errorf("failed to decode favorites: %w", err)
+	}
+	ids := make([]primitive.ObjectID, len(favorites))
+	for i, favorite := range favorites {
+		ids[i] = favorite.PropertyID
+	}
+	return ids, nil
+}
+
+// DeleteForProperties removes every bookmark of deleted listings
+func (s *FavoriteService) DeleteForProperties(ctx context.Context, propertyIDs ...primitive.ObjectID) error {
+	if _, err := s.mongo.GetCollection("favorites").DeleteMany(ctx, bson.M{"propertyId": bson.M{"$in": propertyIDs}}); err != nil {
+		return fmt.Errorf("failed to delete favorites: %w", err)
+	}
+	return nil
+}
+
+// AdjustCount moves the listing's favoritesCount by delta and returns the new count
+func (s *FavoriteService) AdjustCount(propertyID primitive.ObjectID, delta int) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var updated struct {
+		FavoritesCount int64 `bson:"favoritesCount"`
+	}
+	err := s.mongo.GetCollection("properties").FindOneAndUpdate(ctx,
+		bson.M{"_id": propertyID},
+		bson.M{"$inc": bson.M{"favoritesCount": delta}},
+		options.FindOneAndUpdate().
+			SetReturnDocument(options.After).
+			SetProjection(bson.M{"favoritesCount": 1}),
+	).Decode(&updated)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update favorites count: %w", err)
+	}
+	return updated.FavoritesCount, nil
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrFeaturedListingProtected is returned when a password-protected listing is chosen as property of the week
+var ErrFeaturedListingProtected = errors.New("password-protected listings cannot be featured")
+
+// FeaturedListing is the property of the week as drawn in the contact page inset
+type FeaturedListing struct {
+	PropertyID primitive.ObjectID
+	Title      string
+	TitleAr    string
+	Tagline    string
+	ImageURL   string
+	Price      float64
+	Currency   string
+}
+
+// FeaturedListingProvider supplies the listing cross-sold in brochures; nil means none is set
+type FeaturedListingProvider interface {
+	FeaturedListing() (*FeaturedListing, error)
+}
+
+// FeaturedListingService stores the property of the week as a single document in the "propertyOfTheWeek" collection
+type FeaturedListingService struct {
+	mongo MongoStorage
+}
+
+var _ FeaturedListingProvider = (*FeaturedListingService)(nil)
+
+func NewFeaturedListingService(mongo MongoStorage) *FeaturedListingService {
+	return &FeaturedListingService{mongo: mongo}
+}
+
+// SetPropertyOfTheWeek features an existing listing with a promotional tagline, replacing the previous one.
+// It returns mongo.ErrNoDocuments when the listing does not exist.
+func (s *FeaturedListingService) SetPropertyOfTheWeek(propertyID primitive.ObjectID, tagline string) (*models.PropertyOfTheWeek, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var property models.Property
+	if err := s.mongo.GetCollection("properties").FindOne(ctx, bson.M{"_id": propertyID}).Decode(&property); err != nil {
+		return nil, err
+	}
+	if property.IsPasswordProtected {
+		return nil, ErrFeaturedListingProtected
+	}
+
+	featured := &models.PropertyOfTheWeek{
+		ID:         models.PropertyOfTheWeekID,
+		PropertyID: propertyID,
+		Tagline:    tagline,
+		UpdatedAt:  time.Now(),
+	}
+	_, err := s.mongo.GetCollection("propertyOfTheWeek").ReplaceOne(ctx,
+		bson.M{"_id": models.PropertyOfTheWeekID}, featured, options.Replace().SetUpsert(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save property of the week: %w", err)
+	}
+	return featured, nil
+}
+
+// FeaturedListing loads the property of the week, or nil when none is set or its listing was deleted
+func (s *FeaturedListingService) FeaturedListing() (*FeaturedListing, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var featured models.PropertyOfTheWeek
+	err := s.mongo.GetCollection("propertyOfTheWeek").FindOne(ctx, bson.M{"_id": models.PropertyOfTheWeekID}).Decode(&featured)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load property of the week: %w", err)
+	}
+
+	var property models.Property
+	err = s.mongo.GetCollection("properties").FindOne(ctx, bson.M{"_id": featured.PropertyID}).Decode(&property)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load featured property %s: %w", featured.PropertyID.Hex(), err)
+	}
+	// The listing may have been protected after it was featured
+	if property.IsPasswordProtected {
+		return nil, nil
+	}
+
+	listing := &FeaturedListing{
+		PropertyID: property.ID,
+		Title:      property.Title,
+		TitleAr:    property.ArabicContent.Title,
+		Tagline:    featured.Tagline,
+		Price:      property.Price,
+		Currency:   property.Currency,
+	}
+	if property.EnglishContent.Title != "" {
+		listing.Title = property.EnglishContent.Title
+	}
+	if len(property.ImageURLs) > 0 {
+		listing.ImageURL = property.ImageURLs[0]
+	}
+	return listing, nil
+}
+
+// WithFeaturedListing adds an inset promoting the property of the week to brochure contact pages
+func WithFeaturedListing(provider FeaturedListingProvider) PDFOption {
+	return func(s *PDFService) { s.featured = provider }
+}
+
+// featuredInsetHeight is the height of the property of the week box on the contact page
+const featuredInsetHeight = 30.0
+
+// addFeaturedListingInset draws the property of the week above the bottom decoration of the contact page.
+// It is skipped when none is set, when it is the listing itself, or when the page has no room left.
+func (s *PDFService) addFeaturedListingInset(pdf *gofpdf.Fpdf, property *models.Property, useArabic bool) {
+	if s.featured == nil {
+		return
+	}
+	listing, err := s.featured.FeaturedListing()
+	if err != nil {
+		log.Printf("Error loading featured listing: %v", err)
+		return
+	}
+	if listing == nil || listing.PropertyID == property.ID {
+		return
+	}
+
+	margins := pageMargins(pdf)
+	_, pageHeight, contentWidth := pageSize(pdf)
+	// Clear of the bottom diamond, which sits 25mm above the page bottom
+	boxY := pageHeight - 33 - featuredInsetHeight
+	if pdf.GetY()+5 > boxY {
+		log.Printf("Skipping featured listing inset, no room left on the contact page")
+		return
+	}
+
+	pdf.SetFillColor(255, 255, 255)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.4)
+	pdf.Rect(margins.Left, boxY, contentWidth, featuredInsetHeight, "FD")
+
+	// Thumbnail on the left, text beside it
+	thumbW, thumbH := 36.0, featuredInsetHeight-6
+	textX := margins.Left + 4
+	if listing.ImageURL != "" {
+		if err := s.addCroppedImageFromURL(pdf, listing.ImageURL, margins.Left+3, boxY+3, thumbW, thumbH); err != nil {
+			log.Printf("Error adding featured listing thumbnail: %v", err)
+		} else {
+			textX = margins.Left + 3 + thumbW + 5
+		}
+	}
+	textW := margins.Left + contentWidth - 4 - textX
+
+	label, title, align := "PROPERTY OF THE WEEK", listing.Title, "L"
+	arabic := useArabic && s.hasArabicFont
+	if arabic {
+		label, align = s.rtl("عقار الأسبوع"), "R"
+		if listing.TitleAr != "" && !s.urdu {
+			title = listing.TitleAr
+		}
+	}
+	setFont := func(style string, size float64) {
+		if arabic {
+			pdf.SetFont(s.arabicFontName, "", size)
+		} else {
+			pdf.SetFont(s.coreFont(style), style, size)
+		}
+	}
+
+	setFont("B", 8)
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	pdf.SetXY(textX, boxY+3)
+	pdf.CellFormat(textW, 4, label, "", 0, align, false, 0, "")
+
+	setFont("B", 11)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	pdf.SetXY(textX, boxY+8)
+	pdf.CellFormat(textW, 6, truncateText(s.fixMojibakeLatin1ToUTF8(title), 55), "", 0, align, false, 0, "")
+
+	if listing.Tagline != "" {
+		setFont("I", 9)
+		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+		pdf.SetXY(textX, boxY+14.5)
+		pdf.CellFormat(textW, 5, truncateText(listing.Tagline, 75), "", 0, align, false, 0, "")
+	}
+
+	if listing.Price > 0 {
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
+		pdf.SetTextColor(s.theme.AccentColor.RGB())
+		pdf.SetXY(textX, boxY+featuredInsetHeight-9)
+		pdf.CellFormat(textW, 6, coreText(pdf, s.formatPrice(listing.Price, listing.Currency)), "", 0, align, false, 0, "")
+	}
+}
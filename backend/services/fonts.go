@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"property-brochure-backend/models"
+	"time"
+
+	"github.com/google/uuid"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/jung-kurt/gofpdf"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MaxFontSize is the largest font file accepted for upload
+const MaxFontSize = 2 << 20
+
+// fontCacheSize is the number of downloaded fonts kept in memory, at most 32 x MaxFontSize
+const fontCacheSize = 32
+
+// ErrFontNotFound is returned when a font ID does not match an uploaded font
+var ErrFontNotFound = errors.New("font not found")
+
+// CustomFont is an uploaded font replacing the body ("en") or Arabic ("ar") font of a brochure
+type CustomFont struct {
+	Language string
+	Data     []byte
+}
+
+// FontLoader provides uploaded fonts to the PDF service by ID
+type FontLoader interface {
+	LoadFont(id string) (*CustomFont, error)
+}
+
+// FontService stores agency brand fonts in object storage, with their metadata in the "fonts" collection
+type FontService struct {
+	mongo   MongoStorage
+	storage StorageService
+
+	// Uploaded fonts never change, so the bytes of the most recently used ones are cached after the
+	// first download
+	cache *lru.Cache[string, *CustomFont]
+}
+
+var _ FontLoader = (*FontService)(nil)
+
+func NewFontService(mongo MongoStorage, storage StorageService) *FontService {
+	// lru.New only fails for a non-positive size
+	cache, _ := lru.New[string, *CustomFont](fontCacheSize)
+	return &FontService{
+		mongo:   mongo,
+		storage: storage,
+		cache:   cache,
+	}
+}
+
+// ValidateFont checks that data is a TrueType-outline font the PDF renderer can embed.
+// CFF-based OpenType fonts ("OTTO") are rejected because gofpdf only reads glyf outlines.
+func ValidateFont(data []byte) error {
+	if len(data) < 12 {
+		return errors.New("file is too small to be a font")
+	}
+	switch string(data[:4]) {
+	case "\x00\x01\x00\x00", "true":
+	case "OTTO":
+		return errors.New("OpenType fonts with CFF outlines are not supported, use a TrueType-flavoured TTF or OTF")
+	default:
+		return errors.New("file is not a TrueType or OpenType font")
+	}
+	numTables := int(binary.BigEndian.Uint16(data[4:6]))
+	if numTables == 0 || 12+16*numTables > len(data) {
+		return errors.New("font table directory is invalid")
+	}
+	return tryRegisterFont(data)
+}
+
+// tryRegisterFont parses the font the way brochure generation will, recovering from parser panics
+func tryRegisterFont(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("font could not be parsed: %v", r)
+		}
+	}()
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes("Check", "", data)
+	if pdf.Err() {
+		return fmt.Errorf("font could not be parsed: %w", pdf.Error())
+	}
+	return nil
+}
+
+// Upload stores a font file that passed ValidateFont, returning its metadata
+func (s *FontService) Upload(name, language, ext string, data []byte) (*models.Font, error) {
+	key := fmt.Sprintf("fonts/%s%s", uuid.New().String(), ext)
+	if err := s.storage.PutObject(key, data, "font/"+ext[1:]); err != nil {
+		return nil, fmt.Errorf("failed to upload font: %w", err)
+	}
+
+	font := &models.Font{
+		ID:         primitive.NewObjectID(),
+		Name:       name,
+		Language:   language,
+		S3Key:      key,
+		UploadedAt: time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := s.mongo.GetCollection("fonts").InsertOne(ctx, font); err != nil {
+		if delErr := s.storage.DeleteObjects([]string{key}); delErr != nil {
+			log.Printf("Error deleting font %s after failed save: %v", key, delErr)
+		}
+		return nil, fmt.Errorf("failed to save font: %w", err)
+	}
+	return font, nil
+}
+
+// LoadFont returns the font's language and bytes, downloading them on first use
+func (s *FontService) LoadFont(id string) (*CustomFont, error) {
+	if cached, ok := s.cache.Get(id); ok {
+		return cached, nil
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrFontNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var font models.Font
+	if err := s.mongo.GetCollection("fonts").FindOne(ctx, bson.M{"_id": objectID}).Decode(&font); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrFontNotFound
+		}
+		return nil, fmt.Errorf("failed to load font: %w", err)
+	}
+
+	data, err := s.storage.GetObject(font.S3Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download font %s: %w", font.S3Key, err)
+	}
+
+	loaded := &CustomFont{Language: font.Language, Data: data}
+	s.cache.Add(id, loaded)
+	return loaded, nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAddressNotFound is returned when the geocoder has no match for an address
+var ErrAddressNotFound = errors.New("address not found")
+
+// GeocodedAddress holds the address components a geocoder resolved; components it could not find are empty
+type GeocodedAddress struct {
+	City    string
+	State   string
+	ZipCode string
+
+	// Coordinates of the match; nil when the geocoder did not return them
+	Latitude  *float64
+	Longitude *float64
+}
+
+// Geocoder resolves a free-form address into its components
+type Geocoder interface {
+	Geocode(address string) (*GeocodedAddress, error)
+}
+
+// nominatimMinInterval spaces requests to respect the public Nominatim usage policy of one request per second
+const nominatimMinInterval = time.Second
+
+// NominatimGeocoder looks addresses up with an OpenStreetMap Nominatim server
+type NominatimGeocoder struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+
+	// Requests are serialized and spaced by nominatimMinInterval
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+var _ Geocoder = (*NominatimGeocoder)(nil)
+
+// NewNominatimGeocoder creates a geocoder for the Nominatim server at baseURL. Nominatim requires a
+// User-Agent identifying the application.
+func NewNominatimGeocoder(baseURL, userAgent string) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		userAgent: userAgent,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// nominatimResult is the part of a /search result used here
+type nominatimResult struct {
+	Lat     string `json:"lat"`
+	Lon     string `json:"lon"`
+	Address struct {
+		City         string `json:"city"`
+		Town         string `json:"town"`
+		Village      string `json:"village"`
+		Municipality string `json:"municipality"`
+		State        string `json:"state"`
+		Region       string `json:"region"`
+		Postcode     string `json:"postcode"`
+	} `json:"address"`
+}
+
+// Geocode returns the city, state and postcode of the best match for address
+func (g *NominatimGeocoder) Geocode(address string) (*GeocodedAddress, error) {
+	query := url.Values{
+		"q":              {address},
+		"format":         {"jsonv2"},
+		"addressdetails": {"1"},
+		"limit":          {"1"},
+	}
+	req, err := http.NewRequest(http.MethodGet, g.baseURL+"/search?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build geocoding request: %w", err)
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	g.mu.Lock()
+	if wait := nominatimMinInterval - time.Since(g.lastRequest); wait > 0 {
+		time.Sleep(wait)
+	}
+	resp, err := g.client.Do(req)
+	g.lastRequest = time.Now()
+	g.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("geocoding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geocoding request failed with status %d", resp.StatusCode)
+	}
+
+	var results []nominatimResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode geocoding response: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, ErrAddressNotFound
+	}
+
+	a := results[0].Address
+	geocoded := &GeocodedAddress{
+		City:    firstNonEmpty(a.City, a.Town, a.Village, a.Municipality),
+		State:   firstNonEmpty(a.State, a.Region),
+		ZipCode: a.Postcode,
+	}
+	// Nominatim returns the coordinates as decimal strings
+	lat, latErr := strconv.ParseFloat(results[0].Lat, 64)
+	lon, lonErr := strconv.ParseFloat(results[0].Lon, 64)
+	if latErr == nil && lonErr == nil {
+		geocoded.Latitude, geocoded.Longitude = &lat, &lon
+	}
+	return geocoded, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
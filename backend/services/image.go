@@ -0,0 +1,195 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+// jpegQuality is used whenever an image has to be re-encoded
+const jpegQuality = 90
+
+// NormalizeJPEGOrientation applies the EXIF Orientation tag to the pixels and strips EXIF metadata.
+// Non-JPEG input is returned unchanged.
+func NormalizeJPEGOrientation(data []byte) ([]byte, error) {
+	if !isJPEG(data) {
+		return data, nil
+	}
+
+	orientation := 1
+	if x, err := exif.Decode(bytes.NewReader(data)); err == nil {
+		if tag, err := x.Get(exif.Orientation); err == nil {
+			if v, err := tag.Int(0); err == nil {
+				orientation = v
+			}
+		}
+	}
+
+	// Already upright: drop the EXIF block without re-encoding to avoid quality loss
+	if orientation < 2 || orientation > 8 {
+		return stripJPEGExif(data), nil
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JPEG: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, applyOrientation(img, orientation), &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode JPEG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// applyOrientation transforms the image so that EXIF orientation 1 (upright) is correct
+func applyOrientation(img image.Image, orientation int) image.Image {
+	switch orientation {
+	case 2:
+		return flipH(img)
+	case 3:
+		return rotate180(img)
+	case 4:
+		return flipH(rotate180(img))
+	case 5:
+		return flipH(rotate90(img))
+	case 6:
+		return rotate90(img)
+	case 7:
+		return flipH(rotate270(img))
+	case 8:
+		return rotate270(img)
+	}
+	return img
+}
+
+// toNRGBA copies any image into an NRGBA with a zero-based origin
+func toNRGBA(img image.Image) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst
+}
+
+// rotate90 rotates the image 90 degrees clockwise
+func rotate90(img image.Image) image.Image {
+	src := toNRGBA(img)
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, h, w))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.SetNRGBA(h-1-y, x, src.NRGBAAt(x, y))
+		}
+	}
+	return dst
+}
+
+// rotate180 rotates the image 180 degrees
+func rotate180(img image.Image) image.Image {
+	src := toNRGBA(img)
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.SetNRGBA(w-1-x, h-1-y, src.NRGBAAt(x, y))
+		}
+	}
+	return dst
+}
+
+// rotate270 rotates the image 90 degrees counter-clockwise
+func rotate270(img image.Image) image.Image {
+	src := toNRGBA(img)
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, h, w))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.SetNRGBA(y, w-1-x, src.NRGBAAt(x, y))
+		}
+	}
+	return dst
+}
+
+// flipH mirrors the image horizontally
+func flipH(img image.Image) image.Image {
+	src := toNRGBA(img)
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.SetNRGBA(w-1-x, y, src.NRGBAAt(x, y))
+		}
+	}
+	return dst
+}
+
+func isJPEG(data []byte) bool {
+	return len(data) > 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF
+}
+
+// stripJPEGExif removes APP1 (EXIF/XMP) segments from a JPEG without touching the image data
+func stripJPEGExif(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...) // SOI
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return data // unexpected layout, leave untouched
+		}
+		marker := data[i+1]
+		// Start of scan: the rest is entropy-coded image data
+		if marker == 0xDA {
+			return append(out, data[i:]...)
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return data
+		}
+		if marker != 0xE1 {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return data
+}
+
+// cropToAspect center-crops the image to the targetW:targetH aspect ratio
+func cropToAspect(img image.Image, targetW, targetH int) image.Image {
+	b := img.Bounds()
+	if targetW <= 0 || targetH <= 0 || b.Dx() == 0 || b.Dy() == 0 {
+		return img
+	}
+
+	w, h := b.Dx(), b.Dy()
+	cropW, cropH := w, h
+	if w*targetH > h*targetW {
+		// Too wide: trim the sides
+		cropW = h * targetW / targetH
+	} else {
+		// Too tall: trim top and bottom
+		cropH = w * targetH / targetW
+	}
+	if cropW == w && cropH == h {
+		return img
+	}
+
+	x0 := b.Min.X + (w-cropW)/2
+	y0 := b.Min.Y + (h-cropH)/2
+	rect := image.Rect(x0, y0, x0+cropW, y0+cropH)
+
+	if sub, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(rect)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, cropW, cropH))
+	draw.Draw(dst, dst.Bounds(), img, rect.Min, draw.Src)
+	return dst
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"property-brochure-backend/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ImageDedupService uploads images once per unique content, reusing the stored object for repeats
+type ImageDedupService struct {
+	mongo   MongoStorage
+	storage StorageService
+}
+
+func NewImageDedupService(mongo MongoStorage, storage StorageService) *ImageDedupService {
+	return &ImageDedupService{
+		mongo:   mongo,
+		storage: storage,
+	}
+}
+
+// UploadFile normalizes the image, hashes its bytes and only uploads when no identical image has been stored before.
+// record describes the object when a new one was written, and is nil when an existing one was reused. It is not
+// stored yet: pass it to Record once a listing referencing the object is saved, so other submissions never reuse
+// an object that a failed submission rolls back.
+func (s *ImageDedupService) UploadFile(file multipart.File, header *multipart.FileHeader, folder string) (url string, record *models.ImageHash, err error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Apply EXIF orientation so phone photos are upright in the brochure, and drop the EXIF block
+	if normalized, err := NormalizeJPEGOrientation(data); err != nil {
+		log.Printf("Failed to normalize orientation of %s, uploading original: %v", header.Filename, err)
+	} else {
+		data = normalized
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	// Storage backends read exactly header.Size bytes, so describe the normalized content
+	normalizedHeader := *header
+	normalizedHeader.Size = int64(len(data))
+	header = &normalizedHeader
+	file = bytesFile{bytes.NewReader(data)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if url, ok := s.reuse(ctx, hash, header.Filename); ok {
+		return url, nil, nil
+	}
+
+	url, err = s.storage.UploadFile(file, header, folder)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key, err := s.storage.KeyFromURL(url)
+	if err != nil {
+		log.Printf("Skipping image hash record for %s: %v", header.Filename, err)
+		return url, nil, nil
+	}
+	return url, &models.ImageHash{Hash: hash, S3Key: key, URL: url}, nil
+}
+
+// Record stores the hash records of images uploaded for a saved listing, so later submissions reuse them.
+// When an identical image was recorded first by a concurrent submission, both objects are kept, as each
+// is referenced by its listing, and only the first is reused from then on.
+func (s *ImageDedupService) Record(records []models.ImageHash) error {
+	if len(records) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	docs := make([]interface{}, len(records))
+	for i, record := range records {
+		record.CreatedAt = time.Now()
+		docs[i] = record
+	}
+	_, err := s.mongo.GetCollection("image_hashes").InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil && !isOnlyDuplicateKeyErrors(err) {
+		return fmt.Errorf("failed to record image hashes: %w", err)
+	}
+	return nil
+}
+
+// isOnlyDuplicateKeyErrors reports whether every write of a bulk insert failed on a duplicate key
+func isOnlyDuplicateKeyErrors(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if !mongo.IsDuplicateKeyError(writeErr) {
+			return false
+		}
+	}
+	return true
+}
+
+// reuse signs a fresh URL for the stored image with the given hash; ok is false when there is none or
+// it cannot be signed, and the image should be uploaded
+func (s *ImageDedupService) reuse(ctx context.Context, hash, filename string) (url string, ok bool) {
+	var existing models.ImageHash
+	err := s.mongo.GetCollection("image_hashes").FindOne(ctx, bson.M{"hash": hash}).Decode(&existing)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", false
+	}
+	if err != nil {
+		log.Printf("Image hash lookup failed, uploading without deduplication: %v", err)
+		return "", false
+	}
+
+	// Stored URLs are pre-signed and expire, so sign a fresh one for the existing key
+	url, err = s.storage.GeneratePresignedURL(existing.S3Key, URLExpirationTime)
+	if err != nil {
+		log.Printf("Failed to re-sign deduplicated image %s, uploading again: %v", existing.S3Key, err)
+		return "", false
+	}
+	log.Printf("Reusing stored image %s for %s", existing.S3Key, filename)
+	return url, true
+}
+
+// ForgetKeys removes the hash records pointing at the given storage keys, e.g. after they were rolled back
+func (s *ImageDedupService) ForgetKeys(keys []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := s.mongo.GetCollection("image_hashes").DeleteMany(ctx, bson.M{"s3_key": bson.M{"$in": keys}})
+	if err != nil {
+		return fmt.Errorf("failed to delete image hash records: %w", err)
+	}
+	return nil
+}
+
+// bytesFile adapts an in-memory buffer to the multipart.File interface expected by StorageService
+type bytesFile struct {
+	*bytes.Reader
+}
+
+func (bytesFile) Close() error { return nil }
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LocalStorageService stores files on the local filesystem for development without cloud credentials
+type LocalStorageService struct {
+	basePath string
+	baseURL  string
+}
+
+func NewLocalStorageService(basePath, baseURL string) (*LocalStorageService, error) {
+	if err := os.MkdirAll(basePath, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+
+	return &LocalStorageService{
+		basePath: basePath,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *LocalStorageService) UploadFile(file multipart.File, header *multipart.FileHeader, folder string) (string, error) {
+	// Read file content
+	buffer, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Generate unique filename
+	ext := filepath.Ext(header.Filename)
+	key := fmt.Sprintf("%s/%s-%s%s", folder, time.Now().Format("20060102"), uuid.New().String(), ext)
+
+	if err := s.write(key, buffer); err != nil {
+		return "", err
+	}
+
+	return s.GeneratePresignedURL(key, URLExpirationTime)
+}
+
+func (s *LocalStorageService) UploadPDF(data []byte, filename string) (string, error) {
+	key := fmt.Sprintf("brochures/%s-%s.pdf", time.Now().Format("20060102"), uuid.New().String())
+
+	if err := s.write(key, data); err != nil {
+		return "", err
+	}
+
+	return s.fileURL(key, URLExpirationTime, "inline", filename), nil
+}
+
+func (s *LocalStorageService) UploadPDFWithUrls(data []byte, filename string) (*PDFUrls, error) {
+	key := fmt.Sprintf("brochures/%s-%s.pdf", time.Now().Format("20060102"), uuid.New().String())
+
+	if err := s.write(key, data); err != nil {
+		return nil, err
+	}
+
+	return &PDFUrls{
+		ViewUrl:     s.fileURL(key, URLExpirationTime, "inline", filename),
+		DownloadUrl: s.fileURL(key, URLExpirationTime, "attachment", filename),
+	}, nil
+}
+
+// DeleteObjects removes the given keys from the storage directory
+func (s *LocalStorageService) DeleteObjects(keys []string) error {
+	for _, key := range keys {
+		path, err := s.Path(key)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// GeneratePresignedURL returns a /files/ URL; expiry is enforced by the file handler from the file's modification time
+func (s *LocalStorageService) GeneratePresignedURL(key string, expiration time.Duration) (string, error) {
+	return s.fileURL(key, expiration, "", ""), nil
+}
+
+// GeneratePresignedURLWithDisposition returns a /files/ URL served with the disposition type and filename
+// of a Content-Disposition value such as `inline; filename="brochure.pdf"`
+func (s *LocalStorageService) GeneratePresignedURLWithDisposition(key string, expiration time.Duration, disposition string) (string, error) {
+	dispositionType, params, err := mime.ParseMediaType(disposition)
+	if err != nil {
+		return "", fmt.Errorf("invalid Content-Disposition %q: %w", disposition, err)
+	}
+	return s.fileURL(key, expiration, dispositionType, strings.TrimSuffix(params["filename"], ".pdf")), nil
+}
+
+// PublicURL returns a /files/ URL without a TTL; the file handler still applies the default expiry
+func (s *LocalStorageService) PublicURL(key string) string {
+	return fmt.Sprintf("%s/files/%s", s.baseURL, key)
+}
+
+// PutObject stores raw bytes at a fixed key
+func (s *LocalStorageService) PutObject(key string, data []byte, contentType string) error {
+	return s.write(key, data)
+}
+
+// GetObject reads the file stored at key
+func (s *LocalStorageService) GetObject(key string) ([]byte, error) {
+	path, err := s.Path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// KeyFromURL extracts the storage key from a /files/ URL
+func (s *LocalStorageService) KeyFromURL(rawURL string) (string, error) {
+	return keyFromURLPath(rawURL, "files/")
+}
+
+// Path resolves a storage key to an absolute path, rejecting keys that escape the storage directory
+func (s *LocalStorageService) Path(key string) (string, error) {
+	base, err := filepath.Abs(s.basePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve storage directory: %w", err)
+	}
+	path := filepath.Join(base, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, base+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid storage key: %s", key)
+	}
+	return path, nil
+}
+
+func (s *LocalStorageService) write(key string, data []byte) error {
+	path, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// fileURL builds the public URL for a key, carrying the TTL and optional Content-Disposition as query params
+func (s *LocalStorageService) fileURL(key string, expiration time.Duration, disposition, filename string) string {
+	query := url.Values{}
+	query.Set("ttl", fmt.Sprintf("%d", int64(expiration.Seconds())))
+	if disposition != "" {
+		query.Set("disposition", disposition)
+		query.Set("filename", filename+".pdf")
+	}
+	return fmt.Sprintf("%s/files/%s?%s", s.baseURL, key, query.Encode())
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// moderationThreshold is the category score above which text is rejected
+const moderationThreshold = 0.8
+
+// ModerationResult is the outcome of a content check; Category and Score are those of the highest
+// scoring category checked
+type ModerationResult struct {
+	Flagged  bool
+	Category string
+	Score    float64
+}
+
+// ContentModerator screens user-supplied text before it is used in brochures
+type ContentModerator interface {
+	ModerateText(text string) (*ModerationResult, error)
+}
+
+var _ ContentModerator = (*OpenAIService)(nil)
+
+// ModerateText scores text with the OpenAI Moderation API and flags it when a hate, harassment, sexual
+// or violence score exceeds moderationThreshold
+func (s *OpenAIService) ModerateText(text string) (*ModerationResult, error) {
+	resp, err := s.client.Moderations(context.Background(), openai.ModerationRequest{Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to moderate text: %w", err)
+	}
+	if len(resp.Results) == 0 {
+		return nil, fmt.Errorf("moderation returned no results")
+	}
+
+	scores := resp.Results[0].CategoryScores
+	result := &ModerationResult{}
+	for _, category := range []struct {
+		name  string
+		score float32
+	}{
+		{"hate", scores.Hate},
+		{"hate/threatening", scores.HateThreatening},
+		{"harassment", scores.Harassment},
+		{"harassment/threatening", scores.HarassmentThreatening},
+		{"sexual", scores.Sexual},
+		{"sexual/minors", scores.SexualMinors},
+		{"violence", scores.Violence},
+		{"violence/graphic", scores.ViolenceGraphic},
+	} {
+		if float64(category.score) > result.Score || result.Category == "" {
+			result.Category, result.Score = category.name, float64(category.score)
+		}
+	}
+	result.Flagged = result.Score > moderationThreshold
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func TestModerateTextFlagsHarassment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/moderations" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "modr-1", "model": "omni-moderation-latest", "results": [{
+			"flagged": true,
+			"categories": {"harassment": true},
+			"category_scores": {"hate": 0.12, "harassment": 0.93, "harassment/threatening": 0.41, "sexual": 0.01, "violence": 0.2}
+		}]}`))
+	}))
+	defer server.Close()
+
+	config := openai.DefaultConfig("test-key")
+	config.BaseURL = server.URL
+	s := &OpenAIService{client: openai.NewClientWithConfig(config)}
+
+	result, err := s.ModerateText("a description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Flagged || result.Category != "harassment" || result.Score < 0.92 {
+		t.Errorf("result = %+v, want flagged for harassment at 0.93", result)
+	}
+}
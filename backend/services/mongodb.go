@@ -3,8 +3,10 @@ package services
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -14,11 +16,33 @@ type MongoDBService struct {
 	Database *mongo.Database
 }
 
-func NewMongoDBService(uri, database string) (*MongoDBService, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// MongoStorage is the database access the handlers and services depend on
+type MongoStorage interface {
+	GetCollection(name string) *mongo.Collection
+}
+
+var _ MongoStorage = (*MongoDBService)(nil)
+
+// MongoPoolConfig tunes the driver's connection pool and network timeouts
+type MongoPoolConfig struct {
+	MinPoolSize    uint64
+	MaxPoolSize    uint64
+	ConnectTimeout time.Duration
+	SocketTimeout  time.Duration
+}
+
+func NewMongoDBService(uri, database string, pool MongoPoolConfig) (*MongoDBService, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pool.ConnectTimeout)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	clientOptions := options.Client().
+		ApplyURI(uri).
+		SetMinPoolSize(pool.MinPoolSize).
+		SetMaxPoolSize(pool.MaxPoolSize).
+		SetConnectTimeout(pool.ConnectTimeout).
+		SetSocketTimeout(pool.SocketTimeout)
+
+	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
@@ -34,6 +58,108 @@ func NewMongoDBService(uri, database string) (*MongoDBService, error) {
 	}, nil
 }
 
+// mongoIndex is an index to create at startup, named after its keys
+type mongoIndex struct {
+	name   string
+	keys   bson.D
+	unique bool
+}
+
+// propertyIndexes are the indexes of the properties collection
+var propertyIndexes = []mongoIndex{
+	{"city_1_price_1", bson.D{{Key: "city", Value: 1}, {Key: "price", Value: 1}}, false},
+	{"agentInfo.email_1", bson.D{{Key: "agentInfo.email", Value: 1}}, false},
+	// Encrypted emails are random ciphertexts, so with encryption enabled agents are looked up by the blind index
+	{"agentInfo.emailHash_1", bson.D{{Key: "agentInfo.emailHash", Value: 1}}, false},
+	{"createdAt_-1", bson.D{{Key: "createdAt", Value: -1}}, false},
+	// Property search (GET /api/properties/search)
+	// Listings with coordinates, for nearby searches; the index skips listings without a location
+	{"location_2dsphere", bson.D{{Key: "location", Value: "2dsphere"}}, false},
+	{"status_1_cityLower_1_price_1_bedrooms_1", bson.D{{Key: "status", Value: 1}, {Key: "cityLower", Value: 1}, {Key: "price", Value: 1}, {Key: "bedrooms", Value: 1}}, false},
+}
+
+// shareLinkIndexes are the indexes of the shareLinks collection; every visit looks up a token
+var shareLinkIndexes = []mongoIndex{
+	{"token_1", bson.D{{Key: "token", Value: 1}}, true},
+	{"propertyId_1_createdAt_-1", bson.D{{Key: "propertyId", Value: 1}, {Key: "createdAt", Value: -1}}, false},
+}
+
+// favoriteIndexes are the indexes of the favorites collection; a user bookmarks a listing at most once
+var favoriteIndexes = []mongoIndex{
+	{"userId_1_propertyId_1", bson.D{{Key: "userId", Value: 1}, {Key: "propertyId", Value: 1}}, true},
+}
+
+// imageHashIndexes are the indexes of the image_hashes collection: every upload looks up its hash, which
+// is unique so concurrent uploads of the same image keep one object, and rollbacks delete by key
+var imageHashIndexes = []mongoIndex{
+	{"hash_1", bson.D{{Key: "hash", Value: 1}}, true},
+	{"s3_key_1", bson.D{{Key: "s3_key", Value: 1}}, false},
+}
+
+// eventIndexes are the indexes of the events collection; the audit log is read newest first, across
+// all listings or for one
+var eventIndexes = []mongoIndex{
+	{"propertyId_1_timestamp_-1", bson.D{{Key: "propertyId", Value: 1}, {Key: "timestamp", Value: -1}}, false},
+	{"timestamp_-1", bson.D{{Key: "timestamp", Value: -1}}, false},
+}
+
+// agentIndexes are the indexes of the agents collection, which is listed sorted by name
+var agentIndexes = []mongoIndex{
+	{"name_1", bson.D{{Key: "name", Value: 1}}, false},
+}
+
+// InitIndexes creates the indexes of every queried collection that do not exist yet
+func (s *MongoDBService) InitIndexes(ctx context.Context) error {
+	collections := []struct {
+		name    string
+		indexes []mongoIndex
+	}{
+		{"properties", propertyIndexes},
+		{"shareLinks", shareLinkIndexes},
+		{"favorites", favoriteIndexes},
+		{"image_hashes", imageHashIndexes},
+		{"events", eventIndexes},
+		{"agents", agentIndexes},
+	}
+	for _, collection := range collections {
+		if err := s.createIndexes(ctx, collection.name, collection.indexes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createIndexes creates the indexes of a collection that do not exist yet
+func (s *MongoDBService) createIndexes(ctx context.Context, collection string, wanted []mongoIndex) error {
+	indexes := s.GetCollection(collection).Indexes()
+
+	specs, err := indexes.ListSpecifications(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list indexes of %s: %w", collection, err)
+	}
+	existing := map[string]bool{}
+	for _, spec := range specs {
+		existing[spec.Name] = true
+	}
+
+	for _, index := range wanted {
+		if existing[index.name] {
+			log.Printf("MongoDB index %s.%s already exists", collection, index.name)
+			continue
+		}
+		opts := options.Index().SetName(index.name)
+		if index.unique {
+			opts.SetUnique(true)
+		}
+		model := mongo.IndexModel{Keys: index.keys, Options: opts}
+		if _, err := indexes.CreateOne(ctx, model); err != nil {
+			return fmt.Errorf("failed to create index %s.%s: %w", collection, index.name, err)
+		}
+		log.Printf("Created MongoDB index %s.%s", collection, index.name)
+	}
+	return nil
+}
+
 func (s *MongoDBService) Close() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -44,3 +170,29 @@ func (s *MongoDBService) GetCollection(name string) *mongo.Collection {
 	return s.Database.Collection(name)
 }
 
+// BackfillSearchFields sets the search fields of listings stored before they were introduced: the
+// lowercase city and the GeoJSON location. MongoDB's $toLower only lowercases ASCII, which covers the
+// stored city names; new listings get both fields in Go.
+func (s *MongoDBService) BackfillSearchFields(ctx context.Context) error {
+	properties := s.GetCollection("properties")
+	result, err := properties.UpdateMany(ctx,
+		bson.M{"cityLower": bson.M{"$exists": false}, "city": bson.M{"$type": "string"}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"cityLower": bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$city"}}}}}}})
+	if err != nil {
+		return fmt.Errorf("failed to backfill cityLower: %w", err)
+	}
+	if result.ModifiedCount > 0 {
+		log.Printf("Backfilled cityLower of %d properties", result.ModifiedCount)
+	}
+
+	result, err = properties.UpdateMany(ctx,
+		bson.M{"location": bson.M{"$exists": false}, "latitude": bson.M{"$type": "number"}, "longitude": bson.M{"$type": "number"}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"location": bson.M{"type": "Point", "coordinates": bson.A{"$longitude", "$latitude"}}}}}})
+	if err != nil {
+		return fmt.Errorf("failed to backfill location: %w", err)
+	}
+	if result.ModifiedCount > 0 {
+		log.Printf("Backfilled location of %d properties", result.ModifiedCount)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"math"
+	"property-brochure-backend/models"
+)
+
+// MonthlyMortgagePayment returns the fixed monthly payment for the financed part of the price
+// using the standard amortization formula: P * r / (1 - (1 + r)^-n)
+func MonthlyMortgagePayment(price float64, m models.MortgageDetails) float64 {
+	principal := price * (1 - m.DownPaymentPct/100)
+	months := float64(m.TermYears * 12)
+	if principal <= 0 || months <= 0 {
+		return 0
+	}
+
+	monthlyRate := m.InterestRate / 100 / 12
+	if monthlyRate == 0 {
+		return principal / months
+	}
+
+	return principal * monthlyRate / (1 - math.Pow(1+monthlyRate, -months))
+}
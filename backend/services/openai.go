@@ -3,16 +3,79 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"property-brochure-backend/models"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	openai "github.com/sashabaranov/go-openai"
 )
 
 type OpenAIService struct {
 	client *openai.Client
+
+	// Token usage and estimated cost of the chat completions made since startup
+	costs openAICosts
+}
+
+// openAIPrice is the USD price of a million prompt and completion tokens
+type openAIPrice struct {
+	prompt     float64
+	completion float64
+}
+
+// openAIPrices are the list prices of the chat models, used to estimate what the API calls cost.
+// Dated snapshots such as gpt-4o-mini-2024-07-18 use the price of their model.
+var openAIPrices = map[string]openAIPrice{
+	"gpt-4o-mini":   {prompt: 0.15, completion: 0.60},
+	"gpt-4o":        {prompt: 2.50, completion: 10.00},
+	"gpt-4-turbo":   {prompt: 10.00, completion: 30.00},
+	"gpt-4":         {prompt: 30.00, completion: 60.00},
+	"gpt-3.5-turbo": {prompt: 0.50, completion: 1.50},
+}
+
+// estimateOpenAICost prices the token usage of a completion; models without a known price cost 0
+func estimateOpenAICost(model string, usage openai.Usage) float64 {
+	price, ok := openAIPrices[model]
+	if !ok {
+		// The longest matching name, so gpt-4o-mini snapshots are not priced as gpt-4o
+		match := ""
+		for name, p := range openAIPrices {
+			if strings.HasPrefix(model, name+"-") && len(name) > len(match) {
+				match, price, ok = name, p, true
+			}
+		}
+		if !ok {
+			return 0
+		}
+	}
+	return (float64(usage.PromptTokens)*price.prompt + float64(usage.CompletionTokens)*price.completion) / 1e6
 }
 
+// maxPromptTokens is the estimated prompt size above which listing descriptions are truncated, leaving
+// headroom for the response in the model's context window
+const maxPromptTokens = 6000
+
+// localizedPromptOverheadTokens approximates the instructions and JSON template of a localized content prompt
+const localizedPromptOverheadTokens = 900
+
+// AIContentGenerator writes the listing copy and market estimates used in the brochures
+type AIContentGenerator interface {
+	GeneratePropertyContent(title, description, price, currency string, amenities []string) (*AIGeneratedContent, error)
+	GenerateLocalizedContent(title, description, price, currency string, amenities []string, languages []string) (*LocalizedContentGenerated, error)
+	GenerateComparableSales(city, state string, price float64, propertyType string) (*ComparableSales, error)
+	GenerateVirtualStagingDescription(space, propertyType string) (string, error)
+	InferBedrooms(propertyType, description string) (int, error)
+	EstimateSubmissionCost(req *models.PropertyRequest) float64
+}
+
+var _ AIContentGenerator = (*OpenAIService)(nil)
+
 type AIGeneratedContent struct {
 	EnglishDescription string
 	ArabicDescription  string
@@ -22,6 +85,7 @@ type AIGeneratedContent struct {
 type LocalizedContentGenerated struct {
 	EnglishContent LocalizedContentData `json:"englishContent"`
 	ArabicContent  LocalizedContentData `json:"arabicContent"`
+	UrduContent    LocalizedContentData `json:"urduContent"`
 }
 
 type LocalizedContentData struct {
@@ -44,6 +108,17 @@ type LocalizedContentData struct {
 	ThankYouMessage          string   `json:"thankYouMessage"`
 }
 
+// ComparableSales holds illustrative, AI-estimated comparable sold properties
+type ComparableSales struct {
+	Sales []ComparableSaleData `json:"sales"`
+}
+
+type ComparableSaleData struct {
+	AddressStub  string  `json:"addressStub"`
+	Price        float64 `json:"price"`
+	DaysOnMarket int     `json:"daysOnMarket"`
+}
+
 func NewOpenAIService(apiKey string) *OpenAIService {
 	return &OpenAIService{
 		client: openai.NewClient(apiKey),
@@ -64,7 +139,7 @@ func (s *OpenAIService) GeneratePropertyContent(title, description, price, curre
 The description should be 3-4 paragraphs long, highlight the key features, and appeal to potential buyers. Make it compelling and professional.`, 
 			title, price, currency, strings.Join(amenities, ", "))
 
-		resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		resp, err := s.createChatCompletion(ctx, "english_description", openai.ChatCompletionRequest{
 			Model: "gpt-4o-mini",
 			Messages: []openai.ChatCompletionMessage{
 				{
@@ -88,7 +163,7 @@ The description should be 3-4 paragraphs long, highlight the key features, and a
 	// Translate to Arabic
 	arabicPrompt := fmt.Sprintf("Translate the following real estate property description to Arabic. Maintain the professional tone and structure:\n\n%s", englishDesc)
 	
-	arabicResp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+	arabicResp, err := s.createChatCompletion(ctx, "arabic_translation", openai.ChatCompletionRequest{
 		Model: "gpt-4o-mini",
 		Messages: []openai.ChatCompletionMessage{
 			{
@@ -118,7 +193,7 @@ Description: %s
 Return only the bullet points, one per line, without bullet symbols or numbering.`, 
 		title, price, currency, strings.Join(amenities, ", "), englishDesc)
 
-	highlightsResp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+	highlightsResp, err := s.createChatCompletion(ctx, "highlights", openai.ChatCompletionRequest{
 		Model: "gpt-4o-mini",
 		Messages: []openai.ChatCompletionMessage{
 			{
@@ -165,12 +240,133 @@ Return only the bullet points, one per line, without bullet symbols or numbering
 	}, nil
 }
 
-// GenerateLocalizedContent generates fully localized content for both English and Arabic
-func (s *OpenAIService) GenerateLocalizedContent(title, description, price, currency string, amenities []string) (*LocalizedContentGenerated, error) {
-	ctx := context.Background()
+// GenerateLocalizedContent generates fully localized content for the requested languages ("en", "ar", "ur").
+// Each language has its own focused prompt; the requested languages are generated concurrently.
+func (s *OpenAIService) GenerateLocalizedContent(title, description, price, currency string, amenities []string, languages []string) (*LocalizedContentGenerated, error) {
+	wantEnglish, wantArabic, wantUrdu := false, false, false
+	for _, lang := range languages {
+		switch lang {
+		case "en":
+			wantEnglish = true
+		case "ar":
+			wantArabic = true
+		case "ur":
+			wantUrdu = true
+		}
+	}
+
+	// Very long descriptions would overflow the context window with an opaque API error
+	otherTokens := localizedPromptOverheadTokens + s.estimateTokenCount(title) + s.estimateTokenCount(strings.Join(amenities, ", "))
+	description = s.fitDescription(description, maxPromptTokens-otherTokens)
+
+	var result LocalizedContentGenerated
+	var englishErr, arabicErr, urduErr error
+	var wg sync.WaitGroup
+
+	if wantEnglish {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			content, err := s.GenerateEnglishContent(title, description, price, currency, amenities)
+			if err != nil {
+				englishErr = err
+				return
+			}
+			result.EnglishContent = *content
+		}()
+	}
+	if wantArabic {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			content, err := s.GenerateArabicContent(title, description, price, currency, amenities)
+			if err != nil {
+				arabicErr = err
+				return
+			}
+			result.ArabicContent = *content
+		}()
+	}
+	if wantUrdu {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			content, err := s.GenerateUrduContent(title, description, price, currency, amenities)
+			if err != nil {
+				urduErr = err
+				return
+			}
+			result.UrduContent = *content
+		}()
+	}
+	wg.Wait()
 
-	// Create a comprehensive prompt that asks for both English and Arabic localized content
-	prompt := fmt.Sprintf(`You are a professional real estate content generator. Generate fully localized content for a property listing in both English and Arabic.
+	if err := errors.Join(englishErr, arabicErr, urduErr); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// estimateTokenCount approximates the model's token count of text without a tokenizer: about 1.3 tokens
+// per English word, and one per 2.5 bytes of Arabic-script words, which tokenize far more densely
+func (s *OpenAIService) estimateTokenCount(text string) int {
+	tokens := 0.0
+	for _, word := range strings.Fields(text) {
+		tokens += wordTokens(word)
+	}
+	return int(math.Ceil(tokens))
+}
+
+func wordTokens(word string) float64 {
+	if strings.IndexFunc(word, func(r rune) bool { return unicode.Is(unicode.Arabic, r) }) >= 0 {
+		return float64(len(word)) / 2.5
+	}
+	return 1.3
+}
+
+// fitDescription truncates description to the last sentence that fits within budget tokens, or to the
+// last fitting word when even the first sentence is too long
+func (s *OpenAIService) fitDescription(description string, budget int) string {
+	total := s.estimateTokenCount(description)
+	if total <= budget {
+		return description
+	}
+
+	// Sentences end at terminal punctuation (including the Arabic question mark and Urdu full stop)
+	// followed by whitespace, so the estimate adds up sentence by sentence
+	cut, tokens, start := 0, 0, 0
+	for i, r := range description {
+		next := i + utf8.RuneLen(r)
+		if !strings.ContainsRune(".!?؟۔\n", r) || (next < len(description) && !unicode.IsSpace(rune(description[next]))) {
+			continue
+		}
+		sentence := s.estimateTokenCount(description[start:next])
+		if tokens+sentence > budget {
+			break
+		}
+		tokens += sentence
+		cut, start = next, next
+	}
+	if cut == 0 {
+		words := strings.Fields(description)
+		kept, estimate := 0, 0.0
+		for kept < len(words) && estimate+wordTokens(words[kept]) <= float64(budget) {
+			estimate += wordTokens(words[kept])
+			kept++
+		}
+		truncated := strings.Join(words[:kept], " ")
+		log.Printf("Truncated listing description from ~%d to ~%d tokens at a word boundary to fit the prompt", total, s.estimateTokenCount(truncated))
+		return truncated
+	}
+
+	truncated := strings.TrimSpace(description[:cut])
+	log.Printf("Truncated listing description from ~%d to ~%d tokens to fit the prompt", total, s.estimateTokenCount(truncated))
+	return truncated
+}
+
+// GenerateEnglishContent generates the English copy and labels for a property listing
+func (s *OpenAIService) GenerateEnglishContent(title, description, price, currency string, amenities []string) (*LocalizedContentData, error) {
+	prompt := fmt.Sprintf(`You are a professional real estate content generator. Generate English content for a property listing.
 
 Property Details:
 - Title: %s
@@ -180,62 +376,281 @@ Property Details:
 
 Please generate a JSON response with the following structure:
 {
-  "englishContent": {
-    "title": "<translated/enhanced property title in English>",
-    "description": "<3-4 paragraph professional description in English>",
-    "highlights": ["<5-7 short key highlights in English, each 5-10 words>"],
-    "translatedAmenities": ["<all amenities translated to English>"],
-    "priceLabel": "Price",
-    "addressLabel": "Address",
-    "cityLabel": "City",
-    "stateLabel": "State",
-    "zipCodeLabel": "ZIP Code",
-    "amenitiesLabel": "Amenities & Features",
-    "agentLabel": "Contact Your Agent",
-    "propertyDescriptionLabel": "Property Description",
-    "keyHighlightsLabel": "Key Highlights",
-    "propertyGalleryLabel": "Property Gallery",
-    "additionalSectionTitle": "<creative section title like 'Investment Opportunity' or 'Why This Property?'>",
-    "additionalSectionContent": "<3-6 concise, impactful lines written as if a professional real estate agent is speaking directly to a buyer. Focus on: prime location value, growth potential, and unique selling points. Write in first-person, conversational tone. Keep it brief but powerful - like an elevator pitch from an experienced agent.>",
-    "thankYouMessage": "<warm 2-3 paragraph thank you message expressing gratitude for interest and encouraging next steps>"
-  },
-  "arabicContent": {
-    "title": "<property title fully translated to Arabic>",
-    "description": "<3-4 paragraph professional description fully in Arabic>",
-    "highlights": ["<5-7 short key highlights in Arabic>"],
-    "translatedAmenities": ["<all amenities translated to Arabic>"],
-    "priceLabel": "السعر",
-    "addressLabel": "العنوان",
-    "cityLabel": "المدينة",
-    "stateLabel": "الولاية",
-    "zipCodeLabel": "الرمز البريدي",
-    "amenitiesLabel": "المرافق والميزات",
-    "agentLabel": "اتصل بوكيلك",
-    "propertyDescriptionLabel": "وصف العقار",
-    "keyHighlightsLabel": "المميزات الرئيسية",
-    "propertyGalleryLabel": "معرض العقار",
-    "additionalSectionTitle": "<creative section title in Arabic like 'فرصة استثمارية' or 'لماذا هذا العقار؟'>",
-    "additionalSectionContent": "<3-6 concise, impactful lines in Arabic as if a professional real estate agent is speaking directly to a buyer. Focus on: prime location value, growth potential, and unique selling points. Write in first-person, conversational tone. Keep it brief but powerful.>",
-    "thankYouMessage": "<warm 2-3 paragraph thank you message in Arabic expressing gratitude and encouraging next steps>"
-  }
+  "title": "<translated/enhanced property title in English>",
+  "description": "<3-4 paragraph professional description in English>",
+  "highlights": ["<5-7 short key highlights in English, each 5-10 words>"],
+  "translatedAmenities": ["<all amenities translated to English>"],
+  "priceLabel": "Price",
+  "addressLabel": "Address",
+  "cityLabel": "City",
+  "stateLabel": "State",
+  "zipCodeLabel": "ZIP Code",
+  "amenitiesLabel": "Amenities & Features",
+  "agentLabel": "Contact Your Agent",
+  "propertyDescriptionLabel": "Property Description",
+  "keyHighlightsLabel": "Key Highlights",
+  "propertyGalleryLabel": "Property Gallery",
+  "additionalSectionTitle": "<creative section title like 'Investment Opportunity' or 'Why This Property?'>",
+  "additionalSectionContent": "<3-6 concise, impactful lines written as if a professional real estate agent is speaking directly to a buyer. Focus on: prime location value, growth potential, and unique selling points. Write in first-person, conversational tone. Keep it brief but powerful - like an elevator pitch from an experienced agent.>",
+  "thankYouMessage": "<warm 2-3 paragraph thank you message expressing gratitude for interest and encouraging next steps>"
 }
 
 Important:
-1. The Arabic version must be COMPLETELY in Arabic - no English words
+1. Keep highlights concise and impactful
+2. Return ONLY valid JSON, no additional text
+
+Generate the content now:`, 
+		title, price, currency, strings.Join(amenities, ", "), description)
+
+	c, err := s.generateLanguageContent(prompt, "You are a professional real estate content writer. You always return valid JSON responses.", "English")
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure we have all required fields with fallbacks
+	if c.Title == "" {
+		c.Title = title
+	}
+	if c.PriceLabel == "" {
+		c.PriceLabel = "Price"
+	}
+	if c.AddressLabel == "" {
+		c.AddressLabel = "Address"
+	}
+	if c.CityLabel == "" {
+		c.CityLabel = "City"
+	}
+	if c.StateLabel == "" {
+		c.StateLabel = "State"
+	}
+	if c.ZipCodeLabel == "" {
+		c.ZipCodeLabel = "ZIP Code"
+	}
+	if c.AmenitiesLabel == "" {
+		c.AmenitiesLabel = "Amenities & Features"
+	}
+	if c.AgentLabel == "" {
+		c.AgentLabel = "Contact Your Agent"
+	}
+	if c.PropertyDescriptionLabel == "" {
+		c.PropertyDescriptionLabel = "Property Description"
+	}
+	if c.KeyHighlightsLabel == "" {
+		c.KeyHighlightsLabel = "Key Highlights"
+	}
+	if c.PropertyGalleryLabel == "" {
+		c.PropertyGalleryLabel = "Property Gallery"
+	}
+	if c.AdditionalSectionTitle == "" {
+		c.AdditionalSectionTitle = "Investment Opportunity"
+	}
+	if c.AdditionalSectionContent == "" {
+		c.AdditionalSectionContent = "I've been selling properties in this area for years, and I can tell you - this is a rare find. The location commands premium value, and we're seeing consistent appreciation year after year. What really excites me is the potential here, both for investors seeking solid returns and families looking for their dream home. The market fundamentals are strong, demand is high, and properties like this don't stay available for long. Trust me, at this price point and in this neighborhood, you're looking at an opportunity that ticks all the boxes."
+	}
+	if c.ThankYouMessage == "" {
+		c.ThankYouMessage = "Thank you for considering this exceptional property. We appreciate your interest and would be delighted to provide you with additional information or arrange a viewing at your convenience. Please don't hesitate to reach out to our dedicated agent for any questions or to schedule a visit."
+	}
+
+	return c, nil
+}
+
+// GenerateArabicContent generates the Arabic copy and labels for a property listing
+func (s *OpenAIService) GenerateArabicContent(title, description, price, currency string, amenities []string) (*LocalizedContentData, error) {
+	prompt := fmt.Sprintf(`You are a professional real estate content generator. Generate fully localized Arabic content for a property listing.
+
+Property Details:
+- Title: %s
+- Price: %s %s
+- Amenities: %s
+- Description: %s
+
+Please generate a JSON response with the following structure:
+{
+  "title": "<property title fully translated to Arabic>",
+  "description": "<3-4 paragraph professional description fully in Arabic>",
+  "highlights": ["<5-7 short key highlights in Arabic>"],
+  "translatedAmenities": ["<all amenities translated to Arabic>"],
+  "priceLabel": "السعر",
+  "addressLabel": "العنوان",
+  "cityLabel": "المدينة",
+  "stateLabel": "الولاية",
+  "zipCodeLabel": "الرمز البريدي",
+  "amenitiesLabel": "المرافق والميزات",
+  "agentLabel": "اتصل بوكيلك",
+  "propertyDescriptionLabel": "وصف العقار",
+  "keyHighlightsLabel": "المميزات الرئيسية",
+  "propertyGalleryLabel": "معرض العقار",
+  "additionalSectionTitle": "<creative section title in Arabic like 'فرصة استثمارية' or 'لماذا هذا العقار؟'>",
+  "additionalSectionContent": "<3-6 concise, impactful lines in Arabic as if a professional real estate agent is speaking directly to a buyer. Focus on: prime location value, growth potential, and unique selling points. Write in first-person, conversational tone. Keep it brief but powerful.>",
+  "thankYouMessage": "<warm 2-3 paragraph thank you message in Arabic expressing gratitude and encouraging next steps>"
+}
+
+Important:
+1. The content must be COMPLETELY in Arabic - no English words
 2. Translate amenities accurately (e.g., Swimming Pool → حمام السباحة, Parking → موقف سيارات, Garden → حديقة, Gym → صالة رياضية)
-3. All labels in Arabic must use proper Arabic terminology
+3. All labels must use proper Arabic terminology
+4. Keep highlights concise and impactful
+5. Return ONLY valid JSON, no additional text
+
+Generate the content now:`, 
+		title, price, currency, strings.Join(amenities, ", "), description)
+
+	c, err := s.generateLanguageContent(prompt, "You are a professional real estate content generator with expertise in Arabic. You always return valid JSON responses.", "Arabic")
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure we have all required fields with fallbacks
+	if c.Title == "" {
+		c.Title = title
+	}
+	if c.PriceLabel == "" {
+		c.PriceLabel = "السعر"
+	}
+	if c.AddressLabel == "" {
+		c.AddressLabel = "العنوان"
+	}
+	if c.CityLabel == "" {
+		c.CityLabel = "المدينة"
+	}
+	if c.StateLabel == "" {
+		c.StateLabel = "الولاية"
+	}
+	if c.ZipCodeLabel == "" {
+		c.ZipCodeLabel = "الرمز البريدي"
+	}
+	if c.AmenitiesLabel == "" {
+		c.AmenitiesLabel = "المرافق والميزات"
+	}
+	if c.AgentLabel == "" {
+		c.AgentLabel = "اتصل بوكيلك"
+	}
+	if c.PropertyDescriptionLabel == "" {
+		c.PropertyDescriptionLabel = "وصف العقار"
+	}
+	if c.KeyHighlightsLabel == "" {
+		c.KeyHighlightsLabel = "المميزات الرئيسية"
+	}
+	if c.PropertyGalleryLabel == "" {
+		c.PropertyGalleryLabel = "معرض العقار"
+	}
+	if c.AdditionalSectionTitle == "" {
+		c.AdditionalSectionTitle = "فرصة استثمارية"
+	}
+	if c.AdditionalSectionContent == "" {
+		c.AdditionalSectionContent = "أعمل في بيع العقارات في هذه المنطقة منذ سنوات، وأستطيع أن أخبرك - هذا اكتشاف نادر. الموقع يتمتع بقيمة متميزة، ونحن نشهد ارتفاعًا مستمرًا في الأسعار عامًا بعد عام. ما يثير حماسي حقًا هو الإمكانات الهائلة هنا، سواء للمستثمرين الباحثين عن عوائد قوية أو العائلات الباحثة عن منزل أحلامهم. أساسيات السوق قوية، والطلب مرتفع، والعقارات مثل هذا لا تبقى متاحة لفترة طويلة. ثق بي، بهذا السعر وفي هذا الحي، أنت تنظر إلى فرصة تحقق جميع المعايير."
+	}
+	if c.ThankYouMessage == "" {
+		c.ThankYouMessage = "نشكركم على اهتمامكم بهذا العقار الاستثنائي. نحن نقدر اهتمامكم ويسعدنا تزويدكم بمعلومات إضافية أو ترتيب موعد للمعاينة في الوقت المناسب لكم. لا تترددوا في التواصل مع وكيلنا المختص لأية استفسارات أو لتحديد موعد للزيارة."
+	}
+
+	return c, nil
+}
+
+// GenerateUrduContent generates the Urdu copy and labels for a property listing. Brochures set Urdu in
+// Nastaliq, so the prompt asks for Urdu-specific letters rather than their Arabic look-alikes.
+func (s *OpenAIService) GenerateUrduContent(title, description, price, currency string, amenities []string) (*LocalizedContentData, error) {
+	prompt := fmt.Sprintf(`You are a professional real estate content generator. Generate fully localized Urdu content for a property listing aimed at buyers in Pakistan.
+
+Property Details:
+- Title: %s
+- Price: %s %s
+- Amenities: %s
+- Description: %s
+
+Please generate a JSON response with the following structure:
+{
+  "title": "<property title fully translated to Urdu>",
+  "description": "<3-4 paragraph professional description fully in Urdu>",
+  "highlights": ["<5-7 short key highlights in Urdu>"],
+  "translatedAmenities": ["<all amenities translated to Urdu>"],
+  "priceLabel": "قیمت",
+  "addressLabel": "پتہ",
+  "cityLabel": "شہر",
+  "stateLabel": "صوبہ",
+  "zipCodeLabel": "پوسٹل کوڈ",
+  "amenitiesLabel": "سہولیات اور خصوصیات",
+  "agentLabel": "اپنے ایجنٹ سے رابطہ کریں",
+  "propertyDescriptionLabel": "پراپرٹی کی تفصیل",
+  "keyHighlightsLabel": "نمایاں خصوصیات",
+  "propertyGalleryLabel": "پراپرٹی گیلری",
+  "additionalSectionTitle": "<creative section title in Urdu like 'سرمایہ کاری کا موقع' or 'یہ پراپرٹی کیوں؟'>",
+  "additionalSectionContent": "<3-6 concise, impactful lines in Urdu as if a professional real estate agent is speaking directly to a buyer. Focus on: prime location value, growth potential, and unique selling points. Write in first-person, conversational tone. Keep it brief but powerful.>",
+  "thankYouMessage": "<warm 2-3 paragraph thank you message in Urdu expressing gratitude and encouraging next steps>"
+}
+
+Important:
+1. The content must be COMPLETELY in Urdu - not Arabic, Persian or Roman Urdu, and no English words
+2. The brochure is typeset in Nastaliq: use Urdu letters (ک، ی، ہ، ے، ٹ، ڈ، ڑ، ں) rather than their Arabic forms (ك، ي، ه)
+3. Use the natural real estate terms of Pakistani Urdu (e.g., Swimming Pool → سوئمنگ پول, Parking → پارکنگ, Garden → باغ, Gym → جم)
 4. Keep highlights concise and impactful
 5. Return ONLY valid JSON, no additional text
 
 Generate the content now:`, 
 		title, price, currency, strings.Join(amenities, ", "), description)
 
-	resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+	c, err := s.generateLanguageContent(prompt, "You are a professional real estate content generator with expertise in Urdu. You always return valid JSON responses.", "Urdu")
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure we have all required fields with fallbacks
+	if c.Title == "" {
+		c.Title = title
+	}
+	if c.PriceLabel == "" {
+		c.PriceLabel = "قیمت"
+	}
+	if c.AddressLabel == "" {
+		c.AddressLabel = "پتہ"
+	}
+	if c.CityLabel == "" {
+		c.CityLabel = "شہر"
+	}
+	if c.StateLabel == "" {
+		c.StateLabel = "صوبہ"
+	}
+	if c.ZipCodeLabel == "" {
+		c.ZipCodeLabel = "پوسٹل کوڈ"
+	}
+	if c.AmenitiesLabel == "" {
+		c.AmenitiesLabel = "سہولیات اور خصوصیات"
+	}
+	if c.AgentLabel == "" {
+		c.AgentLabel = "اپنے ایجنٹ سے رابطہ کریں"
+	}
+	if c.PropertyDescriptionLabel == "" {
+		c.PropertyDescriptionLabel = "پراپرٹی کی تفصیل"
+	}
+	if c.KeyHighlightsLabel == "" {
+		c.KeyHighlightsLabel = "نمایاں خصوصیات"
+	}
+	if c.PropertyGalleryLabel == "" {
+		c.PropertyGalleryLabel = "پراپرٹی گیلری"
+	}
+	if c.AdditionalSectionTitle == "" {
+		c.AdditionalSectionTitle = "سرمایہ کاری کا موقع"
+	}
+	if c.AdditionalSectionContent == "" {
+		c.AdditionalSectionContent = "میں برسوں سے اس علاقے میں پراپرٹی فروخت کر رہا ہوں، اور یقین سے کہہ سکتا ہوں کہ یہ ایک نایاب موقع ہے۔ اس مقام کی قدر ہر سال بڑھ رہی ہے، اور یہاں سرمایہ کاروں اور اپنے خوابوں کا گھر تلاش کرنے والے خاندانوں دونوں کے لیے بے پناہ امکانات ہیں۔ مارکیٹ مضبوط ہے، طلب زیادہ ہے، اور ایسی پراپرٹیز زیادہ دیر دستیاب نہیں رہتیں۔"
+	}
+	if c.ThankYouMessage == "" {
+		c.ThankYouMessage = "اس غیر معمولی پراپرٹی میں دلچسپی لینے کا شکریہ۔ ہم آپ کی دلچسپی کی قدر کرتے ہیں اور آپ کو مزید معلومات فراہم کرنے یا آپ کی سہولت کے مطابق معائنے کا وقت طے کرنے میں خوشی محسوس کریں گے۔ کسی بھی سوال یا معائنے کا وقت طے کرنے کے لیے ہمارے ایجنٹ سے بلا جھجک رابطہ کریں۔"
+	}
+
+	return c, nil
+}
+
+// generateLanguageContent sends a single-language prompt and parses the JSON reply
+func (s *OpenAIService) generateLanguageContent(prompt, systemPrompt, language string) (*LocalizedContentData, error) {
+	ctx := context.Background()
+
+	resp, err := s.createChatCompletion(ctx, strings.ToLower(language)+"_content", openai.ChatCompletionRequest{
 		Model: "gpt-4o-mini",
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: "You are a professional real estate content generator with expertise in English and Arabic. You always return valid JSON responses.",
+				Content: systemPrompt,
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
@@ -243,10 +658,10 @@ Generate the content now:`,
 			},
 		},
 		Temperature: 0.7,
-		MaxTokens:   2000,
+		MaxTokens:   1100,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate localized content: %w", err)
+		return nil, fmt.Errorf("failed to generate %s content: %w", language, err)
 	}
 
 	// Parse the JSON response
@@ -258,100 +673,234 @@ Generate the content now:`,
 	responseText = strings.TrimSuffix(responseText, "```")
 	responseText = strings.TrimSpace(responseText)
 
-	var result LocalizedContentGenerated
+	var result LocalizedContentData
 	err = json.Unmarshal([]byte(responseText), &result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse localized content JSON: %w\nResponse: %s", err, responseText)
+		return nil, fmt.Errorf("failed to parse %s content JSON: %w\nResponse: %s", language, err, responseText)
 	}
 
-	// Ensure we have all required fields with fallbacks
-	if result.EnglishContent.Title == "" {
-		result.EnglishContent.Title = title
-	}
-	if result.EnglishContent.PriceLabel == "" {
-		result.EnglishContent.PriceLabel = "Price"
-	}
-	if result.EnglishContent.AddressLabel == "" {
-		result.EnglishContent.AddressLabel = "Address"
-	}
-	if result.EnglishContent.CityLabel == "" {
-		result.EnglishContent.CityLabel = "City"
-	}
-	if result.EnglishContent.StateLabel == "" {
-		result.EnglishContent.StateLabel = "State"
-	}
-	if result.EnglishContent.ZipCodeLabel == "" {
-		result.EnglishContent.ZipCodeLabel = "ZIP Code"
-	}
-	if result.EnglishContent.AmenitiesLabel == "" {
-		result.EnglishContent.AmenitiesLabel = "Amenities & Features"
-	}
-	if result.EnglishContent.AgentLabel == "" {
-		result.EnglishContent.AgentLabel = "Contact Your Agent"
-	}
-	if result.EnglishContent.PropertyDescriptionLabel == "" {
-		result.EnglishContent.PropertyDescriptionLabel = "Property Description"
-	}
-	if result.EnglishContent.KeyHighlightsLabel == "" {
-		result.EnglishContent.KeyHighlightsLabel = "Key Highlights"
-	}
-	if result.EnglishContent.PropertyGalleryLabel == "" {
-		result.EnglishContent.PropertyGalleryLabel = "Property Gallery"
-	}
-	if result.EnglishContent.AdditionalSectionTitle == "" {
-		result.EnglishContent.AdditionalSectionTitle = "Investment Opportunity"
-	}
-	if result.EnglishContent.AdditionalSectionContent == "" {
-		result.EnglishContent.AdditionalSectionContent = "I've been selling properties in this area for years, and I can tell you - this is a rare find. The location commands premium value, and we're seeing consistent appreciation year after year. What really excites me is the potential here, both for investors seeking solid returns and families looking for their dream home. The market fundamentals are strong, demand is high, and properties like this don't stay available for long. Trust me, at this price point and in this neighborhood, you're looking at an opportunity that ticks all the boxes."
-	}
-	if result.EnglishContent.ThankYouMessage == "" {
-		result.EnglishContent.ThankYouMessage = "Thank you for considering this exceptional property. We appreciate your interest and would be delighted to provide you with additional information or arrange a viewing at your convenience. Please don't hesitate to reach out to our dedicated agent for any questions or to schedule a visit."
-	}
-	
-	// Arabic fallbacks
-	if result.ArabicContent.Title == "" {
-		result.ArabicContent.Title = title
-	}
-	if result.ArabicContent.PriceLabel == "" {
-		result.ArabicContent.PriceLabel = "السعر"
-	}
-	if result.ArabicContent.AddressLabel == "" {
-		result.ArabicContent.AddressLabel = "العنوان"
+	return &result, nil
+}
+
+
+// GenerateComparableSales asks the model for three fictional comparable sales near the listing.
+// The output is illustrative estimated market data, not real transactions.
+func (s *OpenAIService) GenerateComparableSales(city, state string, price float64, propertyType string) (*ComparableSales, error) {
+	ctx := context.Background()
+
+	if propertyType == "" {
+		propertyType = "residential property"
 	}
-	if result.ArabicContent.CityLabel == "" {
-		result.ArabicContent.CityLabel = "المدينة"
+
+	prompt := fmt.Sprintf(`Generate 3 fictional but realistic comparable sold properties for a real estate brochure.
+
+Subject property:
+- Location: %s, %s
+- Type: %s
+- Asking price: %.0f
+
+Each comparable must be plausible for the same area and price bracket (within roughly 20%% of the asking price).
+Do NOT use real street numbers; use an address stub such as "Marina Walk area" or "Near Central Park".
+
+Return ONLY valid JSON with this structure:
+{
+  "sales": [
+    {"addressStub": "<short fictional address stub>", "price": <sold price as a number>, "daysOnMarket": <integer>}
+  ]
+}`, city, state, propertyType, price)
+
+	resp, err := s.createChatCompletion(ctx, "comparable_sales", openai.ChatCompletionRequest{
+		Model: "gpt-4o-mini",
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "You are a real estate market analyst producing illustrative estimates. You always return valid JSON responses.",
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+		Temperature: 0.5,
+		MaxTokens:   400,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate comparable sales: %w", err)
 	}
-	if result.ArabicContent.StateLabel == "" {
-		result.ArabicContent.StateLabel = "الولاية"
+
+	responseText := strings.TrimSpace(resp.Choices[0].Message.Content)
+	responseText = strings.TrimPrefix(responseText, "```json")
+	responseText = strings.TrimPrefix(responseText, "```")
+	responseText = strings.TrimSuffix(responseText, "```")
+	responseText = strings.TrimSpace(responseText)
+
+	var result ComparableSales
+	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse comparable sales JSON: %w\nResponse: %s", err, responseText)
 	}
-	if result.ArabicContent.ZipCodeLabel == "" {
-		result.ArabicContent.ZipCodeLabel = "الرمز البريدي"
+	if len(result.Sales) == 0 {
+		return nil, fmt.Errorf("no comparable sales returned")
 	}
-	if result.ArabicContent.AmenitiesLabel == "" {
-		result.ArabicContent.AmenitiesLabel = "المرافق والميزات"
+	if len(result.Sales) > 3 {
+		result.Sales = result.Sales[:3]
 	}
-	if result.ArabicContent.AgentLabel == "" {
-		result.ArabicContent.AgentLabel = "اتصل بوكيلك"
+
+	return &result, nil
+}
+
+// GenerateVirtualStagingDescription asks the model how a space, described by the agent (e.g. "unfurnished
+// living room, Scandinavian style"), would look when virtually staged. The result is a visualization
+// for the brochure, not a description of the property as it is.
+func (s *OpenAIService) GenerateVirtualStagingDescription(space, propertyType string) (string, error) {
+	ctx := context.Background()
+
+	if propertyType == "" {
+		propertyType = "residential property"
 	}
-	if result.ArabicContent.PropertyDescriptionLabel == "" {
-		result.ArabicContent.PropertyDescriptionLabel = "وصف العقار"
+
+	prompt := fmt.Sprintf(`Describe how the following space would look once virtually staged, for a real estate brochure.
+
+Space: %s
+Property type: %s
+
+Requirements:
+- Name the furniture, materials, colors and lighting that stage the space
+- Use the decor style the space description asks for; otherwise choose one that suits the property type
+- Write in the conditional ("would", "could"); never claim the space is furnished today
+- 80-120 words in one paragraph of plain text, without a title, markdown or quotes`, space, propertyType)
+
+	resp, err := s.createChatCompletion(ctx, "virtual_staging", openai.ChatCompletionRequest{
+		Model: "gpt-4o-mini",
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "You are an interior designer writing vivid virtual staging descriptions for property brochures.",
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+		Temperature: 0.7,
+		MaxTokens:   250,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate virtual staging description: %w", err)
 	}
-	if result.ArabicContent.KeyHighlightsLabel == "" {
-		result.ArabicContent.KeyHighlightsLabel = "المميزات الرئيسية"
+
+	description := strings.Trim(strings.TrimSpace(resp.Choices[0].Message.Content), `"`)
+	if description == "" {
+		return "", fmt.Errorf("no virtual staging description returned")
 	}
-	if result.ArabicContent.PropertyGalleryLabel == "" {
-		result.ArabicContent.PropertyGalleryLabel = "معرض العقار"
+	return description, nil
+}
+
+// InfersBedrooms reports whether enrichment asks InferBedrooms for a submission's bedrooms: only apartments
+// without a bedroom count and with a description to read it from
+func InfersBedrooms(req *models.PropertyRequest) bool {
+	return req.EnrichMissingFields && req.Bedrooms == 0 && strings.TrimSpace(req.Description) != "" &&
+		strings.EqualFold(strings.TrimSpace(req.PropertyType), "apartment")
+}
+
+// InferBedrooms asks the model for the number of bedrooms a listing description states or clearly implies;
+// 0 means the description does not tell
+func (s *OpenAIService) InferBedrooms(propertyType, description string) (int, error) {
+	ctx := context.Background()
+
+	prompt := fmt.Sprintf(`How many bedrooms does this %s have?
+
+Description: %s
+
+Answer from the description only: count the bedrooms it states or clearly implies (a studio has 0).
+Answer 0 when the description does not tell. Do NOT guess from the price or the location.
+
+Return ONLY valid JSON with this structure:
+{"bedrooms": <whole number>}`, propertyType, s.fitDescription(description, maxPromptTokens))
+
+	resp, err := s.createChatCompletion(ctx, "bedroom_inference", openai.ChatCompletionRequest{
+		Model: "gpt-4o-mini",
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "You are a real estate listing assistant. You always return valid JSON responses.",
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+		Temperature: 0,
+		MaxTokens:   20,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to infer bedrooms: %w", err)
 	}
-	if result.ArabicContent.AdditionalSectionTitle == "" {
-		result.ArabicContent.AdditionalSectionTitle = "فرصة استثمارية"
+
+	responseText := strings.TrimSpace(resp.Choices[0].Message.Content)
+	responseText = strings.TrimPrefix(responseText, "```json")
+	responseText = strings.TrimPrefix(responseText, "```")
+	responseText = strings.TrimSuffix(responseText, "```")
+	responseText = strings.TrimSpace(responseText)
+
+	var result struct {
+		Bedrooms int `json:"bedrooms"`
 	}
-	if result.ArabicContent.AdditionalSectionContent == "" {
-		result.ArabicContent.AdditionalSectionContent = "أعمل في بيع العقارات في هذه المنطقة منذ سنوات، وأستطيع أن أخبرك - هذا اكتشاف نادر. الموقع يتمتع بقيمة متميزة، ونحن نشهد ارتفاعًا مستمرًا في الأسعار عامًا بعد عام. ما يثير حماسي حقًا هو الإمكانات الهائلة هنا، سواء للمستثمرين الباحثين عن عوائد قوية أو العائلات الباحثة عن منزل أحلامهم. أساسيات السوق قوية، والطلب مرتفع، والعقارات مثل هذا لا تبقى متاحة لفترة طويلة. ثق بي، بهذا السعر وفي هذا الحي، أنت تنظر إلى فرصة تحقق جميع المعايير."
+	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
+		return 0, fmt.Errorf("failed to parse bedrooms JSON: %w\nResponse: %s", err, responseText)
 	}
-	if result.ArabicContent.ThankYouMessage == "" {
-		result.ArabicContent.ThankYouMessage = "نشكركم على اهتمامكم بهذا العقار الاستثنائي. نحن نقدر اهتمامكم ويسعدنا تزويدكم بمعلومات إضافية أو ترتيب موعد للمعاينة في الوقت المناسب لكم. لا تترددوا في التواصل مع وكيلنا المختص لأية استفسارات أو لتحديد موعد للزيارة."
+	return result.Bedrooms, nil
+}
+
+var _ AddressNormalizer = (*OpenAIService)(nil)
+
+// NormalizeAddress asks the model to correct typos, abbreviations and casing in a postal address and to
+// rate how likely the corrected address exists
+func (s *OpenAIService) NormalizeAddress(input models.PostalAddress) (*NormalizedAddress, error) {
+	ctx := context.Background()
+
+	prompt := fmt.Sprintf(`Normalize this property address entered by a real estate agent.
+
+Street address: %s
+City: %s
+State/region: %s
+Zip/postal code: %s
+
+Fix typos, casing and abbreviations, and fill in a missing city, state or postal code only when the rest of
+the address makes it unambiguous. Keep components empty when unsure. Do NOT invent street numbers.
+Rate your confidence that the normalized address is a real, existing address from 0 (certainly not) to 1 (certain).
+
+Return ONLY valid JSON with this structure:
+{"address": "<street address>", "city": "<city>", "state": "<state or region>", "zipCode": "<postal code>", "confidence": <number from 0 to 1>}`,
+		input.Address, input.City, input.State, input.ZipCode)
+
+	resp, err := s.createChatCompletion(ctx, "address_normalization", openai.ChatCompletionRequest{
+		Model: "gpt-4o-mini",
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "You are a postal address verification assistant. You always return valid JSON responses.",
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+		Temperature: 0,
+		MaxTokens:   200,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize address: %w", err)
 	}
 
+	responseText := strings.TrimSpace(resp.Choices[0].Message.Content)
+	responseText = strings.TrimPrefix(responseText, "```json")
+	responseText = strings.TrimPrefix(responseText, "```")
+	responseText = strings.TrimSuffix(responseText, "```")
+	responseText = strings.TrimSpace(responseText)
+
+	var result NormalizedAddress
+	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse normalized address JSON: %w\nResponse: %s", err, responseText)
+	}
 	return &result, nil
 }
-
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"math"
+	"property-brochure-backend/models"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// openAICostHistorySize is the number of recent completions kept for GET /api/admin/openai-costs
+const openAICostHistorySize = 100
+
+// OpenAIUsageEntry is the token usage and estimated cost of one chat completion
+type OpenAIUsageEntry struct {
+	Purpose          string    `json:"purpose"`
+	Model            string    `json:"model"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	EstimatedCostUSD float64   `json:"estimatedCostUSD"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// OpenAICostReport sums up the chat completions made since the server started
+type OpenAICostReport struct {
+	TotalTokensUsed   int64              `json:"totalTokensUsed"`
+	EstimatedCostUSD  float64            `json:"estimatedCostUSD"`
+	PerRequestHistory []OpenAIUsageEntry `json:"perRequestHistory"`
+}
+
+// openAICosts accumulates token usage and its estimated cost in memory
+type openAICosts struct {
+	tokens  atomic.Int64
+	costUSD atomic.Uint64 // math.Float64bits of the running total
+
+	mu      sync.Mutex
+	history [openAICostHistorySize]OpenAIUsageEntry
+	next    int // index the next entry is written to
+	count   int
+}
+
+func (c *openAICosts) record(entry OpenAIUsageEntry) {
+	c.tokens.Add(int64(entry.PromptTokens + entry.CompletionTokens))
+	for {
+		old := c.costUSD.Load()
+		if c.costUSD.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+entry.EstimatedCostUSD)) {
+			break
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.history[c.next] = entry
+	c.next = (c.next + 1) % len(c.history)
+	if c.count < len(c.history) {
+		c.count++
+	}
+}
+
+// report returns the totals and the recorded completions, newest first
+func (c *openAICosts) report() OpenAICostReport {
+	c.mu.Lock()
+	history := make([]OpenAIUsageEntry, c.count)
+	for i := range history {
+		history[i] = c.history[(c.next-1-i+len(c.history))%len(c.history)]
+	}
+	c.mu.Unlock()
+
+	return OpenAICostReport{
+		TotalTokensUsed:   c.tokens.Load(),
+		EstimatedCostUSD:  math.Float64frombits(c.costUSD.Load()),
+		PerRequestHistory: history,
+	}
+}
+
+// Costs reports the token usage and estimated cost of the chat completions made since startup
+func (s *OpenAIService) Costs() OpenAICostReport {
+	return s.costs.report()
+}
+
+// createChatCompletion sends the request and records its token usage under purpose
+func (s *OpenAIService) createChatCompletion(ctx context.Context, purpose string, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	resp, err := s.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	model := resp.Model
+	if model == "" {
+		model = req.Model
+	}
+	s.costs.record(OpenAIUsageEntry{
+		Purpose:          purpose,
+		Model:            model,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		EstimatedCostUSD: estimateOpenAICost(model, resp.Usage),
+		CreatedAt:        time.Now(),
+	})
+	return resp, nil
+}
+
+// EstimateSubmissionCost estimates, in USD, the chat completions a listing submission makes, assuming
+// each one uses its full completion budget. It is an upper bound for QA and planning, not a quote.
+func (s *OpenAIService) EstimateSubmissionCost(req *models.PropertyRequest) float64 {
+	listing := s.estimateTokenCount(req.Title) + s.estimateTokenCount(strings.Join(req.Amenities, ", "))
+	description := s.estimateTokenCount(req.Description)
+
+	var usage openai.Usage
+	add := func(prompt, completion int) {
+		usage.PromptTokens += prompt
+		usage.CompletionTokens += completion
+	}
+
+	// GeneratePropertyContent: an English description when the agent's is too short, its Arabic
+	// translation and the highlights; the prompt sizes approximate their instructions
+	english := description
+	if len(req.Description) < 50 {
+		add(100+listing, 500)
+		english = 500
+	}
+	add(60+english, 600)
+	add(80+listing+english, 300)
+
+	// GenerateLocalizedContent makes one completion per language with the description cut to fit
+	languages := len(req.Languages)
+	if languages == 0 {
+		languages = 2
+	}
+	localized := localizedPromptOverheadTokens + listing
+	if localized+description > maxPromptTokens {
+		description = maxPromptTokens - localized
+	}
+	add(languages*(localized+description), languages*1100)
+
+	if req.IncludeComps {
+		add(250, 400)
+	}
+	if req.VirtualStaging != "" {
+		add(150+s.estimateTokenCount(req.VirtualStaging), 250)
+	}
+	if InfersBedrooms(req) {
+		add(100+s.estimateTokenCount(req.Description), 20)
+	}
+	if req.ValidateAddress {
+		add(250+s.estimateTokenCount(req.Address+" "+req.City+" "+req.State+" "+req.ZipCode), 200)
+	}
+	return estimateOpenAICost("gpt-4o-mini", usage)
+}
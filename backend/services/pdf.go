@@ -4,22 +4,33 @@ import (
 	"bytes"
 	"fmt"
     "image"
-    _ "image/jpeg"
+    "image/draw"
+    "image/gif"
+    "image/jpeg"
     _ "image/png"
     "io"
+	"log"
+	"math"
 	"net/http"
     "os"
 	"property-brochure-backend/models"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/jung-kurt/gofpdf"
+	"github.com/jung-kurt/gofpdf/contrib/barcode"
+	"github.com/skip2/go-qrcode"
+    "golang.org/x/image/webp"
     "golang.org/x/text/encoding/charmap"
     "golang.org/x/text/transform"
 )
 
 
 const (
-	// Primary colors
+	// Primary colors of the default theme
 	darkBlueR, darkBlueG, darkBlueB = 31, 78, 121   
 	goldR, goldG, goldB             = 212, 175, 55  
 	
@@ -28,47 +39,351 @@ const (
 	darkGrayR, darkGrayG, darkGrayB    = 60, 60, 60    
 	mediumGrayR, mediumGrayG, mediumGrayB = 120, 120, 120 
 	
-	// Background colors - warm cream/beige for professional look
+	// Background colors - warm cream/beige for professional look (default theme)
 	bgCreamR, bgCreamG, bgCreamB = 250, 248, 243
 	
-	// Page dimensions
-	pageWidth  = 210.0
-	pageHeight = 297.0
-	marginX    = 15.0
-	marginY    = 15.0
-	contentWidth = pageWidth - (2 * marginX)
+	// Default page margins; a brochure may override them with marginMm
+	defaultMargin = 15.0
+	MinMarginMm   = 5
+	MaxMarginMm   = 30
 )
 
+// Margins are the page margins in millimetres
+type Margins struct {
+	Left, Right, Top, Bottom float64
+}
+
+// Default font files in the project fonts folder
+const (
+	DefaultArabicFontPath = "fonts/NotoNaskhArabic-Regular.ttf"
+	DefaultBodyFontPath   = "fonts/Roboto-Regular.ttf"
+)
+
+// Preset is a named brochure theme bundling the colors, core font families and cover layout.
+// Presets are loaded from a YAML file at startup and selected per listing with themePreset.
+type Preset struct {
+	PrimaryColor    Color  `yaml:"primaryColor"`
+	AccentColor     Color  `yaml:"accentColor"`
+	BackgroundColor Color  `yaml:"backgroundColor"`
+	TitleFontName   string `yaml:"titleFontName"`
+	BodyFontName    string `yaml:"bodyFontName"`
+	CoverTemplate   string `yaml:"coverTemplate"`
+
+	// SectionHeaderPattern fills the section header bars: "solid", "gradient" or "lines"
+	SectionHeaderPattern string `yaml:"sectionHeaderPattern"`
+}
+
+// defaultPreset is the dark blue and gold theme used when a listing has no preset;
+// presets loaded from YAML start from it, so omitted settings keep these values
+var defaultPreset = Preset{
+	PrimaryColor:    Color{darkBlueR, darkBlueG, darkBlueB},
+	AccentColor:     Color{goldR, goldG, goldB},
+	BackgroundColor: Color{bgCreamR, bgCreamG, bgCreamB},
+	TitleFontName:   "Arial",
+	BodyFontName:    "Arial",
+	CoverTemplate:   CoverTemplateClassic,
+
+	SectionHeaderPattern: SectionHeaderSolid,
+}
+
+// PDFOption customizes a PDFService created by NewPDFService
+type PDFOption func(*PDFService)
+
+// WithArabicFontPath sets the Arabic TTF file; an empty path skips it and falls back to the core fonts
+func WithArabicFontPath(path string) PDFOption {
+	return func(s *PDFService) { s.arabicFontPath = path }
+}
+
+// WithBodyFontPath sets the body TTF file; an empty path skips it and falls back to the Arabic or core fonts
+func WithBodyFontPath(path string) PDFOption {
+	return func(s *PDFService) { s.bodyFontPath = path }
+}
+
+// WithBrandLogoURL draws the logo at the URL in the top-right corner of the pages
+func WithBrandLogoURL(url string) PDFOption {
+	return func(s *PDFService) { s.brandLogoURL = url }
+}
+
+// WithMargins sets the default page margins in millimetres
+func WithMargins(margins Margins) PDFOption {
+	return func(s *PDFService) { s.margins = margins }
+}
+
+// WithFontLoader enables agency fonts uploaded through the font endpoint
+func WithFontLoader(loader FontLoader) PDFOption {
+	return func(s *PDFService) { s.fontLoader = loader }
+}
+
+// WithCoverAspectRatio sets the cover image aspect ratio from a "W:H" string such as "4:3";
+// empty or malformed values keep 16:9
+func WithCoverAspectRatio(ratio string) PDFOption {
+	return func(s *PDFService) {
+		s.coverAspectW, s.coverAspectH = parseAspectRatio(ratio, 16, 9)
+	}
+}
+
+// WithSectionHeaderPattern sets the section header fill of the default theme; presets set their own.
+// Empty or unknown patterns keep the solid fill.
+func WithSectionHeaderPattern(pattern string) PDFOption {
+	return func(s *PDFService) {
+		if isSectionHeaderPattern(pattern) {
+			s.theme.SectionHeaderPattern = pattern
+		}
+	}
+}
+
+// WithThemePresets makes the named presets available to listings' themePreset
+func WithThemePresets(presets map[string]Preset) PDFOption {
+	return func(s *PDFService) { s.presets = presets }
+}
+
+// WithPDFToImagePath sets the rasterizer used for cover previews: pdftoppm or ImageMagick
+func WithPDFToImagePath(path string) PDFOption {
+	return func(s *PDFService) {
+		if path != "" {
+			s.pdfToImagePath = path
+		}
+	}
+}
+
+// PageToggles switches optional brochure pages ("virtualTour", "floorPlan", "comps") off at render time
+type PageToggles interface {
+	PageEnabled(page string) bool
+}
+
+// WithPageToggles consults toggles before rendering an optional page; without it every page is enabled
+func WithPageToggles(toggles PageToggles) PDFOption {
+	return func(s *PDFService) { s.pageToggles = toggles }
+}
+
+// pageEnabled reports whether an optional page may be rendered
+func (s *PDFService) pageEnabled(page string) bool {
+	return s.pageToggles == nil || s.pageToggles.PageEnabled(page)
+}
+
+// BrochureGenerator renders a listing's PDF brochures and cover preview
+type BrochureGenerator interface {
+	GenerateEnglishBrochure(property *models.Property) ([]byte, error)
+	GenerateArabicBrochure(property *models.Property) ([]byte, error)
+	GenerateUrduBrochure(property *models.Property) ([]byte, error)
+	GenerateBilingualBrochure(property *models.Property) ([]byte, error)
+	GenerateCoverPagePreview(property *models.Property) ([]byte, error)
+	GenerateComparisonBrochure(left, right *models.Property) ([]byte, error)
+	CheckCoverImage(property *models.Property) error
+	HasThemePreset(name string) bool
+	EncryptPDF(data []byte, password string) ([]byte, error)
+}
+
+var _ BrochureGenerator = (*PDFService)(nil)
+
 type PDFService struct{
     arabicFontName string
     hasArabicFont  bool
     brandLogoURL   string
     bodyFontName   string
     hasBodyFont    bool
+    coverAspectW   int
+    coverAspectH   int
+    pdfToImagePath string
+
+    // Font files are read once from these paths and registered from memory on each document
+    arabicFontPath string
+    bodyFontPath   string
+    fontFiles      *fontFiles
+
+    margins Margins
+
+    // fontLoader provides agency fonts selected per listing; nil disables them
+    fontLoader FontLoader
+
+    // theme is the preset applied to the current generation call, see themed
+    theme   Preset
+    presets map[string]Preset
+
+    // Brochures larger than optimizeThreshold bytes are re-compressed with Ghostscript when enabled
+    optimizePDFs      bool
+    optimizeThreshold int64
+    ghostscriptPath   string
+
+    // toolTimeout bounds each run of an external PDF tool, see runPDFTool
+    toolTimeout time.Duration
+
+    // linearizeCommand rewrites brochures for fast web view when set, see WithPDFLinearization
+    linearizeCommand []string
+
+    // staticMapsAPIKey enables the cover map strip of listings with coordinates, see WithStaticMaps
+    staticMapsAPIKey string
+
+    // featured supplies the property of the week cross-sold on contact pages; nil disables the inset
+    featured FeaturedListingProvider
+
+    // urduFontPath is the Nastaliq font of Urdu brochures; urdu marks the copy rendering one, see forUrdu
+    urduFontPath string
+    urdu         bool
+
+    // pageToggles can switch optional pages off without a redeployment; nil enables them all
+    pageToggles PageToggles
+
+    // objects reads uploaded photos by key; printReady marks the copy rendering a print-ready
+    // brochure, see forPrint, and printKeywords are the metadata keywords of that copy
+    objects       ObjectReader
+    printReady    bool
+    printKeywords string
+}
+
+// fontFiles holds the configured font bytes, shared by the themed copies of a service
+type fontFiles struct {
+    once   sync.Once
+    arabic []byte
+    body   []byte
+    urdu   []byte
 }
 
-func NewPDFService() *PDFService {
-    // Optional branding logo via env var
-    logoURL := os.Getenv("BRAND_LOGO_URL")
-    return &PDFService{brandLogoURL: logoURL}
+// Maximum height of the cover image box; wider aspect ratios produce shorter boxes
+const coverImageMaxHeight = 155.0
+
+// NewPDFService creates the service with the project fonts, a 16:9 cover, pdftoppm previews,
+// 15mm margins, the default theme and no logo, then applies the options
+func NewPDFService(opts ...PDFOption) *PDFService {
+    s := &PDFService{
+        arabicFontPath: DefaultArabicFontPath,
+        bodyFontPath:   DefaultBodyFontPath,
+        urduFontPath:   DefaultUrduFontPath,
+        coverAspectW:   16,
+        coverAspectH:   9,
+        pdfToImagePath: "pdftoppm",
+        ghostscriptPath: "gs",
+        toolTimeout:    defaultPDFToolTimeout,
+        margins:        Margins{Left: defaultMargin, Right: defaultMargin, Top: defaultMargin, Bottom: defaultMargin},
+        fontFiles:      &fontFiles{},
+        theme:          defaultPreset,
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+// parseAspectRatio parses a "W:H" string, returning the defaults when it is empty or malformed
+func parseAspectRatio(value string, defaultW, defaultH int) (int, int) {
+    var w, h int
+    if _, err := fmt.Sscanf(value, "%d:%d", &w, &h); err != nil || w <= 0 || h <= 0 {
+        return defaultW, defaultH
+    }
+    return w, h
+}
+
+// coverImageHeight returns the cover image box height matching the configured aspect ratio
+func (s *PDFService) coverImageHeight(contentWidth float64) float64 {
+    height := contentWidth * float64(s.coverAspectH) / float64(s.coverAspectW)
+    if height > coverImageMaxHeight {
+        height = coverImageMaxHeight
+    }
+    return height
+}
+
+// orientation returns the gofpdf orientation code for the brochure
+func (s *PDFService) orientation(property *models.Property) string {
+	if property.Landscape {
+		return "L"
+	}
+	return "P"
+}
+
+// pageSizes maps the supported paper sizes to their portrait dimensions in millimetres
+var pageSizes = map[string]gofpdf.SizeType{
+	"A4":     {Wd: 210, Ht: 297},
+	"Letter": {Wd: 215.9, Ht: 279.4},
+	"Legal":  {Wd: 215.9, Ht: 355.6},
+}
+
+// newDocument creates a PDF in the given orientation and paper size, defaulting to A4.
+// A positive marginMm replaces the configured margins on all four sides.
+func (s *PDFService) newDocument(orientation, size string, marginMm int) *gofpdf.Fpdf {
+	dims, ok := pageSizes[size]
+	if !ok {
+		dims = pageSizes["A4"]
+	}
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: orientation,
+		UnitStr:        "mm",
+		Size:           dims,
+	})
+
+	margins := s.margins
+	if marginMm > 0 {
+		m := float64(marginMm)
+		margins = Margins{Left: m, Right: m, Top: m, Bottom: m}
+	}
+	// The margins live on the document so concurrent brochures can use different values
+	pdf.SetMargins(margins.Left, margins.Top, margins.Right)
+	pdf.SetAutoPageBreak(false, margins.Bottom)
+	if s.printReady {
+		s.setPrintMetadata(pdf)
+	}
+	return pdf
+}
+
+// pageMargins returns the margins the document was created with
+func pageMargins(pdf *gofpdf.Fpdf) Margins {
+	left, top, right, bottom := pdf.GetMargins()
+	return Margins{Left: left, Right: right, Top: top, Bottom: bottom}
+}
+
+// decorationScale is the ratio of the page's short side to A4 width, used to size decorative elements
+func decorationScale(pdf *gofpdf.Fpdf) float64 {
+	width, height := pdf.GetPageSize()
+	return math.Min(width, height) / 210
+}
+
+// pageSize returns the page width, page height and usable content width of the document,
+// so the same layout code serves portrait and landscape brochures
+func pageSize(pdf *gofpdf.Fpdf) (float64, float64, float64) {
+	width, height := pdf.GetPageSize()
+	margins := pageMargins(pdf)
+	return width, height, width - margins.Left - margins.Right
 }
 
 func (s *PDFService) GenerateBrochure(property *models.Property) ([]byte, error) {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetAutoPageBreak(false, 15) 
-    s.setupFonts(pdf)
+	s = s.themed(property)
+	pdf := s.newDocument("P", property.PageSize, property.MarginMm)
+    s.setupFonts(pdf, property)
 	
 	// Page 1: Cover Page
 	s.addCoverPage(pdf, property)
+	s.addBookmark(pdf, "cover", false)
 	
 	// Page 2: Property Description & Details (English)
 	s.addDetailsPageOnly(pdf, property, false)
+	s.addBookmark(pdf, "details", false)
 	
 	// Page 3: Investment Opportunity & Gallery
 	s.addInvestmentAndGalleryPage(pdf, property, false)
+	s.addBookmark(pdf, "investmentGallery", false)
+	
+	// Optional: Virtual Tour QR code
+	if property.VirtualTourURL != "" && s.pageEnabled("virtualTour") {
+		s.addVirtualTourPage(pdf, property, false)
+		s.addBookmark(pdf, "virtualTour", false)
+	}
+	
+	// Optional: Comparable Sales (illustrative estimates)
+	if len(property.ComparableSales) > 0 && s.pageEnabled("comps") {
+		s.addComparableSalesPage(pdf, property, false)
+		s.addBookmark(pdf, "comps", false)
+	}
+	
+	// Optional: Floor Plan
+	if property.FloorPlanURL != "" && s.pageEnabled("floorPlan") {
+		s.addFloorPlanPage(pdf, property, false)
+		s.addBookmark(pdf, "floorPlan", false)
+	}
 	
 	// Page 4: Arabic Description & Agent Contact Info
 	s.addArabicAndContactPage(pdf, property)
+	s.addBookmark(pdf, "contact", false)
+	
+	s.addPropertyReferenceCode(pdf, property, 1)
 	
 	// Generate PDF bytes
 	var buf bytes.Buffer
@@ -77,26 +392,17 @@ func (s *PDFService) GenerateBrochure(property *models.Property) ([]byte, error)
 		return nil, fmt.Errorf("failed to generate PDF: %w", err)
 	}
 
-	return buf.Bytes(), nil
+	return s.finishPDF(buf.Bytes(), "brochure"), nil
 }
 
 // GenerateEnglishBrochure creates an English-only brochure
 func (s *PDFService) GenerateEnglishBrochure(property *models.Property) ([]byte, error) {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetAutoPageBreak(false, 15)
-	s.setupFonts(pdf)
+	s = s.themed(property)
+	pdf := s.newDocument(s.orientation(property), property.PageSize, property.MarginMm)
+	s.setupFonts(pdf, property)
 	
-	// Page 1: Cover Page
-	s.addCoverPage(pdf, property)
-	
-	// Page 2: Property Description & Details (Description, Highlights, Amenities)
-	s.addDetailsPageOnly(pdf, property, false)
-	
-	// Page 3: Investment Opportunity & Gallery
-	s.addInvestmentAndGalleryPage(pdf, property, false)
-	
-	// Page 4: Agent Contact Info & Thank You
-	s.addContactPage(pdf, property)
+	coverPage := s.addPagesInOrder(pdf, property, false)
+	s.addPropertyReferenceCode(pdf, property, coverPage)
 	
 	// Generate PDF bytes
 	var buf bytes.Buffer
@@ -105,26 +411,17 @@ func (s *PDFService) GenerateEnglishBrochure(property *models.Property) ([]byte,
 		return nil, fmt.Errorf("failed to generate English PDF: %w", err)
 	}
 
-	return buf.Bytes(), nil
+	return s.finishPDF(buf.Bytes(), "English"), nil
 }
 
 // GenerateArabicBrochure creates an Arabic-only brochure with RTL layout
 func (s *PDFService) GenerateArabicBrochure(property *models.Property) ([]byte, error) {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetAutoPageBreak(false, 15)
-	s.setupFonts(pdf)
-	
-	// Page 1: Cover Page (Arabic-focused)
-	s.addCoverPageArabic(pdf, property)
+	s = s.themed(property)
+	pdf := s.newDocument(s.orientation(property), property.PageSize, property.MarginMm)
+	s.setupFonts(pdf, property)
 	
-	// Page 2: Arabic Description & Details (Description, Highlights, Amenities)
-	s.addDetailsPageOnly(pdf, property, true)
-	
-	// Page 3: Investment Opportunity & Gallery
-	s.addInvestmentAndGalleryPage(pdf, property, true)
-	
-	// Page 4: Agent Contact Info & Thank You (Arabic labels)
-	s.addContactPageWithLanguage(pdf, property, true)
+	coverPage := s.addPagesInOrder(pdf, property, true)
+	s.addPropertyReferenceCode(pdf, property, coverPage)
 	
 	// Generate PDF bytes
 	var buf bytes.Buffer
@@ -133,15 +430,327 @@ func (s *PDFService) GenerateArabicBrochure(property *models.Property) ([]byte,
 		return nil, fmt.Errorf("failed to generate Arabic PDF: %w", err)
 	}
 
-	return buf.Bytes(), nil
+	return s.finishPDF(buf.Bytes(), "Arabic"), nil
+}
+
+// defaultPageOrder is the brochure layout used when the request does not specify one.
+// "investment" directly followed by "gallery" shares a single page.
+var defaultPageOrder = []string{"cover", "details", "investment", "gallery", "virtualTour", "comps", "floorPlan", "contact"}
+
+// ValidatePageOrder checks a requested page order: every name must be a page of defaultPageOrder and
+// appear at most once, so one brochure never renders more pages than the default layout
+func ValidatePageOrder(order []string) error {
+	if len(order) > len(defaultPageOrder) {
+		return fmt.Errorf("page order must list at most %d pages", len(defaultPageOrder))
+	}
+	seen := make(map[string]bool, len(order))
+	for _, name := range order {
+		if !isPageName(name) {
+			return fmt.Errorf("unknown page %q in page order, expected one of %s", name, strings.Join(defaultPageOrder, ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("page %q is listed more than once in page order", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// isPageName reports whether name is a page of the brochure layout
+func isPageName(name string) bool {
+	for _, page := range defaultPageOrder {
+		if page == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addPagesInOrder renders the brochure pages in property.PageOrder (or the default order),
+// inserting a table of contents after the cover for longer brochures.
+// It returns the page number of the cover, or 0 when the order has no cover.
+func (s *PDFService) addPagesInOrder(pdf *gofpdf.Fpdf, property *models.Property, isArabic bool) int {
+	coverPage := 0
+	pages := s.planPages(property)
+	
+	// First pass: decide where the TOC goes so every page number is known up front
+	if len(pages) > 0 {
+		contentPages := 0
+		tocIndex := 0
+		for i, key := range pages {
+			if key == "cover" {
+				tocIndex = i + 1
+			} else {
+				contentPages++
+			}
+		}
+		if contentPages > tocMinContentPages {
+			pages = append(pages[:tocIndex], append([]string{"toc"}, pages[tocIndex:]...)...)
+		}
+	}
+	
+	// Second pass: render, with the TOC using the planned page numbers
+	for _, key := range pages {
+		switch key {
+		case "cover":
+			if property.Landscape {
+				s.addLandscapeCoverPage(pdf, property, isArabic)
+			} else if isArabic {
+				s.addCoverPageArabic(pdf, property)
+			} else {
+				s.addCoverPage(pdf, property)
+			}
+			coverPage = pdf.PageNo()
+		case "toc":
+			s.addTableOfContentsPage(pdf, property, pages, isArabic)
+		case "details":
+			s.addDetailsPageOnly(pdf, property, isArabic)
+		case "investmentGallery":
+			s.addInvestmentAndGalleryPage(pdf, property, isArabic)
+		case "investment":
+			s.addInvestmentGalleryPage(pdf, property, isArabic, true, false)
+		case "gallery":
+			s.addInvestmentGalleryPage(pdf, property, isArabic, false, true)
+		case "virtualTour":
+			s.addVirtualTourPage(pdf, property, isArabic)
+		case "comps":
+			s.addComparableSalesPage(pdf, property, isArabic)
+		case "floorPlan":
+			s.addFloorPlanPage(pdf, property, isArabic)
+		case "contact":
+			s.addContactPageWithLanguage(pdf, property, isArabic)
+		}
+		
+		// Outline entry pointing at the page just rendered
+		s.addBookmark(pdf, key, isArabic)
+	}
+	return coverPage
+}
+
+// addPropertyReferenceCode draws the property ID as a 40x10mm Code128 barcode in the bottom-right
+// corner of the cover page, with the hex ID printed beneath it in 8-character groups
+func (s *PDFService) addPropertyReferenceCode(pdf *gofpdf.Fpdf, property *models.Property, coverPage int) {
+	margins := pageMargins(pdf)
+	if coverPage == 0 || property.ID.IsZero() {
+		return
+	}
+	pageWidth, pageHeight, _ := pageSize(pdf)
+	
+	// Return to the cover, then restore the last page so the document closes where it ended
+	lastPage := pdf.PageNo()
+	pdf.SetPage(coverPage)
+	defer pdf.SetPage(lastPage)
+	
+	code := property.ID.Hex()
+	barcodeW, barcodeH := 40.0, 10.0
+	x := pageWidth - margins.Right - barcodeW
+	y := pageHeight - margins.Bottom - barcodeH - 4
+	
+	key := barcode.RegisterCode128(pdf, code)
+	barcode.Barcode(pdf, key, x, y, barcodeW, barcodeH, false)
+	
+	pdf.SetFont(s.theme.BodyFontName, "", 6)
+	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+	pdf.SetXY(x, y+barcodeH+0.5)
+	pdf.CellFormat(barcodeW, 3, code[0:8]+" "+code[8:16]+" "+code[16:24], "", 0, "C", false, 0, "")
+}
+
+// bookmarkTitles holds the English and Arabic outline labels for each page key
+var bookmarkTitles = map[string][2]string{
+	"cover":             {"Cover", "الغلاف"},
+	"toc":               {"Contents", "المحتويات"},
+	"details":           {"Property Description", "وصف العقار"},
+	"investmentGallery": {"Gallery & Investment", "المعرض والاستثمار"},
+	"investment":        {"Investment", "الاستثمار"},
+	"gallery":           {"Gallery", "المعرض"},
+	"virtualTour":       {"Virtual Tour", "جولة افتراضية"},
+	"comps":             {"Comparable Sales", "مبيعات مماثلة"},
+	"floorPlan":         {"Floor Plan", "مخطط الطابق"},
+	"contact":           {"Contact", "التواصل"},
+}
+
+// addBookmark adds a top-level outline entry for the current page so viewers can navigate the brochure
+func (s *PDFService) addBookmark(pdf *gofpdf.Fpdf, key string, isArabic bool) {
+	titles, ok := bookmarkTitles[key]
+	if !ok {
+		return
+	}
+	title := titles[0]
+	if isArabic && s.hasArabicFont {
+		title = s.rtl(titles[1])
+	}
+	
+	// Outline titles are encoded as UTF-16 only while a UTF-8 font is active
+	if s.hasBodyFont {
+		pdf.SetFont(s.bodyFontName, "", 10)
+	}
+	pdf.Bookmark(title, 0, 0)
+}
+
+// planPages resolves the requested page order into the pages that will actually be rendered.
+// Unknown and repeated names are skipped (listings stored before ValidatePageOrder may have them), optional pages are skipped when their data is missing or they are toggled off, and
+// "investment" directly followed by "gallery" becomes the combined "investmentGallery" page.
+func (s *PDFService) planPages(property *models.Property) []string {
+	order := property.PageOrder
+	if len(order) == 0 {
+		order = defaultPageOrder
+	}
+	
+	pages := []string{}
+	seen := map[string]bool{}
+	for i := 0; i < len(order); i++ {
+		if seen[order[i]] {
+			continue
+		}
+		seen[order[i]] = true
+		switch order[i] {
+		case "cover", "details", "contact":
+			pages = append(pages, order[i])
+		case "investment":
+			if i+1 < len(order) && order[i+1] == "gallery" && !seen["gallery"] {
+				pages = append(pages, "investmentGallery")
+				seen["gallery"] = true
+				i++
+			} else {
+				pages = append(pages, "investment")
+			}
+		case "gallery":
+			if len(property.ImageURLs) > 1 {
+				pages = append(pages, "gallery")
+			}
+		case "virtualTour":
+			if property.VirtualTourURL != "" && s.pageEnabled("virtualTour") {
+				pages = append(pages, "virtualTour")
+			}
+		case "comps":
+			if len(property.ComparableSales) > 0 && s.pageEnabled("comps") {
+				pages = append(pages, "comps")
+			}
+		case "floorPlan":
+			if property.FloorPlanURL != "" && s.pageEnabled("floorPlan") {
+				pages = append(pages, "floorPlan")
+			}
+		}
+	}
+	return pages
+}
+
+// tocMinContentPages is the number of content pages (excluding the cover) above which a TOC is added
+const tocMinContentPages = 3
+
+// addTableOfContentsPage lists each section with its page number; pages is the full render plan including the TOC
+func (s *PDFService) addTableOfContentsPage(pdf *gofpdf.Fpdf, property *models.Property, pages []string, isArabic bool) {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+	pdf.AddPage()
+	
+	// Add cream background
+	s.addPageBackground(pdf)
+	
+	s.addBrandingIfAvailable(pdf)
+	currentY := margins.Top + 10.0
+	
+	useArabic := isArabic && s.hasArabicFont
+	if useArabic {
+		currentY = s.addSectionHeaderAligned(pdf, s.rtl("المحتويات"), currentY, s.arabicFontName, "R")
+	} else {
+		currentY = s.addSectionHeaderWithIcon(pdf, "Contents", currentY, "toc")
+	}
+	currentY += 8
+	
+	rowH := 12.0
+	numberW := 15.0
+	for i, key := range pages {
+		if key == "cover" || key == "toc" {
+			continue
+		}
+		pageNo := i + 1
+		title := s.sectionTitle(property, key, isArabic)
+		
+		if useArabic {
+			pdf.SetFont(s.arabicFontName, "", 13)
+		} else {
+			pdf.SetFont(s.theme.BodyFontName, "", 13)
+		}
+		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+		
+		// Title and page number on opposite sides, mirrored for RTL
+		titleX, numberX, titleAlign, numberAlign := margins.Left, margins.Left+contentWidth-numberW, "L", "R"
+		if useArabic {
+			titleX, numberX, titleAlign, numberAlign = margins.Left+numberW, margins.Left, "R", "L"
+		}
+		link := pdf.AddLink()
+		pdf.SetLink(link, 0, pageNo)
+		pdf.SetXY(titleX, currentY)
+		pdf.CellFormat(contentWidth-numberW, rowH, title, "", 0, titleAlign, false, link, "")
+		
+		pdf.SetFont(s.theme.TitleFontName, "B", 13)
+		pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+		pdf.SetXY(numberX, currentY)
+		pdf.CellFormat(numberW, rowH, fmt.Sprintf("%d", pageNo), "", 0, numberAlign, false, link, "")
+		
+		// Dotted gold rule under each entry
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
+		pdf.SetLineWidth(0.3)
+		pdf.SetDashPattern([]float64{0.5, 1.5}, 0)
+		pdf.Line(margins.Left, currentY+rowH, margins.Left+contentWidth, currentY+rowH)
+		pdf.SetDashPattern([]float64{}, 0)
+		
+		currentY += rowH + 2
+	}
+	
+	// Add decorative bottom diamond element
+	s.addBottomDiamondDecoration(pdf)
+	
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+// sectionTitle returns the table of contents label for a planned page
+func (s *PDFService) sectionTitle(property *models.Property, key string, isArabic bool) string {
+	content := property.EnglishContent
+	if isArabic {
+		content = property.ArabicContent
+	}
+	pick := func(localized, english, arabic string) string {
+		if localized != "" {
+			return s.fixMojibakeLatin1ToUTF8(localized)
+		}
+		if isArabic && s.hasArabicFont {
+			return arabic
+		}
+		return english
+	}
+	
+	switch key {
+	case "details":
+		return pick(content.PropertyDescriptionLabel, "Property Description", s.rtl("وصف العقار"))
+	case "investment":
+		return pick(content.AdditionalSectionTitle, "Investment Opportunity", s.rtl("فرصة استثمارية"))
+	case "gallery":
+		return pick(content.PropertyGalleryLabel, "Property Gallery", s.rtl("معرض العقار"))
+	case "investmentGallery":
+		return s.sectionTitle(property, "investment", isArabic) + " & " + s.sectionTitle(property, "gallery", isArabic)
+	case "virtualTour":
+		return pick("", "Take a Virtual Tour", s.rtl("جولة افتراضية"))
+	case "comps":
+		return pick("", "Comparable Sales", s.rtl("مبيعات مماثلة"))
+	case "floorPlan":
+		return pick("", "Floor Plan", s.rtl("مخطط الطابق"))
+	case "contact":
+		return pick(content.AgentLabel, "Contact Your Agent", s.rtl("تواصل مع الوكيل"))
+	}
+	return key
 }
 
 // addCoverPage creates an attractive cover page with main image, title, and price
 func (s *PDFService) addCoverPage(pdf *gofpdf.Fpdf, property *models.Property) {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
 	pdf.AddPage()
 	
 	// Add cream background to entire page
 	s.addPageBackground(pdf)
+	s.addCoverBanner(pdf)
 	
     s.addBrandingIfAvailable(pdf)
 	
@@ -150,48 +759,50 @@ func (s *PDFService) addCoverPage(pdf *gofpdf.Fpdf, property *models.Property) {
 	
 	// Add "Property Brochure" heading at the top
 	pdf.SetY(10)
-	pdf.SetFont("Arial", "B", 16)
-	pdf.SetTextColor(darkBlueR, darkBlueG, darkBlueB)
+	pdf.SetFont(s.theme.TitleFontName, "B", 16)
+	pdf.SetTextColor(s.coverHeadingColor())
 	pdf.CellFormat(contentWidth, 8, "Property Brochure", "", 1, "C", false, 0, "")
 	
 	// Add gold accent bar below heading
-	pdf.SetFillColor(goldR, goldG, goldB)
-	pdf.Rect(marginX+40, 19, contentWidth-80, 2, "F")
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Rect(margins.Left+40, 19, contentWidth-80, 2, "F")
 	
 	// Add main property image (large, full-width)
-	imageHeight := 155.0
+	imageHeight := s.coverImageHeight(contentWidth)
 	imageStartY := 26.0
 	if len(property.ImageURLs) > 0 {
 		// Add decorative border around image
-		pdf.SetDrawColor(goldR, goldG, goldB)
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
 		pdf.SetLineWidth(1.5)
-		pdf.Rect(marginX-1, imageStartY-1, contentWidth+2, imageHeight+2, "D")
+		pdf.Rect(margins.Left-1, imageStartY-1, contentWidth+2, imageHeight+2, "D")
 		
-		// Add image with slight margins
-		err := s.addImageFromURL(pdf, property.ImageURLs[0], marginX, imageStartY, contentWidth, imageHeight)
+		// Add image cropped to fill the cover box
+		err := s.addCroppedImageFromURL(pdf, property.ImageURLs[0], margins.Left, imageStartY, contentWidth, imageHeight)
 		if err != nil {
 			// If image fails, create a placeholder
 			pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
-			pdf.Rect(marginX, imageStartY, contentWidth, imageHeight, "F")
-			pdf.SetFont("Arial", "I", 12)
+			pdf.Rect(margins.Left, imageStartY, contentWidth, imageHeight, "F")
+			pdf.SetFont(s.theme.BodyFontName, "I", 12)
 			pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
-			pdf.SetXY(marginX, imageStartY+imageHeight/2)
+			pdf.SetXY(margins.Left, imageStartY+imageHeight/2)
 			pdf.CellFormat(contentWidth, 10, "Image Not Available", "", 0, "C", false, 0, "")
+		} else {
+			s.addVideoPlayButton(pdf, property, margins.Left, imageStartY, contentWidth, imageHeight, false)
 		}
 	} else {
 		// Placeholder for missing image
 		pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
-		pdf.Rect(marginX, imageStartY, contentWidth, imageHeight, "F")
-		pdf.SetFont("Arial", "I", 12)
+		pdf.Rect(margins.Left, imageStartY, contentWidth, imageHeight, "F")
+		pdf.SetFont(s.theme.BodyFontName, "I", 12)
 		pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
-		pdf.SetXY(marginX, imageStartY+imageHeight/2)
+		pdf.SetXY(margins.Left, imageStartY+imageHeight/2)
 		pdf.CellFormat(contentWidth, 10, "No Image Available", "", 0, "C", false, 0, "")
 	}
 	
 	// Property Title (large, bold, dark blue)
-	pdf.SetY(186)
-	pdf.SetFont("Arial", "B", 26)
-	pdf.SetTextColor(darkBlueR, darkBlueG, darkBlueB)
+	pdf.SetY(imageStartY + imageHeight + 5)
+	pdf.SetFont(s.theme.TitleFontName, "B", 26)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
 	
 	// Handle long titles
 	titleLines := pdf.SplitLines([]byte(property.Title), contentWidth)
@@ -203,58 +814,212 @@ func (s *PDFService) addCoverPage(pdf *gofpdf.Fpdf, property *models.Property) {
 	// Add a subtle price background box for emphasis
 	priceBoxY := pdf.GetY()
 	pdf.SetFillColor(255, 255, 255)
-	pdf.Rect(marginX+35, priceBoxY-2, contentWidth-70, 18, "F")
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.Rect(margins.Left+35, priceBoxY-2, contentWidth-70, 18, "F")
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.8)
-	pdf.Rect(marginX+35, priceBoxY-2, contentWidth-70, 18, "D")
+	pdf.Rect(margins.Left+35, priceBoxY-2, contentWidth-70, 18, "D")
 	
 	// Price (prominent, gold color)
 	pdf.SetY(priceBoxY)
-	pdf.SetFont("Arial", "B", 28)
-	pdf.SetTextColor(goldR, goldG, goldB)
-	priceText := s.formatPrice(property.Price, property.Currency)
+	pdf.SetFont(s.theme.TitleFontName, "B", 28)
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	priceText := coreText(pdf, s.formatPrice(property.Price, property.Currency))
 	pdf.CellFormat(contentWidth, 14, priceText, "", 1, "C", false, 0, "")
 	pdf.Ln(5)
 
 	// Location (gray, medium size)
-	pdf.SetFont("Arial", "", 13)
+	pdf.SetFont(s.theme.BodyFontName, "", 13)
 	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
 	locationText := s.formatLocation(property)
 	pdf.MultiCell(contentWidth, 6, locationText, "", "C", false)
 	
-	// Decorative bottom section with elegant design
-	pdf.SetY(268)
+	// Decorative bottom section with elegant design, unless the location map takes its place
+	if !s.addStaticMapStrip(pdf, property) {
+		s.addBottomDiamondDecoration(pdf)
+	}
 	
-	// Add decorative diamond shape in center
-	centerX := pageWidth / 2
-	diamondY := 272.0
-	pdf.SetFillColor(goldR, goldG, goldB)
-	// Create diamond with lines
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	// Add page number
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+// addVideoPlayButton overlays a play button linking to the listing's video tour on the cover image box,
+// with a "Watch Video" caption in the price colour below it. Nothing is drawn without a video URL.
+func (s *PDFService) addVideoPlayButton(pdf *gofpdf.Fpdf, property *models.Property, x, y, w, h float64, isArabic bool) {
+	if property.VideoURL == "" {
+		return
+	}
+
+	caption := "Watch Video"
+	if isArabic && s.hasArabicFont {
+		caption = s.rtl("شاهد الفيديو")
+		pdf.SetFont(s.arabicFontName, "", 12)
+	} else {
+		pdf.SetFont(s.theme.TitleFontName, "B", 12)
+	}
+	const labelH, iconW, padding = 8.0, 3.0, 4.0
+	labelW := padding + iconW + 2 + pdf.GetStringWidth(caption) + padding
+
+	radius := math.Min(w, h) * 0.12
+	cx, cy := x+w/2, y+h/2-(labelH+3)/2
+	labelX, labelY := cx-labelW/2, cy+radius+3
+
+	// Translucent backdrops keep the button readable on light and dark photos
+	pdf.SetAlpha(0.7, "Normal")
+	pdf.SetFillColor(s.theme.PrimaryColor.RGB())
+	pdf.Circle(cx, cy, radius, "F")
+	pdf.RoundedRect(labelX, labelY, labelW, labelH, 2, "1234", "F")
+	pdf.SetAlpha(1, "Normal")
+
+	pdf.SetDrawColor(255, 255, 255)
+	pdf.SetLineWidth(1.2)
+	pdf.Circle(cx, cy, radius, "D")
+
+	// The triangle sits slightly right of centre so it looks optically centred in the circle
+	side := radius * 0.9
+	pdf.SetFillColor(255, 255, 255)
+	pdf.Polygon([]gofpdf.PointType{
+		{X: cx - side*0.35, Y: cy - side*0.5},
+		{X: cx - side*0.35, Y: cy + side*0.5},
+		{X: cx + side*0.55, Y: cy},
+	}, "F")
+
+	// Caption with a small play glyph drawn as a triangle, which the core fonts cannot render
+	iconX, iconY := labelX+padding, labelY+labelH/2
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Polygon([]gofpdf.PointType{
+		{X: iconX, Y: iconY - iconW/2},
+		{X: iconX, Y: iconY + iconW/2},
+		{X: iconX + iconW, Y: iconY},
+	}, "F")
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	pdf.SetXY(iconX+iconW+2, labelY)
+	pdf.CellFormat(labelW-padding-iconW-2-padding, labelH, caption, "", 0, "L", false, 0, "")
+
+	// One link area over the button and the caption
+	linkW := math.Max(2*radius, labelW)
+	pdf.LinkString(cx-linkW/2, cy-radius, linkW, labelY+labelH-(cy-radius), property.VideoURL)
+}
+
+// addLandscapeCoverPage lays out the cover for landscape brochures: image on one side, title and price on the other.
+// Arabic brochures mirror the layout so the text panel sits on the left.
+func (s *PDFService) addLandscapeCoverPage(pdf *gofpdf.Fpdf, property *models.Property, isArabic bool) {
+	margins := pageMargins(pdf)
+	_, pageHeight, contentWidth := pageSize(pdf)
+	pdf.AddPage()
+	
+	// Add cream background to entire page
+	s.addPageBackground(pdf)
+	s.addCoverBanner(pdf)
+	
+	s.addBrandingIfAvailable(pdf)
+	
+	// Add decorative corner elements
+	s.addDecorativeCorners(pdf)
+	
+	useArabic := isArabic && s.hasArabicFont
+	
+	// Heading at the top
+	heading := "Property Brochure"
+	pdf.SetY(10)
+	if useArabic {
+		heading = s.rtl("كتيب العقار")
+		pdf.SetFont(s.arabicFontName, "", 16)
+	} else {
+		pdf.SetFont(s.theme.TitleFontName, "B", 16)
+	}
+	pdf.SetTextColor(s.coverHeadingColor())
+	pdf.CellFormat(contentWidth, 8, heading, "", 1, "C", false, 0, "")
+	
+	// Add gold accent bar below heading
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Rect(margins.Left+60, 19, contentWidth-120, 2, "F")
+	
+	// Image takes ~60% of the width, text panel the rest
+	gap := 10.0
+	imageStartY := 26.0
+	imageWidth := contentWidth * 0.6
+	imageHeight := pageHeight - imageStartY - 35
+	panelWidth := contentWidth - imageWidth - gap
+	imageX, panelX := margins.Left, margins.Left+imageWidth+gap
+	if isArabic {
+		panelX, imageX = margins.Left, margins.Left+panelWidth+gap
+	}
+	
+	placed := false
+	if len(property.ImageURLs) > 0 {
+		// Add decorative border around image
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
+		pdf.SetLineWidth(1.5)
+		pdf.Rect(imageX-1, imageStartY-1, imageWidth+2, imageHeight+2, "D")
+		
+		placed = s.addCroppedImageFromURL(pdf, property.ImageURLs[0], imageX, imageStartY, imageWidth, imageHeight) == nil
+	}
+	if placed {
+		s.addVideoPlayButton(pdf, property, imageX, imageStartY, imageWidth, imageHeight, isArabic)
+	} else {
+		pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
+		pdf.Rect(imageX, imageStartY, imageWidth, imageHeight, "F")
+		pdf.SetFont(s.theme.BodyFontName, "I", 12)
+		pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+		pdf.SetXY(imageX, imageStartY+imageHeight/2)
+		pdf.CellFormat(imageWidth, 10, "No Image Available", "", 0, "C", false, 0, "")
+	}
+	
+	// Title, vertically offset into the panel
+	title := property.Title
+	if isArabic && property.ArabicContent.Title != "" {
+		title = s.fixMojibakeLatin1ToUTF8(property.ArabicContent.Title)
+	}
+	if useArabic {
+		pdf.SetFont(s.arabicFontName, "", 22)
+	} else {
+		pdf.SetFont(s.theme.TitleFontName, "B", 22)
+	}
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	pdf.SetXY(panelX, imageStartY+20)
+	pdf.MultiCell(panelWidth, 10, title, "", "C", false)
+	
+	// Short gold rule under the title
+	ruleY := pdf.GetY() + 4
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.8)
-	pdf.Line(centerX-4, diamondY, centerX, diamondY-3)
-	pdf.Line(centerX, diamondY-3, centerX+4, diamondY)
-	pdf.Line(centerX+4, diamondY, centerX, diamondY+3)
-	pdf.Line(centerX, diamondY+3, centerX-4, diamondY)
+	pdf.Line(panelX+panelWidth/2-20, ruleY, panelX+panelWidth/2+20, ruleY)
 	
-	// Lines extending from diamond
-	pdf.SetLineWidth(0.5)
-	pdf.Line(marginX+50, diamondY, centerX-6, diamondY)
-	pdf.Line(centerX+6, diamondY, pageWidth-marginX-50, diamondY)
+	// Price box
+	priceBoxY := ruleY + 10
+	pdf.SetFillColor(255, 255, 255)
+	pdf.Rect(panelX+5, priceBoxY-2, panelWidth-10, 18, "F")
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.8)
+	pdf.Rect(panelX+5, priceBoxY-2, panelWidth-10, 18, "D")
 	
-	// Add page number
-	s.addPageNumber(pdf, 1)
+	pdf.SetXY(panelX, priceBoxY)
+	pdf.SetFont(s.theme.TitleFontName, "B", 24)
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	pdf.CellFormat(panelWidth, 14, coreText(pdf, s.formatPrice(property.Price, property.Currency)), "", 1, "C", false, 0, "")
+	
+	// Location
+	pdf.SetFont(s.theme.BodyFontName, "", 12)
+	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+	pdf.SetXY(panelX, priceBoxY+24)
+	pdf.MultiCell(panelWidth, 6, s.formatLocation(property), "", "C", false)
+	
+	// Add decorative bottom diamond element
+	s.addBottomDiamondDecoration(pdf)
+	
+	s.addPageNumber(pdf, pdf.PageNo())
 }
 
 // addDetailsPageOnly creates page 2 with only description, highlights, and amenities
 func (s *PDFService) addDetailsPageOnly(pdf *gofpdf.Fpdf, property *models.Property, isArabic bool) {
+	margins := pageMargins(pdf)
 	pdf.AddPage()
 	
 	// Add cream background
 	s.addPageBackground(pdf)
 	
     s.addBrandingIfAvailable(pdf)
-	currentY := marginY + 10.0
+	currentY := margins.Top + 10.0
 	
 	if isArabic {
 		s.addArabicDetailsContent(pdf, property, &currentY)
@@ -266,11 +1031,13 @@ func (s *PDFService) addDetailsPageOnly(pdf *gofpdf.Fpdf, property *models.Prope
 	s.addBottomDiamondDecoration(pdf)
 	
 	// Add page number
-	s.addPageNumber(pdf, 2)
+	s.addPageNumber(pdf, pdf.PageNo())
 }
 
 // addEnglishDetailsContent adds English description, highlights, and amenities
 func (s *PDFService) addEnglishDetailsContent(pdf *gofpdf.Fpdf, property *models.Property, currentY *float64) {
+	margins := pageMargins(pdf)
+	_, pageHeight, contentWidth := pageSize(pdf)
 	// Use localized content if available, fallback to legacy
 	var descLabel, highlightsLabel, amenitiesLabel string
 	var description string
@@ -308,10 +1075,10 @@ func (s *PDFService) addEnglishDetailsContent(pdf *gofpdf.Fpdf, property *models
     if s.hasBodyFont {
         pdf.SetFont(s.bodyFontName, "", 11)
     } else {
-        pdf.SetFont("Arial", "", 11)
+        pdf.SetFont(s.theme.BodyFontName, "", 11)
     }
 	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX, *currentY)
+	pdf.SetXY(margins.Left, *currentY)
 	
 	pdf.MultiCell(contentWidth, 5.5, description, "", "L", false)
 	*currentY = pdf.GetY() + 8
@@ -320,21 +1087,21 @@ func (s *PDFService) addEnglishDetailsContent(pdf *gofpdf.Fpdf, property *models
 	if len(highlights) > 0 {
 		*currentY = s.addSectionHeader(pdf, highlightsLabel, *currentY)
 
-		pdf.SetFont("Arial", "", 11)
+		pdf.SetFont(s.theme.BodyFontName, "", 11)
 		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
 		
         for _, raw := range highlights {
             highlight := s.sanitizeBulletText(raw)
             // Draw a gold bullet (filled circle) to avoid Unicode bullet issues
-            bulletX := marginX + 5
+            bulletX := margins.Left + 5
             bulletY := *currentY + 3.5
-            pdf.SetFillColor(goldR, goldG, goldB)
+            pdf.SetFillColor(s.theme.AccentColor.RGB())
             pdf.Circle(bulletX, bulletY, 1.6, "F")
 
             // Highlight text
             pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-            pdf.SetFont("Arial", "", 11)
-            pdf.SetXY(marginX+12, *currentY)
+            pdf.SetFont(s.theme.BodyFontName, "", 11)
+            pdf.SetXY(margins.Left+12, *currentY)
             pdf.MultiCell(contentWidth-12, 6, highlight, "", "L", false)
             *currentY = pdf.GetY() + 1
         }
@@ -344,40 +1111,35 @@ func (s *PDFService) addEnglishDetailsContent(pdf *gofpdf.Fpdf, property *models
 	// Section: Amenities
 	if len(amenities) > 0 {
 		// Check if we need space on page
-		if *currentY > 220 {
+		if *currentY > pageHeight-77 {
 			// Skip to make room - we won't add a new page, just adjust spacing
-			*currentY = 220
+			*currentY = pageHeight - 77
 		}
 		
 		*currentY = s.addSectionHeader(pdf, amenitiesLabel, *currentY)
 		
-		pdf.SetFont("Arial", "", 10)
+		pdf.SetFont(s.theme.BodyFontName, "", 10)
 		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
 		
-        // Display amenities in a 2-column grid with checkmarks
+        // Display amenities in a 2-column grid with icons
 		colWidth := (contentWidth - 10) / 2
 		amenityHeight := 7.0
 		
 		for i, amenity := range amenities {
 			col := i % 2
-			xPos := marginX + float64(col)*(colWidth+10)
+			xPos := margins.Left + float64(col)*(colWidth+10)
 			
 			pdf.SetXY(xPos, *currentY)
 			
-            // Draw a green check mark using vector lines (avoids Unicode glyph issues)
-            pdf.SetDrawColor(46, 125, 50)
-            pdf.SetLineWidth(0.8)
-            startX := xPos
-            startY := *currentY + amenityHeight/2
-            pdf.Line(startX, startY, startX+2.0, startY+2.0)
-            pdf.Line(startX+2.0, startY+2.0, startX+6.0, startY-1.0)
+            // Draw the amenity's icon using vector shapes (avoids Unicode glyph issues)
+            s.drawAmenityIcon(pdf, xPos, *currentY+amenityHeight/2, amenityIconFor(property.EnglishContent.AmenityIcon, amenity))
 			
             // Amenity text
             pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
             if s.hasBodyFont {
                 pdf.SetFont(s.bodyFontName, "", 10)
             } else {
-                pdf.SetFont("Arial", "", 10)
+                pdf.SetFont(s.theme.BodyFontName, "", 10)
             }
             pdf.SetX(xPos + 9)
 			pdf.CellFormat(colWidth-7, amenityHeight, amenity, "", 0, "", false, 0, "")
@@ -397,6 +1159,8 @@ func (s *PDFService) addEnglishDetailsContent(pdf *gofpdf.Fpdf, property *models
 
 // addArabicDetailsContent adds Arabic description, highlights, and amenities
 func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.Property, currentY *float64) {
+	margins := pageMargins(pdf)
+	pageWidth, pageHeight, contentWidth := pageSize(pdf)
 	// Use localized content if available, fallback to legacy
 	var descLabel, highlightsLabel, amenitiesLabel string
 	var description string
@@ -413,16 +1177,16 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 		amenities = property.ArabicContent.Amenities
 	} else {
 		// Fallback to legacy fields
-		descLabel = "وصف العقار"
-		highlightsLabel = "المميزات الرئيسية"
-		amenitiesLabel = "المرافق والميزات"
+		descLabel = s.rtl("وصف العقار")
+		highlightsLabel = s.rtl("المميزات الرئيسية")
+		amenitiesLabel = s.rtl("المرافق والميزات")
 		description = property.AIContent.ArabicDescription
 		highlights = []string{}
 		amenities = property.Amenities
 	}
 	
 	if description == "" {
-		description = "لا يوجد وصف متاح"
+		description = s.rtl("لا يوجد وصف متاح")
 	}
 	
 	// Section: Arabic Description
@@ -436,10 +1200,10 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 	if s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 12)
 	} else {
-		pdf.SetFont("Arial", "", 11)
+		pdf.SetFont(s.theme.BodyFontName, "", 11)
 	}
 	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX, *currentY)
+	pdf.SetXY(margins.Left, *currentY)
 	
 	// Right-aligned for Arabic text
 	description = s.fixMojibakeLatin1ToUTF8(description)
@@ -457,7 +1221,7 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 		if s.hasArabicFont {
 			pdf.SetFont(s.arabicFontName, "", 11)
 		} else {
-			pdf.SetFont("Arial", "", 11)
+			pdf.SetFont(s.theme.BodyFontName, "", 11)
 		}
 		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
 		
@@ -466,9 +1230,9 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 			highlight = s.fixMojibakeLatin1ToUTF8(highlight)
 			
 			// Draw a gold bullet (filled circle)
-			bulletX := pageWidth - marginX - 5 // Right side for RTL
+			bulletX := pageWidth - margins.Right - 5 // Right side for RTL
 			bulletY := *currentY + 3.5
-			pdf.SetFillColor(goldR, goldG, goldB)
+			pdf.SetFillColor(s.theme.AccentColor.RGB())
 			pdf.Circle(bulletX, bulletY, 1.6, "F")
 			
 			// Highlight text (right-aligned)
@@ -476,9 +1240,9 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 			if s.hasArabicFont {
 				pdf.SetFont(s.arabicFontName, "", 11)
 			} else {
-				pdf.SetFont("Arial", "", 11)
+				pdf.SetFont(s.theme.BodyFontName, "", 11)
 			}
-			pdf.SetXY(marginX, *currentY)
+			pdf.SetXY(margins.Left, *currentY)
 			pdf.MultiCell(contentWidth-12, 6, highlight, "", "R", false)
 			*currentY = pdf.GetY() + 1
 		}
@@ -488,8 +1252,8 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 	// Section: Amenities (if available)
 	if len(amenities) > 0 {
 		// Check if we need space on page
-		if *currentY > 220 {
-			*currentY = 220
+		if *currentY > pageHeight-77 {
+			*currentY = pageHeight - 77
 		}
 		
 		if s.hasArabicFont {
@@ -501,27 +1265,22 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 		if s.hasArabicFont {
 			pdf.SetFont(s.arabicFontName, "", 10)
 		} else {
-			pdf.SetFont("Arial", "", 10)
+			pdf.SetFont(s.theme.BodyFontName, "", 10)
 		}
 		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
 		
-		// Display amenities in a 2-column grid with checkmarks
+		// Display amenities in a 2-column grid with icons
 		colWidth := (contentWidth - 10) / 2
 		amenityHeight := 7.0
 		
 		for i, amenity := range amenities {
 			col := i % 2
-			xPos := marginX + float64(col)*(colWidth+10)
+			xPos := margins.Left + float64(col)*(colWidth+10)
 			
 			pdf.SetXY(xPos, *currentY)
 			
-			// Draw a green check mark using vector lines
-			pdf.SetDrawColor(46, 125, 50)
-			pdf.SetLineWidth(0.8)
-			startX := xPos
-			startY := *currentY + amenityHeight/2
-			pdf.Line(startX, startY, startX+2.0, startY+2.0)
-			pdf.Line(startX+2.0, startY+2.0, startX+6.0, startY-1.0)
+			// Draw the amenity's icon using vector shapes
+			s.drawAmenityIcon(pdf, xPos, *currentY+amenityHeight/2, amenityIconFor(property.ArabicContent.AmenityIcon, amenity))
 			
 			// Amenity text (apply mojibake fix for Arabic)
 			amenity = s.fixMojibakeLatin1ToUTF8(amenity)
@@ -529,7 +1288,7 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 			if s.hasArabicFont {
 				pdf.SetFont(s.arabicFontName, "", 10)
 			} else {
-				pdf.SetFont("Arial", "", 10)
+				pdf.SetFont(s.theme.BodyFontName, "", 10)
 			}
 			pdf.SetX(xPos + 9)
 			pdf.CellFormat(colWidth-7, amenityHeight, amenity, "", 0, "", false, 0, "")
@@ -549,13 +1308,20 @@ func (s *PDFService) addArabicDetailsContent(pdf *gofpdf.Fpdf, property *models.
 
 // addInvestmentAndGalleryPage creates page 3 with investment opportunity and property gallery
 func (s *PDFService) addInvestmentAndGalleryPage(pdf *gofpdf.Fpdf, property *models.Property, isArabic bool) {
+	s.addInvestmentGalleryPage(pdf, property, isArabic, true, true)
+}
+
+// addInvestmentGalleryPage renders the investment section, the gallery, or both on one page
+func (s *PDFService) addInvestmentGalleryPage(pdf *gofpdf.Fpdf, property *models.Property, isArabic, withInvestment, withGallery bool) {
+	margins := pageMargins(pdf)
+	_, pageHeight, contentWidth := pageSize(pdf)
 	pdf.AddPage()
 	
 	// Add cream background
 	s.addPageBackground(pdf)
 	
     s.addBrandingIfAvailable(pdf)
-	currentY := marginY + 10.0
+	currentY := margins.Top + 10.0
 	
 	// Section: Investment Opportunity
 	var additionalTitle, additionalContent string
@@ -564,8 +1330,8 @@ func (s *PDFService) addInvestmentAndGalleryPage(pdf *gofpdf.Fpdf, property *mod
 			additionalTitle = property.ArabicContent.AdditionalSectionTitle
 			additionalContent = property.ArabicContent.AdditionalSectionContent
 		} else {
-			additionalTitle = "فرصة استثمارية"
-			additionalContent = "يمثل هذا العقار فرصة استثمارية ممتازة."
+			additionalTitle = s.rtl("فرصة استثمارية")
+			additionalContent = s.rtl("يمثل هذا العقار فرصة استثمارية ممتازة.")
 		}
 		additionalTitle = s.fixMojibakeLatin1ToUTF8(additionalTitle)
 		additionalContent = s.fixMojibakeLatin1ToUTF8(additionalContent)
@@ -579,7 +1345,7 @@ func (s *PDFService) addInvestmentAndGalleryPage(pdf *gofpdf.Fpdf, property *mod
 		}
 	}
 	
-	if additionalContent != "" {
+	if withInvestment && additionalContent != "" {
 		if isArabic && s.hasArabicFont {
 			currentY = s.addSectionHeaderAligned(pdf, additionalTitle, currentY, s.arabicFontName, "R")
 			pdf.SetFont(s.arabicFontName, "", 11)
@@ -588,12 +1354,12 @@ func (s *PDFService) addInvestmentAndGalleryPage(pdf *gofpdf.Fpdf, property *mod
 			if s.hasBodyFont {
 				pdf.SetFont(s.bodyFontName, "", 11)
 			} else {
-				pdf.SetFont("Arial", "", 11)
+				pdf.SetFont(s.theme.BodyFontName, "", 11)
 			}
 		}
 		
 		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-		pdf.SetXY(marginX, currentY)
+		pdf.SetXY(margins.Left, currentY)
 		align := "L"
 		if isArabic {
 			align = "R"
@@ -602,14 +1368,19 @@ func (s *PDFService) addInvestmentAndGalleryPage(pdf *gofpdf.Fpdf, property *mod
 		currentY = pdf.GetY() + 12
 	}
 	
+	// The staging description is generated in English, so only English brochures show it
+	if withInvestment && !isArabic && property.VirtualStagingDescription != "" {
+		currentY = s.addVirtualStagingSection(pdf, property, currentY)
+	}
+	
 	// Add Property Gallery (if images available)
-	if len(property.ImageURLs) > 1 {
+	if withGallery && len(property.ImageURLs) > 1 {
 		galleryLabel := "Property Gallery"
 		if isArabic {
 			if property.ArabicContent.PropertyGalleryLabel != "" {
 				galleryLabel = property.ArabicContent.PropertyGalleryLabel
 			} else {
-				galleryLabel = "معرض العقار"
+				galleryLabel = s.rtl("معرض العقار")
 			}
 			galleryLabel = s.fixMojibakeLatin1ToUTF8(galleryLabel)
 		} else {
@@ -626,9 +1397,18 @@ func (s *PDFService) addInvestmentAndGalleryPage(pdf *gofpdf.Fpdf, property *mod
 		currentY += 3
 		
 		// Display up to 4 additional images in a compact 2x2 grid
-		imgWidth := (contentWidth - 8) / 2
-		imgHeight := imgWidth * 0.65
 		spacing := 8.0
+		imgWidth := (contentWidth - spacing) / 2
+		imgHeight := imgWidth * 0.65
+		// Leave room for the "more photos" strip when some images will not fit in the grid
+		stripSpace := 0.0
+		if len(property.ImageURLs) > 5 {
+			stripSpace = s.morePhotosStripHeight(property) + spacing
+		}
+		// Landscape pages are shorter: flatten the rows so both still fit
+		if maxHeight := (pageHeight - 35 - currentY - spacing - stripSpace) / 2; imgHeight > maxHeight {
+			imgHeight = maxHeight
+		}
 		
 		imageCount := 0
 		maxImages := 4
@@ -637,7 +1417,7 @@ func (s *PDFService) addInvestmentAndGalleryPage(pdf *gofpdf.Fpdf, property *mod
 			row := imageCount / 2
 			col := imageCount % 2
 			
-			xPos := marginX + float64(col)*(imgWidth+spacing)
+			xPos := margins.Left + float64(col)*(imgWidth+spacing)
 			yPos := currentY + float64(row)*(imgHeight+spacing)
 			
 			// Check if we're running out of space
@@ -654,37 +1434,418 @@ func (s *PDFService) addInvestmentAndGalleryPage(pdf *gofpdf.Fpdf, property *mod
 			pdf.Rect(xPos, yPos, imgWidth, imgHeight, "F")
 			
 			// Add gold border/frame effect
-			pdf.SetDrawColor(goldR, goldG, goldB)
+			pdf.SetDrawColor(s.theme.AccentColor.RGB())
 			pdf.SetLineWidth(0.6)
 			pdf.Rect(xPos, yPos, imgWidth, imgHeight, "D")
 			
 			err := s.addImageFromURL(pdf, property.ImageURLs[i], xPos+2, yPos+2, imgWidth-4, imgHeight-4)
 			if err != nil {
-				// Placeholder for failed images
-				pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
-				pdf.Rect(xPos+2, yPos+2, imgWidth-4, imgHeight-4, "F")
+				// Gallery failures are non-fatal: show a placeholder and keep going
+				log.Printf("Gallery image %d unavailable (%s): %v", i, property.ImageURLs[i], err)
+				s.addImagePlaceholder(pdf, xPos+2, yPos+2, imgWidth-4, imgHeight-4)
+				warnImageUnavailable(property, fmt.Sprintf("Gallery image %d is unavailable and was replaced with a placeholder", i))
 			}
 			
 			imageCount++
 		}
+		
+		if remaining := len(property.ImageURLs) - 1 - imageCount; remaining > 0 {
+			rows := (imageCount + 1) / 2
+			stripY := currentY + float64(rows)*(imgHeight+spacing)
+			if stripY+s.morePhotosStripHeight(property) <= pageHeight-35 {
+				s.addMorePhotosStrip(pdf, property, stripY, remaining, isArabic)
+			}
+		}
 	}
 	
 	// Add decorative bottom diamond element
 	s.addBottomDiamondDecoration(pdf)
 	
 	// Add page number
-	s.addPageNumber(pdf, 3)
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+// addVirtualStagingSection renders the AI-written virtual staging description below the investment
+// section, labeled so readers do not mistake it for the current furnishing of the property
+func (s *PDFService) addVirtualStagingSection(pdf *gofpdf.Fpdf, property *models.Property, y float64) float64 {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+
+	y = s.addSectionHeaderWithIcon(pdf, "Virtual Staging", y, "staging")
+
+	pdf.SetFont(s.coreFont("I"), "I", 8.5)
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	pdf.SetXY(margins.Left, y)
+	pdf.MultiCell(contentWidth, 4.5, "Computer-generated visualization description. It imagines how the space could look when staged; the property is not furnished as described.", "", "L", false)
+
+	if s.hasBodyFont {
+		pdf.SetFont(s.bodyFontName, "", 11)
+	} else {
+		pdf.SetFont(s.theme.BodyFontName, "", 11)
+	}
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(margins.Left, pdf.GetY()+2)
+	pdf.MultiCell(contentWidth, 5.5, property.VirtualStagingDescription, "", "L", false)
+	return pdf.GetY() + 12
+}
+
+// morePhotosStripHeight is taller when the strip carries a virtual tour QR code
+func (s *PDFService) morePhotosStripHeight(property *models.Property) float64 {
+	if property.VirtualTourURL != "" {
+		return 20.0
+	}
+	return 12.0
+}
+
+// addMorePhotosStrip draws a dark blue "+ N more photos" bar under the gallery grid.
+// With a virtual tour, the bar links to the tour and carries a small QR code for it.
+func (s *PDFService) addMorePhotosStrip(pdf *gofpdf.Fpdf, property *models.Property, y float64, remaining int, isArabic bool) {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+	stripHeight := s.morePhotosStripHeight(property)
+	useArabic := isArabic && s.hasArabicFont
+	
+	pdf.SetFillColor(s.theme.PrimaryColor.RGB())
+	pdf.Rect(margins.Left, y, contentWidth, stripHeight, "F")
+	
+	text := fmt.Sprintf("+ %d more photos available", remaining)
+	if useArabic {
+		text = fmt.Sprintf(s.rtl("+ %d صور إضافية متاحة"), remaining)
+	}
+	
+	textX, textW := margins.Left+6, contentWidth-12
+	if property.VirtualTourURL != "" {
+		qrSize := stripHeight - 4
+		qrX := margins.Left + contentWidth - qrSize - 2
+		if useArabic {
+			qrX = margins.Left + 2
+			textX = qrX + qrSize + 4
+		}
+		textW = contentWidth - qrSize - 12
+		
+		if png, err := qrcode.Encode(property.VirtualTourURL, qrcode.Medium, 256); err == nil {
+			pdf.SetFillColor(255, 255, 255)
+			pdf.Rect(qrX, y+2, qrSize, qrSize, "F")
+			opts := gofpdf.ImageOptions{ImageType: "png"}
+			pdf.RegisterImageOptionsReader("virtual_tour_qr", opts, bytes.NewReader(png))
+			pdf.ImageOptions("virtual_tour_qr", qrX+0.5, y+2.5, qrSize-1, qrSize-1, false, opts, 0, property.VirtualTourURL)
+		}
+		pdf.LinkString(margins.Left, y, contentWidth, stripHeight, property.VirtualTourURL)
+		
+		if useArabic {
+			text += s.rtl(" - امسح الرمز للجولة الافتراضية")
+		} else {
+			text += " - scan for the virtual tour"
+		}
+	}
+	
+	align := "L"
+	if useArabic {
+		align = "R"
+		pdf.SetFont(s.arabicFontName, "", 12)
+	} else {
+		pdf.SetFont(s.theme.TitleFontName, "B", 12)
+	}
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetXY(textX, y)
+	pdf.CellFormat(textW, stripHeight, text, "", 0, align, false, 0, "")
+}
+
+// addVirtualTourPage creates an optional page with a scannable, clickable QR code for the virtual tour
+func (s *PDFService) addVirtualTourPage(pdf *gofpdf.Fpdf, property *models.Property, isArabic bool) {
+	margins := pageMargins(pdf)
+	pageWidth, _, contentWidth := pageSize(pdf)
+	pdf.AddPage()
+	
+	// Add cream background
+	s.addPageBackground(pdf)
+	
+	s.addBrandingIfAvailable(pdf)
+	currentY := margins.Top + 10.0
+	
+	header := "Take a Virtual Tour"
+	instructions := "Scan the QR code with your phone camera, or click it, to explore this property from anywhere."
+	if isArabic && s.hasArabicFont {
+		header = s.rtl("جولة افتراضية")
+		instructions = s.rtl("امسح رمز الاستجابة السريعة بكاميرا هاتفك، أو انقر عليه، لاستكشاف هذا العقار من أي مكان.")
+		currentY = s.addSectionHeaderAligned(pdf, header, currentY, s.arabicFontName, "R")
+	} else {
+		currentY = s.addSectionHeaderWithIcon(pdf, header, currentY, "tour")
+	}
+	
+	// Centered 50x50mm QR code
+	qrSize := 50.0
+	qrX := (pageWidth - qrSize) / 2
+	qrY := currentY + 30
+	
+	png, err := qrcode.Encode(property.VirtualTourURL, qrcode.Medium, 512)
+	if err != nil {
+		pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
+		pdf.Rect(qrX, qrY, qrSize, qrSize, "F")
+	} else {
+		// White card with gold frame behind the code
+		pdf.SetFillColor(255, 255, 255)
+		pdf.Rect(qrX-4, qrY-4, qrSize+8, qrSize+8, "F")
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
+		pdf.SetLineWidth(0.8)
+		pdf.Rect(qrX-4, qrY-4, qrSize+8, qrSize+8, "D")
+		
+		opts := gofpdf.ImageOptions{ImageType: "png"}
+		pdf.RegisterImageOptionsReader("virtual_tour_qr", opts, bytes.NewReader(png))
+		pdf.ImageOptions("virtual_tour_qr", qrX, qrY, qrSize, qrSize, false, opts, 0, property.VirtualTourURL)
+	}
+	currentY = qrY + qrSize + 12
+	
+	// Instructional text
+	if isArabic && s.hasArabicFont {
+		pdf.SetFont(s.arabicFontName, "", 12)
+	} else if s.hasBodyFont {
+		pdf.SetFont(s.bodyFontName, "", 11)
+	} else {
+		pdf.SetFont(s.theme.BodyFontName, "", 11)
+	}
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(margins.Left+15, currentY)
+	pdf.MultiCell(contentWidth-30, 6, instructions, "", "C", false)
+	currentY = pdf.GetY() + 6
+	
+	// Clickable URL below the instructions
+	pdf.SetFont(s.theme.BodyFontName, "U", 10)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	linkText := property.VirtualTourURL
+	if len(linkText) > 70 {
+		linkText = linkText[:67] + "..."
+	}
+	linkW := pdf.GetStringWidth(linkText)
+	linkX := (pageWidth - linkW) / 2
+	pdf.SetXY(linkX, currentY)
+	pdf.CellFormat(linkW, 6, linkText, "", 0, "C", false, 0, "")
+	pdf.LinkString(linkX, currentY, linkW, 6, property.VirtualTourURL)
+	
+	// Add decorative bottom diamond element
+	s.addBottomDiamondDecoration(pdf)
+	
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+// addComparableSalesPage renders AI-estimated comparable sales as a table, clearly labeled as illustrative
+func (s *PDFService) addComparableSalesPage(pdf *gofpdf.Fpdf, property *models.Property, isArabic bool) {
+	margins := pageMargins(pdf)
+	pageWidth, _, contentWidth := pageSize(pdf)
+	pdf.AddPage()
+	
+	// Add cream background
+	s.addPageBackground(pdf)
+	
+	s.addBrandingIfAvailable(pdf)
+	currentY := margins.Top + 10.0
+	
+	useArabic := isArabic && s.hasArabicFont
+	headers := []string{"Property", "Sold Price", "Days on Market"}
+	disclaimerTitle := "ESTIMATED MARKET DATA - ILLUSTRATIVE ONLY"
+	disclaimer := "The comparable sales below are fictional examples generated to illustrate typical market conditions. They are not actual transactions and must not be relied upon for valuation, lending or investment decisions."
+	if useArabic {
+		currentY = s.addSectionHeaderAligned(pdf, s.rtl("مبيعات مماثلة"), currentY, s.arabicFontName, "R")
+		headers = []string{s.rtl("العقار"), s.rtl("سعر البيع"), s.rtl("أيام في السوق")}
+		disclaimerTitle = s.rtl("بيانات سوق تقديرية - للتوضيح فقط")
+		disclaimer = s.rtl("المبيعات المماثلة أدناه أمثلة افتراضية لتوضيح ظروف السوق المعتادة. وهي ليست معاملات فعلية ولا يجوز الاعتماد عليها في قرارات التقييم أو التمويل أو الاستثمار.")
+	} else {
+		currentY = s.addSectionHeaderWithIcon(pdf, "Comparable Sales", currentY, "comps")
+	}
+	currentY += 3
+	
+	setFont := func(style string, size float64) {
+		if useArabic {
+			pdf.SetFont(s.arabicFontName, "", size)
+		} else {
+			pdf.SetFont(s.coreFont(style), style, size)
+		}
+	}
+	
+	// Prominent disclaimer box above the table
+	pdf.SetFillColor(255, 243, 224)
+	pdf.SetDrawColor(230, 120, 0)
+	pdf.SetLineWidth(0.8)
+	boxY := currentY
+	pdf.Rect(margins.Left, boxY, contentWidth, 32, "FD")
+	setFont("B", 12)
+	pdf.SetTextColor(180, 80, 0)
+	pdf.SetXY(margins.Left+5, boxY+4)
+	pdf.CellFormat(contentWidth-10, 7, disclaimerTitle, "", 0, "C", false, 0, "")
+	setFont("", 9)
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(margins.Left+5, boxY+13)
+	pdf.MultiCell(contentWidth-10, 5, disclaimer, "", "C", false)
+	currentY = boxY + 32 + 10
+	
+	// Table columns (mirrored for RTL)
+	widths := []float64{contentWidth * 0.5, contentWidth * 0.28, contentWidth * 0.22}
+	aligns := []string{"L", "R", "C"}
+	if useArabic {
+		headers = []string{headers[2], headers[1], headers[0]}
+		widths = []float64{widths[2], widths[1], widths[0]}
+		aligns = []string{"C", "L", "R"}
+	}
+	rowH := 12.0
+	
+	// Header row
+	pdf.SetFillColor(s.theme.PrimaryColor.RGB())
+	pdf.SetTextColor(255, 255, 255)
+	setFont("B", 11)
+	pdf.SetXY(margins.Left, currentY)
+	for i, h := range headers {
+		pdf.CellFormat(widths[i], rowH, h, "", 0, aligns[i], true, 0, "")
+	}
+	currentY += rowH
+	
+	setFont("", 10)
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	for i, sale := range property.ComparableSales {
+		if i%2 == 0 {
+			pdf.SetFillColor(255, 255, 255)
+		} else {
+			pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
+		}
+		cells := []string{
+			"* " + sale.AddressStub,
+			s.formatPrice(sale.Price, property.Currency),
+			fmt.Sprintf("%d", sale.DaysOnMarket),
+		}
+		if useArabic {
+			cells = []string{cells[2], cells[1], sale.AddressStub + " *"}
+		} else {
+			cells[1] = coreText(pdf, cells[1])
+		}
+		pdf.SetXY(margins.Left, currentY)
+		for j, text := range cells {
+			pdf.CellFormat(widths[j], rowH, text, "", 0, aligns[j], true, 0, "")
+		}
+		currentY += rowH
+	}
+	
+	// Gold rule under the table and footnote repeating the label
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.8)
+	pdf.Line(margins.Left, currentY, pageWidth-margins.Right, currentY)
+	currentY += 5
+	
+	footnote := "* Estimated market data. Addresses, prices and days on market are illustrative and do not describe real properties."
+	footAlign := "L"
+	if useArabic {
+		footnote = s.rtl("* بيانات سوق تقديرية. العناوين والأسعار وأيام العرض توضيحية ولا تصف عقارات حقيقية.")
+		footAlign = "R"
+	}
+	setFont("I", 8)
+	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+	pdf.SetXY(margins.Left, currentY)
+	pdf.MultiCell(contentWidth, 4.5, footnote, "", footAlign, false)
+	
+	// Add decorative bottom diamond element
+	s.addBottomDiamondDecoration(pdf)
+	
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+// addFloorPlanPage creates an optional page with the full-width floor plan and dimension annotations
+func (s *PDFService) addFloorPlanPage(pdf *gofpdf.Fpdf, property *models.Property, isArabic bool) {
+	margins := pageMargins(pdf)
+	_, pageHeight, contentWidth := pageSize(pdf)
+	pdf.AddPage()
+	
+	// Add cream background
+	s.addPageBackground(pdf)
+	
+	s.addBrandingIfAvailable(pdf)
+	currentY := margins.Top + 10.0
+	
+	if isArabic && s.hasArabicFont {
+		currentY = s.addSectionHeaderAligned(pdf, s.rtl("مخطط الطابق"), currentY, s.arabicFontName, "R")
+	} else {
+		currentY = s.addSectionHeaderWithIcon(pdf, "Floor Plan", currentY, "floorplan")
+	}
+	currentY += 5
+	
+	// Leave room around the plan for the dimension annotations
+	hasDimensions := property.FloorPlanWidth > 0 && property.FloorPlanHeight > 0
+	boxX, boxW := margins.Left, contentWidth
+	boxY := currentY
+	if hasDimensions {
+		boxX += 10
+		boxW -= 10
+		boxY += 10
+	}
+	boxH := pageHeight - 35 - boxY
+	
+	x, y, w, h, err := s.placeImageFromURL(pdf, property.FloorPlanURL, boxX, boxY, boxW, boxH)
+	if err != nil {
+		pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
+		pdf.Rect(boxX, boxY, boxW, boxH, "F")
+		pdf.SetFont(s.theme.BodyFontName, "I", 12)
+		pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+		pdf.SetXY(boxX, boxY+boxH/2)
+		pdf.CellFormat(boxW, 10, "Floor Plan Not Available", "", 0, "C", false, 0, "")
+		log.Printf("Floor plan unavailable (%s): %v", property.FloorPlanURL, err)
+		warnImageUnavailable(property, "Floor plan image is unavailable and was replaced with a placeholder")
+	} else {
+		// Thin gold frame around the plan
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
+		pdf.SetLineWidth(0.4)
+		pdf.Rect(x, y, w, h, "D")
+		
+		if hasDimensions {
+			s.addDimensionAnnotations(pdf, x, y, w, h, property.FloorPlanWidth, property.FloorPlanHeight)
+		}
+	}
+	
+	// Add decorative bottom diamond element
+	s.addBottomDiamondDecoration(pdf)
+	
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+// addDimensionAnnotations draws width (above) and height (left) measurement lines for a drawn plan
+func (s *PDFService) addDimensionAnnotations(pdf *gofpdf.Fpdf, x, y, w, h, widthM, heightM float64) {
+	pdf.SetDrawColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetLineWidth(0.3)
+	pdf.SetFont(s.theme.BodyFontName, "", 9)
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	
+	// Width: horizontal line above the plan with end ticks
+	lineY := y - 5
+	pdf.Line(x, lineY, x+w, lineY)
+	pdf.Line(x, lineY-1.5, x, lineY+1.5)
+	pdf.Line(x+w, lineY-1.5, x+w, lineY+1.5)
+	widthLabel := fmt.Sprintf("%.1f m", widthM)
+	labelW := pdf.GetStringWidth(widthLabel) + 4
+	pdf.SetFillColor(s.theme.BackgroundColor.RGB())
+	pdf.SetXY(x+(w-labelW)/2, lineY-2.5)
+	pdf.CellFormat(labelW, 5, widthLabel, "", 0, "C", true, 0, "")
+	
+	// Height: vertical line left of the plan with end ticks
+	lineX := x - 5
+	pdf.Line(lineX, y, lineX, y+h)
+	pdf.Line(lineX-1.5, y, lineX+1.5, y)
+	pdf.Line(lineX-1.5, y+h, lineX+1.5, y+h)
+	heightLabel := fmt.Sprintf("%.1f m", heightM)
+	labelW = pdf.GetStringWidth(heightLabel) + 4
+	pdf.TransformBegin()
+	pdf.TransformRotate(90, lineX, y+h/2)
+	pdf.SetXY(lineX-labelW/2, y+h/2-2.5)
+	pdf.CellFormat(labelW, 5, heightLabel, "", 0, "C", true, 0, "")
+	pdf.TransformEnd()
 }
 
 // addGalleryPage creates an image gallery for additional property photos
 func (s *PDFService) addGalleryPage(pdf *gofpdf.Fpdf, property *models.Property) {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
 	pdf.AddPage()
 	
 	// Add cream background
 	s.addPageBackground(pdf)
 	
     s.addBrandingIfAvailable(pdf)
-	currentY := marginY + 10.0
+	currentY := margins.Top + 10.0
 	
 	// Use localized label if available
 	galleryLabel := "Property Gallery"
@@ -708,7 +1869,7 @@ func (s *PDFService) addGalleryPage(pdf *gofpdf.Fpdf, property *models.Property)
 		row := imageCount / 2
 		col := imageCount % 2
 		
-		xPos := marginX + float64(col)*(imgWidth+spacing)
+		xPos := margins.Left + float64(col)*(imgWidth+spacing)
 		yPos := currentY + float64(row)*(imgHeight+spacing)
 		
 		// Add shadow effect
@@ -720,36 +1881,39 @@ func (s *PDFService) addGalleryPage(pdf *gofpdf.Fpdf, property *models.Property)
 		pdf.Rect(xPos, yPos, imgWidth, imgHeight, "F")
 		
 		// Add gold border/frame effect
-		pdf.SetDrawColor(goldR, goldG, goldB)
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
 		pdf.SetLineWidth(0.8)
 		pdf.Rect(xPos, yPos, imgWidth, imgHeight, "D")
 		
 		err := s.addImageFromURL(pdf, property.ImageURLs[i], xPos+2, yPos+2, imgWidth-4, imgHeight-4)
 		if err != nil {
-			// Placeholder for failed images
-			pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
-			pdf.Rect(xPos+2, yPos+2, imgWidth-4, imgHeight-4, "F")
+			// Gallery failures are non-fatal: show a placeholder and keep going
+			log.Printf("Gallery image %d unavailable (%s): %v", i, property.ImageURLs[i], err)
+			s.addImagePlaceholder(pdf, xPos+2, yPos+2, imgWidth-4, imgHeight-4)
+			warnImageUnavailable(property, fmt.Sprintf("Gallery image %d is unavailable and was replaced with a placeholder", i))
 		}
 		
 		imageCount++
 	}
 	
 	// Add page number
-	s.addPageNumber(pdf, 3)
+	s.addPageNumber(pdf, pdf.PageNo())
 }
 
 // addArabicAndContactPage creates the Arabic description and agent contact page
 func (s *PDFService) addArabicAndContactPage(pdf *gofpdf.Fpdf, property *models.Property) {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
 	pdf.AddPage()
 	
 	// Add cream background
 	s.addPageBackground(pdf)
 	
     s.addBrandingIfAvailable(pdf)
-	currentY := marginY + 10.0
+	currentY := margins.Top + 10.0
 	
     // Section: Arabic Description (use Arabic font and right alignment if available)
-    headerTextAr := "وصف العقار"
+    headerTextAr := s.rtl("وصف العقار")
     if s.hasArabicFont {
         currentY = s.addSectionHeaderAligned(pdf, headerTextAr, currentY, s.arabicFontName, "R")
     } else {
@@ -763,15 +1927,15 @@ func (s *PDFService) addArabicAndContactPage(pdf *gofpdf.Fpdf, property *models.
         if s.hasBodyFont {
             pdf.SetFont(s.bodyFontName, "", 11)
         } else {
-            pdf.SetFont("Arial", "", 11)
+            pdf.SetFont(s.theme.BodyFontName, "", 11)
         }
     }
 	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX, currentY)
+	pdf.SetXY(margins.Left, currentY)
 	
     arabicDesc := property.AIContent.ArabicDescription
 	if arabicDesc == "" {
-		arabicDesc = "لا يوجد وصف متاح"
+		arabicDesc = s.rtl("لا يوجد وصف متاح")
 	}
 	
     // Right-aligned for Arabic text (ensure UTF-8 font and R align). Apply shaping if font is present.
@@ -785,14 +1949,23 @@ func (s *PDFService) addArabicAndContactPage(pdf *gofpdf.Fpdf, property *models.
 	// Add spacing
 	currentY += 15
 	
+	// Mortgage estimate box
+	currentY = s.addMortgageEstimate(pdf, property, currentY, false)
+	
 	// Add thank you message
 	s.addThankYouMessage(pdf, property, currentY, false)
 	
 	// Add decorative bottom diamond element
 	s.addBottomDiamondDecoration(pdf)
 	
-	// Add page number (now page 4 with restructuring)
-	s.addPageNumber(pdf, 4)
+	// Tear-off strip replaces the page number in print mode
+	if property.PrintMode {
+		s.addTearOffStrip(pdf, property)
+		return
+	}
+	
+	// Add page number (last page, after any optional pages)
+	s.addPageNumber(pdf, pdf.PageNo())
 }
 
 // addAgentContactCard creates a professional contact card for the agent (English)
@@ -802,17 +1975,19 @@ func (s *PDFService) addAgentContactCard(pdf *gofpdf.Fpdf, property *models.Prop
 
 // addAgentContactCardLocalized creates a professional contact card with optional Arabic labels
 func (s *PDFService) addAgentContactCardLocalized(pdf *gofpdf.Fpdf, property *models.Property, startY float64, useArabic bool) {
+	margins := pageMargins(pdf)
+	pageWidth, pageHeight, contentWidth := pageSize(pdf)
 	cardHeight := 55.0
-	cardY := pageHeight - marginY - cardHeight - 20
+	cardY := pageHeight - margins.Bottom - cardHeight - 20
 	
 	// Background card
 	pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
-	pdf.Rect(marginX, cardY, contentWidth, cardHeight, "F")
+	pdf.Rect(margins.Left, cardY, contentWidth, cardHeight, "F")
 	
 	// Gold accent border
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.8)
-	pdf.Rect(marginX, cardY, contentWidth, cardHeight, "D")
+	pdf.Rect(margins.Left, cardY, contentWidth, cardHeight, "D")
 	
 	// Determine labels based on language
 	var agentLabel, nameLabel, emailLabel, phoneLabel string
@@ -820,9 +1995,9 @@ func (s *PDFService) addAgentContactCardLocalized(pdf *gofpdf.Fpdf, property *mo
 	
 	if useArabic && property.ArabicContent.AgentLabel != "" {
 		agentLabel = property.ArabicContent.AgentLabel
-		nameLabel = "الاسم:"
-		emailLabel = "البريد الإلكتروني:"
-		phoneLabel = "الهاتف:"
+		nameLabel = s.rtl("الاسم:")
+		emailLabel = s.rtl("البريد الإلكتروني:")
+		phoneLabel = s.rtl("الهاتف:")
 		align = "R"
 	} else if !useArabic && property.EnglishContent.AgentLabel != "" {
 		agentLabel = property.EnglishContent.AgentLabel
@@ -840,146 +2015,155 @@ func (s *PDFService) addAgentContactCardLocalized(pdf *gofpdf.Fpdf, property *mo
 	}
 	
 	// "Contact Agent" header
-	pdf.SetXY(marginX+5, cardY+5)
+	pdf.SetXY(margins.Left+5, cardY+5)
 	if useArabic && s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 14)
 	} else {
-		pdf.SetFont("Arial", "B", 14)
+		pdf.SetFont(s.theme.TitleFontName, "B", 14)
 	}
-	pdf.SetTextColor(darkBlueR, darkBlueG, darkBlueB)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
 	agentLabel = s.fixMojibakeLatin1ToUTF8(agentLabel)
 	pdf.CellFormat(contentWidth-10, 8, agentLabel, "", 1, align, false, 0, "")
 	
 	// Divider line
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.3)
-	pdf.Line(marginX+30, cardY+13, pageWidth-marginX-30, cardY+13)
+	pdf.Line(margins.Left+30, cardY+13, pageWidth-margins.Right-30, cardY+13)
 	
 	// Agent info
 	if useArabic && s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 11)
 	} else {
-		pdf.SetFont("Arial", "B", 11)
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
 	}
 	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX+10, cardY+18)
+	pdf.SetXY(margins.Left+10, cardY+18)
 	nameLabel = s.fixMojibakeLatin1ToUTF8(nameLabel)
 	pdf.CellFormat(50, 6, nameLabel, "", 0, "", false, 0, "")
 	
-	if s.hasBodyFont && !useArabic {
-		pdf.SetFont(s.bodyFontName, "", 11)
-	} else if useArabic && s.hasArabicFont {
-		pdf.SetFont(s.arabicFontName, "", 11)
-	} else {
-		pdf.SetFont("Arial", "", 11)
-	}
+	s.setAgentNameFont(pdf, property.AgentInfo.Name, 11, useArabic)
 	pdf.CellFormat(0, 6, property.AgentInfo.Name, "", 0, "", false, 0, "")
 	
 	if useArabic && s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 11)
 	} else {
-		pdf.SetFont("Arial", "B", 11)
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
 	}
-	pdf.SetXY(marginX+10, cardY+28)
+	pdf.SetXY(margins.Left+10, cardY+28)
 	emailLabel = s.fixMojibakeLatin1ToUTF8(emailLabel)
 	pdf.CellFormat(50, 6, emailLabel, "", 0, "", false, 0, "")
-	pdf.SetFont("Arial", "", 11)
-	pdf.SetTextColor(darkBlueR, darkBlueG, darkBlueB)
-	pdf.CellFormat(0, 6, property.AgentInfo.Email, "", 0, "", false, 0, "")
+	pdf.SetFont(s.theme.BodyFontName, "", 11)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	// Clickable mailto: link
+	pdf.WriteLinkString(6, property.AgentInfo.Email, "mailto:"+property.AgentInfo.Email)
 	
 	if useArabic && s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 11)
 	} else {
-		pdf.SetFont("Arial", "B", 11)
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
 	}
 	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX+10, cardY+38)
+	pdf.SetXY(margins.Left+10, cardY+38)
 	phoneLabel = s.fixMojibakeLatin1ToUTF8(phoneLabel)
 	pdf.CellFormat(50, 6, phoneLabel, "", 0, "", false, 0, "")
-	pdf.SetFont("Arial", "", 11)
-	pdf.SetTextColor(goldR, goldG, goldB)
-	pdf.CellFormat(0, 6, property.AgentInfo.Phone, "", 0, "", false, 0, "")
+	pdf.SetFont(s.theme.BodyFontName, "", 11)
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	// Clickable tel: link
+	pdf.WriteLinkString(6, property.AgentInfo.Phone, telURI(property.AgentInfo.Phone))
 }
 
 // addSectionHeader creates a styled section header
 func (s *PDFService) addSectionHeader(pdf *gofpdf.Fpdf, title string, y float64) float64 {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+	return s.addSectionHeaderInColumn(pdf, title, margins.Left, y, contentWidth)
+}
+
+// addSectionHeaderInColumn draws the section header bar within a column of the given position and width
+func (s *PDFService) addSectionHeaderInColumn(pdf *gofpdf.Fpdf, title string, x, y, width float64) float64 {
 	// Background bar
-	pdf.SetFillColor(darkBlueR, darkBlueG, darkBlueB)
-	pdf.Rect(marginX, y, contentWidth, 10, "F")
+	s.addSectionHeaderBar(pdf, x, y, width, 10)
 	
 	// Title text
-	pdf.SetXY(marginX+5, y+1.5)
-	pdf.SetFont("Arial", "B", 13)
+	pdf.SetXY(x+5, y+1.5)
+	pdf.SetFont(s.theme.TitleFontName, "B", 13)
 	pdf.SetTextColor(255, 255, 255) // White text
-	pdf.CellFormat(contentWidth-10, 7, title, "", 0, "L", false, 0, "")
+	pdf.CellFormat(width-10, 7, title, "", 0, "L", false, 0, "")
 	
 	// Gold accent line
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.8)
-	pdf.Line(marginX, y+10, pageWidth-marginX, y+10)
+	pdf.Line(x, y+10, x+width, y+10)
 	
 	return y + 15
 }
 
 // addSectionHeaderWithIcon creates an enhanced section header with decorative elements
 func (s *PDFService) addSectionHeaderWithIcon(pdf *gofpdf.Fpdf, title string, y float64, iconType string) float64 {
-	// Gradient effect using two rectangles
-	pdf.SetFillColor(darkBlueR, darkBlueG, darkBlueB)
-	pdf.Rect(marginX, y, contentWidth, 10, "F")
+	margins := pageMargins(pdf)
+	pageWidth, _, contentWidth := pageSize(pdf)
+	// Background bar
+	s.addSectionHeaderBar(pdf, margins.Left, y, contentWidth, 10)
 	
 	// Add decorative left accent bar
-	pdf.SetFillColor(goldR, goldG, goldB)
-	pdf.Rect(marginX, y, 3, 10, "F")
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Rect(margins.Left, y, 3, 10, "F")
 	
 	// Add decorative right corner
-	pdf.SetFillColor(goldR-20, goldG-20, goldB-20)
-	pdf.Rect(pageWidth-marginX-3, y, 3, 10, "F")
+	pdf.SetFillColor(s.theme.AccentColor.darken(20).RGB())
+	pdf.Rect(pageWidth-margins.Right-3, y, 3, 10, "F")
 	
 	// Icon/bullet point
-	iconX := marginX + 8
+	iconX := margins.Left + 8
 	iconY := y + 5
-	pdf.SetFillColor(goldR, goldG, goldB)
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
 	pdf.Circle(iconX, iconY, 2, "F")
 	
 	// Title text
-	pdf.SetXY(marginX+14, y+1.5)
-	pdf.SetFont("Arial", "B", 13)
+	pdf.SetXY(margins.Left+14, y+1.5)
+	pdf.SetFont(s.theme.TitleFontName, "B", 13)
 	pdf.SetTextColor(255, 255, 255) // White text
 	pdf.CellFormat(contentWidth-20, 7, title, "", 0, "L", false, 0, "")
 	
 	// Gold accent line with fade effect
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(1.0)
-	pdf.Line(marginX, y+10, pageWidth-marginX, y+10)
+	pdf.Line(margins.Left, y+10, pageWidth-margins.Right, y+10)
 	
 	return y + 15
 }
 
 // addSectionHeaderAligned is like addSectionHeader but allows custom font and alignment
 func (s *PDFService) addSectionHeaderAligned(pdf *gofpdf.Fpdf, title string, y float64, fontName string, align string) float64 {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+    return s.addSectionHeaderAlignedInColumn(pdf, title, margins.Left, y, contentWidth, fontName, align)
+}
+
+// addSectionHeaderAlignedInColumn is addSectionHeaderAligned restricted to a column
+func (s *PDFService) addSectionHeaderAlignedInColumn(pdf *gofpdf.Fpdf, title string, x, y, width float64, fontName string, align string) float64 {
     if align != "R" {
         align = "L"
     }
     // Background bar
-    pdf.SetFillColor(darkBlueR, darkBlueG, darkBlueB)
-    pdf.Rect(marginX, y, contentWidth, 10, "F")
+    s.addSectionHeaderBar(pdf, x, y, width, 10)
 
     // Title text with custom font if provided
     pdf.SetTextColor(255, 255, 255)
     if fontName != "" {
         pdf.SetFont(fontName, "", 13)
     } else {
-        pdf.SetFont("Arial", "B", 13)
+        pdf.SetFont(s.theme.TitleFontName, "B", 13)
     }
 
     // Position and alignment
-    pdf.SetXY(marginX+5, y+1.5)
-    pdf.CellFormat(contentWidth-10, 7, title, "", 0, align, false, 0, "")
+    pdf.SetXY(x+5, y+1.5)
+    pdf.CellFormat(width-10, 7, title, "", 0, align, false, 0, "")
 
     // Gold accent line
-    pdf.SetDrawColor(goldR, goldG, goldB)
+    pdf.SetDrawColor(s.theme.AccentColor.RGB())
     pdf.SetLineWidth(0.8)
-    pdf.Line(marginX, y+10, pageWidth-marginX, y+10)
+    pdf.Line(x, y+10, x+width, y+10)
 
     return y + 15
 }
@@ -987,82 +2171,123 @@ func (s *PDFService) addSectionHeaderAligned(pdf *gofpdf.Fpdf, title string, y f
 // addPageNumber adds page number at the bottom of the page
 func (s *PDFService) addPageNumber(pdf *gofpdf.Fpdf, pageNum int) {
 	pdf.SetY(-10)
-	pdf.SetFont("Arial", "I", 9)
+	pdf.SetFont(s.theme.BodyFontName, "I", 9)
 	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
 	pdf.CellFormat(0, 10, fmt.Sprintf("Page %d", pageNum), "", 0, "C", false, 0, "")
 }
 
-// setupFonts attempts to load optional Unicode fonts for better internationalization
-func (s *PDFService) setupFonts(pdf *gofpdf.Fpdf) {
-    // Force override: Use hardcoded paths from project fonts folder
-    fontPath := "fonts/NotoNaskhArabic-Regular.ttf"
-    
-    fmt.Println("[PDF DEBUG] Using Arabic font path:", fontPath)
-    
-    if _, err := os.Stat(fontPath); err == nil {
-        pdf.AddUTF8Font("ArabicFont", "", fontPath)
+// shortenURL cuts URLs longer than 40 characters to 37 and an ellipsis, counting runes so that
+// internationalized domains and paths are not cut inside a character
+func shortenURL(url string) string {
+	if runes := []rune(url); len(runes) > 40 {
+		return string(runes[:37]) + "..."
+	}
+	return url
+}
+
+// setupFonts registers the optional Unicode fonts on the document, reading them from disk only once
+func (s *PDFService) setupFonts(pdf *gofpdf.Fpdf, property *models.Property) {
+    s.fontFiles.once.Do(s.loadFontFiles)
+
+    // An agency font uploaded for the listing replaces the body or Arabic font in this document only
+    arabicFontBytes, bodyFontBytes := s.fontFiles.arabic, s.fontFiles.body
+    if s.urdu && s.fontFiles.urdu != nil {
+        arabicFontBytes = s.fontFiles.urdu
+    }
+    if custom := s.customFont(property); custom != nil {
+        if custom.Language == "ar" {
+            arabicFontBytes = custom.Data
+        } else {
+            bodyFontBytes = custom.Data
+        }
+    }
+
+    // gofpdf registers fonts per document, so the cached bytes are added to every new instance.
+    // Each document gets its own copy: gofpdf pads tables in place when subsetting the font on output,
+    // which would otherwise race between concurrent brochures.
+    if arabicFontBytes != nil {
+        pdf.AddUTF8FontFromBytes("ArabicFont", "", bytes.Clone(arabicFontBytes))
         s.arabicFontName = "ArabicFont"
         s.hasArabicFont = true
-        fmt.Println("[PDF] Loaded Arabic UTF-8 font:", fontPath)
-    } else {
-        fmt.Println("[PDF] ARABIC_TTF_PATH not found:", fontPath, "err:", err)
     }
-
-    // Force override: Use hardcoded paths from project fonts folder
-    bodyPath := "fonts/Roboto-Regular.ttf"
-    fmt.Println("[PDF DEBUG] Using body font path:", bodyPath)
-    
-    if _, err := os.Stat(bodyPath); err == nil {
-        pdf.AddUTF8Font("BodyFont", "", bodyPath)
+    if bodyFontBytes != nil {
+        pdf.AddUTF8FontFromBytes("BodyFont", "", bytes.Clone(bodyFontBytes))
         s.bodyFontName = "BodyFont"
         s.hasBodyFont = true
-        fmt.Println("[PDF] Loaded Body UTF-8 font:", bodyPath)
-    } else {
-        fmt.Println("[PDF] BODY_TTF_PATH not found:", bodyPath, "err:", err)
     }
 
     // Fallback: if body font not set but Arabic font exists, use Arabic font for body too
     if !s.hasBodyFont && s.hasArabicFont {
         s.bodyFontName = s.arabicFontName
         s.hasBodyFont = true
+    }
+}
+
+// customFont loads the listing's uploaded font, falling back to the default fonts when it is unavailable
+func (s *PDFService) customFont(property *models.Property) *CustomFont {
+    if property == nil || property.FontID == "" || s.fontLoader == nil {
+        return nil
+    }
+    font, err := s.fontLoader.LoadFont(property.FontID)
+    if err != nil {
+        log.Printf("Using default fonts, custom font %s is unavailable: %v", property.FontID, err)
+        return nil
+    }
+    return font
+}
+
+// loadFontFiles reads the configured font files into memory; empty paths are skipped
+func (s *PDFService) loadFontFiles() {
+    if fontPath := s.arabicFontPath; fontPath != "" {
+        if data, err := os.ReadFile(fontPath); err == nil {
+            s.fontFiles.arabic = data
+            fmt.Println("[PDF] Loaded Arabic UTF-8 font:", fontPath)
+        } else {
+            fmt.Println("[PDF] ARABIC_TTF_PATH not found:", fontPath, "err:", err)
+        }
+    }
+
+    if bodyPath := s.bodyFontPath; bodyPath != "" {
+        if data, err := os.ReadFile(bodyPath); err == nil {
+            s.fontFiles.body = data
+            fmt.Println("[PDF] Loaded Body UTF-8 font:", bodyPath)
+        } else {
+            fmt.Println("[PDF] BODY_TTF_PATH not found:", bodyPath, "err:", err)
+        }
+    }
+
+    if urduPath := s.urduFontPath; urduPath != "" {
+        if data, err := os.ReadFile(urduPath); err == nil {
+            s.fontFiles.urdu = data
+            fmt.Println("[PDF] Loaded Urdu UTF-8 font:", urduPath)
+        } else {
+            fmt.Println("[PDF] URDU_FONT_PATH not found, Urdu brochures use the Arabic font:", urduPath, "err:", err)
+        }
+    }
+
+    if s.fontFiles.body == nil && s.fontFiles.arabic != nil {
         fmt.Println("[PDF] Using Arabic font as body font fallback.")
     }
 }
 
 // addBrandingIfAvailable draws a small logo in the top-right corner if BRAND_LOGO_URL is set
 func (s *PDFService) addBrandingIfAvailable(pdf *gofpdf.Fpdf) {
+	margins := pageMargins(pdf)
+	pageWidth, _, _ := pageSize(pdf)
     if s.brandLogoURL == "" {
         return
     }
     // Reserve a small square area for the logo
     boxW, boxH := 18.0, 18.0
-    x := pageWidth - marginX - boxW
+    x := pageWidth - margins.Right - boxW
     y := 6.0
     _ = s.addImageFromURL(pdf, s.brandLogoURL, x, y, boxW, boxH)
 }
 
-// formatPrice formats the price with currency symbol
+// formatPrice formats the price with the currency's symbol and separators (USD when empty).
+// The result is UTF-8; wrap it in coreText when drawing with a core font.
 func (s *PDFService) formatPrice(price float64, currency string) string {
-	if currency == "" {
-		currency = "USD"
-	}
-	
-	// Format with thousand separators
-	priceStr := fmt.Sprintf("%.0f", price)
-	
-	// Add thousand separators
-	if len(priceStr) > 3 {
-		result := ""
-		for i, digit := range priceStr {
-			if i > 0 && (len(priceStr)-i)%3 == 0 {
-				result += ","
-			}
-			result += string(digit)
-		}
-		priceStr = result
-	}
-	
-	return fmt.Sprintf("%s %s", currency, priceStr)
+	return CurrencyFormatFor(currency).Format(price)
 }
 
 // formatLocation creates a formatted location string
@@ -1107,7 +2332,7 @@ func (s *PDFService) sanitizeBulletText(text string) string {
 }
 
 // fixMojibakeLatin1ToUTF8 attempts to convert text that was UTF-8 but decoded as Latin-1
-// This helps when inputs show sequences like "Ã˜" instead of proper Arabic letters.
+// This helps when inputs show sequences like "Ø§Ù„" instead of proper Arabic letters.
 func (s *PDFService) fixMojibakeLatin1ToUTF8(text string) string {
     // If text already contains Arabic codepoints, return as-is
     for _, r := range text {
@@ -1115,101 +2340,137 @@ func (s *PDFService) fixMojibakeLatin1ToUTF8(text string) string {
             return text
         }
     }
-    // Heuristic: if it contains 'Ã' (common mojibake indicator), try Latin-1 decode
-    if !strings.ContainsRune(text, 'Ã') {
+    // Heuristic: UTF-8 lead bytes of Latin (Ã, Â) and Arabic (Ø, Ù) letters as they appear after mis-decoding
+    if !strings.ContainsAny(text, "ÃÂØÙ") {
         return text
     }
-    reader := transform.NewReader(strings.NewReader(text), charmap.ISO8859_1.NewDecoder())
-    decoded, err := io.ReadAll(reader)
-    if err != nil {
+    // Re-encoding the characters recovers the original bytes (Windows-1252 covers the 0x80-0x9F range
+    // Latin-1 leaves undefined); text that was never mojibake does not yield valid UTF-8 and is kept
+    reader := transform.NewReader(strings.NewReader(text), charmap.Windows1252.NewEncoder())
+    original, err := io.ReadAll(reader)
+    if err != nil || !utf8.Valid(original) {
         return text
     }
-    return string(decoded)
+    return string(original)
 }
 
 // addPageBackground adds a cream-colored background to the entire page
 func (s *PDFService) addPageBackground(pdf *gofpdf.Fpdf) {
-	pdf.SetFillColor(bgCreamR, bgCreamG, bgCreamB)
+	pageWidth, pageHeight, _ := pageSize(pdf)
+	pdf.SetFillColor(s.theme.BackgroundColor.RGB())
 	pdf.Rect(0, 0, pageWidth, pageHeight, "F")
 }
 
 // addDecorativeCorners adds decorative corner elements to the page
 func (s *PDFService) addDecorativeCorners(pdf *gofpdf.Fpdf) {
+	pageWidth, pageHeight, _ := pageSize(pdf)
+	scale := decorationScale(pdf)
+	inset, arm := 5*scale, 10*scale
+	
 	// Top-left corner
-	pdf.SetDrawColor(goldR, goldG, goldB)
-	pdf.SetLineWidth(0.5)
-	pdf.Line(5, 5, 15, 5)
-	pdf.Line(5, 5, 5, 15)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.5 * scale)
+	pdf.Line(inset, inset, inset+arm, inset)
+	pdf.Line(inset, inset, inset, inset+arm)
 	
 	// Top-right corner
-	pdf.Line(pageWidth-15, 5, pageWidth-5, 5)
-	pdf.Line(pageWidth-5, 5, pageWidth-5, 15)
+	pdf.Line(pageWidth-inset-arm, inset, pageWidth-inset, inset)
+	pdf.Line(pageWidth-inset, inset, pageWidth-inset, inset+arm)
 	
 	// Bottom-left corner
-	pdf.Line(5, pageHeight-15, 5, pageHeight-5)
-	pdf.Line(5, pageHeight-5, 15, pageHeight-5)
+	pdf.Line(inset, pageHeight-inset-arm, inset, pageHeight-inset)
+	pdf.Line(inset, pageHeight-inset, inset+arm, pageHeight-inset)
 	
 	// Bottom-right corner
-	pdf.Line(pageWidth-15, pageHeight-5, pageWidth-5, pageHeight-5)
-	pdf.Line(pageWidth-5, pageHeight-15, pageWidth-5, pageHeight-5)
+	pdf.Line(pageWidth-inset-arm, pageHeight-inset, pageWidth-inset, pageHeight-inset)
+	pdf.Line(pageWidth-inset, pageHeight-inset-arm, pageWidth-inset, pageHeight-inset)
 }
 
 // addBottomDiamondDecoration adds the elegant diamond with lines decoration at the bottom of the page
 func (s *PDFService) addBottomDiamondDecoration(pdf *gofpdf.Fpdf) {
+	margins := pageMargins(pdf)
+	pageWidth, pageHeight, _ := pageSize(pdf)
+	scale := decorationScale(pdf)
 	// Position near bottom but above page number
-	pdf.SetY(268)
+	pdf.SetY(pageHeight - 29)
 	
 	// Add decorative diamond shape in center
 	centerX := pageWidth / 2
-	diamondY := 272.0
-	pdf.SetFillColor(goldR, goldG, goldB)
+	diamondY := pageHeight - 25
+	dx, dy := 4*scale, 3*scale
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
 	
 	// Create diamond with lines
-	pdf.SetDrawColor(goldR, goldG, goldB)
-	pdf.SetLineWidth(0.8)
-	pdf.Line(centerX-4, diamondY, centerX, diamondY-3)
-	pdf.Line(centerX, diamondY-3, centerX+4, diamondY)
-	pdf.Line(centerX+4, diamondY, centerX, diamondY+3)
-	pdf.Line(centerX, diamondY+3, centerX-4, diamondY)
-	
-	// Lines extending from diamond
-	pdf.SetLineWidth(0.5)
-	pdf.Line(marginX+50, diamondY, centerX-6, diamondY)
-	pdf.Line(centerX+6, diamondY, pageWidth-marginX-50, diamondY)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.8 * scale)
+	pdf.Line(centerX-dx, diamondY, centerX, diamondY-dy)
+	pdf.Line(centerX, diamondY-dy, centerX+dx, diamondY)
+	pdf.Line(centerX+dx, diamondY, centerX, diamondY+dy)
+	pdf.Line(centerX, diamondY+dy, centerX-dx, diamondY)
+	
+	// Lines extending from diamond, keeping the A4 proportions of the page width
+	lineInset := (margins.Left + 50) * pageWidth / 210
+	pdf.SetLineWidth(0.5 * scale)
+	pdf.Line(lineInset, diamondY, centerX-dx-2, diamondY)
+	pdf.Line(centerX+dx+2, diamondY, pageWidth-lineInset, diamondY)
 }
 
-// addAgentContactCardTop creates a professional contact card at the top of the page and returns the Y position after the card
+// addAgentContactCardTop creates a professional contact card at the top of the page, preceded by a
+// "Listed By" row when the listing has co-listing agents, and returns the Y position after the card
 func (s *PDFService) addAgentContactCardTop(pdf *gofpdf.Fpdf, property *models.Property, startY float64, useArabic bool) float64 {
+	margins := pageMargins(pdf)
+	pageWidth, _, contentWidth := pageSize(pdf)
 	cardHeight := 55.0
 	
+	if len(property.CoListingAgents) > 0 {
+		startY = s.addListedByRow(pdf, property, startY, useArabic) + 4
+	}
+	
+	agents := append([]models.AgentInfo{property.AgentInfo}, property.SecondaryAgents...)
+	
+	// Secondary agents share the card: compress the rows to 5pt and grow the card
+	rowPitch, rowHeight, agentGap, bottomPadding := 10.0, 6.0, 0.0, 7.0
+	if len(property.SecondaryAgents) > 0 {
+		rowPitch, rowHeight, agentGap, bottomPadding = 6.0, 5.0, 4.0, 4.0
+	}
+	rows := 0
+	for _, agent := range agents {
+		rows += agentRowCount(agent)
+	}
+	if rows > 3 {
+		cardHeight = 18 + float64(rows)*rowPitch + float64(len(agents)-1)*agentGap + bottomPadding
+	}
+	
 	// Background card with shadow effect
 	pdf.SetFillColor(200, 200, 200)
-	pdf.Rect(marginX+2, startY+2, contentWidth, cardHeight, "F")
+	pdf.Rect(margins.Left+2, startY+2, contentWidth, cardHeight, "F")
 	
 	// Main card background
 	pdf.SetFillColor(255, 255, 255)
-	pdf.Rect(marginX, startY, contentWidth, cardHeight, "F")
+	pdf.Rect(margins.Left, startY, contentWidth, cardHeight, "F")
 	
 	// Gold accent border
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.8)
-	pdf.Rect(marginX, startY, contentWidth, cardHeight, "D")
+	pdf.Rect(margins.Left, startY, contentWidth, cardHeight, "D")
 	
 	// Determine labels based on language
-	var agentLabel, nameLabel, emailLabel, phoneLabel string
+	var agentLabel, nameLabel, emailLabel, phoneLabel, websiteLabel string
 	var align string
 	
 	if useArabic && property.ArabicContent.AgentLabel != "" {
 		agentLabel = property.ArabicContent.AgentLabel
-		nameLabel = "الاسم:"
-		emailLabel = "البريد الإلكتروني:"
-		phoneLabel = "الهاتف:"
+		nameLabel = s.rtl("الاسم:")
+		emailLabel = s.rtl("البريد الإلكتروني:")
+		phoneLabel = s.rtl("الهاتف:")
+		websiteLabel = s.rtl("الموقع الإلكتروني:")
 		align = "R"
 	} else if !useArabic && property.EnglishContent.AgentLabel != "" {
 		agentLabel = property.EnglishContent.AgentLabel
 		nameLabel = "Name:"
 		emailLabel = "Email:"
 		phoneLabel = "Phone:"
+		websiteLabel = "Website:"
 		align = "C"
 	} else {
 		// Fallback to English
@@ -1217,75 +2478,337 @@ func (s *PDFService) addAgentContactCardTop(pdf *gofpdf.Fpdf, property *models.P
 		nameLabel = "Name:"
 		emailLabel = "Email:"
 		phoneLabel = "Phone:"
+		websiteLabel = "Website:"
 		align = "C"
 	}
 	
 	// "Contact Agent" header
-	pdf.SetXY(marginX+5, startY+5)
+	pdf.SetXY(margins.Left+5, startY+5)
 	if useArabic && s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 14)
 	} else {
-		pdf.SetFont("Arial", "B", 14)
+		pdf.SetFont(s.theme.TitleFontName, "B", 14)
 	}
-	pdf.SetTextColor(darkBlueR, darkBlueG, darkBlueB)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
 	agentLabel = s.fixMojibakeLatin1ToUTF8(agentLabel)
 	pdf.CellFormat(contentWidth-10, 8, agentLabel, "", 1, align, false, 0, "")
 	
 	// Divider line
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.3)
-	pdf.Line(marginX+30, startY+13, pageWidth-marginX-30, startY+13)
+	pdf.Line(margins.Left+30, startY+13, pageWidth-margins.Right-30, startY+13)
+	
+	// Agent info: the primary agent, then any secondary agents separated by divider lines that stop short
+	// of the primary agent's vCard QR code on the right
+	labels := []string{nameLabel, emailLabel, phoneLabel, websiteLabel}
+	rowY := startY + 18
+	for i, agent := range agents {
+		if i > 0 {
+			pdf.SetDrawColor(s.theme.AccentColor.RGB())
+			pdf.SetLineWidth(0.2)
+			pdf.Line(margins.Left+10, rowY-agentGap/2, pageWidth-margins.Right-40, rowY-agentGap/2)
+		}
+		s.addAgentRows(pdf, agent, rowY, rowPitch, rowHeight, labels, useArabic)
+		rowY += float64(agentRowCount(agent))*rowPitch + agentGap
+	}
+	s.addAgentQRCode(pdf, property.AgentInfo, pageWidth-margins.Right-34, startY+17, 28)
 	
-	// Agent info
+	return startY + cardHeight
+}
+
+// addListedByRow draws the primary agent and the co-listing agents side by side with their name and
+// phone, the primary agent first (rightmost in Arabic), and returns the Y position after the row
+func (s *PDFService) addListedByRow(pdf *gofpdf.Fpdf, property *models.Property, startY float64, useArabic bool) float64 {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+	const rowHeight = 24.0
+	
+	agents := append([]models.AgentInfo{property.AgentInfo}, property.CoListingAgents...)
+	if useArabic {
+		for i, j := 0, len(agents)-1; i < j; i, j = i+1, j-1 {
+			agents[i], agents[j] = agents[j], agents[i]
+		}
+	}
+	
+	pdf.SetFillColor(255, 255, 255)
+	pdf.Rect(margins.Left, startY, contentWidth, rowHeight, "F")
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.3)
+	pdf.Rect(margins.Left, startY, contentWidth, rowHeight, "D")
+	
+	label := "Listed By"
 	if useArabic && s.hasArabicFont {
+		label = s.rtl("معروض بواسطة")
 		pdf.SetFont(s.arabicFontName, "", 11)
 	} else {
-		pdf.SetFont("Arial", "B", 11)
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
 	}
-	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX+10, startY+18)
-	nameLabel = s.fixMojibakeLatin1ToUTF8(nameLabel)
-	pdf.CellFormat(50, 6, nameLabel, "", 0, "", false, 0, "")
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	pdf.SetXY(margins.Left, startY+2)
+	pdf.CellFormat(contentWidth, 6, label, "", 0, "C", false, 0, "")
+	
+	colW := contentWidth / float64(len(agents))
+	for i, agent := range agents {
+		x := margins.Left + float64(i)*colW
+		if i > 0 {
+			pdf.SetDrawColor(s.theme.AccentColor.RGB())
+			pdf.SetLineWidth(0.2)
+			pdf.Line(x, startY+9, x, startY+rowHeight-3)
+		}
 	
-	if s.hasBodyFont && !useArabic {
-		pdf.SetFont(s.bodyFontName, "", 11)
-	} else if useArabic && s.hasArabicFont {
+		// The name links to the agent's email, the phone to a call
+		s.setAgentNameFont(pdf, agent.Name, 10, useArabic)
+		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+		pdf.SetXY(x+2, startY+9)
+		pdf.CellFormat(colW-4, 6, agent.Name, "", 0, "C", false, 0, "mailto:"+agent.Email)
+	
+		pdf.SetFont(s.theme.BodyFontName, "", 9)
+		pdf.SetTextColor(s.theme.AccentColor.RGB())
+		pdf.SetXY(x+2, startY+15)
+		pdf.CellFormat(colW-4, 5, agent.Phone, "", 0, "C", false, 0, telURI(agent.Phone))
+	}
+	
+	return startY + rowHeight
+}
+
+// addAgentQRCode draws a scannable vCard of the agent, so the brochure reader can save the contact
+func (s *PDFService) addAgentQRCode(pdf *gofpdf.Fpdf, agent models.AgentInfo, x, y, size float64) {
+	png, err := qrcode.Encode(agentVCard(agent), qrcode.Medium, 256)
+	if err != nil {
+		log.Printf("Warning: failed to encode agent QR code: %v", err)
+		return
+	}
+	opts := gofpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("agent_vcard_qr", opts, bytes.NewReader(png))
+	pdf.ImageOptions("agent_vcard_qr", x, y, size, size, false, opts, 0, "")
+}
+
+// agentVCard formats the agent's contact details as a vCard 3.0
+func agentVCard(agent models.AgentInfo) string {
+	escape := strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`).Replace
+	lines := []string{"BEGIN:VCARD", "VERSION:3.0", "FN:" + escape(agent.Name)}
+	if agent.Phone != "" {
+		lines = append(lines, "TEL;TYPE=CELL:"+escape(agent.Phone))
+	}
+	if agent.Email != "" {
+		lines = append(lines, "EMAIL:"+escape(agent.Email))
+	}
+	if agent.Website != "" {
+		lines = append(lines, "URL:"+agent.Website)
+	}
+	lines = append(lines, "END:VCARD")
+	return strings.Join(lines, "\r\n")
+}
+
+// setAgentNameFont selects the font of an agent name: the Arabic font in Arabic brochures and for names
+// written in Arabic script, which the Latin fonts cannot render, and the body font otherwise
+func (s *PDFService) setAgentNameFont(pdf *gofpdf.Fpdf, name string, size float64, useArabic bool) {
+	switch {
+	case s.hasArabicFont && (useArabic || containsArabicScript(name)):
+		pdf.SetFont(s.arabicFontName, "", size)
+	case s.hasBodyFont && !useArabic:
+		pdf.SetFont(s.bodyFontName, "", size)
+	default:
+		pdf.SetFont(s.theme.BodyFontName, "", size)
+	}
+}
+
+// containsArabicScript reports whether the text has any Arabic-script letters (Arabic, Urdu, Persian)
+func containsArabicScript(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool { return unicode.Is(unicode.Arabic, r) }) >= 0
+}
+
+// agentRowCount is the number of contact card rows an agent needs (name, email, phone and optional website)
+func agentRowCount(agent models.AgentInfo) int {
+	if agent.Website != "" {
+		return 4
+	}
+	return 3
+}
+
+// addAgentRows renders the name, email, phone and website rows of one agent starting at y.
+// labels holds the name, email, phone and website labels in that order.
+func (s *PDFService) addAgentRows(pdf *gofpdf.Fpdf, agent models.AgentInfo, y, rowPitch, rowHeight float64, labels []string, useArabic bool) {
+	margins := pageMargins(pdf)
+	nameLabel, emailLabel, phoneLabel, websiteLabel := labels[0], labels[1], labels[2], labels[3]
+	if useArabic && s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 11)
 	} else {
-		pdf.SetFont("Arial", "", 11)
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
 	}
-	pdf.CellFormat(0, 6, property.AgentInfo.Name, "", 0, "", false, 0, "")
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(margins.Left+10, y)
+	pdf.CellFormat(50, rowHeight, s.fixMojibakeLatin1ToUTF8(nameLabel), "", 0, "", false, 0, "")
+	
+	s.setAgentNameFont(pdf, agent.Name, 11, useArabic)
+	pdf.CellFormat(0, rowHeight, agent.Name, "", 0, "", false, 0, "")
 	
 	if useArabic && s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 11)
 	} else {
-		pdf.SetFont("Arial", "B", 11)
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
 	}
-	pdf.SetXY(marginX+10, startY+28)
-	emailLabel = s.fixMojibakeLatin1ToUTF8(emailLabel)
-	pdf.CellFormat(50, 6, emailLabel, "", 0, "", false, 0, "")
-	pdf.SetFont("Arial", "", 11)
-	pdf.SetTextColor(darkBlueR, darkBlueG, darkBlueB)
-	pdf.CellFormat(0, 6, property.AgentInfo.Email, "", 0, "", false, 0, "")
+	pdf.SetXY(margins.Left+10, y+rowPitch)
+	pdf.CellFormat(50, rowHeight, s.fixMojibakeLatin1ToUTF8(emailLabel), "", 0, "", false, 0, "")
+	pdf.SetFont(s.theme.BodyFontName, "", 11)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	// Clickable mailto: link
+	pdf.WriteLinkString(rowHeight, agent.Email, "mailto:"+agent.Email)
 	
 	if useArabic && s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 11)
 	} else {
-		pdf.SetFont("Arial", "B", 11)
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
 	}
 	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX+10, startY+38)
-	phoneLabel = s.fixMojibakeLatin1ToUTF8(phoneLabel)
-	pdf.CellFormat(50, 6, phoneLabel, "", 0, "", false, 0, "")
-	pdf.SetFont("Arial", "", 11)
-	pdf.SetTextColor(goldR, goldG, goldB)
-	pdf.CellFormat(0, 6, property.AgentInfo.Phone, "", 0, "", false, 0, "")
+	pdf.SetXY(margins.Left+10, y+2*rowPitch)
+	pdf.CellFormat(50, rowHeight, s.fixMojibakeLatin1ToUTF8(phoneLabel), "", 0, "", false, 0, "")
+	pdf.SetFont(s.theme.BodyFontName, "", 11)
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	// Clickable tel: link
+	pdf.WriteLinkString(rowHeight, agent.Phone, telURI(agent.Phone))
+	
+	if agent.Website == "" {
+		return
+	}
+	if useArabic && s.hasArabicFont {
+		pdf.SetFont(s.arabicFontName, "", 11)
+	} else {
+		pdf.SetFont(s.theme.TitleFontName, "B", 11)
+	}
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(margins.Left+10, y+3*rowPitch)
+	pdf.CellFormat(50, rowHeight, s.fixMojibakeLatin1ToUTF8(websiteLabel), "", 0, "", false, 0, "")
+	pdf.SetFont(s.theme.BodyFontName, "", 11)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	// Clickable link to the full URL; long URLs are shortened for display only
+	display := shortenURL(agent.Website)
+	linkX, linkW := pdf.GetX(), pdf.GetStringWidth(display)
+	pdf.CellFormat(linkW, rowHeight, display, "", 0, "", false, 0, "")
+	pdf.LinkString(linkX, y+3*rowPitch, linkW, rowHeight, agent.Website)
+}
+
+// tearOffStripHeight is the height of the tear-off contact strip at the bottom of the contact page
+const tearOffStripHeight = 20.0
+
+// addTearOffStrip draws a dashed cut line and repeating agent contact columns across the bottom of the page
+func (s *PDFService) addTearOffStrip(pdf *gofpdf.Fpdf, property *models.Property) {
+	margins := pageMargins(pdf)
+	pageWidth, pageHeight, contentWidth := pageSize(pdf)
+	const columns = 6
+	stripY := pageHeight - tearOffStripHeight
+	colW := contentWidth / columns
+	
+	// Dashed cut line across the full page width
+	pdf.SetDrawColor(mediumGrayR, mediumGrayG, mediumGrayB)
+	pdf.SetLineWidth(0.3)
+	pdf.SetDashPattern([]float64{2, 1.5}, 0)
+	pdf.Line(0, stripY, pageWidth, stripY)
 	
-	return startY + cardHeight
+	// Dashed separators between the columns
+	for i := 1; i < columns; i++ {
+		x := margins.Left + float64(i)*colW
+		pdf.Line(x, stripY, x, pageHeight)
+	}
+	pdf.SetDashPattern([]float64{}, 0)
+	
+	pdf.SetFont(s.theme.BodyFontName, "", 7)
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	lines := []string{property.AgentInfo.Name, property.AgentInfo.Phone}
+	lines = append(lines, s.splitEmailToWidth(pdf, property.AgentInfo.Email, colW-2)...)
+	
+	lineH := 3.0
+	textY := stripY + (tearOffStripHeight-float64(len(lines))*lineH)/2
+	for i := 0; i < columns; i++ {
+		x := margins.Left + float64(i)*colW
+		for j, line := range lines {
+			pdf.SetXY(x+1, textY+float64(j)*lineH)
+			pdf.CellFormat(colW-2, lineH, line, "", 0, "C", false, 0, "")
+		}
+	}
+}
+
+// splitEmailToWidth breaks an email after the @ when it does not fit the given width
+func (s *PDFService) splitEmailToWidth(pdf *gofpdf.Fpdf, text string, width float64) []string {
+	if pdf.GetStringWidth(text) <= width {
+		return []string{text}
+	}
+	if at := strings.Index(text, "@"); at > 0 {
+		return []string{text[:at], text[at:]}
+	}
+	return []string{text}
+}
+
+// telURI builds a tel: URI from a display phone number, keeping only digits and a leading +
+func telURI(phone string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		if (r >= '0' && r <= '9') || (r == '+' && i == 0) {
+			b.WriteRune(r)
+		}
+	}
+	return "tel:" + b.String()
+}
+
+// addMortgageEstimate draws the estimated monthly payment with its assumptions and returns the Y below the box
+func (s *PDFService) addMortgageEstimate(pdf *gofpdf.Fpdf, property *models.Property, startY float64, useArabic bool) float64 {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+	if property.Mortgage == nil || property.Price <= 0 {
+		return startY
+	}
+	m := *property.Mortgage
+	payment := MonthlyMortgagePayment(property.Price, m)
+	if payment <= 0 {
+		return startY
+	}
+	
+	downPayment := property.Price * m.DownPaymentPct / 100
+	label := "Estimated Monthly Payment: " + coreText(pdf, s.formatPrice(payment, property.Currency))
+	assumptions := fmt.Sprintf("Assumes %.0f%% down payment (%s), %.2f%% fixed interest rate, %d-year term. Principal and interest only; excludes taxes, insurance and fees.",
+		m.DownPaymentPct, coreText(pdf, s.formatPrice(downPayment, property.Currency)), m.InterestRate, m.TermYears)
+	align := "C"
+	if useArabic && s.hasArabicFont {
+		label = s.rtl("القسط الشهري التقديري: ") + s.formatPrice(payment, property.Currency)
+		assumptions = fmt.Sprintf(s.rtl("بافتراض دفعة أولى %.0f%% (%s)، وسعر فائدة ثابت %.2f%%، ومدة %d سنة. يشمل أصل القرض والفائدة فقط، ولا يشمل الضرائب والتأمين والرسوم."),
+			m.DownPaymentPct, s.formatPrice(downPayment, property.Currency), m.InterestRate, m.TermYears)
+	}
+	
+	boxH := 28.0
+	pdf.SetFillColor(255, 255, 255)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.6)
+	pdf.Rect(margins.Left, startY, contentWidth, boxH, "FD")
+	
+	// Gold accent bar on the left
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Rect(margins.Left, startY, 3, boxH, "F")
+	
+	if useArabic && s.hasArabicFont {
+		pdf.SetFont(s.arabicFontName, "", 14)
+	} else {
+		pdf.SetFont(s.theme.TitleFontName, "B", 14)
+	}
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	pdf.SetXY(margins.Left+5, startY+4)
+	pdf.CellFormat(contentWidth-10, 8, label, "", 0, align, false, 0, "")
+	
+	if useArabic && s.hasArabicFont {
+		pdf.SetFont(s.arabicFontName, "", 9)
+	} else {
+		pdf.SetFont(s.theme.BodyFontName, "", 9)
+	}
+	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+	pdf.SetXY(margins.Left+8, startY+14)
+	pdf.MultiCell(contentWidth-16, 4.5, assumptions, "", align, false)
+	
+	return startY + boxH + 15
 }
 
 // addThankYouMessage adds a thank you message section below the agent card
 func (s *PDFService) addThankYouMessage(pdf *gofpdf.Fpdf, property *models.Property, startY float64, useArabic bool) {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
 	var thankYouMsg string
 	var align string
 	
@@ -1298,7 +2821,7 @@ func (s *PDFService) addThankYouMessage(pdf *gofpdf.Fpdf, property *models.Prope
 	} else {
 		// Fallback
 		if useArabic {
-			thankYouMsg = "نشكركم على اهتمامكم بهذا العقار الاستثنائي. نحن نقدر اهتمامكم ويسعدنا تزويدكم بمعلومات إضافية أو ترتيب موعد للمعاينة في الوقت المناسب لكم."
+			thankYouMsg = s.rtl("نشكركم على اهتمامكم بهذا العقار الاستثنائي. نحن نقدر اهتمامكم ويسعدنا تزويدكم بمعلومات إضافية أو ترتيب موعد للمعاينة في الوقت المناسب لكم.")
 			align = "R"
 		} else {
 			thankYouMsg = "Thank you for considering this exceptional property. We appreciate your interest and would be delighted to provide you with additional information or arrange a viewing at your convenience."
@@ -1308,9 +2831,9 @@ func (s *PDFService) addThankYouMessage(pdf *gofpdf.Fpdf, property *models.Prope
 	
 	// Add simple decorative line (thin gold line only)
 	pdf.SetY(startY)
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.5)
-	pdf.Line(marginX+contentWidth/2-30, startY, marginX+contentWidth/2+30, startY)
+	pdf.Line(margins.Left+contentWidth/2-30, startY, margins.Left+contentWidth/2+30, startY)
 	
 	startY += 10
 	
@@ -1320,10 +2843,10 @@ func (s *PDFService) addThankYouMessage(pdf *gofpdf.Fpdf, property *models.Prope
 	} else if s.hasBodyFont {
 		pdf.SetFont(s.bodyFontName, "", 11)
 	} else {
-		pdf.SetFont("Arial", "", 11)
+		pdf.SetFont(s.theme.BodyFontName, "", 11)
 	}
 	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX, startY)
+	pdf.SetXY(margins.Left, startY)
 	
 	thankYouMsg = s.fixMojibakeLatin1ToUTF8(thankYouMsg)
 	pdf.MultiCell(contentWidth, 6, thankYouMsg, "", align, false)
@@ -1331,31 +2854,76 @@ func (s *PDFService) addThankYouMessage(pdf *gofpdf.Fpdf, property *models.Prope
 }
 
 
-func (s *PDFService) addImageFromURL(pdf *gofpdf.Fpdf, url string, x, y, w, h float64) error {
-	// Download image
+// downloadImage fetches image bytes and the reported content type
+func (s *PDFService) downloadImage(url string) ([]byte, string, error) {
+	// Print-ready brochures read the uploaded originals directly rather than through pre-signed URLs
+	if s.printReady && s.objects != nil {
+		if data, contentType, ok := s.readStoredImage(url); ok {
+			return data, contentType, nil
+		}
+	}
+
 	resp, err := http.Get(url)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download image: status %d", resp.StatusCode)
+		return nil, "", fmt.Errorf("failed to download image: status %d", resp.StatusCode)
 	}
 
-    // Read the body into memory so we can decode dimensions and also register with gofpdf
-    var imgBuf bytes.Buffer
-    if _, err := io.Copy(&imgBuf, resp.Body); err != nil {
-        return err
-    }
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+func (s *PDFService) addImageFromURL(pdf *gofpdf.Fpdf, url string, x, y, w, h float64) error {
+	_, _, _, _, err := s.placeImageFromURL(pdf, url, x, y, w, h)
+	return err
+}
+
+// placeImageFromURL aspect-fits the image inside the box and returns the rectangle it was drawn in
+func (s *PDFService) placeImageFromURL(pdf *gofpdf.Fpdf, url string, x, y, w, h float64) (float64, float64, float64, float64, error) {
+	// Download image into memory so we can decode dimensions and also register with gofpdf
+	data, contentType, err := s.downloadImage(url)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	imgBuf := bytes.NewBuffer(data)
 
 	// Determine image type from content type
 	imageType := "jpg"
-	contentType := resp.Header.Get("Content-Type")
 	if strings.Contains(contentType, "png") {
 		imageType = "png"
 	} else if strings.Contains(contentType, "jpeg") || strings.Contains(contentType, "jpg") {
 		imageType = "jpg"
+	} else if strings.Contains(contentType, "webp") || isWebP(data) {
+		imageType = "webp"
+	} else if strings.Contains(contentType, "gif") || isGIF(data) {
+		imageType = "gif"
+	}
+
+	// gofpdf cannot embed WebP: re-encode it as JPEG first
+	if imageType == "webp" {
+		converted, err := webpToJPEG(data)
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		imgBuf = bytes.NewBuffer(converted)
+		imageType = "jpg"
+	}
+	
+	// GIFs (often animated banners) are flattened to their first frame as JPEG
+	if imageType == "gif" {
+		converted, err := gifToJPEG(data, url)
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		imgBuf = bytes.NewBuffer(converted)
+		imageType = "jpg"
 	}
 
     // Decode to get intrinsic dimensions
@@ -1375,6 +2943,7 @@ func (s *PDFService) addImageFromURL(pdf *gofpdf.Fpdf, url string, x, y, w, h fl
             }
             drawW := imgW * scale
             drawH := imgH * scale
+            s.checkPrintResolution(url, decoded.Bounds().Dx(), drawW)
             // center within the box
             x = x + (w-drawW)/2
             y = y + (h-drawH)/2
@@ -1399,6 +2968,159 @@ func (s *PDFService) addImageFromURL(pdf *gofpdf.Fpdf, url string, x, y, w, h fl
 		AllowNegativePosition: false,
 	}
     pdf.RegisterImageOptionsReader(uniqueName, opts, imgReader)
+	if err := s.takeImageError(pdf); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	pdf.ImageOptions(uniqueName, x, y, w, h, false, opts, 0, "")
+
+	return x, y, w, h, nil
+}
+
+// isWebP reports whether the bytes start with a RIFF/WEBP header
+func isWebP(data []byte) bool {
+	return len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+}
+
+// webpToJPEG decodes a WebP image and re-encodes it as JPEG for gofpdf
+func webpToJPEG(data []byte) ([]byte, error) {
+	decoded, err := webp.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode WebP image: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, decoded, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to convert WebP image to JPEG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// isGIF reports whether the bytes start with a GIF87a/GIF89a header
+func isGIF(data []byte) bool {
+	return len(data) >= 6 && string(data[0:4]) == "GIF8"
+}
+
+// gifToJPEG re-encodes the first frame of a GIF as JPEG, painting transparent pixels white
+func gifToJPEG(data []byte, source string) ([]byte, error) {
+	decoded, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode GIF image: %w", err)
+	}
+	if len(decoded.Image) > 1 {
+		log.Printf("GIF %s has %d frames, only the first frame is used", source, len(decoded.Image))
+	}
+	
+	frame := decoded.Image[0]
+	bounds := image.Rect(0, 0, decoded.Config.Width, decoded.Config.Height)
+	if bounds.Empty() {
+		bounds = frame.Bounds()
+	}
+	flat := image.NewRGBA(bounds)
+	draw.Draw(flat, bounds, image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+	
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to convert GIF image to JPEG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// takeImageError returns and clears a failed image registration, so one unreadable image
+// does not put the whole document into gofpdf's error state
+func (s *PDFService) takeImageError(pdf *gofpdf.Fpdf) error {
+	if !pdf.Ok() {
+		err := pdf.Error()
+		pdf.ClearError()
+		return fmt.Errorf("failed to register image: %w", err)
+	}
+	return nil
+}
+
+// addImagePlaceholder draws a gray box with "Image Unavailable" where an image could not be loaded
+func (s *PDFService) addImagePlaceholder(pdf *gofpdf.Fpdf, x, y, w, h float64) {
+	pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
+	pdf.Rect(x, y, w, h, "F")
+	pdf.SetFont(s.theme.BodyFontName, "I", 10)
+	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+	pdf.SetXY(x, y+h/2-3)
+	pdf.CellFormat(w, 6, "Image Unavailable", "", 0, "C", false, 0, "")
+}
+
+// CheckCoverImage verifies that the cover image can be downloaded and decoded before generation, since
+// a brochure without its cover is not worth rendering. Other images are only downloaded while rendering,
+// where failures are replaced with placeholders and recorded in the listing's ImageWarnings.
+func (s *PDFService) CheckCoverImage(property *models.Property) error {
+	if len(property.ImageURLs) == 0 {
+		return nil
+	}
+	if err := s.checkImage(property.ImageURLs[0]); err != nil {
+		return fmt.Errorf("cover image unavailable: %w", err)
+	}
+	return nil
+}
+
+// warnImageUnavailable records a placeholder image once, although every brochure language renders it
+func warnImageUnavailable(property *models.Property, warning string) {
+	for _, recorded := range property.ImageWarnings {
+		if recorded == warning {
+			return
+		}
+	}
+	property.ImageWarnings = append(property.ImageWarnings, warning)
+}
+
+// checkImage downloads an image and confirms it decodes
+func (s *PDFService) checkImage(url string) error {
+	data, _, err := s.downloadImage(url)
+	if err != nil {
+		return err
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("unsupported image data: %w", err)
+	}
+	return nil
+}
+
+// addCroppedImageFromURL center-crops the image to the w:h ratio of the box and fills it completely
+func (s *PDFService) addCroppedImageFromURL(pdf *gofpdf.Fpdf, url string, x, y, w, h float64) error {
+	data, _, err := s.downloadImage(url)
+	if err != nil {
+		return err
+	}
+	if s.printReady {
+		return s.addClippedImage(pdf, data, url, x, y, w, h)
+	}
+	if isGIF(data) {
+		if data, err = gifToJPEG(data, url); err != nil {
+			return err
+		}
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		// Unknown format: fall back to aspect-fit placement
+		return s.addImageFromURL(pdf, url, x, y, w, h)
+	}
+
+	// Box dimensions in tenths of a millimetre keep the ratio precise as integers
+	cropped := cropToAspect(decoded, int(w*10), int(h*10))
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, cropped, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return fmt.Errorf("failed to encode cropped image: %w", err)
+	}
+
+	urlSuffix := url
+	if len(url) > 20 {
+		urlSuffix = url[len(url)-20:]
+	}
+	uniqueName := fmt.Sprintf("crop_%s_%.0f_%.0f", urlSuffix, x, y)
+
+	opts := gofpdf.ImageOptions{ImageType: "jpg"}
+	pdf.RegisterImageOptionsReader(uniqueName, opts, &buf)
+	if err := s.takeImageError(pdf); err != nil {
+		return err
+	}
 	pdf.ImageOptions(uniqueName, x, y, w, h, false, opts, 0, "")
 
 	return nil
@@ -1411,6 +3133,7 @@ func (s *PDFService) addContactPage(pdf *gofpdf.Fpdf, property *models.Property)
 
 // addContactPageWithLanguage creates a standalone contact page with language support
 func (s *PDFService) addContactPageWithLanguage(pdf *gofpdf.Fpdf, property *models.Property, useArabic bool) {
+	margins := pageMargins(pdf)
 	pdf.AddPage()
 	
 	// Add cream background
@@ -1418,7 +3141,7 @@ func (s *PDFService) addContactPageWithLanguage(pdf *gofpdf.Fpdf, property *mode
 	
 	s.addBrandingIfAvailable(pdf)
 	
-	currentY := marginY + 10.0
+	currentY := margins.Top + 10.0
 	
 	// Agent Contact Card at the top
 	currentY = s.addAgentContactCardTop(pdf, property, currentY, useArabic)
@@ -1426,22 +3149,41 @@ func (s *PDFService) addContactPageWithLanguage(pdf *gofpdf.Fpdf, property *mode
 	// Add spacing
 	currentY += 15
 	
-	// Add thank you message below agent card
-	s.addThankYouMessage(pdf, property, currentY, useArabic)
+	// Mortgage estimate box
+	currentY = s.addMortgageEstimate(pdf, property, currentY, useArabic)
+	
+	// Add thank you message below agent card, or the agency's own closing for white-label brochures
+	if property.WhiteLabelClosing != nil {
+		s.addWhiteLabelClosing(pdf, property, currentY, useArabic)
+	} else {
+		s.addThankYouMessage(pdf, property, currentY, useArabic)
+	}
+	
+	// Property of the week cross-sell above the bottom decoration
+	s.addFeaturedListingInset(pdf, property, useArabic)
 	
 	// Add decorative bottom diamond element
 	s.addBottomDiamondDecoration(pdf)
 	
-	// Add page number (now page 4 with restructuring)
-	s.addPageNumber(pdf, 4)
+	// Tear-off strip replaces the page number in print mode
+	if property.PrintMode {
+		s.addTearOffStrip(pdf, property)
+		return
+	}
+	
+	// Add page number (last page, after any optional pages)
+	s.addPageNumber(pdf, pdf.PageNo())
 }
 
 // addCoverPageArabic creates an Arabic-focused cover page
 func (s *PDFService) addCoverPageArabic(pdf *gofpdf.Fpdf, property *models.Property) {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
 	pdf.AddPage()
 	
 	// Add cream background
 	s.addPageBackground(pdf)
+	s.addCoverBanner(pdf)
 	
 	s.addBrandingIfAvailable(pdf)
 	
@@ -1453,52 +3195,54 @@ func (s *PDFService) addCoverPageArabic(pdf *gofpdf.Fpdf, property *models.Prope
 	if s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 16)
 	} else {
-		pdf.SetFont("Arial", "B", 16)
+		pdf.SetFont(s.theme.TitleFontName, "B", 16)
 	}
-	pdf.SetTextColor(darkBlueR, darkBlueG, darkBlueB)
-	brochureLabel := "كتيب العقار"
+	pdf.SetTextColor(s.coverHeadingColor())
+	brochureLabel := s.rtl("كتيب العقار")
 	brochureLabel = s.fixMojibakeLatin1ToUTF8(brochureLabel)
 	pdf.CellFormat(contentWidth, 8, brochureLabel, "", 1, "C", false, 0, "")
 	
 	// Add gold accent bar below heading
-	pdf.SetFillColor(goldR, goldG, goldB)
-	pdf.Rect(marginX+40, 19, contentWidth-80, 2, "F")
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Rect(margins.Left+40, 19, contentWidth-80, 2, "F")
 	
 	// Add main property image (large, full-width)
-	imageHeight := 155.0
+	imageHeight := s.coverImageHeight(contentWidth)
 	imageStartY := 26.0
 	if len(property.ImageURLs) > 0 {
 		// Add decorative border around image
-		pdf.SetDrawColor(goldR, goldG, goldB)
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
 		pdf.SetLineWidth(1.5)
-		pdf.Rect(marginX-1, imageStartY-1, contentWidth+2, imageHeight+2, "D")
+		pdf.Rect(margins.Left-1, imageStartY-1, contentWidth+2, imageHeight+2, "D")
 		
-		err := s.addImageFromURL(pdf, property.ImageURLs[0], marginX, imageStartY, contentWidth, imageHeight)
+		err := s.addCroppedImageFromURL(pdf, property.ImageURLs[0], margins.Left, imageStartY, contentWidth, imageHeight)
 		if err != nil {
 			pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
-			pdf.Rect(marginX, imageStartY, contentWidth, imageHeight, "F")
-			pdf.SetFont("Arial", "I", 12)
+			pdf.Rect(margins.Left, imageStartY, contentWidth, imageHeight, "F")
+			pdf.SetFont(s.theme.BodyFontName, "I", 12)
 			pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
-			pdf.SetXY(marginX, imageStartY+imageHeight/2)
+			pdf.SetXY(margins.Left, imageStartY+imageHeight/2)
 			pdf.CellFormat(contentWidth, 10, "Image Not Available", "", 0, "C", false, 0, "")
+		} else {
+			s.addVideoPlayButton(pdf, property, margins.Left, imageStartY, contentWidth, imageHeight, true)
 		}
 	} else {
 		pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
-		pdf.Rect(marginX, imageStartY, contentWidth, imageHeight, "F")
-		pdf.SetFont("Arial", "I", 12)
+		pdf.Rect(margins.Left, imageStartY, contentWidth, imageHeight, "F")
+		pdf.SetFont(s.theme.BodyFontName, "I", 12)
 		pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
-		pdf.SetXY(marginX, imageStartY+imageHeight/2)
+		pdf.SetXY(margins.Left, imageStartY+imageHeight/2)
 		pdf.CellFormat(contentWidth, 10, "No Image Available", "", 0, "C", false, 0, "")
 	}
 	
 	// Property Title (Use Arabic localized title if available)
-	pdf.SetY(186)
+	pdf.SetY(imageStartY + imageHeight + 5)
 	if s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 24)
 	} else {
-		pdf.SetFont("Arial", "B", 26)
+		pdf.SetFont(s.theme.TitleFontName, "B", 26)
 	}
-	pdf.SetTextColor(darkBlueR, darkBlueG, darkBlueB)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
 	
 	// Use localized Arabic title if available, otherwise fallback to English title
 	title := property.Title
@@ -1516,57 +3260,44 @@ func (s *PDFService) addCoverPageArabic(pdf *gofpdf.Fpdf, property *models.Prope
 	// Add a subtle price background box for emphasis
 	priceBoxY := pdf.GetY()
 	pdf.SetFillColor(255, 255, 255)
-	pdf.Rect(marginX+35, priceBoxY-2, contentWidth-70, 18, "F")
-	pdf.SetDrawColor(goldR, goldG, goldB)
+	pdf.Rect(margins.Left+35, priceBoxY-2, contentWidth-70, 18, "F")
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
 	pdf.SetLineWidth(0.8)
-	pdf.Rect(marginX+35, priceBoxY-2, contentWidth-70, 18, "D")
+	pdf.Rect(margins.Left+35, priceBoxY-2, contentWidth-70, 18, "D")
 	
 	// Price (prominent, gold color)
 	pdf.SetY(priceBoxY)
-	pdf.SetFont("Arial", "B", 28)
-	pdf.SetTextColor(goldR, goldG, goldB)
-	priceText := s.formatPrice(property.Price, property.Currency)
+	pdf.SetFont(s.theme.TitleFontName, "B", 28)
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	priceText := coreText(pdf, s.formatPrice(property.Price, property.Currency))
 	pdf.CellFormat(contentWidth, 14, priceText, "", 1, "C", false, 0, "")
 	pdf.Ln(5)
 	
 	// Location (gray, medium size)
-	pdf.SetFont("Arial", "", 13)
+	pdf.SetFont(s.theme.BodyFontName, "", 13)
 	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
 	locationText := s.formatLocation(property)
 	pdf.MultiCell(contentWidth, 6, locationText, "", "C", false)
 	
-	// Decorative bottom section with elegant design
-	pdf.SetY(268)
-	
-	// Add decorative diamond shape in center
-	centerX := pageWidth / 2
-	diamondY := 272.0
-	pdf.SetFillColor(goldR, goldG, goldB)
-	// Create diamond with lines
-	pdf.SetDrawColor(goldR, goldG, goldB)
-	pdf.SetLineWidth(0.8)
-	pdf.Line(centerX-4, diamondY, centerX, diamondY-3)
-	pdf.Line(centerX, diamondY-3, centerX+4, diamondY)
-	pdf.Line(centerX+4, diamondY, centerX, diamondY+3)
-	pdf.Line(centerX, diamondY+3, centerX-4, diamondY)
-	
-	// Lines extending from diamond
-	pdf.SetLineWidth(0.5)
-	pdf.Line(marginX+50, diamondY, centerX-6, diamondY)
-	pdf.Line(centerX+6, diamondY, pageWidth-marginX-50, diamondY)
+	// Decorative bottom section with elegant design, unless the location map takes its place
+	if !s.addStaticMapStrip(pdf, property) {
+		s.addBottomDiamondDecoration(pdf)
+	}
 	
-	s.addPageNumber(pdf, 1)
+	s.addPageNumber(pdf, pdf.PageNo())
 }
 
 // addDetailsPageArabicCombined creates the Arabic property description, highlights, amenities, investment opportunity, and gallery
 func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *models.Property) {
+	margins := pageMargins(pdf)
+	pageWidth, pageHeight, contentWidth := pageSize(pdf)
 	pdf.AddPage()
 	
 	// Add cream background
 	s.addPageBackground(pdf)
 	
 	s.addBrandingIfAvailable(pdf)
-	currentY := marginY + 10.0
+	currentY := margins.Top + 10.0
 	
 	// Use localized content if available, fallback to legacy
 	var descLabel, highlightsLabel, amenitiesLabel string
@@ -1584,16 +3315,16 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 		amenities = property.ArabicContent.Amenities
 	} else {
 		// Fallback to legacy fields
-		descLabel = "وصف العقار"
-		highlightsLabel = "المميزات الرئيسية"
-		amenitiesLabel = "المرافق والميزات"
+		descLabel = s.rtl("وصف العقار")
+		highlightsLabel = s.rtl("المميزات الرئيسية")
+		amenitiesLabel = s.rtl("المرافق والميزات")
 		description = property.AIContent.ArabicDescription
 		highlights = []string{} // Legacy didn't have Arabic highlights
 		amenities = property.Amenities
 	}
 	
 	if description == "" {
-		description = "لا يوجد وصف متاح"
+		description = s.rtl("لا يوجد وصف متاح")
 	}
 	
 	// Section: Arabic Description
@@ -1607,10 +3338,10 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 	if s.hasArabicFont {
 		pdf.SetFont(s.arabicFontName, "", 12)
 	} else {
-		pdf.SetFont("Arial", "", 11)
+		pdf.SetFont(s.theme.BodyFontName, "", 11)
 	}
 	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-	pdf.SetXY(marginX, currentY)
+	pdf.SetXY(margins.Left, currentY)
 	
 	// Right-aligned for Arabic text
 	description = s.fixMojibakeLatin1ToUTF8(description)
@@ -1619,11 +3350,11 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 	
 	// Section: Key Highlights (Arabic)
 	if len(highlights) > 0 {
-		if currentY > 220 {
+		if currentY > pageHeight-77 {
 			pdf.AddPage()
 			s.addPageBackground(pdf)
 			s.addBrandingIfAvailable(pdf)
-			currentY = marginY + 10
+			currentY = margins.Top + 10
 		}
 		
 		if s.hasArabicFont {
@@ -1635,7 +3366,7 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 		if s.hasArabicFont {
 			pdf.SetFont(s.arabicFontName, "", 11)
 		} else {
-			pdf.SetFont("Arial", "", 11)
+			pdf.SetFont(s.theme.BodyFontName, "", 11)
 		}
 		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
 		
@@ -1644,9 +3375,9 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 			highlight = s.fixMojibakeLatin1ToUTF8(highlight)
 			
 			// Draw a gold bullet (filled circle)
-			bulletX := pageWidth - marginX - 5 // Right side for RTL
+			bulletX := pageWidth - margins.Right - 5 // Right side for RTL
 			bulletY := currentY + 3.5
-			pdf.SetFillColor(goldR, goldG, goldB)
+			pdf.SetFillColor(s.theme.AccentColor.RGB())
 			pdf.Circle(bulletX, bulletY, 1.6, "F")
 			
 			// Highlight text (right-aligned)
@@ -1654,9 +3385,9 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 			if s.hasArabicFont {
 				pdf.SetFont(s.arabicFontName, "", 11)
 			} else {
-				pdf.SetFont("Arial", "", 11)
+				pdf.SetFont(s.theme.BodyFontName, "", 11)
 			}
-			pdf.SetXY(marginX, currentY)
+			pdf.SetXY(margins.Left, currentY)
 			pdf.MultiCell(contentWidth-12, 6, highlight, "", "R", false)
 			currentY = pdf.GetY() + 1
 		}
@@ -1665,11 +3396,11 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 	
 	// Section: Amenities (if available)
 	if len(amenities) > 0 {
-		if currentY > 220 {
+		if currentY > pageHeight-77 {
 			pdf.AddPage()
 			s.addPageBackground(pdf)
 			s.addBrandingIfAvailable(pdf)
-			currentY = marginY + 10
+			currentY = margins.Top + 10
 		}
 		
 		if s.hasArabicFont {
@@ -1681,27 +3412,22 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 		if s.hasArabicFont {
 			pdf.SetFont(s.arabicFontName, "", 10)
 		} else {
-			pdf.SetFont("Arial", "", 10)
+			pdf.SetFont(s.theme.BodyFontName, "", 10)
 		}
 		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
 		
-		// Display amenities in a 2-column grid with checkmarks
+		// Display amenities in a 2-column grid with icons
 		colWidth := (contentWidth - 10) / 2
 		amenityHeight := 7.0
 		
 		for i, amenity := range amenities {
 			col := i % 2
-			xPos := marginX + float64(col)*(colWidth+10)
+			xPos := margins.Left + float64(col)*(colWidth+10)
 			
 			pdf.SetXY(xPos, currentY)
 			
-			// Draw a green check mark using vector lines
-			pdf.SetDrawColor(46, 125, 50)
-			pdf.SetLineWidth(0.8)
-			startX := xPos
-			startY := currentY + amenityHeight/2
-			pdf.Line(startX, startY, startX+2.0, startY+2.0)
-			pdf.Line(startX+2.0, startY+2.0, startX+6.0, startY-1.0)
+			// Draw the amenity's icon using vector shapes
+			s.drawAmenityIcon(pdf, xPos, currentY+amenityHeight/2, amenityIconFor(property.ArabicContent.AmenityIcon, amenity))
 			
 			// Amenity text (apply mojibake fix for Arabic)
 			amenity = s.fixMojibakeLatin1ToUTF8(amenity)
@@ -1709,7 +3435,7 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 			if s.hasArabicFont {
 				pdf.SetFont(s.arabicFontName, "", 10)
 			} else {
-				pdf.SetFont("Arial", "", 10)
+				pdf.SetFont(s.theme.BodyFontName, "", 10)
 			}
 			pdf.SetX(xPos + 9)
 			pdf.CellFormat(colWidth-7, amenityHeight, amenity, "", 0, "", false, 0, "")
@@ -1734,16 +3460,16 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 		additionalTitle = property.ArabicContent.AdditionalSectionTitle
 		additionalContent = property.ArabicContent.AdditionalSectionContent
 	} else {
-		additionalTitle = "فرصة استثمارية"
-		additionalContent = "يمثل هذا العقار فرصة استثمارية ممتازة في موقع متميز."
+		additionalTitle = s.rtl("فرصة استثمارية")
+		additionalContent = s.rtl("يمثل هذا العقار فرصة استثمارية ممتازة في موقع متميز.")
 	}
 	
 	// Check if we need a new page for investment content
-	if currentY > 200 {
+	if currentY > pageHeight-97 {
 		pdf.AddPage()
 		s.addPageBackground(pdf)
 		s.addBrandingIfAvailable(pdf)
-		currentY = marginY + 10
+		currentY = margins.Top + 10
 	}
 	
 	if additionalContent != "" {
@@ -1756,10 +3482,10 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 		if s.hasArabicFont {
 			pdf.SetFont(s.arabicFontName, "", 11)
 		} else {
-			pdf.SetFont("Arial", "", 10.5)
+			pdf.SetFont(s.theme.BodyFontName, "", 10.5)
 		}
 		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
-		pdf.SetXY(marginX, currentY)
+		pdf.SetXY(margins.Left, currentY)
 		additionalContent = s.fixMojibakeLatin1ToUTF8(additionalContent)
 		pdf.MultiCell(contentWidth, 5.5, additionalContent, "", "R", false)
 		currentY = pdf.GetY() + 8
@@ -1768,14 +3494,14 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 	// Add Property Gallery (if images available) on the same page
 	if len(property.ImageURLs) > 1 {
 		// Check if we need a new page for gallery
-		if currentY > 200 {
+		if currentY > pageHeight-97 {
 			pdf.AddPage()
 			s.addPageBackground(pdf)
 			s.addBrandingIfAvailable(pdf)
-			currentY = marginY + 10
+			currentY = margins.Top + 10
 		}
 		
-		galleryLabel := "معرض العقار"
+		galleryLabel := s.rtl("معرض العقار")
 		if property.ArabicContent.PropertyGalleryLabel != "" {
 			galleryLabel = property.ArabicContent.PropertyGalleryLabel
 		}
@@ -1789,9 +3515,13 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 		currentY += 3
 		
 		// Display up to 4 additional images in a compact 2x2 grid
-		imgWidth := (contentWidth - 8) / 2
-		imgHeight := imgWidth * 0.65
 		spacing := 8.0
+		imgWidth := (contentWidth - spacing) / 2
+		imgHeight := imgWidth * 0.65
+		// Landscape pages are shorter: flatten the rows so both still fit
+		if maxHeight := (pageHeight - 35 - currentY - spacing) / 2; imgHeight > maxHeight {
+			imgHeight = maxHeight
+		}
 		
 		imageCount := 0
 		maxImages := 4
@@ -1800,7 +3530,7 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 			row := imageCount / 2
 			col := imageCount % 2
 			
-			xPos := marginX + float64(col)*(imgWidth+spacing)
+			xPos := margins.Left + float64(col)*(imgWidth+spacing)
 			yPos := currentY + float64(row)*(imgHeight+spacing)
 			
 			// Check if we're running out of space
@@ -1817,15 +3547,16 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 			pdf.Rect(xPos, yPos, imgWidth, imgHeight, "F")
 			
 			// Add gold border/frame effect
-			pdf.SetDrawColor(goldR, goldG, goldB)
+			pdf.SetDrawColor(s.theme.AccentColor.RGB())
 			pdf.SetLineWidth(0.6)
 			pdf.Rect(xPos, yPos, imgWidth, imgHeight, "D")
 			
 			err := s.addImageFromURL(pdf, property.ImageURLs[i], xPos+2, yPos+2, imgWidth-4, imgHeight-4)
 			if err != nil {
-				// Placeholder for failed images
-				pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
-				pdf.Rect(xPos+2, yPos+2, imgWidth-4, imgHeight-4, "F")
+				// Gallery failures are non-fatal: show a placeholder and keep going
+				log.Printf("Gallery image %d unavailable (%s): %v", i, property.ImageURLs[i], err)
+				s.addImagePlaceholder(pdf, xPos+2, yPos+2, imgWidth-4, imgHeight-4)
+				warnImageUnavailable(property, fmt.Sprintf("Gallery image %d is unavailable and was replaced with a placeholder", i))
 			}
 			
 			imageCount++
@@ -1835,6 +3566,99 @@ func (s *PDFService) addDetailsPageArabicCombined(pdf *gofpdf.Fpdf, property *mo
 	// Add decorative bottom diamond element
 	s.addBottomDiamondDecoration(pdf)
 	
-	s.addPageNumber(pdf, 2)
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+
+// socialLink is a round icon on the white-label closing, labelled with the network's initials
+type socialLink struct {
+	initials string
+	url      string
 }
 
+// addWhiteLabelClosing draws the agency's closing below the agent card: logo, headline, message and round
+// social media icons linking to their profiles. The message falls back to the localized thank-you copy,
+// never to the generic default.
+func (s *PDFService) addWhiteLabelClosing(pdf *gofpdf.Fpdf, property *models.Property, startY float64, useArabic bool) {
+	margins := pageMargins(pdf)
+	pageWidth, _, contentWidth := pageSize(pdf)
+	closing := property.WhiteLabelClosing
+	centerX := pageWidth / 2
+	y := startY
+	
+	if closing.LogoURL != "" {
+		const logoW, logoH = 50.0, 20.0
+		if err := s.addImageFromURL(pdf, closing.LogoURL, centerX-logoW/2, y, logoW, logoH); err != nil {
+			log.Printf("Warning: failed to load white-label logo %s: %v", closing.LogoURL, err)
+		} else {
+			y += logoH + 5
+		}
+	}
+	
+	textFont := func(style string, size float64) {
+		switch {
+		case useArabic && s.hasArabicFont:
+			pdf.SetFont(s.arabicFontName, "", size)
+		case s.hasBodyFont:
+			pdf.SetFont(s.bodyFontName, "", size)
+		default:
+			pdf.SetFont(s.theme.BodyFontName, style, size)
+		}
+	}
+	
+	if closing.Headline != "" {
+		textFont("B", 16)
+		pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+		pdf.SetXY(margins.Left, y)
+		pdf.CellFormat(contentWidth, 9, s.fixMojibakeLatin1ToUTF8(closing.Headline), "", 0, "C", false, 0, "")
+		y += 11
+	}
+	
+	message := closing.Message
+	if message == "" {
+		message = property.EnglishContent.ThankYouMessage
+		if useArabic {
+			message = property.ArabicContent.ThankYouMessage
+		}
+	}
+	if message != "" {
+		textFont("", 11)
+		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+		pdf.SetXY(margins.Left, y)
+		pdf.MultiCell(contentWidth, 6, s.fixMojibakeLatin1ToUTF8(message), "", "C", false)
+		y = pdf.GetY() + 4
+	}
+	
+	var links []socialLink
+	for _, link := range []socialLink{
+		{"IG", closing.InstagramURL},
+		{"FB", closing.FacebookURL},
+		{"in", closing.LinkedInURL},
+		{"WWW", closing.Website},
+	} {
+		if link.url != "" {
+			links = append(links, link)
+		}
+	}
+	if len(links) > 0 {
+		const radius, gap = 5.0, 6.0
+		rowW := float64(len(links))*2*radius + float64(len(links)-1)*gap
+		x := centerX - rowW/2
+		cy := y + radius
+		for _, link := range links {
+			cx := x + radius
+			pdf.SetFillColor(s.theme.PrimaryColor.RGB())
+			pdf.Circle(cx, cy, radius, "F")
+			pdf.SetFont(s.theme.TitleFontName, "B", 8)
+			pdf.SetTextColor(255, 255, 255)
+			pdf.SetXY(cx-radius, cy-2)
+			pdf.CellFormat(2*radius, 4, link.initials, "", 0, "C", false, 0, "")
+			pdf.LinkString(cx-radius, cy-radius, 2*radius, 2*radius, link.url)
+			x += 2*radius + gap
+		}
+		y += 2*radius + 4
+	}
+	
+	// Leave the cursor below the closing so later insets can tell how much room is left
+	pdf.SetY(y)
+}
@@ -0,0 +1,389 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"property-brochure-backend/models"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Two-column bilingual layout: English on the left, Arabic (RTL) on the right
+const (
+	bilingualGutter = 5.0
+
+	// Content in each column stops above the shared contact band
+	bilingualContactY      = 238.0
+	bilingualMaxDescChars  = 900
+	bilingualMaxHighlights = 5
+	bilingualMaxAmenities  = 8
+)
+
+// bilingualColumn holds the localized content rendered into one column
+type bilingualColumn struct {
+	descLabel       string
+	highlightsLabel string
+	amenitiesLabel  string
+	description     string
+	highlights      []string
+	amenities       []string
+}
+
+// GenerateBilingualBrochure creates a 2-page brochure with English and Arabic side by side
+func (s *PDFService) GenerateBilingualBrochure(property *models.Property) ([]byte, error) {
+	s = s.themed(property)
+	pdf := s.newDocument("P", property.PageSize, property.MarginMm)
+	s.setupFonts(pdf, property)
+
+	// Page 1: Cover with both titles stacked
+	s.addBilingualCoverPage(pdf, property)
+
+	// Page 2: English and Arabic columns with shared contact band
+	s.addBilingualDetailsPage(pdf, property)
+
+	s.addPropertyReferenceCode(pdf, property, 1)
+
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate bilingual PDF: %w", err)
+	}
+
+	return s.finishPDF(buf.Bytes(), "bilingual"), nil
+}
+
+// addBilingualCoverPage shows the main image with the English and Arabic titles stacked underneath
+func (s *PDFService) addBilingualCoverPage(pdf *gofpdf.Fpdf, property *models.Property) {
+	margins := pageMargins(pdf)
+	pageWidth, _, contentWidth := pageSize(pdf)
+	pdf.AddPage()
+
+	s.addPageBackground(pdf)
+	s.addCoverBanner(pdf)
+	s.addBrandingIfAvailable(pdf)
+	s.addDecorativeCorners(pdf)
+
+	pdf.SetY(10)
+	pdf.SetFont(s.theme.TitleFontName, "B", 16)
+	pdf.SetTextColor(s.coverHeadingColor())
+	pdf.CellFormat(contentWidth, 8, "Property Brochure", "", 1, "C", false, 0, "")
+
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Rect(margins.Left+40, 19, contentWidth-80, 2, "F")
+
+	imageHeight := s.coverImageHeight(contentWidth)
+	imageStartY := 26.0
+	placed := false
+	if len(property.ImageURLs) > 0 {
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
+		pdf.SetLineWidth(1.5)
+		pdf.Rect(margins.Left-1, imageStartY-1, contentWidth+2, imageHeight+2, "D")
+		placed = s.addCroppedImageFromURL(pdf, property.ImageURLs[0], margins.Left, imageStartY, contentWidth, imageHeight) == nil
+	}
+	if placed {
+		s.addVideoPlayButton(pdf, property, margins.Left, imageStartY, contentWidth, imageHeight, false)
+	} else {
+		pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
+		pdf.Rect(margins.Left, imageStartY, contentWidth, imageHeight, "F")
+		pdf.SetFont(s.theme.BodyFontName, "I", 12)
+		pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+		pdf.SetXY(margins.Left, imageStartY+imageHeight/2)
+		pdf.CellFormat(contentWidth, 10, "No Image Available", "", 0, "C", false, 0, "")
+	}
+
+	// English title
+	pdf.SetY(imageStartY + imageHeight + 5)
+	pdf.SetFont(s.theme.TitleFontName, "B", 22)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	englishTitle := property.Title
+	if property.EnglishContent.Title != "" {
+		englishTitle = property.EnglishContent.Title
+	}
+	for _, line := range pdf.SplitLines([]byte(englishTitle), contentWidth) {
+		pdf.CellFormat(contentWidth, 10, string(line), "", 1, "C", false, 0, "")
+	}
+
+	// Arabic title below, separated by a short gold rule
+	if s.hasArabicFont && property.ArabicContent.Title != "" {
+		ruleY := pdf.GetY() + 2
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
+		pdf.SetLineWidth(0.5)
+		pdf.Line(pageWidth/2-20, ruleY, pageWidth/2+20, ruleY)
+
+		pdf.SetXY(margins.Left, ruleY+3)
+		pdf.SetFont(s.arabicFontName, "", 20)
+		pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+		pdf.MultiCell(contentWidth, 10, s.fixMojibakeLatin1ToUTF8(property.ArabicContent.Title), "", "C", false)
+	}
+	pdf.Ln(3)
+
+	priceBoxY := pdf.GetY()
+	pdf.SetFillColor(255, 255, 255)
+	pdf.Rect(margins.Left+35, priceBoxY-2, contentWidth-70, 18, "F")
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.8)
+	pdf.Rect(margins.Left+35, priceBoxY-2, contentWidth-70, 18, "D")
+
+	pdf.SetY(priceBoxY)
+	pdf.SetFont(s.theme.TitleFontName, "B", 26)
+	pdf.SetTextColor(s.theme.AccentColor.RGB())
+	pdf.CellFormat(contentWidth, 14, coreText(pdf, s.formatPrice(property.Price, property.Currency)), "", 1, "C", false, 0, "")
+	pdf.Ln(5)
+
+	pdf.SetFont(s.theme.BodyFontName, "", 12)
+	pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+	pdf.MultiCell(contentWidth, 6, s.formatLocation(property), "", "C", false)
+
+	s.addBottomDiamondDecoration(pdf)
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+// bilingualColumns returns the x positions of the English and Arabic columns and their shared width
+func bilingualColumns(pdf *gofpdf.Fpdf) (leftX, rightX, width float64) {
+	_, _, contentWidth := pageSize(pdf)
+	width = (contentWidth - bilingualGutter) / 2
+	leftX = pageMargins(pdf).Left
+	return leftX, leftX + width + bilingualGutter, width
+}
+
+// addBilingualDetailsPage renders English (left) and Arabic (right) columns above a shared contact band
+func (s *PDFService) addBilingualDetailsPage(pdf *gofpdf.Fpdf, property *models.Property) {
+	margins := pageMargins(pdf)
+	pdf.AddPage()
+
+	s.addPageBackground(pdf)
+	s.addBrandingIfAvailable(pdf)
+	startY := margins.Top + 10.0
+
+	s.addBilingualEnglishColumn(pdf, s.englishColumnContent(property), startY)
+	s.addBilingualArabicColumn(pdf, s.arabicColumnContent(property), startY)
+
+	// Thin gold divider in the gutter
+	leftX, _, colWidth := bilingualColumns(pdf)
+	dividerX := leftX + colWidth + bilingualGutter/2
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.3)
+	pdf.Line(dividerX, startY, dividerX, bilingualContactY-5)
+
+	s.addBilingualContactBand(pdf, property, bilingualContactY)
+
+	s.addBottomDiamondDecoration(pdf)
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+func (s *PDFService) englishColumnContent(property *models.Property) bilingualColumn {
+	if property.EnglishContent.Description != "" {
+		return bilingualColumn{
+			descLabel:       property.EnglishContent.PropertyDescriptionLabel,
+			highlightsLabel: property.EnglishContent.KeyHighlightsLabel,
+			amenitiesLabel:  property.EnglishContent.AmenitiesLabel,
+			description:     property.EnglishContent.Description,
+			highlights:      property.EnglishContent.Highlights,
+			amenities:       property.EnglishContent.Amenities,
+		}
+	}
+
+	description := property.AIContent.EnglishDescription
+	if description == "" {
+		description = property.Description
+	}
+	if description == "" {
+		description = "No description available."
+	}
+	return bilingualColumn{
+		descLabel:       "Property Description",
+		highlightsLabel: "Key Highlights",
+		amenitiesLabel:  "Amenities & Features",
+		description:     description,
+		highlights:      property.AIContent.KeyHighlights,
+		amenities:       property.Amenities,
+	}
+}
+
+func (s *PDFService) arabicColumnContent(property *models.Property) bilingualColumn {
+	if property.ArabicContent.Description != "" {
+		return bilingualColumn{
+			descLabel:       property.ArabicContent.PropertyDescriptionLabel,
+			highlightsLabel: property.ArabicContent.KeyHighlightsLabel,
+			amenitiesLabel:  property.ArabicContent.AmenitiesLabel,
+			description:     property.ArabicContent.Description,
+			highlights:      property.ArabicContent.Highlights,
+			amenities:       property.ArabicContent.Amenities,
+		}
+	}
+
+	description := property.AIContent.ArabicDescription
+	if description == "" {
+		description = "لا يوجد وصف متاح"
+	}
+	return bilingualColumn{
+		descLabel:      "وصف العقار",
+		amenitiesLabel: "المرافق والميزات",
+		description:    description,
+		amenities:      property.Amenities,
+	}
+}
+
+// addBilingualEnglishColumn renders the left-to-right column
+func (s *PDFService) addBilingualEnglishColumn(pdf *gofpdf.Fpdf, col bilingualColumn, y float64) {
+	x, _, w := bilingualColumns(pdf)
+	bodyFont := func(size float64) {
+		if s.hasBodyFont {
+			pdf.SetFont(s.bodyFontName, "", size)
+		} else {
+			pdf.SetFont(s.theme.BodyFontName, "", size)
+		}
+	}
+
+	y = s.addSectionHeaderInColumn(pdf, col.descLabel, x, y, w)
+	bodyFont(9.5)
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(x, y)
+	pdf.MultiCell(w, 4.8, truncateText(col.description, bilingualMaxDescChars), "", "L", false)
+	y = pdf.GetY() + 6
+
+	if len(col.highlights) > 0 && y < bilingualContactY-30 {
+		y = s.addSectionHeaderInColumn(pdf, col.highlightsLabel, x, y, w)
+		for i, raw := range col.highlights {
+			if i == bilingualMaxHighlights || y > bilingualContactY-15 {
+				break
+			}
+			pdf.SetFillColor(s.theme.AccentColor.RGB())
+			pdf.Circle(x+3, y+2.5, 1.2, "F")
+			bodyFont(9)
+			pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+			pdf.SetXY(x+7, y)
+			pdf.MultiCell(w-7, 4.8, s.sanitizeBulletText(raw), "", "L", false)
+			y = pdf.GetY() + 1
+		}
+		y += 5
+	}
+
+	if len(col.amenities) > 0 && y < bilingualContactY-25 {
+		y = s.addSectionHeaderInColumn(pdf, col.amenitiesLabel, x, y, w)
+		bodyFont(9)
+		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+		for i, amenity := range col.amenities {
+			if i == bilingualMaxAmenities || y > bilingualContactY-10 {
+				break
+			}
+			s.drawCheckMark(pdf, x, y+2.5)
+			pdf.SetXY(x+8, y)
+			pdf.CellFormat(w-8, 5.5, amenity, "", 0, "L", false, 0, "")
+			y += 5.5
+		}
+	}
+}
+
+// addBilingualArabicColumn renders the right-to-left column
+func (s *PDFService) addBilingualArabicColumn(pdf *gofpdf.Fpdf, col bilingualColumn, y float64) {
+	_, x, w := bilingualColumns(pdf)
+	fontName := ""
+	if s.hasArabicFont {
+		fontName = s.arabicFontName
+	}
+	arabicFont := func(size float64) {
+		if s.hasArabicFont {
+			pdf.SetFont(s.arabicFontName, "", size)
+		} else {
+			pdf.SetFont(s.theme.BodyFontName, "", size)
+		}
+	}
+
+	y = s.addSectionHeaderAlignedInColumn(pdf, col.descLabel, x, y, w, fontName, "R")
+	arabicFont(10)
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(x, y)
+	pdf.MultiCell(w, 5, truncateText(s.fixMojibakeLatin1ToUTF8(col.description), bilingualMaxDescChars), "", "R", false)
+	y = pdf.GetY() + 6
+
+	if len(col.highlights) > 0 && y < bilingualContactY-30 {
+		y = s.addSectionHeaderAlignedInColumn(pdf, col.highlightsLabel, x, y, w, fontName, "R")
+		for i, raw := range col.highlights {
+			if i == bilingualMaxHighlights || y > bilingualContactY-15 {
+				break
+			}
+			// Bullet on the right for RTL
+			pdf.SetFillColor(s.theme.AccentColor.RGB())
+			pdf.Circle(x+w-3, y+2.5, 1.2, "F")
+			arabicFont(9.5)
+			pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+			pdf.SetXY(x, y)
+			pdf.MultiCell(w-7, 5, s.fixMojibakeLatin1ToUTF8(s.sanitizeBulletText(raw)), "", "R", false)
+			y = pdf.GetY() + 1
+		}
+		y += 5
+	}
+
+	if len(col.amenities) > 0 && y < bilingualContactY-25 {
+		y = s.addSectionHeaderAlignedInColumn(pdf, col.amenitiesLabel, x, y, w, fontName, "R")
+		arabicFont(9.5)
+		pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+		for i, amenity := range col.amenities {
+			if i == bilingualMaxAmenities || y > bilingualContactY-10 {
+				break
+			}
+			s.drawCheckMark(pdf, x+w-6, y+2.5)
+			pdf.SetXY(x, y)
+			pdf.CellFormat(w-8, 5.5, s.fixMojibakeLatin1ToUTF8(amenity), "", 0, "R", false, 0, "")
+			y += 5.5
+		}
+	}
+}
+
+// addBilingualContactBand draws the agent details once, with labels in both languages
+func (s *PDFService) addBilingualContactBand(pdf *gofpdf.Fpdf, property *models.Property, y float64) {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+	bandH := 26.0
+	pdf.SetFillColor(255, 255, 255)
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.6)
+	pdf.Rect(margins.Left, y, contentWidth, bandH, "FD")
+
+	s.addSectionHeaderBar(pdf, margins.Left, y, contentWidth, 8)
+
+	pdf.SetFont(s.theme.TitleFontName, "B", 11)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetXY(margins.Left+5, y+0.5)
+	pdf.CellFormat(contentWidth/2-5, 7, "Contact Your Agent", "", 0, "L", false, 0, "")
+	if s.hasArabicFont {
+		pdf.SetFont(s.arabicFontName, "", 11)
+		pdf.SetXY(margins.Left+contentWidth/2, y+0.5)
+		pdf.CellFormat(contentWidth/2-5, 7, "تواصل مع الوكيل", "", 0, "R", false, 0, "")
+	}
+
+	pdf.SetFont(s.theme.TitleFontName, "B", 12)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	pdf.SetXY(margins.Left, y+10)
+	pdf.CellFormat(contentWidth, 6, property.AgentInfo.Name, "", 0, "C", false, 0, "")
+
+	pdf.SetFont(s.theme.BodyFontName, "", 10)
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(margins.Left, y+17)
+	pdf.CellFormat(contentWidth, 6, property.AgentInfo.Phone+"   |   "+property.AgentInfo.Email, "", 0, "C", false, 0, "")
+}
+
+// drawCheckMark draws the green vector check mark used in amenity lists
+func (s *PDFService) drawCheckMark(pdf *gofpdf.Fpdf, x, y float64) {
+	pdf.SetDrawColor(46, 125, 50)
+	pdf.SetLineWidth(0.6)
+	pdf.Line(x, y, x+1.5, y+1.5)
+	pdf.Line(x+1.5, y+1.5, x+4.5, y-1)
+}
+
+// truncateText shortens text to at most maxChars runes, cutting at a word boundary
+func truncateText(text string, maxChars int) string {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+	runes := []rune(text)[:maxChars]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "..."
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"property-brochure-backend/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Side-by-side comparison layout; the columns share the bilingual brochure's split (87.5mm each on A4)
+const (
+	comparisonImageHeight   = 55.0
+	comparisonMaxHighlights = 4
+	comparisonMaxAmenities  = 8
+)
+
+// GenerateComparisonBrochure creates a PDF comparing two listings in parallel columns:
+// cover image and title, then price, location, type, bedrooms, floor plan size, estimated payment,
+// highlights and amenities. Listings have no bathroom count, so there is no bathrooms row.
+func (s *PDFService) GenerateComparisonBrochure(left, right *models.Property) ([]byte, error) {
+	pdf := s.newDocument("P", "A4", 0)
+	// Agency fonts and theme presets are per listing, so the comparison keeps the defaults
+	s.setupFonts(pdf, nil)
+
+	s.addComparisonPage(pdf, [2]*models.Property{left, right})
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate comparison PDF: %w", err)
+	}
+	return s.finishPDF(buf.Bytes(), "comparison"), nil
+}
+
+// comparisonRow renders one value per property in the two columns; each returns the y below its content
+type comparisonRow struct {
+	label  string
+	render func(pdf *gofpdf.Fpdf, property *models.Property, x, y, w float64) float64
+}
+
+func (s *PDFService) addComparisonPage(pdf *gofpdf.Fpdf, properties [2]*models.Property) {
+	margins := pageMargins(pdf)
+	_, pageHeight, contentWidth := pageSize(pdf)
+	leftX, rightX, colWidth := bilingualColumns(pdf)
+	columnX := [2]float64{leftX, rightX}
+
+	s.addComparisonPageFrame(pdf)
+
+	pdf.SetY(margins.Top - 5)
+	pdf.SetFont(s.theme.TitleFontName, "B", 18)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	pdf.CellFormat(contentWidth, 9, "Property Comparison", "", 1, "C", false, 0, "")
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Rect(margins.Left+50, pdf.GetY()+1, contentWidth-100, 1.5, "F")
+	y := pdf.GetY() + 7
+
+	// Column headers: cover image and title
+	titleBottom := y
+	for i, property := range properties {
+		x := columnX[i]
+		placed := false
+		if len(property.ImageURLs) > 0 {
+			placed = s.addCroppedImageFromURL(pdf, property.ImageURLs[0], x, y, colWidth, comparisonImageHeight) == nil
+		}
+		if !placed {
+			pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
+			pdf.Rect(x, y, colWidth, comparisonImageHeight, "F")
+			pdf.SetFont(s.theme.BodyFontName, "I", 10)
+			pdf.SetTextColor(mediumGrayR, mediumGrayG, mediumGrayB)
+			pdf.SetXY(x, y+comparisonImageHeight/2-3)
+			pdf.CellFormat(colWidth, 6, "No Image Available", "", 0, "C", false, 0, "")
+		}
+		pdf.SetDrawColor(s.theme.AccentColor.RGB())
+		pdf.SetLineWidth(0.8)
+		pdf.Rect(x, y, colWidth, comparisonImageHeight, "D")
+
+		s.comparisonFont(pdf, "B", 12)
+		pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+		pdf.SetXY(x, y+comparisonImageHeight+3)
+		title := property.Title
+		if property.EnglishContent.Title != "" {
+			title = property.EnglishContent.Title
+		}
+		pdf.MultiCell(colWidth, 5.5, title, "", "C", false)
+		if pdf.GetY() > titleBottom {
+			titleBottom = pdf.GetY()
+		}
+	}
+	y = titleBottom + 5
+
+	for _, row := range s.comparisonRows() {
+		// Keep a row's label with at least the first lines of its values
+		if y > pageHeight-margins.Bottom-25 {
+			s.addComparisonPageFrame(pdf)
+			y = margins.Top
+		}
+		y = s.addComparisonRowLabel(pdf, row.label, y)
+
+		rowBottom := y
+		for i, property := range properties {
+			if bottom := row.render(pdf, property, columnX[i], y, colWidth); bottom > rowBottom {
+				rowBottom = bottom
+			}
+		}
+		y = rowBottom + 4
+	}
+}
+
+// addComparisonPageFrame starts a page with the brochure background and page number
+func (s *PDFService) addComparisonPageFrame(pdf *gofpdf.Fpdf) {
+	pdf.AddPage()
+	s.addPageBackground(pdf)
+	s.addBrandingIfAvailable(pdf)
+	s.addDecorativeCorners(pdf)
+	s.addPageNumber(pdf, pdf.PageNo())
+}
+
+// addComparisonRowLabel draws a light full-width label bar spanning both columns
+func (s *PDFService) addComparisonRowLabel(pdf *gofpdf.Fpdf, label string, y float64) float64 {
+	margins := pageMargins(pdf)
+	_, _, contentWidth := pageSize(pdf)
+
+	pdf.SetFillColor(lightGrayR, lightGrayG, lightGrayB)
+	pdf.Rect(margins.Left, y, contentWidth, 6.5, "F")
+	pdf.SetFillColor(s.theme.AccentColor.RGB())
+	pdf.Rect(margins.Left, y, 2, 6.5, "F")
+
+	pdf.SetFont(s.theme.TitleFontName, "B", 10)
+	pdf.SetTextColor(s.theme.PrimaryColor.RGB())
+	pdf.SetXY(margins.Left+4, y)
+	pdf.CellFormat(contentWidth-8, 6.5, label, "", 0, "L", false, 0, "")
+	return y + 9
+}
+
+// comparisonFont uses the UTF-8 body font when available so listing text renders correctly
+func (s *PDFService) comparisonFont(pdf *gofpdf.Fpdf, style string, size float64) {
+	if s.hasBodyFont && style == "" {
+		pdf.SetFont(s.bodyFontName, "", size)
+		return
+	}
+	pdf.SetFont(s.coreFont(style), style, size)
+}
+
+// comparisonText writes wrapped text in a column and returns the y below it
+func (s *PDFService) comparisonText(pdf *gofpdf.Fpdf, text string, x, y, w float64) float64 {
+	s.comparisonFont(pdf, "", 10)
+	pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+	pdf.SetXY(x, y)
+	pdf.MultiCell(w, 5, text, "", "L", false)
+	return pdf.GetY()
+}
+
+// comparisonRows lists the compared attributes in display order
+func (s *PDFService) comparisonRows() []comparisonRow {
+	text := func(value func(p *models.Property) string) func(*gofpdf.Fpdf, *models.Property, float64, float64, float64) float64 {
+		return func(pdf *gofpdf.Fpdf, p *models.Property, x, y, w float64) float64 {
+			return s.comparisonText(pdf, value(p), x, y, w)
+		}
+	}
+
+	return []comparisonRow{
+		{"Price", func(pdf *gofpdf.Fpdf, p *models.Property, x, y, w float64) float64 {
+			pdf.SetFont(s.theme.TitleFontName, "B", 14)
+			pdf.SetTextColor(s.theme.AccentColor.RGB())
+			pdf.SetXY(x, y)
+			pdf.CellFormat(w, 7, coreText(pdf, s.formatPrice(p.Price, p.Currency)), "", 0, "L", false, 0, "")
+			return y + 7
+		}},
+		{"Location", text(s.formatLocation)},
+		{"Property Type", text(func(p *models.Property) string {
+			if p.PropertyType == "" {
+				return "Not specified"
+			}
+			return p.PropertyType
+		})},
+		{"Bedrooms", text(func(p *models.Property) string {
+			if p.Bedrooms == 0 {
+				return "Not specified"
+			}
+			return fmt.Sprint(p.Bedrooms)
+		})},
+		{"Floor Plan Size", text(formatFloorPlanSize)},
+		{"Est. Monthly Payment", text(func(p *models.Property) string {
+			if p.Mortgage == nil || p.Mortgage.TermYears == 0 {
+				return "Not available"
+			}
+			payment := MonthlyMortgagePayment(p.Price, *p.Mortgage)
+			return fmt.Sprintf("%s (%.0f%% down, %.2f%%, %d years)",
+				s.formatPrice(payment, p.Currency), p.Mortgage.DownPaymentPct, p.Mortgage.InterestRate, p.Mortgage.TermYears)
+		})},
+		{"Key Highlights", func(pdf *gofpdf.Fpdf, p *models.Property, x, y, w float64) float64 {
+			highlights := s.englishColumnContent(p).highlights
+			if len(highlights) == 0 {
+				return s.comparisonText(pdf, "None listed", x, y, w)
+			}
+			for i, raw := range highlights {
+				if i == comparisonMaxHighlights {
+					break
+				}
+				pdf.SetFillColor(s.theme.AccentColor.RGB())
+				pdf.Circle(x+2, y+2.5, 1, "F")
+				y = s.comparisonText(pdf, s.sanitizeBulletText(raw), x+5, y, w-5) + 1
+			}
+			return y
+		}},
+		{"Amenities", func(pdf *gofpdf.Fpdf, p *models.Property, x, y, w float64) float64 {
+			amenities := s.englishColumnContent(p).amenities
+			if len(amenities) == 0 {
+				return s.comparisonText(pdf, "None listed", x, y, w)
+			}
+			for i, amenity := range amenities {
+				if i == comparisonMaxAmenities {
+					break
+				}
+				s.drawAmenityIcon(pdf, x, y+2.75, amenityIconFor(p.EnglishContent.AmenityIcon, amenity))
+				s.comparisonFont(pdf, "", 10)
+				pdf.SetTextColor(darkGrayR, darkGrayG, darkGrayB)
+				pdf.SetXY(x+8, y)
+				pdf.CellFormat(w-8, 5.5, amenity, "", 0, "L", false, 0, "")
+				y += 5.5
+			}
+			return y
+		}},
+	}
+}
+
+// formatFloorPlanSize describes the floor plan dimensions and area, which listings give in metres
+func formatFloorPlanSize(p *models.Property) string {
+	if p.FloorPlanWidth <= 0 || p.FloorPlanHeight <= 0 {
+		return "Not specified"
+	}
+	return fmt.Sprintf("%.1f x %.1f m (%.0f sq m)", p.FloorPlanWidth, p.FloorPlanHeight, p.FloorPlanWidth*p.FloorPlanHeight)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func init() {
+	// pdfcpu otherwise creates a config.yml in the user's config directory and exits the process when it cannot
+	model.ConfigPath = "disable"
+}
+
+// EncryptPDF protects a brochure with AES-128 so it opens only with password. The owner password is random,
+// so recipients can print the brochure but cannot lift the copy and edit restrictions. pdfcpu rewrites the
+// file without its linearization, so the encrypted brochure is linearized again when that is configured.
+func (s *PDFService) EncryptPDF(data []byte, password string) ([]byte, error) {
+	conf := model.NewAESConfiguration(password, uuid.New().String(), 128)
+
+	var buf bytes.Buffer
+	if err := api.Encrypt(bytes.NewReader(data), &buf, conf); err != nil {
+		return nil, fmt.Errorf("failed to encrypt PDF: %w", err)
+	}
+	if len(s.linearizeCommand) == 0 {
+		return buf.Bytes(), nil
+	}
+
+	linearized, err := s.linearize(buf.Bytes(), password)
+	if err != nil {
+		log.Printf("Error linearizing encrypted PDF, keeping it unlinearized: %v", err)
+		return buf.Bytes(), nil
+	}
+	return linearized, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// linearizedHeaderSize is how far into a linearized PDF its linearization dictionary must appear
+const linearizedHeaderSize = 1024
+
+// WithPDFLinearization rewrites generated brochures as linearized ("fast web view") PDFs so browsers can
+// show the first page before the whole file has downloaded. command is run with the input and output
+// paths appended, e.g. "qpdf --linearize".
+func WithPDFLinearization(command string) PDFOption {
+	return func(s *PDFService) {
+		s.linearizeCommand = strings.Fields(command)
+	}
+}
+
+// LinearizePDF rewrites a PDF with the configured linearization command. gofpdf and pdfcpu cannot write
+// linearized files themselves, so this needs an external tool such as qpdf.
+func (s *PDFService) LinearizePDF(data []byte) ([]byte, error) {
+	return s.linearize(data, "")
+}
+
+// linearize runs the linearization command. An encrypted PDF is opened with its user password, passed in
+// a file with qpdf's --password-file so it never shows up in the process list; qpdf keeps the encryption.
+func (s *PDFService) linearize(data []byte, password string) ([]byte, error) {
+	if len(s.linearizeCommand) == 0 {
+		return nil, errors.New("no PDF linearization command configured")
+	}
+
+	dir, err := os.MkdirTemp("", "brochure-linearize-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	inPath := filepath.Join(dir, "in.pdf")
+	if err := os.WriteFile(inPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write PDF: %w", err)
+	}
+	outPath := filepath.Join(dir, "out.pdf")
+
+	args := append([]string{}, s.linearizeCommand[1:]...)
+	if password != "" {
+		passwordPath := filepath.Join(dir, "password")
+		if err := os.WriteFile(passwordPath, []byte(password+"\n"), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write password file: %w", err)
+		}
+		args = append(args, "--password-file="+passwordPath)
+	}
+	args = append(args, inPath, outPath)
+	if output, err := s.runPDFTool(s.linearizeCommand[0], args...); err != nil {
+		return nil, fmt.Errorf("failed to linearize PDF with %s: %w: %s", s.linearizeCommand[0], err, strings.TrimSpace(string(output)))
+	}
+
+	linearized, err := os.ReadFile(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read linearized PDF: %w", err)
+	}
+	// Catch commands that merely copied or rewrote the file
+	header := linearized
+	if len(header) > linearizedHeaderSize {
+		header = header[:linearizedHeaderSize]
+	}
+	if !bytes.HasPrefix(header, []byte("%PDF-")) || !bytes.Contains(header, []byte("/Linearized")) {
+		return nil, fmt.Errorf("%s did not produce a linearized PDF", s.linearizeCommand[0])
+	}
+	return linearized, nil
+}
+
+// finishPDF post-processes generated bytes: optional optimization, then linearization, which has to come
+// last since rewriting the file again would drop it; EncryptPDF therefore linearizes encrypted brochures
+// again. Linearization is best effort like optimization.
+func (s *PDFService) finishPDF(data []byte, label string) []byte {
+	data = s.optimizeIfLarge(data, label)
+	if len(s.linearizeCommand) == 0 {
+		return data
+	}
+
+	linearized, err := s.LinearizePDF(data)
+	if err != nil {
+		log.Printf("Error linearizing %s PDF, keeping it as generated: %v", label, err)
+		return data
+	}
+	return linearized
+}
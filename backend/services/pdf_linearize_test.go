@@ -0,0 +1,101 @@
+package services
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// fakeLinearizer writes a shell script standing in for qpdf: it records its arguments and the password it
+// was given, and marks the copied input as linearized
+func fakeLinearizer(t *testing.T) (command, argsPath, passwordPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	argsPath = filepath.Join(dir, "args")
+	passwordPath = filepath.Join(dir, "password")
+	script := `#!/bin/sh
+echo "$@" > ` + argsPath + `
+for arg in "$@"; do
+	case "$arg" in --password-file=*) cp "${arg#--password-file=}" ` + passwordPath + ` ;; esac
+done
+for last in "$@"; do :; done
+in=""; for arg in "$@"; do [ "$arg" = "$last" ] || in="$arg"; done
+{ printf '%%PDF-1.7\n%% /Linearized 1\n'; cat "$in"; } > "$last"
+`
+	command = filepath.Join(dir, "linearize")
+	if err := os.WriteFile(command, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return command + " --linearize", argsPath, passwordPath
+}
+
+// onePagePDF is a minimal document pdfcpu can encrypt
+func onePagePDF(t *testing.T) []byte {
+	t.Helper()
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.Cell(40, 10, "Marina View Apartment")
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestEncryptPDFLinearizesLast(t *testing.T) {
+	command, argsPath, passwordPath := fakeLinearizer(t)
+	s := NewPDFService(WithPDFLinearization(command))
+
+	encrypted, err := s.EncryptPDF(onePagePDF(t), "s3cret")
+	if err != nil {
+		t.Fatalf("EncryptPDF failed: %v", err)
+	}
+	if !bytes.Contains(encrypted[:64], []byte("/Linearized")) {
+		t.Error("encrypted brochure is not linearized")
+	}
+	if !bytes.Contains(encrypted, []byte("/Encrypt")) {
+		t.Error("linearized brochure lost its encryption")
+	}
+
+	args, err := os.ReadFile(argsPath)
+	if err != nil {
+		t.Fatalf("linearization command did not run: %v", err)
+	}
+	if strings.Contains(string(args), "s3cret") {
+		t.Errorf("password was passed on the command line: %s", args)
+	}
+	password, err := os.ReadFile(passwordPath)
+	if err != nil || strings.TrimSpace(string(password)) != "s3cret" {
+		t.Errorf("password file = %q (%v), want the user password", password, err)
+	}
+}
+
+func TestEncryptPDFWithoutLinearization(t *testing.T) {
+	encrypted, err := NewPDFService().EncryptPDF(onePagePDF(t), "s3cret")
+	if err != nil {
+		t.Fatalf("EncryptPDF failed: %v", err)
+	}
+	if !bytes.HasPrefix(encrypted, []byte("%PDF-")) || !bytes.Contains(encrypted, []byte("/Encrypt")) {
+		t.Error("EncryptPDF did not return an encrypted PDF")
+	}
+}
+
+func TestFinishPDFKeepsTheBrochureWhenLinearizationHangs(t *testing.T) {
+	s := NewPDFService(WithPDFLinearization(hangingTool(t)+" --linearize"), WithPDFToolTimeout(100*time.Millisecond))
+	data := onePagePDF(t)
+
+	start := time.Now()
+	got := s.finishPDF(data, "English")
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("linearization took %s, want it stopped after the timeout", elapsed)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("brochure changed, want it kept as generated")
+	}
+}
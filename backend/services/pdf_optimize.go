@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WithPDFOptimization re-compresses generated brochures larger than thresholdBytes with Ghostscript
+// (gsPath, "gs" when empty); a threshold of 0 optimizes every brochure
+func WithPDFOptimization(thresholdBytes int64, gsPath string) PDFOption {
+	return func(s *PDFService) {
+		s.optimizePDFs = true
+		s.optimizeThreshold = thresholdBytes
+		if gsPath != "" {
+			s.ghostscriptPath = gsPath
+		}
+	}
+}
+
+// defaultPDFToolTimeout bounds external PDF tools unless WithPDFToolTimeout sets another limit
+const defaultPDFToolTimeout = time.Minute
+
+// pdfToolWaitDelay is how long a killed tool's children may keep its output open before Wait gives up
+const pdfToolWaitDelay = 5 * time.Second
+
+// WithPDFToolTimeout bounds each run of Ghostscript, the linearization command and the preview rasterizer
+func WithPDFToolTimeout(timeout time.Duration) PDFOption {
+	return func(s *PDFService) {
+		if timeout > 0 {
+			s.toolTimeout = timeout
+		}
+	}
+}
+
+// runPDFTool runs an external tool and returns its combined output. The tool is killed when it runs longer
+// than the configured timeout, so a hung Ghostscript or qpdf cannot hold a request forever.
+func (s *PDFService) runPDFTool(name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.toolTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = pdfToolWaitDelay
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("timed out after %s", s.toolTimeout)
+	}
+	return output, err
+}
+
+// OptimizePDF re-compresses a PDF with Ghostscript's /ebook settings, which downsample images to 150 DPI.
+// The original is returned when the result is not smaller.
+func (s *PDFService) OptimizePDF(data []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "brochure-optimize-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	inPath := filepath.Join(dir, "in.pdf")
+	if err := os.WriteFile(inPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write PDF: %w", err)
+	}
+	outPath := filepath.Join(dir, "out.pdf")
+
+	output, err := s.runPDFTool(s.ghostscriptPath,
+		"-sDEVICE=pdfwrite",
+		"-dPDFSETTINGS=/ebook",
+		"-dCompatibilityLevel=1.4",
+		"-dNOPAUSE",
+		"-dBATCH",
+		"-dQUIET",
+		"-sOutputFile="+outPath,
+		inPath,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to optimize PDF with %s: %w: %s", s.ghostscriptPath, err, strings.TrimSpace(string(output)))
+	}
+
+	optimized, err := os.ReadFile(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read optimized PDF: %w", err)
+	}
+	if len(optimized) == 0 || len(optimized) >= len(data) {
+		return data, nil
+	}
+	return optimized, nil
+}
+
+// optimizeIfLarge runs OptimizePDF on brochures over the configured threshold. Optimization is best
+// effort: on failure the brochure is kept as generated. Print-ready brochures are never optimized,
+// since downsampling their images is what print mode avoids.
+func (s *PDFService) optimizeIfLarge(data []byte, label string) []byte {
+	if !s.optimizePDFs || s.printReady || int64(len(data)) <= s.optimizeThreshold {
+		return data
+	}
+
+	optimized, err := s.OptimizePDF(data)
+	if err != nil {
+		log.Printf("Error optimizing %s PDF, keeping the original: %v", label, err)
+		return data
+	}
+	log.Printf("Optimized %s PDF: %s -> %s", label, formatBytes(len(data)), formatBytes(len(optimized)))
+	return optimized
+}
+
+// formatBytes renders a size in KB or MB for logs
+func formatBytes(n int) string {
+	if n >= 1<<20 {
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	}
+	return fmt.Sprintf("%.0fKB", float64(n)/(1<<10))
+}
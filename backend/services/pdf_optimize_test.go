@@ -0,0 +1,45 @@
+package services
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// hangingTool writes a shell script standing in for an external PDF tool that never finishes
+func hangingTool(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hang")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexec sleep 60\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOptimizeKeepsTheBrochureWhenGhostscriptHangs(t *testing.T) {
+	s := NewPDFService(WithPDFOptimization(0, hangingTool(t)), WithPDFToolTimeout(100*time.Millisecond))
+	data := onePagePDF(t)
+
+	start := time.Now()
+	got := s.optimizeIfLarge(data, "English")
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("optimization took %s, want it stopped after the timeout", elapsed)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("brochure changed, want it kept as generated")
+	}
+}
+
+func TestPreviewFailsWhenTheRasterizerHangs(t *testing.T) {
+	s := NewPDFService(WithPDFToImagePath(hangingTool(t)), WithPDFToolTimeout(100*time.Millisecond))
+
+	start := time.Now()
+	if _, err := s.pdfToJPEG(onePagePDF(t)); err == nil {
+		t.Error("pdfToJPEG succeeded, want a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("rasterizing took %s, want it stopped after the timeout", elapsed)
+	}
+}
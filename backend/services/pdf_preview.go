@@ -0,0 +1,78 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"property-brochure-backend/models"
+	"strings"
+)
+
+// previewDPI controls the resolution of rendered cover previews (A4 at 100 DPI is ~827x1169 px)
+const previewDPI = 100
+
+// GenerateCoverPagePreview renders only the cover page and converts it to a JPEG thumbnail
+func (s *PDFService) GenerateCoverPagePreview(property *models.Property) ([]byte, error) {
+	s = s.themed(property)
+	pdf := s.newDocument(s.orientation(property), property.PageSize, property.MarginMm)
+	s.setupFonts(pdf, property)
+
+	if property.Landscape {
+		s.addLandscapeCoverPage(pdf, property, false)
+	} else {
+		s.addCoverPage(pdf, property)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate cover PDF: %w", err)
+	}
+
+	return s.pdfToJPEG(buf.Bytes())
+}
+
+// pdfToJPEG rasterizes the first page of a PDF using pdftoppm or ImageMagick (PDFTOIMAGE_PATH)
+func (s *PDFService) pdfToJPEG(pdfData []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "brochure-preview-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	inPath := filepath.Join(dir, "cover.pdf")
+	if err := os.WriteFile(inPath, pdfData, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write cover PDF: %w", err)
+	}
+	outPath := filepath.Join(dir, "cover.jpg")
+
+	var args []string
+	tool := filepath.Base(s.pdfToImagePath)
+	if strings.Contains(tool, "magick") || tool == "convert" {
+		args = []string{
+			"-density", fmt.Sprint(previewDPI),
+			inPath + "[0]",
+			"-quality", fmt.Sprint(jpegQuality),
+			outPath,
+		}
+	} else {
+		// pdftoppm appends the extension to the output prefix when -singlefile is used
+		args = []string{
+			"-jpeg",
+			"-r", fmt.Sprint(previewDPI),
+			"-singlefile",
+			inPath,
+			strings.TrimSuffix(outPath, ".jpg"),
+		}
+	}
+
+	if output, err := s.runPDFTool(s.pdfToImagePath, args...); err != nil {
+		return nil, fmt.Errorf("failed to convert PDF to JPEG with %s: %w: %s", s.pdfToImagePath, err, strings.TrimSpace(string(output)))
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rendered preview: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"property-brochure-backend/models"
+)
+
+// fixtureProperty is a fully populated listing without images, floor plan or coordinates, so
+// generating its brochures makes no network requests
+func fixtureProperty() *models.Property {
+	return &models.Property{
+		Title:       "Marina View Apartment",
+		Description: "Bright two-bedroom apartment with a full marina view, open-plan kitchen and a private balcony.",
+		Price:       2450000,
+		Currency:    "AED",
+		Address:     "Marina Promenade, Tower 3",
+		City:        "Dubai",
+		State:       "Dubai",
+		ZipCode:     "00000",
+		Amenities:   []string{"Swimming Pool", "Gym", "Covered Parking", "24/7 Security"},
+		AgentInfo: models.AgentInfo{
+			Name:  "Sara Haddad",
+			Email: "sara@example.com",
+			Phone: "+971 50 123 4567",
+		},
+		AIContent: models.AIContent{
+			EnglishDescription: "A bright two-bedroom apartment overlooking the marina, a short walk from the beach.",
+			ArabicDescription:  "شقة مشرقة من غرفتي نوم تطل على المرسى على بعد خطوات من الشاطئ.",
+			KeyHighlights:      []string{"Full marina view", "Private balcony", "Walk to the beach"},
+		},
+		EnglishContent: models.LocalizedContent{
+			Title:       "Marina View Apartment",
+			Description: "A bright two-bedroom apartment overlooking the marina, a short walk from the beach.",
+			Highlights:  []string{"Full marina view", "Private balcony", "Walk to the beach"},
+			Amenities:   []string{"Swimming Pool", "Gym", "Covered Parking", "24/7 Security"},
+		},
+		ArabicContent: models.LocalizedContent{
+			Title:       "شقة بإطلالة على المرسى",
+			Description: "شقة مشرقة من غرفتي نوم تطل على المرسى على بعد خطوات من الشاطئ.",
+			Highlights:  []string{"إطلالة كاملة على المرسى", "شرفة خاصة", "قريبة من الشاطئ"},
+			Amenities:   []string{"مسبح", "صالة رياضية", "موقف مغطى", "أمن على مدار الساعة"},
+			AgentLabel:  "تواصل مع الوكيل",
+		},
+		Mortgage: &models.MortgageDetails{DownPaymentPct: 20, InterestRate: 4.5, TermYears: 25},
+	}
+}
+
+// newTestPDFService creates a PDF service with the project fonts, resolved from the package directory
+func newTestPDFService() *PDFService {
+	return NewPDFService(
+		WithArabicFontPath("../"+DefaultArabicFontPath),
+		WithBodyFontPath("../"+DefaultBodyFontPath),
+		WithUrduFontPath(""),
+	)
+}
+
+// benchmarkImage is a 1600x1200 JPEG, the size of a typical listing photo
+func benchmarkImage(b *testing.B) []byte {
+	b.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 1600, 1200))
+	for y := 0; y < 1200; y++ {
+		for x := 0; x < 1600; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: uint8(x ^ y), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+		b.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// benchmarkBrochureWithImages generates English brochures for the fixture with count images,
+// served by a local HTTP server so the download path is exercised without network access
+func benchmarkBrochureWithImages(b *testing.B, count int) {
+	photo := benchmarkImage(b)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write(photo)
+	}))
+	defer server.Close()
+
+	s := newTestPDFService()
+	property := fixtureProperty()
+	for i := 0; i < count; i++ {
+		property.ImageURLs = append(property.ImageURLs, fmt.Sprintf("%s/photo-%d.jpg", server.URL, i))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GenerateEnglishBrochure(property); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Measured with 1600x1200 JPEGs:
+//
+//	NoImages    20 ms   14.6 MB   18.2k allocs/op
+//	1Image     101 ms   19.6 MB   18.4k allocs/op
+//	5Images    217 ms   35.3 MB   19.3k allocs/op
+//
+// By alloc_space for 5Images, the biggest hot spots are JPEG decoding in the image resize path
+// (image.NewYCbCr, ~40%), the flate writers gofpdf creates per compressed stream (~25%), the
+// output-time font subsetting in gofpdf putfonts (hmtx parsing, ~15%) and the download buffers
+// (io.ReadAll, ~4%). Reading the font files does not show up, because their bytes are loaded
+// once per service; gofpdf still parses the tables to subset them for each document.
+func BenchmarkGenerateBrochure_NoImages(b *testing.B) {
+	benchmarkBrochureWithImages(b, 0)
+}
+
+func BenchmarkGenerateBrochure_1Image(b *testing.B) {
+	benchmarkBrochureWithImages(b, 1)
+}
+
+func BenchmarkGenerateBrochure_5Images(b *testing.B) {
+	benchmarkBrochureWithImages(b, 5)
+}
+
+func BenchmarkGenerateEnglishBrochure(b *testing.B) {
+	s := newTestPDFService()
+	property := fixtureProperty()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GenerateEnglishBrochure(property); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateArabicBrochure(b *testing.B) {
+	s := newTestPDFService()
+	property := fixtureProperty()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GenerateArabicBrochure(property); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBrokenGalleryImagesBecomeWarnings(t *testing.T) {
+	var photo bytes.Buffer
+	if err := jpeg.Encode(&photo, image.NewRGBA(image.Rect(0, 0, 40, 30)), nil); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	downloads := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		downloads[r.URL.Path]++
+		mu.Unlock()
+		if r.URL.Path == "/broken.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write(photo.Bytes())
+	}))
+	defer server.Close()
+
+	s := newTestPDFService()
+	property := fixtureProperty()
+	property.ImageURLs = []string{server.URL + "/cover.jpg", server.URL + "/broken.jpg"}
+
+	if err := s.CheckCoverImage(property); err != nil {
+		t.Fatalf("CheckCoverImage failed: %v", err)
+	}
+	if downloads["/broken.jpg"] != 0 {
+		t.Error("the gallery was downloaded before rendering")
+	}
+	for _, generate := range []func(*models.Property) ([]byte, error){s.GenerateEnglishBrochure, s.GenerateArabicBrochure} {
+		if _, err := generate(property); err != nil {
+			t.Fatalf("generation failed: %v", err)
+		}
+	}
+
+	want := []string{"Gallery image 1 is unavailable and was replaced with a placeholder"}
+	if fmt.Sprint(property.ImageWarnings) != fmt.Sprint(want) {
+		t.Errorf("warnings = %q, want %q once for both brochures", property.ImageWarnings, want)
+	}
+}
+
+func TestBrokenCoverImageIsFatal(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	property := fixtureProperty()
+	property.ImageURLs = []string{server.URL + "/cover.jpg"}
+	if err := newTestPDFService().CheckCoverImage(property); err == nil {
+		t.Error("CheckCoverImage accepted an unavailable cover")
+	}
+}
+
+// TestConcurrentBrochures renders brochures in parallel from the same cached fonts; run it with -race
+func TestConcurrentBrochures(t *testing.T) {
+	s := newTestPDFService()
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = s.GenerateBilingualBrochure(fixtureProperty())
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBrochureLinksAgentContact(t *testing.T) {
+	property := fixtureProperty()
+	property.AgentInfo.Phone = " +971 (50) 123-4567"
+
+	pdfBytes, err := newTestPDFService().GenerateEnglishBrochure(property)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Link annotations are written uncompressed with the page objects
+	for _, want := range []string{"/URI (mailto:sara@example.com)", "/URI (tel:+971501234567)"} {
+		if !bytes.Contains(pdfBytes, []byte(want)) {
+			t.Errorf("brochure has no %s link", want)
+		}
+	}
+}
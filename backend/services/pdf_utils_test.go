@@ -0,0 +1,262 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"property-brochure-backend/models"
+)
+
+func TestFormatPrice(t *testing.T) {
+	s := &PDFService{}
+	tests := []struct {
+		name     string
+		price    float64
+		currency string
+		want     string
+	}{
+		{"zero", 0, "AED", "AED 0"},
+		{"AED", 2450000, "AED", "AED 2,450,000"},
+		{"SAR", 875000, "SAR", "SAR 875,000"},
+		{"lowercase code", 100, "aed", "AED 100"},
+		{"very large", 1e12, "SAR", "SAR 1,000,000,000,000"},
+		{"missing currency defaults to USD", 950000, "", "$950,000"},
+		{"decimal currency", 1234.5, "EUR", "€1.234,50"},
+		{"unknown code", 1000, "XYZ", "XYZ 1,000"},
+		{"negative", -1500, "USD", "-$1,500"},
+		{"negative rounding to zero", -0.4, "USD", "$0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.formatPrice(tt.price, tt.currency); got != tt.want {
+				t.Errorf("formatPrice(%v, %q) = %q, want %q", tt.price, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLocation(t *testing.T) {
+	s := &PDFService{}
+	tests := []struct {
+		name     string
+		property models.Property
+		want     string
+	}{
+		{"full address", models.Property{Address: "Marina Promenade", City: "Dubai", State: "Dubai", ZipCode: "00000"}, "Marina Promenade, Dubai, Dubai, 00000"},
+		{"city and state", models.Property{City: "Riyadh", State: "Riyadh Province"}, "Riyadh, Riyadh Province"},
+		{"address and zip code", models.Property{Address: "12 King Fahd Road", ZipCode: "12211"}, "12 King Fahd Road, 12211"},
+		{"city only", models.Property{City: "Jeddah"}, "Jeddah"},
+		{"empty", models.Property{}, "Location not specified"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.formatLocation(&tt.property); got != tt.want {
+				t.Errorf("formatLocation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFloorPlanSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		property models.Property
+		want     string
+	}{
+		{"dimensions", models.Property{FloorPlanWidth: 12, FloorPlanHeight: 8.5}, "12.0 x 8.5 m (102 sq m)"},
+		{"width only", models.Property{FloorPlanWidth: 12}, "Not specified"},
+		{"none", models.Property{}, "Not specified"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatFloorPlanSize(&tt.property); got != tt.want {
+				t.Errorf("formatFloorPlanSize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeBulletText(t *testing.T) {
+	s := &PDFService{}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"• Swimming pool", "Swimming pool"},
+		{"â€¢ Swimming pool", "Swimming pool"},
+		{"-> Swimming pool", "Swimming pool"},
+		{"=> Swimming pool", "Swimming pool"},
+		{"— Swimming pool", "Swimming pool"},
+		{"· Swimming pool", "Swimming pool"},
+		{"-- Swimming pool", "Swimming pool"},
+		{"- Swimming pool", "Swimming pool"},
+		{"* Swimming pool", "Swimming pool"},
+		{"•Swimming pool", "Swimming pool"},
+		{"  - Swimming pool  ", "Swimming pool"},
+		{"• مسبح", "مسبح"},
+		{"Swimming pool - heated", "Swimming pool - heated"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := s.sanitizeBulletText(tt.text); got != tt.want {
+				t.Errorf("sanitizeBulletText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixMojibakeLatin1ToUTF8(t *testing.T) {
+	s := &PDFService{}
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"Latin mojibake", "CafÃ© near the marina", "Café near the marina"},
+		{"currency symbol mojibake", "Â£500 service charge", "£500 service charge"},
+		{"Arabic mojibake", "Ù…Ù†Ø²Ù„", "منزل"},
+		{"clean Arabic", "منزل فاخر", "منزل فاخر"},
+		{"clean Arabic with a Latin indicator", "منزل Ã", "منزل Ã"},
+		{"clean accented text", "Café", "Café"},
+		{"indicator that is not mojibake", "Ørsted Tower", "Ørsted Tower"},
+		{"ASCII", "Marina View", "Marina View"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.fixMojibakeLatin1ToUTF8(tt.text); got != tt.want {
+				t.Errorf("fixMojibakeLatin1ToUTF8(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAspectRatio(t *testing.T) {
+	tests := []struct {
+		value string
+		wantW int
+		wantH int
+	}{
+		{"16:9", 16, 9},
+		{"4:3", 4, 3},
+		{"1:1", 1, 1},
+		{"", 3, 2},
+		{"16x9", 3, 2},
+		{"0:9", 3, 2},
+		{"16:0", 3, 2},
+		{"-4:3", 3, 2},
+		{"wide", 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			w, h := parseAspectRatio(tt.value, 3, 2)
+			if w != tt.wantW || h != tt.wantH {
+				t.Errorf("parseAspectRatio(%q) = %d:%d, want %d:%d", tt.value, w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxChars int
+		want     string
+	}{
+		{"short text is trimmed", "  Swimming pool  ", 20, "Swimming pool"},
+		{"exact length", "Swimming pool", 13, "Swimming pool"},
+		{"cut at a word boundary", "Sea view apartment", 10, "Sea view..."},
+		{"trailing punctuation", "Pool, gym and spa", 6, "Pool..."},
+		{"single long word", "Penthouse", 4, "Pent..."},
+		{"Arabic counts runes", "شقة فاخرة على البحر", 10, "شقة فاخرة..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateText(tt.text, tt.maxChars); got != tt.want {
+				t.Errorf("truncateText(%q, %d) = %q, want %q", tt.text, tt.maxChars, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortenURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"short URL", "https://agency.example", "https://agency.example"},
+		{"40 characters", "https://agency.example/listings/a-b-c-de", "https://agency.example/listings/a-b-c-de"},
+		{"long URL", "https://agency.example/listings/marina-view-apartment", "https://agency.example/listings/marin..."},
+		{"internationalized domain", "https://وكالة-عقارية.example/عقارات/شقة-المرسى", "https://وكالة-عقارية.example/عقارات/ش..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shortenURL(tt.url)
+			if got != tt.want {
+				t.Errorf("shortenURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("shortenURL(%q) = %q, which is not valid UTF-8", tt.url, got)
+			}
+		})
+	}
+}
+
+func TestTelURI(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone string
+		want  string
+	}{
+		{"international number", "+971 50 123 4567", "tel:+971501234567"},
+		{"punctuation and padding", " +1 (555) 010-2030 ", "tel:+15550102030"},
+		{"local number", "050 123 4567", "tel:0501234567"},
+		{"plus after the start", "00971 50+123", "tel:0097150123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := telURI(tt.phone); got != tt.want {
+				t.Errorf("telURI(%q) = %q, want %q", tt.phone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePageOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   []string
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"default order", defaultPageOrder, false},
+		{"subset", []string{"cover", "gallery", "details", "contact"}, false},
+		{"unknown page", []string{"cover", "pool"}, true},
+		{"combined page name", []string{"cover", "investmentGallery"}, true},
+		{"duplicate page", []string{"cover", "gallery", "gallery"}, true},
+		{"too many pages", append(append([]string{}, defaultPageOrder...), "cover"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePageOrder(tt.order); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePageOrder(%q) = %v, want error %v", tt.order, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlanPagesSkipsRepeatedPages(t *testing.T) {
+	s := &PDFService{}
+	property := &models.Property{
+		ImageURLs: []string{"https://storage.test/a.jpg", "https://storage.test/b.jpg"},
+		PageOrder: []string{"cover", "gallery", "gallery", "investment", "gallery", "contact", "cover"},
+	}
+	want := []string{"cover", "gallery", "investment", "contact"}
+	if got := s.planPages(property); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("planPages() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// presignCacheSize is the number of signed URLs kept in memory
+	presignCacheSize = 4096
+
+	// presignCacheTTL is how long a signed URL is handed out again; far below URLExpirationTime,
+	// so a cached URL always has most of its lifetime left
+	presignCacheTTL = time.Hour
+)
+
+// presignKey identifies a signed URL: the same object signed with another Content-Disposition or
+// lifetime is a different URL
+type presignKey struct {
+	key         string
+	disposition string
+	expiration  time.Duration
+}
+
+type presignedURL struct {
+	url         string
+	generatedAt time.Time
+}
+
+// presignCache reuses recently signed URLs, so listings requested by many clients at once are not
+// signed again for every request, and collapses concurrent signing of the same URL into one call
+type presignCache struct {
+	urls  *lru.Cache[presignKey, presignedURL]
+	group singleflight.Group
+}
+
+func newPresignCache() *presignCache {
+	// lru.New only fails for a non-positive size
+	urls, _ := lru.New[presignKey, presignedURL](presignCacheSize)
+	return &presignCache{urls: urls}
+}
+
+// get returns the cached URL for k, or signs a new one with sign
+func (c *presignCache) get(k presignKey, sign func() (string, error)) (string, error) {
+	if cached, ok := c.urls.Get(k); ok {
+		if time.Since(cached.generatedAt) < presignCacheTTL {
+			return cached.url, nil
+		}
+		c.urls.Remove(k)
+	}
+
+	group := fmt.Sprintf("%s\x00%s\x00%d", k.key, k.disposition, k.expiration)
+	url, err, _ := c.group.Do(group, func() (interface{}, error) {
+		url, err := sign()
+		if err != nil {
+			return "", err
+		}
+		c.urls.Add(k, presignedURL{url: url, generatedAt: time.Now()})
+		return url, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return url.(string), nil
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// PrintDPI is the image resolution print shops expect; print-ready brochures log images below it
+const PrintDPI = 300
+
+// uploadedImagePrefix is the storage folder of listing photos, which print-ready brochures read by key
+const uploadedImagePrefix = "properties/"
+
+// ObjectReader reads stored objects by the key behind their URL
+type ObjectReader interface {
+	KeyFromURL(rawURL string) (string, error)
+	GetObject(key string) ([]byte, error)
+}
+
+// WithObjectReader lets print-ready brochures read uploaded photos straight from storage instead of
+// downloading them through their pre-signed URLs
+func WithObjectReader(objects ObjectReader) PDFOption {
+	return func(s *PDFService) { s.objects = objects }
+}
+
+// CMYK is a process color as ink percentages (0-100)
+type CMYK struct {
+	C, M, Y, K int
+}
+
+func (c CMYK) String() string {
+	return fmt.Sprintf("C%d M%d Y%d K%d", c.C, c.M, c.Y, c.K)
+}
+
+// RGB returns the screen color the ink mix approximates
+func (c CMYK) RGB() Color {
+	channel := func(ink int) int {
+		return int(math.Round(255 * (1 - float64(ink)/100) * (1 - float64(c.K)/100)))
+	}
+	return Color{channel(c.C), channel(c.M), channel(c.Y)}
+}
+
+// brandColorCMYK holds press-tested ink mixes for the built-in theme colors. Their naive conversions
+// print dull or shift hue on coated stock, so these values take precedence.
+var brandColorCMYK = map[Color]CMYK{
+	{darkBlueR, darkBlueG, darkBlueB}: {C: 88, M: 58, Y: 18, K: 22},
+	{goldR, goldG, goldB}:             {C: 15, M: 28, Y: 90, K: 4},
+	{bgCreamR, bgCreamG, bgCreamB}:    {C: 1, M: 1, Y: 4, K: 0},
+	// Presets shipped in themes.yaml
+	{0x1F, 0x3A, 0x5F}: {C: 90, M: 68, Y: 28, K: 30},
+	{0xC9, 0xA2, 0x27}: {C: 18, M: 32, Y: 94, K: 6},
+	{0xFA, 0xF8, 0xF3}: {C: 1, M: 1, Y: 4, K: 0},
+	{0x36, 0x45, 0x4F}: {C: 72, M: 55, Y: 45, K: 40},
+	{0x1A, 0x9E, 0x9A}: {C: 80, M: 10, Y: 45, K: 0},
+	{0xFA, 0xFA, 0xFA}: {C: 0, M: 0, Y: 0, K: 2},
+	{0x1B, 0x3B, 0x6F}: {C: 95, M: 72, Y: 20, K: 20},
+	{0xC2, 0xA4, 0x6D}: {C: 22, M: 32, Y: 62, K: 6},
+	{0xF8, 0xF4, 0xEC}: {C: 2, M: 3, Y: 7, K: 0},
+	{0x4A, 0x55, 0x68}: {C: 68, M: 52, Y: 35, K: 30},
+	{0xE0, 0x7B, 0x22}: {C: 5, M: 60, Y: 95, K: 0},
+	{0xF9, 0xFA, 0xFB}: {C: 1, M: 0, Y: 0, K: 1},
+}
+
+// CMYK returns the color's ink mix: the lookup table value for known brand colors, otherwise the
+// device-independent formula, which is only an approximation without an ICC profile
+func (c Color) CMYK() CMYK {
+	if cmyk, ok := brandColorCMYK[c]; ok {
+		return cmyk
+	}
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	k := 1 - math.Max(r, math.Max(g, b))
+	if k >= 1 {
+		return CMYK{K: 100}
+	}
+	ink := func(v float64) int {
+		return int(math.Round((1 - v - k) / (1 - k) * 100))
+	}
+	return CMYK{C: ink(r), M: ink(g), Y: ink(b), K: int(math.Round(k * 100))}
+}
+
+// forPrint returns a copy of the service rendering a print-ready brochure: brand colors are replaced
+// by the screen colors of their ink mixes, so the PDF shows what the press will print
+func (s *PDFService) forPrint() *PDFService {
+	printable := *s
+	printable.printReady = true
+	printable.printKeywords = strings.Join([]string{
+		"print-ready",
+		"primary=" + s.theme.PrimaryColor.CMYK().String(),
+		"accent=" + s.theme.AccentColor.CMYK().String(),
+		"background=" + s.theme.BackgroundColor.CMYK().String(),
+	}, "; ")
+	printable.theme.PrimaryColor = s.theme.PrimaryColor.CMYK().RGB()
+	printable.theme.AccentColor = s.theme.AccentColor.CMYK().RGB()
+	printable.theme.BackgroundColor = s.theme.BackgroundColor.CMYK().RGB()
+	return &printable
+}
+
+// setPrintMetadata marks a print-ready document in its metadata, listing the ink mixes of the brand
+// colors for the ICC conversion the print shop still has to run
+func (s *PDFService) setPrintMetadata(pdf *gofpdf.Fpdf) {
+	pdf.SetSubject("Print-ready brochure: full-resolution images and RGB colors approximating the listed CMYK mixes; convert with the press ICC profile before printing", false)
+	pdf.SetKeywords(s.printKeywords, false)
+}
+
+// readStoredImage reads an uploaded photo by its storage key; ok is false for URLs that are not
+// uploaded photos or cannot be read, which are downloaded instead
+func (s *PDFService) readStoredImage(url string) (data []byte, contentType string, ok bool) {
+	key, err := s.objects.KeyFromURL(url)
+	if err != nil || !strings.HasPrefix(key, uploadedImagePrefix) {
+		return nil, "", false
+	}
+	data, err = s.objects.GetObject(key)
+	if err != nil {
+		if !errors.Is(err, ErrObjectNotFound) {
+			log.Printf("Error reading image %s from storage, downloading it instead: %v", key, err)
+		}
+		return nil, "", false
+	}
+	return data, http.DetectContentType(data), true
+}
+
+// addClippedImage fills the box with the original image, scaled to cover it and clipped to its edges,
+// so print-ready brochures embed the photo without re-encoding it
+func (s *PDFService) addClippedImage(pdf *gofpdf.Fpdf, data []byte, url string, x, y, w, h float64) error {
+	imageType := "jpg"
+	switch {
+	case isWebP(data):
+		converted, err := webpToJPEG(data)
+		if err != nil {
+			return err
+		}
+		data = converted
+	case isGIF(data):
+		converted, err := gifToJPEG(data, url)
+		if err != nil {
+			return err
+		}
+		data = converted
+	case strings.Contains(http.DetectContentType(data), "png"):
+		imageType = "png"
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width == 0 || config.Height == 0 {
+		// Unknown format: fall back to aspect-fit placement
+		return s.addImageFromURL(pdf, url, x, y, w, h)
+	}
+	imgW, imgH := float64(config.Width), float64(config.Height)
+	scale := math.Max(w/imgW, h/imgH)
+	drawW, drawH := imgW*scale, imgH*scale
+	s.checkPrintResolution(url, config.Width, drawW)
+
+	urlSuffix := url
+	if len(url) > 20 {
+		urlSuffix = url[len(url)-20:]
+	}
+	uniqueName := fmt.Sprintf("print_%s_%.0f_%.0f", urlSuffix, x, y)
+
+	opts := gofpdf.ImageOptions{ImageType: imageType}
+	pdf.RegisterImageOptionsReader(uniqueName, opts, bytes.NewReader(data))
+	if err := s.takeImageError(pdf); err != nil {
+		return err
+	}
+	pdf.ClipRect(x, y, w, h, false)
+	pdf.ImageOptions(uniqueName, x+(w-drawW)/2, y+(h-drawH)/2, drawW, drawH, false, opts, 0, "")
+	pdf.ClipEnd()
+	return nil
+}
+
+// checkPrintResolution logs images of print-ready brochures printed below PrintDPI; they are kept,
+// since upscaling would not add detail
+func (s *PDFService) checkPrintResolution(url string, pixelWidth int, drawWidthMm float64) {
+	if !s.printReady || drawWidthMm <= 0 {
+		return
+	}
+	if dpi := float64(pixelWidth) / (drawWidthMm / 25.4); dpi < PrintDPI {
+		log.Printf("Image %s prints at %.0f DPI, below the %d DPI print shops expect", url, dpi, PrintDPI)
+	}
+}
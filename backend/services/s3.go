@@ -3,44 +3,158 @@ package services
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"log"
 	"mime/multipart"
+	"net/url"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront/sign"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/google/uuid"
 )
 
 type S3Service struct {
-	client *s3.S3
-	bucket string
-	region string
+	// Images and fonts go to the image bucket, brochures and their previews to the PDF bucket;
+	// both are the same bucket unless configured otherwise
+	images s3Bucket
+	pdfs   s3Bucket
+
+	// public PDF buckets are readable without signing, so PDFs get permanent URLs
+	public bool
+
+	// Optional CloudFront distribution in front of the bucket (see UseCloudFront)
+	cdnDomain string
+	cdnSigner *sign.URLSigner
+
+	// Recently signed URLs, reused instead of signing the same object again
+	presigned *presignCache
 }
 
 const (
 	// URL expiration time for uploaded files (7 days)
 	URLExpirationTime = 7 * 24 * time.Hour
+
+	// Payloads above this size (and each part of them) are uploaded with the multipart API;
+	// 5MB is also S3's minimum part size
+	MultipartThreshold = 5 * 1024 * 1024
 )
 
-func NewS3Service(accessKey, secretKey, region, bucket string) (*S3Service, error) {
-	sess, err := session.NewSession(&aws.Config{
-		Region:      aws.String(region),
-		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
-	})
+// S3BucketConfig names a bucket and the region it lives in. Endpoint replaces the AWS endpoint for
+// S3-compatible stores such as MinIO, which usually also need path-style addressing.
+type S3BucketConfig struct {
+	Name           string
+	Region         string
+	Endpoint       string
+	ForcePathStyle bool
+}
+
+// s3Bucket is a bucket with a client for its region
+type s3Bucket struct {
+	client    *s3.S3
+	name      string
+	region    string
+	endpoint  string
+	pathStyle bool
+}
+
+// pdfKeyPrefixes are the key prefixes stored in the PDF bucket: brochures and their cover previews
+var pdfKeyPrefixes = []string{"brochures/", "previews/"}
+
+// NewS3Service stores images in imageBucket and PDFs in pdfBucket. A second client is created only when
+// the PDF bucket differs from the image bucket, since it may be in another region.
+func NewS3Service(accessKey, secretKey string, imageBucket, pdfBucket S3BucketConfig, public bool) (*S3Service, error) {
+	creds := credentials.NewStaticCredentials(accessKey, secretKey, "")
+	images, err := newS3Bucket(creds, imageBucket)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+		return nil, err
+	}
+
+	pdfs := images
+	if pdfBucket != imageBucket {
+		if pdfs, err = newS3Bucket(creds, pdfBucket); err != nil {
+			return nil, err
+		}
 	}
 
 	return &S3Service{
-		client: s3.New(sess),
-		bucket: bucket,
-		region: region,
+		images: images,
+		pdfs:   pdfs,
+		public:    public,
+		presigned: newPresignCache(),
 	}, nil
 }
 
+func newS3Bucket(creds *credentials.Credentials, cfg S3BucketConfig) (s3Bucket, error) {
+	awsConfig := &aws.Config{
+		Region:           aws.String(cfg.Region),
+		Credentials:      creds,
+		S3ForcePathStyle: aws.Bool(cfg.ForcePathStyle),
+	}
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return s3Bucket{}, fmt.Errorf("failed to create AWS session for bucket %s: %w", cfg.Name, err)
+	}
+	return s3Bucket{
+		client:    s3.New(sess),
+		name:      cfg.Name,
+		region:    cfg.Region,
+		endpoint:  strings.TrimSuffix(cfg.Endpoint, "/"),
+		pathStyle: cfg.ForcePathStyle,
+	}, nil
+}
+
+// objectURL is the unsigned URL of a key in the bucket
+func (b s3Bucket) objectURL(key string) string {
+	if b.endpoint == "" {
+		if b.pathStyle {
+			return fmt.Sprintf("https://s3.%s.amazonaws.com/%s/%s", b.region, b.name, key)
+		}
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", b.name, b.region, key)
+	}
+	if b.pathStyle {
+		return fmt.Sprintf("%s/%s/%s", b.endpoint, b.name, key)
+	}
+	endpoint, err := url.Parse(b.endpoint)
+	if err != nil || endpoint.Host == "" {
+		return fmt.Sprintf("%s/%s/%s", b.endpoint, b.name, key)
+	}
+	endpoint.Host = b.name + "." + endpoint.Host
+	return endpoint.String() + "/" + key
+}
+
+// bucketFor returns the bucket an object key belongs to
+func (s *S3Service) bucketFor(key string) s3Bucket {
+	for _, prefix := range pdfKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return s.pdfs
+		}
+	}
+	return s.images
+}
+
+// UseCloudFront serves every generated URL from the given CloudFront domain, signing
+// private URLs with the distribution's RSA key pair instead of pre-signing S3 requests
+func (s *S3Service) UseCloudFront(domain, keyPairID, privateKeyPath string) error {
+	privateKey, err := sign.LoadPEMPrivKeyFile(privateKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load CloudFront private key: %w", err)
+	}
+
+	s.cdnDomain = domain
+	s.cdnSigner = sign.NewURLSigner(keyPairID, privateKey)
+	return nil
+}
+
 func (s *S3Service) UploadFile(file multipart.File, header *multipart.FileHeader, folder string) (string, error) {
 	// Read file content
 	buffer := make([]byte, header.Size)
@@ -52,9 +166,9 @@ func (s *S3Service) UploadFile(file multipart.File, header *multipart.FileHeader
 	ext := filepath.Ext(header.Filename)
 	filename := fmt.Sprintf("%s/%s-%s%s", folder, time.Now().Format("20060102"), uuid.New().String(), ext)
 
-	// Upload to S3 (private bucket)
-	_, err := s.client.PutObject(&s3.PutObjectInput{
-		Bucket:      aws.String(s.bucket),
+	// Upload to the image bucket (private)
+	_, err := s.images.client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(s.images.name),
 		Key:         aws.String(filename),
 		Body:        bytes.NewReader(buffer),
 		ContentType: aws.String(header.Header.Get("Content-Type")),
@@ -64,7 +178,7 @@ func (s *S3Service) UploadFile(file multipart.File, header *multipart.FileHeader
 	}
 
 	// Generate pre-signed URL (valid for 7 days)
-	url, err := s.generatePresignedURL(filename, URLExpirationTime)
+	url, err := s.GeneratePresignedURL(filename, URLExpirationTime)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate pre-signed URL: %w", err)
 	}
@@ -80,19 +194,17 @@ type PDFUrls struct {
 func (s *S3Service) UploadPDF(data []byte, filename string) (string, error) {
 	key := fmt.Sprintf("brochures/%s-%s.pdf", time.Now().Format("20060102"), uuid.New().String())
 
-	// Upload PDF to S3 (private bucket) - no ContentDisposition set on upload
-	_, err := s.client.PutObject(&s3.PutObjectInput{
-		Bucket:      aws.String(s.bucket),
-		Key:         aws.String(key),
-		Body:        bytes.NewReader(data),
-		ContentType: aws.String("application/pdf"),
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to upload PDF to S3: %w", err)
+	// Upload PDF to S3 (private bucket) - no ContentDisposition set on upload; large PDFs go multipart
+	if err := s.PutObject(key, data, "application/pdf"); err != nil {
+		return "", fmt.Errorf("failed to upload PDF: %w", err)
+	}
+
+	if s.public {
+		return s.PublicURL(key), nil
 	}
 
 	// Generate pre-signed URL for viewing (inline)
-	url, err := s.generatePresignedURLWithDisposition(
+	url, err := s.GeneratePresignedURLWithDisposition(
 		key,
 		URLExpirationTime,
 		fmt.Sprintf("inline; filename=\"%s.pdf\"", filename),
@@ -107,19 +219,22 @@ func (s *S3Service) UploadPDF(data []byte, filename string) (string, error) {
 func (s *S3Service) UploadPDFWithUrls(data []byte, filename string) (*PDFUrls, error) {
 	key := fmt.Sprintf("brochures/%s-%s.pdf", time.Now().Format("20060102"), uuid.New().String())
 
-	// Upload PDF to S3 (private bucket) - no ContentDisposition set on upload
-	_, err := s.client.PutObject(&s3.PutObjectInput{
-		Bucket:      aws.String(s.bucket),
-		Key:         aws.String(key),
-		Body:        bytes.NewReader(data),
-		ContentType: aws.String("application/pdf"),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to upload PDF to S3: %w", err)
+	// Upload PDF to S3 (private bucket) - no ContentDisposition set on upload; large PDFs go multipart
+	if err := s.PutObject(key, data, "application/pdf"); err != nil {
+		return nil, fmt.Errorf("failed to upload PDF: %w", err)
+	}
+
+	// Public buckets need no signing; the permanent URL serves both viewing and downloading
+	if s.public {
+		publicUrl := s.PublicURL(key)
+		return &PDFUrls{
+			ViewUrl:     publicUrl,
+			DownloadUrl: publicUrl,
+		}, nil
 	}
 
 	// Generate pre-signed URL for viewing (inline - opens in browser)
-	viewUrl, err := s.generatePresignedURLWithDisposition(
+	viewUrl, err := s.GeneratePresignedURLWithDisposition(
 		key,
 		URLExpirationTime,
 		fmt.Sprintf("inline; filename=\"%s.pdf\"", filename),
@@ -129,7 +244,7 @@ func (s *S3Service) UploadPDFWithUrls(data []byte, filename string) (*PDFUrls, e
 	}
 
 	// Generate pre-signed URL for downloading (attachment - forces download)
-	downloadUrl, err := s.generatePresignedURLWithDisposition(
+	downloadUrl, err := s.GeneratePresignedURLWithDisposition(
 		key,
 		URLExpirationTime,
 		fmt.Sprintf("attachment; filename=\"%s.pdf\"", filename),
@@ -144,10 +259,199 @@ func (s *S3Service) UploadPDFWithUrls(data []byte, filename string) (*PDFUrls, e
 	}, nil
 }
 
-// generatePresignedURL creates a temporary URL for accessing a private S3 object
-func (s *S3Service) generatePresignedURL(key string, expiration time.Duration) (string, error) {
-	req, _ := s.client.GetObjectRequest(&s3.GetObjectInput{
-		Bucket: aws.String(s.bucket),
+// DeleteObjects removes the given keys from their buckets using the batch delete API
+func (s *S3Service) DeleteObjects(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if s.pdfs == s.images {
+		return deleteFromBucket(s.images, keys)
+	}
+
+	var imageKeys, pdfKeys []string
+	for _, key := range keys {
+		if s.bucketFor(key) == s.pdfs {
+			pdfKeys = append(pdfKeys, key)
+		} else {
+			imageKeys = append(imageKeys, key)
+		}
+	}
+	if err := deleteFromBucket(s.images, imageKeys); err != nil {
+		return err
+	}
+	return deleteFromBucket(s.pdfs, pdfKeys)
+}
+
+// deleteFromBucket batch deletes keys from one bucket
+func deleteFromBucket(b s3Bucket, keys []string) error {
+	// S3 accepts at most 1000 keys per DeleteObjects request
+	const batchSize = 1000
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		objects := make([]*s3.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, &s3.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		out, err := b.client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(b.name),
+			Delete: &s3.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete objects from S3: %w", err)
+		}
+		if len(out.Errors) > 0 {
+			return fmt.Errorf("failed to delete %d object(s) from S3, first error: %s",
+				len(out.Errors), aws.StringValue(out.Errors[0].Message))
+		}
+	}
+
+	return nil
+}
+
+// PutObject stores raw bytes at a fixed key, switching to a multipart upload above MultipartThreshold
+func (s *S3Service) PutObject(key string, data []byte, contentType string) error {
+	if len(data) > MultipartThreshold {
+		return s.UploadLargeObject(key, data, contentType)
+	}
+
+	b := s.bucketFor(key)
+	_, err := b.client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(b.name),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload to S3: %w", err)
+	}
+	return nil
+}
+
+// UploadLargeObject stores data with the multipart upload API in MultipartThreshold-sized parts,
+// aborting the upload if any part fails so no orphaned parts are billed
+func (s *S3Service) UploadLargeObject(key string, data []byte, contentType string) error {
+	b := s.bucketFor(key)
+	created, err := b.client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(b.name),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+
+	var parts []*s3.CompletedPart
+	for start, partNumber := 0, int64(1); start < len(data); start, partNumber = start+MultipartThreshold, partNumber+1 {
+		end := start + MultipartThreshold
+		if end > len(data) {
+			end = len(data)
+		}
+
+		out, err := b.client.UploadPart(&s3.UploadPartInput{
+			Bucket:     aws.String(b.name),
+			Key:        aws.String(key),
+			UploadId:   created.UploadId,
+			PartNumber: aws.Int64(partNumber),
+			Body:       bytes.NewReader(data[start:end]),
+		})
+		if err != nil {
+			s.abortMultipartUpload(key, created.UploadId)
+			return fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+		}
+		parts = append(parts, &s3.CompletedPart{
+			ETag:       out.ETag,
+			PartNumber: aws.Int64(partNumber),
+		})
+	}
+
+	_, err = b.client.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(b.name),
+		Key:             aws.String(key),
+		UploadId:        created.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		s.abortMultipartUpload(key, created.UploadId)
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Service) abortMultipartUpload(key string, uploadID *string) {
+	b := s.bucketFor(key)
+	_, err := b.client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(b.name),
+		Key:      aws.String(key),
+		UploadId: uploadID,
+	})
+	if err != nil {
+		log.Printf("Failed to abort multipart upload of %s: %v", key, err)
+	}
+}
+
+// GetObject downloads the object stored at key
+func (s *S3Service) GetObject(key string) ([]byte, error) {
+	b := s.bucketFor(key)
+	out, err := b.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.name),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to download from S3: %w", err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 object: %w", err)
+	}
+	return data, nil
+}
+
+// KeyFromURL extracts the object key from a (pre-signed) S3 or CloudFront URL. Path-style URLs carry the
+// bucket name as the first path segment.
+func (s *S3Service) KeyFromURL(rawURL string) (string, error) {
+	if s.cdnDomain == "" {
+		for _, b := range []s3Bucket{s.images, s.pdfs} {
+			if !b.pathStyle {
+				continue
+			}
+			if key, err := keyFromURLPath(rawURL, b.name+"/"); err == nil {
+				return key, nil
+			}
+		}
+	}
+	return keyFromURLPath(rawURL, "")
+}
+
+// GeneratePresignedURL creates a temporary URL for accessing a private S3 object. URLs signed within
+// the last hour are reused.
+func (s *S3Service) GeneratePresignedURL(key string, expiration time.Duration) (string, error) {
+	return s.presigned.get(presignKey{key: key, expiration: expiration}, func() (string, error) {
+		return s.presignURL(key, expiration)
+	})
+}
+
+// presignURL signs a new URL for the key
+func (s *S3Service) presignURL(key string, expiration time.Duration) (string, error) {
+	if s.cdnSigner != nil {
+		return s.generateCloudFrontURL(key, expiration, "")
+	}
+
+	b := s.bucketFor(key)
+	req, _ := b.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(b.name),
 		Key:    aws.String(key),
 	})
 
@@ -160,10 +464,47 @@ func (s *S3Service) generatePresignedURL(key string, expiration time.Duration) (
 	return url, nil
 }
 
-// generatePresignedURLWithDisposition creates a pre-signed URL with custom response headers
-func (s *S3Service) generatePresignedURLWithDisposition(key string, expiration time.Duration, disposition string) (string, error) {
-	req, _ := s.client.GetObjectRequest(&s3.GetObjectInput{
-		Bucket:                     aws.String(s.bucket),
+// PublicURL returns the permanent URL of an object in a public bucket (via CloudFront when configured)
+func (s *S3Service) PublicURL(key string) string {
+	if s.cdnDomain != "" {
+		return fmt.Sprintf("https://%s/%s", s.cdnDomain, key)
+	}
+	return s.bucketFor(key).objectURL(key)
+}
+
+// generateCloudFrontURL signs a CloudFront URL for the key. The Content-Disposition override is passed
+// as response-content-disposition, which requires the distribution to forward that query string to S3.
+func (s *S3Service) generateCloudFrontURL(key string, expiration time.Duration, disposition string) (string, error) {
+	rawURL := s.PublicURL(key)
+	if disposition != "" {
+		rawURL += "?" + url.Values{"response-content-disposition": {disposition}}.Encode()
+	}
+
+	signed, err := s.cdnSigner.Sign(rawURL, time.Now().Add(expiration))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign CloudFront URL: %w", err)
+	}
+	return signed, nil
+}
+
+// GeneratePresignedURLWithDisposition creates a pre-signed URL with custom response headers, reusing
+// one signed within the last hour
+func (s *S3Service) GeneratePresignedURLWithDisposition(key string, expiration time.Duration, disposition string) (string, error) {
+	k := presignKey{key: key, disposition: disposition, expiration: expiration}
+	return s.presigned.get(k, func() (string, error) {
+		return s.presignURLWithDisposition(key, expiration, disposition)
+	})
+}
+
+// presignURLWithDisposition signs a new URL for the key with the Content-Disposition override
+func (s *S3Service) presignURLWithDisposition(key string, expiration time.Duration, disposition string) (string, error) {
+	if s.cdnSigner != nil {
+		return s.generateCloudFrontURL(key, expiration, disposition)
+	}
+
+	b := s.bucketFor(key)
+	req, _ := b.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     aws.String(b.name),
 		Key:                        aws.String(key),
 		ResponseContentDisposition: aws.String(disposition),
 	})
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"property-brochure-backend/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Share link lifetimes
+const (
+	DefaultShareLinkTTL = 72 * time.Hour
+	MaxShareLinkTTL     = 30 * 24 * time.Hour
+)
+
+// shareLinkTokenBytes is the entropy of a share link token, which is the only secret protecting it
+const shareLinkTokenBytes = 24
+
+var (
+	// ErrShareLinkNotFound is returned for tokens that were never issued or whose listing was deleted
+	ErrShareLinkNotFound = errors.New("share link not found")
+	// ErrShareLinkExpired is returned for tokens past their expiry
+	ErrShareLinkExpired = errors.New("share link expired")
+)
+
+// ShareLinkService stores the public landing page links of listings in the "shareLinks" collection
+type ShareLinkService struct {
+	mongo MongoStorage
+}
+
+func NewShareLinkService(mongo MongoStorage) *ShareLinkService {
+	return &ShareLinkService{mongo: mongo}
+}
+
+// Create issues a new link to the listing, valid for ttl
+func (s *ShareLinkService) Create(propertyID primitive.ObjectID, ttl time.Duration) (*models.ShareLink, error) {
+	token := make([]byte, shareLinkTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate share link token: %w", err)
+	}
+
+	now := time.Now()
+	link := &models.ShareLink{
+		Token:      base64.RawURLEncoding.EncodeToString(token),
+		PropertyID: propertyID,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := s.mongo.GetCollection("shareLinks").InsertOne(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to save share link: %w", err)
+	}
+	return link, nil
+}
+
+// ForProperty returns the listing's links, newest first
+func (s *ShareLinkService) ForProperty(propertyID primitive.ObjectID) ([]models.ShareLink, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := s.mongo.GetCollection("shareLinks").Find(ctx, bson.M{"propertyId": propertyID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query share links: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	links := []models.ShareLink{}
+	if err := cursor.All(ctx, &links); err != nil {
+		return nil, fmt.Errorf("failed to decode share links: %w", err)
+	}
+	return links, nil
+}
+
+// View counts a visit of the link and returns it with the updated view count. Expired links are
+// not counted and return ErrShareLinkExpired.
+func (s *ShareLinkService) View(token string) (*models.ShareLink, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := s.mongo.GetCollection("shareLinks")
+	var link models.ShareLink
+	err := collection.FindOneAndUpdate(ctx,
+		bson.M{"token": token, "expiresAt": bson.M{"$gt": time.Now()}},
+		bson.M{"$inc": bson.M{"viewCount": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&link)
+	if err == nil {
+		return &link, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("failed to update share link: %w", err)
+	}
+
+	// Not updated: tell an expired link from an unknown one
+	err = collection.FindOne(ctx, bson.M{"token": token}).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrShareLinkNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load share link: %w", err)
+	}
+	return nil, ErrShareLinkExpired
+}
+
+// DeleteForProperties removes the links of deleted listings
+func (s *ShareLinkService) DeleteForProperties(ctx context.Context, propertyIDs ...primitive.ObjectID) error {
+	if _, err := s.mongo.GetCollection("shareLinks").DeleteMany(ctx, bson.M{"propertyId": bson.M{"$in": propertyIDs}}); err != nil {
+		return fmt.Errorf("failed to delete share links: %w", err)
+	}
+	return nil
+}
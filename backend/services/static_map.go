@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"property-brochure-backend/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	// staticMapStripHeight is the height of the cover map strip in mm
+	staticMapStripHeight = 30.0
+
+	// staticMapBottomGap keeps the strip clear of the page number
+	staticMapBottomGap = 12.0
+)
+
+// WithStaticMaps adds a Google Static Maps strip with the listing's location to the bottom of the cover
+// of listings with coordinates. The key is only sent to Google, never written to the brochure.
+func WithStaticMaps(apiKey string) PDFOption {
+	return func(s *PDFService) { s.staticMapsAPIKey = apiKey }
+}
+
+// staticMapURL builds a 600x200 Google Static Maps image at zoom 14 with a marker on the coordinates
+func staticMapURL(apiKey string, latitude, longitude float64) string {
+	center := fmt.Sprintf("%.6f,%.6f", latitude, longitude)
+	query := url.Values{
+		"center":  {center},
+		"zoom":    {"14"},
+		"size":    {"600x200"},
+		"markers": {"color:red|" + center},
+		"key":     {apiKey},
+	}
+	return "https://maps.googleapis.com/maps/api/staticmap?" + query.Encode()
+}
+
+// addStaticMapStrip draws the location map across the content width at the bottom of the cover and
+// reports whether it did. Nothing is drawn without an API key or coordinates, when the cover content
+// reaches the strip, or when the map cannot be downloaded.
+func (s *PDFService) addStaticMapStrip(pdf *gofpdf.Fpdf, property *models.Property) bool {
+	if s.staticMapsAPIKey == "" || property.Latitude == nil || property.Longitude == nil {
+		return false
+	}
+
+	margins := pageMargins(pdf)
+	_, pageHeight, contentWidth := pageSize(pdf)
+	y := pageHeight - staticMapBottomGap - staticMapStripHeight
+	if pdf.GetY()+3 > y {
+		log.Printf("Skipping cover map, no room left below the cover content")
+		return false
+	}
+
+	mapURL := staticMapURL(s.staticMapsAPIKey, *property.Latitude, *property.Longitude)
+	if err := s.addCroppedImageFromURL(pdf, mapURL, margins.Left, y, contentWidth, staticMapStripHeight); err != nil {
+		// Request errors quote the URL, which carries the API key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		log.Printf("Warning: failed to load cover map: %v", err)
+		return false
+	}
+	pdf.SetDrawColor(s.theme.AccentColor.RGB())
+	pdf.SetLineWidth(0.5)
+	pdf.Rect(margins.Left, y, contentWidth, staticMapStripHeight, "D")
+	return true
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// StorageService abstracts the object store used for property images and PDF brochures.
+// Objects are addressed either by expiring pre-signed URLs (private stores) or by permanent public URLs.
+type StorageService interface {
+	UploadFile(file multipart.File, header *multipart.FileHeader, folder string) (string, error)
+	UploadPDF(data []byte, filename string) (string, error)
+	UploadPDFWithUrls(data []byte, filename string) (*PDFUrls, error)
+	DeleteObjects(keys []string) error
+	GeneratePresignedURL(key string, expiration time.Duration) (string, error)
+	GeneratePresignedURLWithDisposition(key string, expiration time.Duration, disposition string) (string, error)
+	PublicURL(key string) string
+	KeyFromURL(rawURL string) (string, error)
+	PutObject(key string, data []byte, contentType string) error
+	GetObject(key string) ([]byte, error)
+}
+
+// ErrObjectNotFound is returned by GetObject when the key does not exist
+var ErrObjectNotFound = errors.New("object not found")
+
+var (
+	_ StorageService = (*S3Service)(nil)
+	_ StorageService = (*AzureBlobService)(nil)
+	_ StorageService = (*LocalStorageService)(nil)
+)
+
+// keyFromURLPath returns the object key from a URL path after stripping the given prefix
+func keyFromURLPath(rawURL, prefix string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+	path := strings.TrimPrefix(parsed.Path, "/")
+	if !strings.HasPrefix(path, prefix) || len(path) == len(prefix) {
+		return "", fmt.Errorf("URL %s does not reference a stored object", parsed.Redacted())
+	}
+	return strings.TrimPrefix(path, prefix), nil
+}
+
+// ResignURL signs a fresh URL for the object behind a stored one, keeping the Content-Disposition
+// (inline view or attachment download, with its filename) the stored URL was signed with. URLs that do
+// not expire, such as public bucket URLs, are returned unchanged.
+func ResignURL(storage StorageService, stored string) (string, error) {
+	if _, expires := PresignedURLExpiry(stored); !expires {
+		return stored, nil
+	}
+	key, err := storage.KeyFromURL(stored)
+	if err != nil {
+		return "", err
+	}
+	if disposition := signedDisposition(stored); disposition != "" {
+		return storage.GeneratePresignedURLWithDisposition(key, URLExpirationTime, disposition)
+	}
+	return storage.GeneratePresignedURL(key, URLExpirationTime)
+}
+
+// ViewURL signs a fresh URL that opens the PDF behind a stored URL in the browser (inline) under the
+// given filename. URLs that do not expire, such as public bucket URLs, are returned unchanged.
+func ViewURL(storage StorageService, stored, filename string) (string, error) {
+	if _, expires := PresignedURLExpiry(stored); !expires {
+		return stored, nil
+	}
+	key, err := storage.KeyFromURL(stored)
+	if err != nil {
+		return "", err
+	}
+	return storage.GeneratePresignedURLWithDisposition(key, URLExpirationTime, fmt.Sprintf("inline; filename=\"%s.pdf\"", filename))
+}
+
+// signedDisposition returns the Content-Disposition override of a signed S3/CloudFront
+// (response-content-disposition) or Azure SAS (rscd) URL
+func signedDisposition(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	query := parsed.Query()
+	if disposition := query.Get("response-content-disposition"); disposition != "" {
+		return disposition
+	}
+	return query.Get("rscd")
+}
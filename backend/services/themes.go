@@ -0,0 +1,244 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"property-brochure-backend/models"
+	"sort"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"gopkg.in/yaml.v3"
+)
+
+// Cover page layouts a preset can select
+const (
+	// CoverTemplateClassic puts the heading on the page background above a gold accent bar
+	CoverTemplateClassic = "classic"
+	// CoverTemplateBanner puts the heading in white on a primary-colour band across the top of the cover
+	CoverTemplateBanner = "banner"
+)
+
+// Section header fills a preset can select
+const (
+	// SectionHeaderSolid fills the header bar with the primary color
+	SectionHeaderSolid = "solid"
+	// SectionHeaderGradient lightens the primary color from left to right in bands
+	SectionHeaderGradient = "gradient"
+	// SectionHeaderLines overlays white diagonal hatching on the primary color
+	SectionHeaderLines = "lines"
+)
+
+// sectionHeaderGradientSteps is the number of bands of the gradient header; each is lighter by
+// sectionHeaderGradientStep of the distance to white
+const (
+	sectionHeaderGradientSteps = 10
+	sectionHeaderGradientStep  = 0.04
+)
+
+// sectionHeaderLineSpacing is the horizontal gap between the hatching lines of the lines header
+const sectionHeaderLineSpacing = 3.0
+
+// coverBannerHeight is the height of the banner template's heading band, covering the heading and accent bar
+const coverBannerHeight = 23.0
+
+// coreFontFamilies are the gofpdf core fonts a preset may use for titles and body text
+var coreFontFamilies = []string{"Arial", "Helvetica", "Times", "Courier"}
+
+// Color is an RGB color, written as "#RRGGBB" in the presets file
+type Color struct {
+	R, G, B int
+}
+
+// RGB returns the components in the order gofpdf's color setters take them
+func (c Color) RGB() (int, int, int) {
+	return c.R, c.G, c.B
+}
+
+// darken returns the color with each component reduced by amount, floored at zero
+func (c Color) darken(amount int) Color {
+	shade := func(v int) int {
+		if v < amount {
+			return 0
+		}
+		return v - amount
+	}
+	return Color{shade(c.R), shade(c.G), shade(c.B)}
+}
+
+// lighten returns the color moved toward white by the fraction t (0 to 1) of the remaining distance
+func (c Color) lighten(t float64) Color {
+	tint := func(v int) int {
+		return v + int(float64(255-v)*t+0.5)
+	}
+	return Color{tint(c.R), tint(c.G), tint(c.B)}
+}
+
+// UnmarshalYAML parses a "#RRGGBB" hex color
+func (c *Color) UnmarshalYAML(value *yaml.Node) error {
+	var r, g, b int
+	if len(value.Value) != 7 || value.Value[0] != '#' {
+		return fmt.Errorf("line %d: color %q must be in #RRGGBB form", value.Line, value.Value)
+	}
+	if _, err := fmt.Sscanf(value.Value, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return fmt.Errorf("line %d: color %q must be in #RRGGBB form", value.Line, value.Value)
+	}
+	*c = Color{r, g, b}
+	return nil
+}
+
+// LoadThemePresets reads the named presets from a YAML file mapping preset names to their settings.
+// Settings a preset omits keep the default theme's values.
+func LoadThemePresets(path string) (map[string]Preset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme presets: %w", err)
+	}
+
+	var nodes map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse theme presets %s: %w", path, err)
+	}
+
+	presets := make(map[string]Preset, len(nodes))
+	for name, node := range nodes {
+		preset := defaultPreset
+		if err := node.Decode(&preset); err != nil {
+			return nil, fmt.Errorf("theme preset %q: %w", name, err)
+		}
+		if err := preset.validate(); err != nil {
+			return nil, fmt.Errorf("theme preset %q: %w", name, err)
+		}
+		presets[name] = preset
+	}
+	return presets, nil
+}
+
+// validate checks the preset only names fonts and cover templates the renderer supports
+func (p Preset) validate() error {
+	for _, font := range []string{p.TitleFontName, p.BodyFontName} {
+		if !isCoreFontFamily(font) {
+			return fmt.Errorf("font %q must be one of %s", font, strings.Join(coreFontFamilies, ", "))
+		}
+	}
+	switch p.CoverTemplate {
+	case CoverTemplateClassic, CoverTemplateBanner:
+	default:
+		return fmt.Errorf("cover template %q must be %s or %s", p.CoverTemplate, CoverTemplateClassic, CoverTemplateBanner)
+	}
+	if !isSectionHeaderPattern(p.SectionHeaderPattern) {
+		return fmt.Errorf("section header pattern %q must be %s, %s or %s", p.SectionHeaderPattern, SectionHeaderSolid, SectionHeaderGradient, SectionHeaderLines)
+	}
+	return nil
+}
+
+func isSectionHeaderPattern(pattern string) bool {
+	switch pattern {
+	case SectionHeaderSolid, SectionHeaderGradient, SectionHeaderLines:
+		return true
+	}
+	return false
+}
+
+func isCoreFontFamily(name string) bool {
+	for _, family := range coreFontFamilies {
+		if family == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ThemePresetNames returns the configured preset names in alphabetical order
+func ThemePresetNames(presets map[string]Preset) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasThemePreset reports whether name is a configured theme preset
+func (s *PDFService) HasThemePreset(name string) bool {
+	_, ok := s.presets[name]
+	return ok
+}
+
+// themed returns the service to render the listing with: a copy carrying the listing's preset and
+// print mode, so concurrent brochures with different presets do not share colors and fonts, or s itself
+func (s *PDFService) themed(property *models.Property) *PDFService {
+	if property == nil {
+		return s
+	}
+	if property.ThemePreset != "" {
+		if preset, ok := s.presets[property.ThemePreset]; ok {
+			themed := *s
+			themed.theme = preset
+			s = &themed
+		} else {
+			log.Printf("Using the default theme, preset %q is not configured", property.ThemePreset)
+		}
+	}
+	if property.PrintReady {
+		s = s.forPrint()
+	}
+	return s
+}
+
+// coreFont picks the theme's title font for bold text and its body font otherwise
+func (s *PDFService) coreFont(style string) string {
+	if strings.Contains(style, "B") {
+		return s.theme.TitleFontName
+	}
+	return s.theme.BodyFontName
+}
+
+// addCoverBanner draws the banner template's heading band; classic covers are left unchanged
+func (s *PDFService) addCoverBanner(pdf *gofpdf.Fpdf) {
+	if s.theme.CoverTemplate != CoverTemplateBanner {
+		return
+	}
+	pageWidth, _, _ := pageSize(pdf)
+	pdf.SetFillColor(s.theme.PrimaryColor.RGB())
+	pdf.Rect(0, 0, pageWidth, coverBannerHeight, "F")
+}
+
+// coverHeadingColor is the cover heading's text color: white on the banner, the primary color otherwise
+func (s *PDFService) coverHeadingColor() (int, int, int) {
+	if s.theme.CoverTemplate == CoverTemplateBanner {
+		return 255, 255, 255
+	}
+	return s.theme.PrimaryColor.RGB()
+}
+
+// addSectionHeaderBar fills a section header bar with the theme's pattern
+func (s *PDFService) addSectionHeaderBar(pdf *gofpdf.Fpdf, x, y, w, h float64) {
+	switch s.theme.SectionHeaderPattern {
+	case SectionHeaderGradient:
+		bandW := w / sectionHeaderGradientSteps
+		for i := 0; i < sectionHeaderGradientSteps; i++ {
+			pdf.SetFillColor(s.theme.PrimaryColor.lighten(float64(i) * sectionHeaderGradientStep).RGB())
+			// Bands overlap slightly so no hairline gaps show between them
+			pdf.Rect(x+float64(i)*bandW, y, bandW+0.1, h, "F")
+		}
+	case SectionHeaderLines:
+		pdf.SetFillColor(s.theme.PrimaryColor.RGB())
+		pdf.Rect(x, y, w, h, "F")
+
+		// 45° lines rising left to right, clipped to the bar; faint so the title stays legible
+		pdf.ClipRect(x, y, w, h, false)
+		pdf.SetAlpha(0.2, "Normal")
+		pdf.SetDrawColor(255, 255, 255)
+		pdf.SetLineWidth(0.3)
+		for lineX := x - h; lineX < x+w; lineX += sectionHeaderLineSpacing {
+			pdf.Line(lineX, y+h, lineX+h, y)
+		}
+		pdf.SetAlpha(1, "Normal")
+		pdf.ClipEnd()
+	default:
+		pdf.SetFillColor(s.theme.PrimaryColor.RGB())
+		pdf.Rect(x, y, w, h, "F")
+	}
+}
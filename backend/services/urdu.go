@@ -0,0 +1,100 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"property-brochure-backend/models"
+)
+
+// DefaultUrduFontPath is the Nastaliq font used for Urdu brochures; the Arabic Naskh font is used when it is missing
+const DefaultUrduFontPath = "fonts/NafeesNastaleeq.ttf"
+
+// WithUrduFontPath sets the Urdu TTF file; an empty path renders Urdu brochures with the Arabic font
+func WithUrduFontPath(path string) PDFOption {
+	return func(s *PDFService) { s.urduFontPath = path }
+}
+
+// GenerateUrduBrochure renders the right-to-left brochure layout with the listing's Urdu copy,
+// the Nastaliq font and Urdu wording for the fixed labels
+func (s *PDFService) GenerateUrduBrochure(property *models.Property) ([]byte, error) {
+	s = s.themed(property).forUrdu()
+	urdu := urduProperty(property)
+	pdf := s.newDocument(s.orientation(urdu), urdu.PageSize, urdu.MarginMm)
+	s.setupFonts(pdf, urdu)
+
+	coverPage := s.addPagesInOrder(pdf, urdu, true)
+	s.addPropertyReferenceCode(pdf, urdu, coverPage)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate Urdu PDF: %w", err)
+	}
+
+	return s.finishPDF(buf.Bytes(), "Urdu"), nil
+}
+
+// forUrdu returns a copy of the service that renders its right-to-left pages in Urdu
+func (s *PDFService) forUrdu() *PDFService {
+	urdu := *s
+	urdu.urdu = true
+	return &urdu
+}
+
+// urduProperty returns a copy of the listing with the Urdu copy in place of the Arabic copy the
+// right-to-left pages read from
+func urduProperty(property *models.Property) *models.Property {
+	urdu := *property
+	urdu.ArabicContent = property.UrduContent
+	urdu.AIContent.ArabicDescription = property.UrduContent.Description
+	return &urdu
+}
+
+// rtl returns a fixed right-to-left label, translated to Urdu when rendering an Urdu brochure
+func (s *PDFService) rtl(arabic string) string {
+	if s.urdu {
+		if urdu, ok := urduLabels[arabic]; ok {
+			return urdu
+		}
+	}
+	return arabic
+}
+
+// urduLabels maps the fixed Arabic labels and fallback copy of the brochure to Urdu
+var urduLabels = map[string]string{
+	"المحتويات":         "فہرست",
+	"وصف العقار":        "پراپرٹی کی تفصیل",
+	"فرصة استثمارية":    "سرمایہ کاری کا موقع",
+	"معرض العقار":       "پراپرٹی گیلری",
+	"جولة افتراضية":     "ورچوئل ٹور",
+	"مبيعات مماثلة":     "ملتی جلتی فروخت",
+	"مخطط الطابق":       "فلور پلان",
+	"تواصل مع الوكيل":   "ایجنٹ سے رابطہ کریں",
+	"كتيب العقار":       "پراپرٹی بروشر",
+	"المميزات الرئيسية": "نمایاں خصوصیات",
+	"المرافق والميزات":  "سہولیات اور خصوصیات",
+	"لا يوجد وصف متاح":  "کوئی تفصیل دستیاب نہیں",
+	"يمثل هذا العقار فرصة استثمارية ممتازة.": "یہ پراپرٹی سرمایہ کاری کا ایک بہترین موقع ہے۔",
+	"+ %d صور إضافية متاحة":                  "+ %d مزید تصاویر دستیاب ہیں",
+	" - امسح الرمز للجولة الافتراضية":        " - ورچوئل ٹور کے لیے کوڈ اسکین کریں",
+	"امسح رمز الاستجابة السريعة بكاميرا هاتفك، أو انقر عليه، لاستكشاف هذا العقار من أي مكان.": "اپنے فون کے کیمرے سے QR کوڈ اسکین کریں، یا اس پر کلک کریں، اور کہیں سے بھی یہ پراپرٹی دیکھیں۔",
+	"العقار":        "پراپرٹی",
+	"سعر البيع":     "قیمت فروخت",
+	"أيام في السوق": "مارکیٹ میں دن",
+	"بيانات سوق تقديرية - للتوضيح فقط": "تخمینی مارکیٹ ڈیٹا - صرف وضاحت کے لیے",
+	"المبيعات المماثلة أدناه أمثلة افتراضية لتوضيح ظروف السوق المعتادة. وهي ليست معاملات فعلية ولا يجوز الاعتماد عليها في قرارات التقييم أو التمويل أو الاستثمار.": "ذیل میں دی گئی ملتی جلتی فروخت مارکیٹ کے عام حالات کی وضاحت کے لیے فرضی مثالیں ہیں۔ یہ حقیقی لین دین نہیں ہیں اور قیمت، مالیات یا سرمایہ کاری کے فیصلوں میں ان پر انحصار نہیں کیا جا سکتا۔",
+	"* بيانات سوق تقديرية. العناوين والأسعار وأيام العرض توضيحية ولا تصف عقارات حقيقية.":                                                                           "* تخمینی مارکیٹ ڈیٹا۔ پتے، قیمتیں اور مارکیٹ میں دن وضاحتی ہیں اور حقیقی پراپرٹیز کو بیان نہیں کرتے۔",
+	"الاسم:":                  "نام:",
+	"البريد الإلكتروني:":      "ای میل:",
+	"الهاتف:":                 "فون:",
+	"الموقع الإلكتروني:":      "ویب سائٹ:",
+	"القسط الشهري التقديري: ": "تخمینی ماہانہ قسط: ",
+	"بافتراض دفعة أولى %.0f%% (%s)، وسعر فائدة ثابت %.2f%%، ومدة %d سنة. يشمل أصل القرض والفائدة فقط، ولا يشمل الضرائب والتأمين والرسوم.":        "%.0f%% ڈاؤن پیمنٹ (%s)، %.2f%% مقررہ شرح سود اور %d سالہ مدت کے مفروضے پر۔ صرف اصل رقم اور سود شامل ہیں؛ ٹیکس، انشورنس اور فیس شامل نہیں۔",
+	"نشكركم على اهتمامكم بهذا العقار الاستثنائي. نحن نقدر اهتمامكم ويسعدنا تزويدكم بمعلومات إضافية أو ترتيب موعد للمعاينة في الوقت المناسب لكم.": "اس غیر معمولی پراپرٹی میں دلچسپی لینے کا شکریہ۔ ہم آپ کی دلچسپی کی قدر کرتے ہیں اور آپ کو مزید معلومات فراہم کرنے یا آپ کی سہولت کے مطابق معائنے کا وقت طے کرنے میں خوشی محسوس کریں گے۔",
+	"يمثل هذا العقار فرصة استثمارية ممتازة في موقع متميز.":                                                                                       "یہ پراپرٹی ایک بہترین مقام پر سرمایہ کاری کا شاندار موقع ہے۔",
+	"الغلاف":            "سرورق",
+	"المعرض والاستثمار": "گیلری اور سرمایہ کاری",
+	"الاستثمار":         "سرمایہ کاری",
+	"المعرض":            "گیلری",
+	"التواصل":           "رابطہ",
+	"عقار الأسبوع":      "ہفتے کی پراپرٹی",
+}
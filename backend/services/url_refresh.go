@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"property-brochure-backend/models"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// URLRefreshWindow is how close to expiry a stored pre-signed URL may get before it is regenerated
+const URLRefreshWindow = 48 * time.Hour
+
+// URLRefreshInterval is how often the background refresher runs
+const URLRefreshInterval = 6 * time.Hour
+
+// refreshBatchSize is the number of properties read, and updates written, per page of a refresh run
+const refreshBatchSize = 100
+
+// refreshPageTimeout bounds the reads and writes of one page of properties
+const refreshPageTimeout = time.Minute
+
+// URLRefreshService re-signs the pre-signed URLs stored on property documents before they expire
+type URLRefreshService struct {
+	mongo   MongoStorage
+	storage StorageService
+}
+
+func NewURLRefreshService(mongo MongoStorage, storage StorageService) *URLRefreshService {
+	return &URLRefreshService{
+		mongo:   mongo,
+		storage: storage,
+	}
+}
+
+// RefreshResult summarizes a refresh run
+type RefreshResult struct {
+	Checked   int `json:"checked"`
+	Refreshed int `json:"refreshed"`
+	Failed    int `json:"failed"`
+}
+
+// Start refreshes expiring URLs every interval in a background goroutine until ctx is cancelled; a run
+// in progress stops with it
+func (s *URLRefreshService) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := s.RefreshExpiring(ctx, URLRefreshWindow)
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					log.Printf("Scheduled URL refresh failed: %v", err)
+					continue
+				}
+				log.Printf("Scheduled URL refresh: checked %d, refreshed %d, failed %d", result.Checked, result.Refreshed, result.Failed)
+			}
+		}
+	}()
+}
+
+// RefreshExpiring regenerates the PDF and image URLs of every property with a stored URL that expires within the window.
+// The collection is read in pages of refreshBatchSize properties in _id order, each with its own timeout, so
+// a large collection does not need to fit in one deadline; cancelling ctx stops the run between pages.
+// The updates are written in unordered bulk writes, so one failing document does not stop the others.
+func (s *URLRefreshService) RefreshExpiring(ctx context.Context, window time.Duration) (*RefreshResult, error) {
+	result := &RefreshResult{}
+	var after primitive.ObjectID
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		last, n, err := s.refreshPage(ctx, after, window, result)
+		if err != nil {
+			return result, err
+		}
+		if n < refreshBatchSize {
+			return result, nil
+		}
+		after = last
+	}
+}
+
+// refreshPage refreshes the expiring URLs of the next refreshBatchSize properties after the given _id,
+// returning the last _id read and how many properties were read
+func (s *URLRefreshService) refreshPage(ctx context.Context, after primitive.ObjectID, window time.Duration, result *RefreshResult) (primitive.ObjectID, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, refreshPageTimeout)
+	defer cancel()
+
+	filter := bson.M{"pdfUrl": bson.M{"$ne": ""}}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(refreshBatchSize)
+
+	collection := s.mongo.GetCollection("properties")
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return after, 0, fmt.Errorf("failed to query properties: %w", err)
+	}
+	var properties []models.Property
+	if err := cursor.All(ctx, &properties); err != nil {
+		return after, 0, fmt.Errorf("failed to read properties: %w", err)
+	}
+	if len(properties) == 0 {
+		return after, 0, nil
+	}
+
+	var updates []mongo.WriteModel
+	for i := range properties {
+		property := &properties[i]
+		result.Checked++
+
+		expiry, ok := earliestExpiry(property)
+		if !ok || time.Until(expiry) > window {
+			continue
+		}
+
+		filter, update, err := s.refreshedURLs(property)
+		if err != nil {
+			log.Printf("Error refreshing URLs of property %s: %v", property.ID.Hex(), err)
+			result.Failed++
+			continue
+		}
+		if len(update) == 0 {
+			continue
+		}
+		updates = append(updates, mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(bson.M{"$set": update}))
+	}
+	last := properties[len(properties)-1].ID
+	if len(updates) == 0 {
+		return last, len(properties), nil
+	}
+
+	res, err := collection.BulkWrite(ctx, updates, options.BulkWrite().SetOrdered(false))
+	if res != nil {
+		result.Refreshed += int(res.ModifiedCount)
+	}
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) {
+		for _, writeErr := range bulkErr.WriteErrors {
+			log.Printf("Error saving refreshed URLs: %v", writeErr)
+		}
+		result.Failed += len(bulkErr.WriteErrors)
+	} else if err != nil {
+		return last, len(properties), fmt.Errorf("failed to save refreshed URLs: %w", err)
+	}
+	return last, len(properties), nil
+}
+
+// earliestExpiry returns the soonest expiry among the property's stored brochure and image URLs
+func earliestExpiry(property *models.Property) (time.Time, bool) {
+	var earliest time.Time
+	found := false
+	urls := append([]string{property.PDFUrl, property.PDFUrlEnglish, property.PDFUrlArabic, property.PDFUrlBilingual, property.PDFUrlUrdu}, property.ImageURLs...)
+	for _, stored := range urls {
+		expiry, ok := PresignedURLExpiry(stored)
+		if ok && (!found || expiry.Before(earliest)) {
+			earliest, found = expiry, true
+		}
+	}
+	return earliest, found
+}
+
+// refreshedURLs re-signs every stored URL of the property and returns the fields to update. The filter
+// matches the document only while those fields still hold the URLs that were re-signed, so a concurrent
+// image or brochure change is not overwritten; that listing is refreshed on the next run instead.
+// updatedAt is left alone: it keys the cached cover preview, which re-signing does not change.
+func (s *URLRefreshService) refreshedURLs(property *models.Property) (filter, update bson.M, err error) {
+	filter = bson.M{"_id": property.ID}
+	update = bson.M{}
+
+	for field, current := range map[string]string{
+		"pdfUrl":          property.PDFUrl,
+		"pdfUrlEnglish":   property.PDFUrlEnglish,
+		"pdfUrlArabic":    property.PDFUrlArabic,
+		"pdfUrlBilingual": property.PDFUrlBilingual,
+		"pdfUrlUrdu":      property.PDFUrlUrdu,
+	} {
+		if current == "" {
+			continue
+		}
+		refreshed, err := ResignURL(s.storage, current)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", field, err)
+		}
+		if refreshed != current {
+			filter[field] = current
+			update[field] = refreshed
+		}
+	}
+
+	imageURLs := make([]string, len(property.ImageURLs))
+	changed := false
+	for i, current := range property.ImageURLs {
+		refreshed, err := ResignURL(s.storage, current)
+		if err != nil {
+			return nil, nil, fmt.Errorf("image %d: %w", i+1, err)
+		}
+		imageURLs[i] = refreshed
+		changed = changed || refreshed != current
+	}
+	if changed {
+		filter["imageUrls"] = property.ImageURLs
+		update["imageUrls"] = imageURLs
+	}
+
+	return filter, update, nil
+}
+
+// PresignedURLExpiry reads the expiry time encoded in a pre-signed URL.
+// It understands S3 (X-Amz-Date + X-Amz-Expires), CloudFront (Expires) and Azure SAS (se) URLs;
+// ok is false for URLs that do not expire, such as public bucket URLs.
+func PresignedURLExpiry(rawURL string) (expiry time.Time, ok bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+	query := parsed.Query()
+
+	if expires := query.Get("X-Amz-Expires"); expires != "" {
+		signedAt, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+		seconds, convErr := strconv.ParseInt(expires, 10, 64)
+		if err != nil || convErr != nil {
+			return time.Time{}, false
+		}
+		return signedAt.Add(time.Duration(seconds) * time.Second), true
+	}
+	if expires := query.Get("Expires"); expires != "" {
+		seconds, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(seconds, 0), true
+	}
+	if se := query.Get("se"); se != "" {
+		expiry, err := time.Parse(time.RFC3339, se)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return expiry, true
+	}
+	return time.Time{}, false
+}
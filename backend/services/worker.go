@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// WorkerQueueSize is how many async jobs may wait for a free worker
+const WorkerQueueSize = 100
+
+// WorkerDrainTimeout bounds how long shutdown waits for queued jobs to finish
+const WorkerDrainTimeout = 5 * time.Minute
+
+// ErrQueueFull is returned by Submit when every queue slot is taken
+var ErrQueueFull = errors.New("job queue is full")
+
+// ErrPoolClosed is returned by Submit once the pool has started shutting down
+var ErrPoolClosed = errors.New("worker pool is shutting down")
+
+// WorkerPool runs queued jobs on a fixed number of goroutines
+type WorkerPool struct {
+	jobs chan func()
+	wg   sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewWorkerPool starts size workers reading from a queue holding up to queueSize pending jobs
+func NewWorkerPool(size, queueSize int) *WorkerPool {
+	p := &WorkerPool{jobs: make(chan func(), queueSize)}
+	for i := 0; i < size; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+	return p
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		runJob(job)
+	}
+}
+
+// runJob executes one job, keeping the worker alive if it panics
+func runJob(job func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Worker job panicked: %v", r)
+		}
+	}()
+	job()
+}
+
+// Submit queues a job without blocking; it fails when the queue is full or the pool is shutting down
+func (p *WorkerPool) Submit(job func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	select {
+	case p.jobs <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Pending is the number of queued jobs no worker has picked up yet
+func (p *WorkerPool) Pending() int {
+	return len(p.jobs)
+}
+
+// Shutdown stops accepting jobs and waits until the workers have drained the queue, or ctx is done
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -491,7 +491,7 @@ export function PropertyForm({ onClose }: PropertyFormProps) {
                   Click to upload images
                 </p>
                 <p className="text-sm text-gray-500">
-                  PNG, JPG, WEBP up to 10MB each
+                  PNG, JPG, WEBP, GIF up to 10MB each
                 </p>
               </div>
             </label>
@@ -19,7 +19,7 @@ export const CURRENCY_SYMBOLS = {
 export const FORM_CONFIG = {
   maxImages: 10,
   maxImageSize: 10 * 1024 * 1024, // 10MB in bytes
-  acceptedImageTypes: ['image/jpeg', 'image/jpg', 'image/png', 'image/webp'],
+  acceptedImageTypes: ['image/jpeg', 'image/jpg', 'image/png', 'image/webp', 'image/gif'],
   minDescriptionLength: 50,
 };
 